@@ -22,6 +22,7 @@ type SecretReference struct {
 }
 
 // GatewayClassConfigSpec defines the desired state of GatewayClassConfig.
+// +kubebuilder:validation:XValidation:rule="has(self.tunnelID) != has(self.tunnelName)",message="exactly one of tunnelID or tunnelName must be set"
 type GatewayClassConfigSpec struct {
 	// CloudflareCredentialsSecretRef references a Secret containing Cloudflare API credentials.
 	// The Secret must contain an "api-token" key with a valid Cloudflare API token.
@@ -41,10 +42,119 @@ type GatewayClassConfigSpec struct {
 	// +kubebuilder:validation:XValidation:rule="self == '' || self.matches('^[a-f0-9]{32}$')",message="accountID must be a 32-character lowercase hexadecimal string (Cloudflare account ID format)"
 	AccountID string `json:"accountId,omitempty"`
 
-	// TunnelID is the Cloudflare Tunnel UUID.
-	// +kubebuilder:validation:Required
+	// TunnelID is the Cloudflare Tunnel UUID. Exactly one of TunnelID and
+	// TunnelName must be set.
+	// +optional
 	// +kubebuilder:validation:Pattern=`^[a-f0-9]{8}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{12}$`
-	TunnelID string `json:"tunnelID"` //nolint:tagliatelle // Cloudflare API uses tunnelID
+	TunnelID string `json:"tunnelID,omitempty"` //nolint:tagliatelle // Cloudflare API uses tunnelID
+
+	// TunnelName is the name of the Cloudflare Tunnel, resolved to its UUID
+	// through the Cloudflare API. The name must match exactly one tunnel in
+	// the account that is not deleted. Exactly one of TunnelID and TunnelName
+	// must be set.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	TunnelName string `json:"tunnelName,omitempty"`
+
+	// ClusterDomain is the Kubernetes cluster domain used to build backend
+	// Service origins (<service>.<namespace>.svc.<clusterDomain>) for routes of
+	// GatewayClasses referencing this config. Optional - defaults to the
+	// controller's --cluster-domain (auto-detected from resolv.conf when unset).
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+
+	// OriginRequestDefaults sets class-wide defaults for how the proxy
+	// connects to route backends. Per-route annotations override them.
+	// +optional
+	OriginRequestDefaults *OriginRequestDefaults `json:"originRequestDefaults,omitempty"`
+
+	// DefaultBackend replaces the HTTP 404 returned for requests that match
+	// no route with a fallback origin, e.g. a branded maintenance page or a
+	// status site. Unset keeps the 404.
+	// +optional
+	DefaultBackend *DefaultBackend `json:"defaultBackend,omitempty"`
+
+	// ManageDNS, when true, keeps a proxied CNAME record pointing at the
+	// tunnel for every hostname the tunnel serves, in the account's zones,
+	// and deletes the records it created once their hostname is no longer
+	// routed. Records the controller did not create are never changed.
+	// +optional
+	ManageDNS bool `json:"manageDNS,omitempty"`
+}
+
+// DefaultBackend is the fallback origin for requests that match no route.
+// Exactly one of URL or ServiceRef must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.url) != has(self.serviceRef)",message="exactly one of url or serviceRef must be set"
+type DefaultBackend struct {
+	// URL is an http or https origin outside the cluster. Requests are sent
+	// with the URL's host as the Host header.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^https?://[^/?#]+/?$`
+	URL string `json:"url,omitempty"`
+
+	// ServiceRef references an in-cluster Service. Requests keep the
+	// client's Host header.
+	// +optional
+	ServiceRef *ServiceReference `json:"serviceRef,omitempty"`
+}
+
+// ServiceReference is a reference to a port of a Kubernetes Service.
+type ServiceReference struct {
+	// Name of the Service.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace of the Service. Required because GatewayClassConfig is
+	// cluster-scoped.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// Port of the Service. Port 443 is dialed over https, any other port
+	// over http.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+}
+
+// OriginRequestDefaults holds class-level defaults for the proxy's backend
+// connections.
+type OriginRequestDefaults struct {
+	// DisableHappyEyeballs makes the proxy dial a backend's addresses one
+	// after another instead of racing IPv4 and IPv6. Routes can override it
+	// with the cf.k8s.lex.la/disable-happy-eyeballs annotation.
+	// +optional
+	DisableHappyEyeballs bool `json:"disableHappyEyeballs,omitempty"`
+
+	// ConnectTimeout is how long the proxy waits to establish a connection
+	// to a backend, as a duration in whole seconds ("30s", "2m"). Routes can
+	// override it with the cf.k8s.lex.la/connect-timeout annotation.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+h)?([0-9]+m)?([0-9]+s)?$`
+	ConnectTimeout string `json:"connectTimeout,omitempty"`
+
+	// TLSTimeout is how long the proxy waits for the TLS handshake with an
+	// https backend, as a duration in whole seconds. It has no effect on
+	// other backends.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+h)?([0-9]+m)?([0-9]+s)?$`
+	TLSTimeout string `json:"tlsTimeout,omitempty"`
+
+	// TCPKeepAlive is the keepalive interval of the proxy's TCP
+	// connections to backends, as a duration in whole seconds.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+h)?([0-9]+m)?([0-9]+s)?$`
+	TCPKeepAlive string `json:"tcpKeepAlive,omitempty"`
+
+	// KeepAliveConnections is the maximum number of idle keepalive
+	// connections the proxy keeps open to each HTTP/1.1 or https backend.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	KeepAliveConnections int32 `json:"keepAliveConnections,omitempty"`
 }
 
 // GatewayClassConfigStatus defines the observed state of GatewayClassConfig.
@@ -60,6 +170,7 @@ type GatewayClassConfigStatus struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,shortName=gcconfig
 // +kubebuilder:printcolumn:name="Tunnel ID",type=string,JSONPath=`.spec.tunnelID`
+// +kubebuilder:printcolumn:name="Tunnel Name",type=string,JSONPath=`.spec.tunnelName`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // GatewayClassConfig is the Schema for the gatewayclassconfigs API.
@@ -95,6 +95,17 @@ func (a *ProxyAutoscaling) EffectiveMetricName() string {
 	return a.MetricName
 }
 
+// ProxyPodDisruptionBudget configures a PodDisruptionBudget keeping at least
+// one proxy pod running through voluntary disruptions such as node drains.
+type ProxyPodDisruptionBudget struct {
+	// Enabled renders the PodDisruptionBudget (minAvailable: 1). It is only
+	// rendered while the Deployment runs more than one replica — with
+	// autoscaling, while minReplicas is above one — because on a single
+	// replica it would block every drain.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
 // GatewayConfigSpec configures a DEDICATED data plane for one Gateway: its
 // own proxy Deployment and its own Cloudflare Tunnel, instead of the shared
 // chart-deployed proxy pool serving every Gateway of the class. Referenced
@@ -144,6 +155,11 @@ type GatewayConfigSpec struct {
 	// +kubebuilder:validation:XValidation:rule="has(self.minReplicas) ? self.maxReplicas >= self.minReplicas : self.maxReplicas >= 2",message="maxReplicas must be >= minReplicas (minReplicas defaults to 2 when unset)"
 	Autoscaling *ProxyAutoscaling `json:"autoscaling,omitempty"`
 
+	// PodDisruptionBudget renders a PodDisruptionBudget for the proxy
+	// Deployment.
+	// +optional
+	PodDisruptionBudget *ProxyPodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+
 	// Resources sets the proxy container's resource requests and limits.
 	// Defaults to the controller's built-in proxy defaults when unset.
 	// +optional
@@ -153,3 +153,17 @@ refactor to sprig `keys` would silently drop it.)
 {{- join "," $terms -}}
 {{- end -}}
 
+
+{{/*
+keyValueList renders a string map as the comma-separated key=value list the
+controller's map flags (--common-labels, --common-annotations) take, keys in
+sortAlpha order so the rendered arg is stable across upgrades.
+*/}}
+{{- define "cf-tunnel-gw-ctrl.keyValueList" -}}
+{{- $map := . | default dict -}}
+{{- $pairs := list -}}
+{{- range $key := (keys $map | sortAlpha) -}}
+{{- $pairs = append $pairs (printf "%s=%s" $key (index $map $key)) -}}
+{{- end -}}
+{{- join "," $pairs -}}
+{{- end -}}
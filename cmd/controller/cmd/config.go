@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/controller"
+)
+
+// redactedValue replaces secret configuration values in printed output.
+const redactedValue = "<redacted>"
+
+// effectiveConfig is the printed form of controller.Config. Durations are
+// shown as Go duration strings rather than nanoseconds.
+type effectiveConfig struct {
+	controller.Config
+
+	SyncDebounce                  string
+	APIErrorRequeueDelay          string
+	StartupPendingRequeueDelay    string
+	ConfigValidationRequeueDelay  string
+	FullResyncInterval            string
+	TunnelConnectionsPollInterval string
+	CloudflareReadinessInterval   string
+}
+
+// newConfigCmd returns the `config` subcommand. It accepts the same flags
+// and CF_* environment variables as the controller itself, so the printed
+// configuration is exactly what the controller would run with.
+func newConfigCmd() *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Print the effective controller configuration and exit",
+		Long: `Print the configuration the controller would run with, after flags,
+CF_* environment variables and defaults are resolved, as JSON. Secret values
+such as the proxy auth token are redacted.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return printConfig(cmd.OutOrStdout())
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	// Share the root command's flag objects: viper is bound to them, so a
+	// flag set on `config` resolves exactly as it would on the root command.
+	configCmd.Flags().AddFlagSet(rootCmd.Flags())
+
+	return configCmd
+}
+
+// printConfig writes the effective configuration as indented JSON with
+// secrets redacted. Cluster-domain resolution is not logged, so the output
+// stays parseable.
+func printConfig(out io.Writer) error {
+	cfg := controllerConfig(slog.New(slog.DiscardHandler), viper.GetBool("tracing-enabled"))
+
+	printed := effectiveConfig{
+		Config:                        cfg,
+		SyncDebounce:                  cfg.SyncDebounce.String(),
+		APIErrorRequeueDelay:          cfg.APIErrorRequeueDelay.String(),
+		StartupPendingRequeueDelay:    cfg.StartupPendingRequeueDelay.String(),
+		ConfigValidationRequeueDelay:  cfg.ConfigValidationRequeueDelay.String(),
+		FullResyncInterval:            cfg.FullResyncInterval.String(),
+		TunnelConnectionsPollInterval: cfg.TunnelConnectionsPollInterval.String(),
+		CloudflareReadinessInterval:   cfg.CloudflareReadinessInterval.String(),
+	}
+
+	if printed.ProxyAuthToken != "" {
+		printed.ProxyAuthToken = redactedValue
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+
+	return errors.Wrap(encoder.Encode(printed), "failed to print configuration")
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPrintConfig pins the effective-configuration dump: resolved values
+// appear under their Config field names and the proxy auth token never does.
+func TestPrintConfig(t *testing.T) {
+	viper.Reset()
+	initConfig()
+	viper.Set("cluster-domain", "custom.local")
+	viper.Set("proxy-endpoints", []string{"http://proxy-0:8081"})
+	viper.Set("proxy-auth-token", "s3cr3t-bearer")
+	viper.Set("tunnel-connections-poll-interval", 30*time.Second)
+	viper.Set("cloudflare-readiness-interval", time.Minute)
+	viper.Set("sync-debounce", 2*time.Second)
+	viper.Set("dry-run", true)
+
+	var out bytes.Buffer
+	require.NoError(t, printConfig(&out))
+
+	assert.NotContains(t, out.String(), "s3cr3t-bearer", "the proxy auth token must be redacted")
+
+	var printed map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &printed), "output must be JSON: %s", out.String())
+
+	assert.Equal(t, "custom.local", printed["ClusterDomain"])
+	assert.Equal(t, "cf.k8s.lex.la/tunnel-controller", printed["ControllerName"])
+	assert.Equal(t, []any{"http://proxy-0:8081"}, printed["ProxyEndpoints"])
+	assert.Equal(t, redactedValue, printed["ProxyAuthToken"])
+	assert.Equal(t, "30s", printed["TunnelConnectionsPollInterval"])
+	assert.Equal(t, "1m0s", printed["CloudflareReadinessInterval"])
+	assert.Equal(t, "2s", printed["SyncDebounce"])
+	assert.Equal(t, true, printed["DryRun"])
+}
+
+// TestPrintConfig_EmptyTokenNotRedacted pins that an unset token reads as
+// unset rather than as a redacted secret.
+func TestPrintConfig_EmptyTokenNotRedacted(t *testing.T) {
+	viper.Reset()
+	initConfig()
+
+	var out bytes.Buffer
+	require.NoError(t, printConfig(&out))
+
+	var printed map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &printed))
+
+	assert.Empty(t, printed["ProxyAuthToken"])
+}
+
+// TestConfigCmd_SharesRootFlags pins that every controller flag is accepted
+// by the config subcommand.
+func TestConfigCmd_SharesRootFlags(t *testing.T) {
+	configCmd, _, err := rootCmd.Find([]string{"config"})
+	require.NoError(t, err)
+	require.Equal(t, "config", configCmd.Name())
+
+	for _, name := range []string{"proxy-endpoints", "proxy-auth-token", "dry-run", "cluster-domain"} {
+		assert.NotNil(t, configCmd.Flags().Lookup(name), "flag --%s", name)
+	}
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/option"
+	"github.com/cloudflare/cloudflare-go/v7/zero_trust"
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/ingress"
+)
+
+// apiTokenEnv is read when --api-token is not set, so the token need not
+// appear in shell history or CI logs.
+const apiTokenEnv = "CF_API_TOKEN"
+
+// errDrift is returned when the live tunnel configuration differs from the
+// one the manifests build, so the command exits non-zero.
+var errDrift = errors.New("live tunnel configuration differs from the manifests")
+
+// diffOptions are the `diff` subcommand's flags.
+type diffOptions struct {
+	manifestOptions
+
+	accountID string
+	tunnelID  string
+	apiToken  string
+}
+
+// newDiffCmd returns the `diff` subcommand. Like `render` it takes no
+// controller flags; it reads the live document with the given credentials
+// and never writes it.
+func newDiffCmd() *cobra.Command {
+	opts := diffOptions{}
+
+	diffCmd := &cobra.Command{
+		Use:   "diff --filename FILE --account-id ID --tunnel-id ID",
+		Short: "Compare a live tunnel's ingress configuration with the one built from manifests",
+		Long: `Build the tunnel ingress configuration from manifests exactly as render does,
+read the tunnel's current configuration from the Cloudflare API, and print
+the rules a sync would add, remove or change. The command exits non-zero when
+the two differ, so it can gate CI on drift. It only reads from Cloudflare.
+
+The API token is taken from --api-token or, when that is empty, from the
+CF_API_TOKEN environment variable.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			token := opts.apiToken
+			if token == "" {
+				token = os.Getenv(apiTokenEnv)
+			}
+
+			if token == "" {
+				return errors.Newf("an API token is required: set --api-token or %s", apiTokenEnv)
+			}
+
+			cfClient := cloudflare.NewClient(option.WithAPIToken(token))
+
+			return runDiff(cmd.Context(), cfClient, cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr(), opts)
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	addManifestFlags(diffCmd, &opts.manifestOptions)
+	diffCmd.Flags().StringVar(&opts.accountID, "account-id", "", "Cloudflare account ID owning the tunnel")
+	diffCmd.Flags().StringVar(&opts.tunnelID, "tunnel-id", "", "ID of the tunnel whose configuration is compared")
+	diffCmd.Flags().StringVar(&opts.apiToken, "api-token", "",
+		"Cloudflare API token with tunnel read access (default $"+apiTokenEnv+")")
+	_ = diffCmd.MarkFlagRequired("account-id")
+	_ = diffCmd.MarkFlagRequired("tunnel-id")
+
+	return diffCmd
+}
+
+// runDiff builds the desired document, reads the live one through cfClient
+// and prints the changes between them. It returns errDrift when there are
+// any.
+func runDiff(
+	ctx context.Context,
+	cfClient *cloudflare.Client,
+	stdin io.Reader,
+	out, errOut io.Writer,
+	opts diffOptions,
+) error {
+	desired, err := buildFromManifests(ctx, stdin, errOut, opts.manifestOptions)
+	if err != nil {
+		return err
+	}
+
+	current, err := cfClient.ZeroTrust.Tunnels.Cloudflared.Configurations.Get(ctx, opts.tunnelID,
+		zero_trust.TunnelCloudflaredConfigurationGetParams{AccountID: cloudflare.F(opts.accountID)})
+	if err != nil {
+		return errors.Wrapf(err, "failed to get configuration of tunnel %s", opts.tunnelID)
+	}
+
+	changes := ingress.ComputeChanges(current.Config.Ingress, desired)
+	if changes.IsEmpty() {
+		_, err = fmt.Fprintf(out, "tunnel %s matches the manifests\n", opts.tunnelID)
+
+		return errors.Wrap(err, "failed to print diff")
+	}
+
+	if err := writeChanges(out, changes); err != nil {
+		return err
+	}
+
+	return errDrift
+}
+
+// writeChanges prints changes one rule per line: "+" for a rule the
+// manifests add, "-" for a deployed rule they drop, and "~" with the
+// deployed rule followed by what it becomes for a rule whose settings differ.
+func writeChanges(out io.Writer, changes ingress.Changes) error {
+	var lines []string
+
+	for _, rule := range changes.Added {
+		lines = append(lines, "+ "+formatRule(rule))
+	}
+
+	for _, rule := range changes.Removed {
+		lines = append(lines, "- "+formatRule(rule))
+	}
+
+	for _, change := range changes.Modified {
+		lines = append(lines, "~ "+formatRule(change.Before), "  becomes "+formatRule(change.After))
+	}
+
+	if changes.Reordered {
+		lines = append(lines, "rules are deployed in a different order")
+	}
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(out, line); err != nil {
+			return errors.Wrap(err, "failed to print diff")
+		}
+	}
+
+	return nil
+}
+
+// formatRule renders rule as "hostname path -> service", with "*" standing
+// in for a missing hostname and the origin settings that differ between
+// rules appended when set.
+func formatRule(rule ingress.Rule) string {
+	hostname := rule.Hostname
+	if hostname == "" {
+		hostname = "*"
+	}
+
+	formatted := hostname
+	if rule.Path != "" {
+		formatted += " " + rule.Path
+	}
+
+	formatted += " -> " + rule.Service
+
+	if rule.HostHeader != "" {
+		formatted += " hostHeader=" + rule.HostHeader
+	}
+
+	return formatted
+}
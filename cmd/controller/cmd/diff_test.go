@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/ingress"
+)
+
+// fakeTunnelAPI serves live as the live configuration of tunnel-1 in
+// account acct-1 and records the request methods it saw.
+func fakeTunnelAPI(t *testing.T, live []map[string]any) (*cloudflare.Client, *[]string) {
+	t.Helper()
+
+	var methods []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		methods = append(methods, req.Method)
+
+		writer.Header().Set("Content-Type", "application/json")
+
+		if req.URL.Path != "/accounts/acct-1/cfd_tunnel/tunnel-1/configurations" {
+			writer.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(writer).Encode(map[string]any{
+				"success": false, "errors": []any{map[string]any{"code": 1003, "message": "not found"}},
+			})
+
+			return
+		}
+
+		_ = json.NewEncoder(writer).Encode(map[string]any{
+			"success": true, "errors": []any{},
+			"result": map[string]any{"tunnel_id": "tunnel-1", "config": map[string]any{"ingress": live}},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return cloudflare.NewClient(
+		option.WithAPIToken("test-token"),
+		option.WithBaseURL(server.URL),
+		option.WithMaxRetries(0),
+	), &methods
+}
+
+func fixtureDiffOptions() diffOptions {
+	return diffOptions{
+		manifestOptions: manifestOptions{
+			filenames:     []string{"testdata/render/httproute.yaml", "testdata/render/grpcroute.yaml"},
+			clusterDomain: defaultRenderClusterDomain,
+		},
+		accountID: "acct-1",
+		tunnelID:  "tunnel-1",
+	}
+}
+
+// TestDiff_InSync pins that a live document equal to the rendered one is
+// reported as matching and exits zero, with the API only read.
+func TestDiff_InSync(t *testing.T) {
+	cfClient, methods := fakeTunnelAPI(t, []map[string]any{
+		{"hostname": "app.example.com", "path": "/api*", "service": "http://web.default.svc.cluster.local:8080"},
+		{"hostname": "app.example.com", "service": "http://web.default.svc.cluster.local:8080"},
+		{
+			"hostname": "grpc.example.com", "path": `/helloworld\.Greeter/*`,
+			"service": "http://greeter.default.svc.cluster.local:9000",
+		},
+		{"service": "http_status:404"},
+	})
+
+	var out, errOut bytes.Buffer
+	require.NoError(t, runDiff(context.Background(), cfClient, strings.NewReader(""), &out, &errOut,
+		fixtureDiffOptions()))
+
+	assert.Equal(t, "tunnel tunnel-1 matches the manifests\n", out.String())
+	assert.Equal(t, []string{http.MethodGet}, *methods)
+}
+
+// TestDiff_Drift pins the printed changes and the non-zero exit when the live
+// document has a stale rule, a rule pointing elsewhere and lacks a route.
+func TestDiff_Drift(t *testing.T) {
+	cfClient, methods := fakeTunnelAPI(t, []map[string]any{
+		{"hostname": "app.example.com", "path": "/api*", "service": "http://web.default.svc.cluster.local:8080"},
+		{"hostname": "app.example.com", "service": "http://legacy.default.svc.cluster.local:80"},
+		{"hostname": "old.example.com", "service": "http://old.default.svc.cluster.local:80"},
+		{"service": "http_status:404"},
+	})
+
+	var out, errOut bytes.Buffer
+	err := runDiff(context.Background(), cfClient, strings.NewReader(""), &out, &errOut, fixtureDiffOptions())
+	require.ErrorIs(t, err, errDrift)
+
+	assert.Equal(t, strings.Join([]string{
+		"+ grpc.example.com /helloworld\\.Greeter/* -> http://greeter.default.svc.cluster.local:9000",
+		"- old.example.com -> http://old.default.svc.cluster.local:80",
+		"~ app.example.com -> http://legacy.default.svc.cluster.local:80",
+		"  becomes app.example.com -> http://web.default.svc.cluster.local:8080",
+	}, "\n")+"\n", out.String())
+	assert.Equal(t, []string{http.MethodGet}, *methods, "diff must never write the tunnel")
+}
+
+// TestDiff_APIError pins that a failed read is an error naming the tunnel,
+// not a reported drift.
+func TestDiff_APIError(t *testing.T) {
+	cfClient, _ := fakeTunnelAPI(t, nil)
+
+	opts := fixtureDiffOptions()
+	opts.tunnelID = "tunnel-2"
+
+	var out, errOut bytes.Buffer
+	err := runDiff(context.Background(), cfClient, strings.NewReader(""), &out, &errOut, opts)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, errDrift)
+	assert.Contains(t, err.Error(), "tunnel-2")
+}
+
+func TestFormatRule_OriginSettings(t *testing.T) {
+	assert.Equal(t, "* -> http_status:404", formatRule(ingress.Rule{Service: "http_status:404"}))
+
+	rule := ingress.Rule{
+		Hostname:   "api.example.com",
+		Path:       "/v1*",
+		Service:    "https://api.default.svc.cluster.local:443",
+		HostHeader: "api.internal",
+	}
+
+	assert.Equal(t,
+		"api.example.com /v1* -> https://api.default.svc.cluster.local:443 hostHeader=api.internal",
+		formatRule(rule))
+}
+
+// TestDiffCmd_Registered pins the subcommand's flags.
+func TestDiffCmd_Registered(t *testing.T) {
+	diffCmd, _, err := rootCmd.Find([]string{"diff"})
+	require.NoError(t, err)
+	require.Equal(t, "diff", diffCmd.Name())
+
+	for _, name := range []string{"filename", "cluster-domain", "account-id", "tunnel-id", "api-token"} {
+		assert.NotNil(t, diffCmd.Flags().Lookup(name), "flag --%s", name)
+	}
+}
@@ -0,0 +1,346 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/zero_trust"
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/controller"
+)
+
+const (
+	renderOutputJSON = "json"
+	renderOutputYAML = "yaml"
+
+	// defaultRenderClusterDomain stands in for auto-detection, which needs a
+	// cluster.
+	defaultRenderClusterDomain = "cluster.local"
+
+	// stdinFilename reads manifests from standard input, as with kubectl.
+	stdinFilename = "-"
+)
+
+// manifestOptions are the flags shared by the subcommands that build the
+// ingress document from manifests.
+type manifestOptions struct {
+	filenames     []string
+	clusterDomain string
+}
+
+// renderOptions are the `render` subcommand's flags.
+type renderOptions struct {
+	manifestOptions
+
+	output string
+}
+
+// newRenderCmd returns the `render` subcommand. Unlike `config` it does not
+// share the root command's flags: it never talks to a cluster or Cloudflare,
+// so none of them apply.
+func newRenderCmd() *cobra.Command {
+	opts := renderOptions{}
+
+	renderCmd := &cobra.Command{
+		Use:   "render --filename FILE [--filename FILE...]",
+		Short: "Print the tunnel ingress configuration built from manifests and exit",
+		Long: `Build the Cloudflare Tunnel ingress configuration from HTTPRoute and GRPCRoute
+manifests the way the controller would, and print it without contacting a
+cluster or Cloudflare. Services, ReferenceGrants and
+ExternalBackends in the same files resolve backend references; other kinds
+are ignored. Backend references that fail validation are reported on stderr.
+
+Gateway health rules, listener-scoped catch-alls and GatewayClassConfig origin
+defaults depend on live cluster state and are not rendered.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runRender(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr(), opts)
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	addManifestFlags(renderCmd, &opts.manifestOptions)
+	renderCmd.Flags().StringVarP(&opts.output, "output", "o", renderOutputJSON, "Output format (json, yaml)")
+
+	return renderCmd
+}
+
+// addManifestFlags registers the manifestOptions flags on cmd.
+func addManifestFlags(cmd *cobra.Command, opts *manifestOptions) {
+	cmd.Flags().StringSliceVarP(&opts.filenames, "filename", "f", nil,
+		"Manifest file to read; repeat or comma-separate for several, - reads stdin")
+	cmd.Flags().StringVar(&opts.clusterDomain, "cluster-domain", defaultRenderClusterDomain,
+		"Kubernetes cluster domain backend Service URLs are built under")
+	_ = cmd.MarkFlagRequired("filename")
+}
+
+// runRender decodes the manifests, builds the ingress document and writes it
+// to out in the requested format.
+func runRender(ctx context.Context, stdin io.Reader, out, errOut io.Writer, opts renderOptions) error {
+	if opts.output != renderOutputJSON && opts.output != renderOutputYAML {
+		return errors.Newf("unsupported output format %q (want json or yaml)", opts.output)
+	}
+
+	rules, err := buildFromManifests(ctx, stdin, errOut, opts.manifestOptions)
+	if err != nil {
+		return err
+	}
+
+	return writeIngress(out, rules, opts.output)
+}
+
+// buildFromManifests decodes the manifests and builds their ingress document,
+// reporting backend references that fail validation to errOut.
+func buildFromManifests(
+	ctx context.Context,
+	stdin io.Reader,
+	errOut io.Writer,
+	opts manifestOptions,
+) ([]zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress, error) {
+	manifests, err := loadManifests(stdin, opts.filenames)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := controller.RenderIngress(ctx, manifests.reader, opts.clusterDomain,
+		manifests.httpRoutes, manifests.grpcRoutes)
+
+	for _, failed := range rendered.FailedRefs {
+		_, _ = fmt.Fprintf(errOut, "warning: %s/%s: backend %s/%s: %s: %s\n",
+			failed.RouteNamespace, failed.RouteName, failed.BackendNS, failed.BackendName,
+			failed.Reason, failed.Message)
+	}
+
+	for _, invalid := range rendered.InvalidRules {
+		_, _ = fmt.Fprintf(errOut, "warning: %s/%s: rule %d left out: %v\n",
+			invalid.RouteNamespace, invalid.RouteName, invalid.RuleIndex, invalid.Err)
+	}
+
+	return rendered.Rules, nil
+}
+
+// writeIngress prints rules as the `config` object of a Cloudflare tunnel
+// configuration update.
+func writeIngress(
+	out io.Writer,
+	rules []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress,
+	format string,
+) error {
+	document, err := json.Marshal(zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfig{
+		Ingress: cloudflare.F(rules),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to encode ingress configuration")
+	}
+
+	if format == renderOutputYAML {
+		document, err = yaml.JSONToYAML(document)
+		if err != nil {
+			return errors.Wrap(err, "failed to encode ingress configuration as YAML")
+		}
+
+		_, err = out.Write(document)
+
+		return errors.Wrap(err, "failed to print ingress configuration")
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, document, "", "  "); err != nil {
+		return errors.Wrap(err, "failed to indent ingress configuration")
+	}
+
+	indented.WriteByte('\n')
+
+	_, err = indented.WriteTo(out)
+
+	return errors.Wrap(err, "failed to print ingress configuration")
+}
+
+// renderManifests holds the routes to build and a reader serving every
+// decoded object, so backend Services and ReferenceGrants resolve as they
+// would in the cluster.
+type renderManifests struct {
+	reader     client.Client
+	httpRoutes []gatewayv1.HTTPRoute
+	grpcRoutes []gatewayv1.GRPCRoute
+}
+
+// addRoute records obj for building when it is a route.
+func (m *renderManifests) addRoute(obj client.Object) {
+	switch route := obj.(type) {
+	case *gatewayv1.HTTPRoute:
+		m.httpRoutes = append(m.httpRoutes, *route)
+	case *gatewayv1.GRPCRoute:
+		m.grpcRoutes = append(m.grpcRoutes, *route)
+	}
+}
+
+// renderScheme returns the scheme manifests are decoded with: core types,
+// the Gateway API kinds the builders read, and the controller's own CRDs.
+func renderScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1.Install(scheme))
+	utilruntime.Must(gatewayv1beta1.Install(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+
+	return scheme
+}
+
+// loadManifests decodes every YAML document in filenames. Kinds the scheme
+// does not know are skipped; a malformed document is an error naming its file.
+func loadManifests(stdin io.Reader, filenames []string) (renderManifests, error) {
+	scheme := renderScheme()
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+
+	var (
+		manifests renderManifests
+		objects   []client.Object
+		seen      = make(map[string]bool)
+	)
+
+	for _, filename := range filenames {
+		data, err := readManifest(stdin, filename)
+		if err != nil {
+			return renderManifests{}, err
+		}
+
+		for index, document := range splitYAMLDocuments(data) {
+			obj, _, err := decoder.Decode(document, nil, nil)
+			if runtime.IsNotRegisteredError(err) || runtime.IsMissingKind(err) {
+				continue
+			}
+
+			if err != nil {
+				return renderManifests{}, errors.Wrapf(err, "%s: document %d", filename, index+1)
+			}
+
+			clientObj := renderObject(obj)
+			if clientObj == nil {
+				continue
+			}
+
+			if clientObj.GetNamespace() == "" {
+				clientObj.SetNamespace(metav1.NamespaceDefault)
+			}
+
+			gvk, err := apiutil.GVKForObject(clientObj, scheme)
+			if err != nil {
+				return renderManifests{}, errors.Wrapf(err, "%s: document %d", filename, index+1)
+			}
+
+			key := gvk.Kind + " " + clientObj.GetNamespace() + "/" + clientObj.GetName()
+			if seen[key] {
+				return renderManifests{}, errors.Newf("%s: document %d: duplicate %s", filename, index+1, key)
+			}
+
+			seen[key] = true
+
+			manifests.addRoute(clientObj)
+			objects = append(objects, clientObj)
+		}
+	}
+
+	manifests.reader = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	return manifests, nil
+}
+
+// renderObject returns obj when the builders read its kind, and nil for any
+// other kind. A gateway.networking.k8s.io/v1 ReferenceGrant is stored as
+// v1beta1, the version the ReferenceGrant validator lists.
+func renderObject(obj runtime.Object) client.Object {
+	switch typed := obj.(type) {
+	case *gatewayv1.HTTPRoute, *gatewayv1.GRPCRoute,
+		*corev1.Service, *gatewayv1beta1.ReferenceGrant, *v1alpha1.ExternalBackend:
+		clientObj, _ := typed.(client.Object)
+
+		return clientObj
+	case *gatewayv1.ReferenceGrant:
+		grant := gatewayv1beta1.ReferenceGrant(*typed)
+		grant.TypeMeta = metav1.TypeMeta{}
+
+		return &grant
+	default:
+		return nil
+	}
+}
+
+// readManifest returns the contents of filename, or of stdin for "-".
+func readManifest(stdin io.Reader, filename string) ([]byte, error) {
+	if filename == stdinFilename {
+		data, err := io.ReadAll(stdin)
+
+		return data, errors.Wrap(err, "failed to read manifests from stdin")
+	}
+
+	data, err := os.ReadFile(filename)
+
+	return data, errors.Wrapf(err, "failed to read %s", filename)
+}
+
+// splitYAMLDocuments splits a multi-document YAML stream on "---" separator
+// lines, dropping documents that hold only whitespace or comments.
+func splitYAMLDocuments(data []byte) [][]byte {
+	var (
+		documents [][]byte
+		current   bytes.Buffer
+	)
+
+	flush := func() {
+		if hasYAMLContent(current.String()) {
+			documents = append(documents, bytes.Clone(current.Bytes()))
+		}
+
+		current.Reset()
+	}
+
+	for line := range strings.Lines(string(data)) {
+		if strings.TrimSpace(line) == "---" {
+			flush()
+
+			continue
+		}
+
+		current.WriteString(line)
+	}
+
+	flush()
+
+	return documents
+}
+
+// hasYAMLContent reports whether document holds anything besides blank lines
+// and comments.
+func hasYAMLContent(document string) bool {
+	for line := range strings.Lines(document) {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+// renderedRule is the printed shape of one ingress rule.
+type renderedRule struct {
+	Hostname string `json:"hostname"`
+	Path     string `json:"path"`
+	Service  string `json:"service"`
+}
+
+// renderedDocument is the printed shape of the rendered configuration.
+type renderedDocument struct {
+	Ingress []renderedRule `json:"ingress"`
+}
+
+func renderFixtures(t *testing.T, opts renderOptions) (renderedDocument, string) {
+	t.Helper()
+
+	var out, errOut bytes.Buffer
+	require.NoError(t, runRender(context.Background(), strings.NewReader(""), &out, &errOut, opts))
+
+	var document renderedDocument
+	if opts.output == renderOutputYAML {
+		require.NoError(t, yaml.Unmarshal(out.Bytes(), &document), "output must be YAML: %s", out.String())
+	} else {
+		require.NoError(t, json.Unmarshal(out.Bytes(), &document), "output must be JSON: %s", out.String())
+	}
+
+	return document, errOut.String()
+}
+
+// TestRender_Fixtures pins the rendered document for an HTTPRoute and a
+// GRPCRoute read from separate files: routes sort by hostname, longer paths
+// first, Service URLs use the cluster domain, and the 404 catch-all closes it.
+func TestRender_Fixtures(t *testing.T) {
+	document, warnings := renderFixtures(t, renderOptions{
+		manifestOptions: manifestOptions{
+			filenames:     []string{"testdata/render/httproute.yaml", "testdata/render/grpcroute.yaml"},
+			clusterDomain: defaultRenderClusterDomain,
+		},
+		output: renderOutputJSON,
+	})
+
+	assert.Empty(t, warnings)
+	assert.Equal(t, []renderedRule{
+		{Hostname: "app.example.com", Path: "/api*", Service: "http://web.default.svc.cluster.local:8080"},
+		{Hostname: "app.example.com", Service: "http://web.default.svc.cluster.local:8080"},
+		{
+			Hostname: "grpc.example.com",
+			Path:     `/helloworld\.Greeter/*`,
+			Service:  "http://greeter.default.svc.cluster.local:9000",
+		},
+		{Service: "http_status:404"},
+	}, document.Ingress)
+}
+
+// TestRender_ClusterDomainAndYAML pins that --cluster-domain reaches the
+// Service URLs and that -o yaml prints the same document as YAML.
+func TestRender_ClusterDomainAndYAML(t *testing.T) {
+	document, _ := renderFixtures(t, renderOptions{
+		manifestOptions: manifestOptions{
+			filenames:     []string{"testdata/render/httproute.yaml"},
+			clusterDomain: "corp.local",
+		},
+		output: renderOutputYAML,
+	})
+
+	require.Len(t, document.Ingress, 3)
+	assert.Equal(t, "http://web.default.svc.corp.local:8080", document.Ingress[0].Service)
+	assert.Equal(t, "http_status:404", document.Ingress[2].Service)
+}
+
+// TestRender_MissingServiceWarns pins that a backend the manifests do not
+// define is reported on stderr rather than silently dropped.
+func TestRender_MissingServiceWarns(t *testing.T) {
+	_, warnings := renderFixtures(t, renderOptions{
+		manifestOptions: manifestOptions{
+			filenames:     []string{"testdata/render/grpcroute-only.yaml"},
+			clusterDomain: defaultRenderClusterDomain,
+		},
+		output: renderOutputJSON,
+	})
+
+	assert.Contains(t, warnings, "default/greeter: backend default/greeter")
+}
+
+// TestRender_Errors pins the inputs render refuses.
+func TestRender_Errors(t *testing.T) {
+	stdin := manifestOptions{filenames: []string{"-"}}
+	absent := manifestOptions{filenames: []string{"testdata/render/absent.yaml"}}
+
+	tests := []struct {
+		name    string
+		stdin   string
+		opts    renderOptions
+		wantErr string
+	}{
+		{
+			name:    "unknown output format",
+			opts:    renderOptions{manifestOptions: stdin, output: "toml"},
+			wantErr: "unsupported output format",
+		},
+		{
+			name:    "missing file",
+			opts:    renderOptions{manifestOptions: absent, output: renderOutputJSON},
+			wantErr: "absent.yaml",
+		},
+		{
+			name:    "malformed document",
+			stdin:   "apiVersion: v1\nkind: Service\nspec: [\n",
+			opts:    renderOptions{manifestOptions: stdin, output: renderOutputJSON},
+			wantErr: "-: document 1",
+		},
+		{
+			name:    "duplicate object",
+			stdin:   "apiVersion: v1\nkind: Service\nmetadata:\n  name: web\n---\napiVersion: v1\nkind: Service\nmetadata:\n  name: web\n  namespace: default\n",
+			opts:    renderOptions{manifestOptions: stdin, output: renderOutputJSON},
+			wantErr: "duplicate Service default/web",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+
+			err := runRender(context.Background(), strings.NewReader(tt.stdin), &out, &errOut, tt.opts)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSplitYAMLDocuments(t *testing.T) {
+	input := "# leading comment\n---\nkind: A\n---   \n\n---\nkind: B\n  --- not a separator\n"
+
+	documents := splitYAMLDocuments([]byte(input))
+
+	require.Len(t, documents, 2)
+	assert.Equal(t, "kind: A\n", string(documents[0]))
+	assert.Equal(t, "kind: B\n  --- not a separator\n", string(documents[1]))
+}
+
+// TestRenderCmd_Registered pins that the subcommand is reachable from the
+// root command and requires --filename.
+func TestRenderCmd_Registered(t *testing.T) {
+	renderCmd, _, err := rootCmd.Find([]string{"render"})
+	require.NoError(t, err)
+	require.Equal(t, "render", renderCmd.Name())
+
+	for _, name := range []string{"filename", "cluster-domain", "output"} {
+		assert.NotNil(t, renderCmd.Flags().Lookup(name), "flag --%s", name)
+	}
+
+	assert.Nil(t, renderCmd.Flags().Lookup("proxy-endpoints"), "render must not take controller flags")
+}
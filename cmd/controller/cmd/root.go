@@ -74,12 +74,33 @@ func init() {
 	rootCmd.Flags().String("proxy-deployment-label", "", "Label selector identifying the proxy Deployment(s) to roll on tunnel-token change, in `key=value` form. Defaults to `app.kubernetes.io/component=proxy` (matches the chart).")
 	rootCmd.Flags().String("tunnel-protocol", "auto", "The proxy's configured edge transport (auto|http2|quic); used to warn when GRPCRoutes are present on an explicit quic tunnel, which cannot carry gRPC trailers (auto/unset is upgraded to http2 by the proxy).")
 
+	rootCmd.Flags().Bool("dry-run", false, "Compute and log the tunnel ingress configuration and the proxy config without writing the one to Cloudflare or pushing the other to the proxy. Routes with pending changes get a cf.k8s.lex.la/DryRun=True condition.")
+	rootCmd.Flags().String("grpc-catch-all", "none", "How gRPC calls to a hostname served only by GRPCRoutes that match none of its rules are answered: none (UNIMPLEMENTED) or unavailable (UNAVAILABLE).")
+	rootCmd.Flags().String("shared-tunnel-policy", "warn", "How to handle GatewayClasses whose different GatewayClassConfigs name the same tunnel: warn (refuse to sync and flag the classes with a TunnelShared condition) or merge (write all their routes into one ingress document with the first class's credentials).")
+
+	rootCmd.Flags().Float64("cloudflare-api-rate-limit", 0, "Maximum Cloudflare API requests per second, shared across all tunnels. Requests over the limit are queued and dispatched evenly instead of bursting. 0 disables the limit.")
+
 	rootCmd.Flags().String("proxy-image", "", "Container image for per-Gateway rendered proxy Deployments (GatewayConfig data planes). Empty disables rendering defaults; the chart always sets it to the release's proxy image.")
+	rootCmd.Flags().StringToString("common-labels", nil, "Labels stamped on every object the controller creates (per-Gateway Deployment, Service, HPA, NetworkPolicy, generated auth Secret), as `key=value` pairs separated by commas. Gateway infrastructure labels and controller-owned keys take precedence.")
+	rootCmd.Flags().StringToString("common-annotations", nil, "Annotations stamped on every object the controller creates, as `key=value` pairs separated by commas. Gateway infrastructure annotations take precedence.")
 
 	// Hostname-ownership enforcement (issue #475, controller-side layer).
 	rootCmd.Flags().Bool("hostname-ownership-enforce", false, "Enforce per-namespace hostname ownership in the controller: routes whose hostnames fall outside their namespace's allowed-suffix label are rejected and never programmed. Complements (and is independent of) the chart's ValidatingAdmissionPolicy.")
 	rootCmd.Flags().String("hostname-ownership-label-key", "cf.k8s.lex.la/hostname-suffix", "Namespace label carrying the tenant's allowed hostname suffix.")
 	rootCmd.Flags().String("hostname-ownership-namespace-selector", "", "Label selector scoping which namespaces are policed (kubectl syntax). Empty polices every namespace (fail-closed).")
+	rootCmd.Flags().Bool("route-opt-in", false, "Manage only routes annotated with --route-opt-in-annotation set to \"true\"; other routes are ignored even when they reference our Gateways. Lets teams migrate routes onto the controller incrementally.")
+	rootCmd.Flags().String("route-opt-in-annotation", controller.DefaultRouteOptInAnnotation, "Route annotation that opts a route in when --route-opt-in is set.")
+	rootCmd.Flags().Float64("gateway-degraded-threshold", 0.5, "Fraction of a Gateway's attached routes with unresolved backend references above which the Gateway reports cf.k8s.lex.la/Degraded=True, pointing at a shared cause such as cluster DNS. 0 disables the condition.")
+	rootCmd.Flags().Bool("allow-force-attach", false, "Let routes annotated with "+controller.ForceAttachAnnotation+": \"true\" bind to Gateways whose listeners rejected them, with a Warning Event and an Accepted reason of ForceAttached. A debugging aid for staging; do not enable in production.")
+	rootCmd.Flags().Duration("sync-debounce", 0, "Collapse the full tunnel syncs that route changes request within this window into one, so a bulk apply of many routes writes the tunnel once. Each change waits up to the window before it is applied. 0 syncs on every change.")
+	rootCmd.Flags().Duration("api-error-requeue-delay", 15*time.Second, "How long a route reconcile waits before retrying a failed Cloudflare API sync, and the startup sync between attempts. A Cloudflare rate limit stretches the wait to its Retry-After.")
+	rootCmd.Flags().Duration("startup-pending-requeue-delay", time.Second, "How long a route reconcile that arrives before the startup sync finished waits to be retried.")
+	rootCmd.Flags().Duration("config-validation-requeue-delay", 5*time.Minute, "How often each GatewayClassConfig is re-validated, picking up credentials Secrets that appeared or vanished without an event.")
+	rootCmd.Flags().Duration("full-resync-interval", 0, "Run a full tunnel sync on this interval even without route changes, rereading every tunnel document so out-of-band edits in the dashboard or API are reverted. 0 disables the timer.")
+	rootCmd.Flags().Bool("verify-tunnel-exists", false, "Also check through the Cloudflare API that each GatewayClassConfig's tunnel exists, reporting Valid=False with reason TunnelNotFound when it does not. Off, config validation makes no network calls.")
+	rootCmd.Flags().Duration("tunnel-connections-poll-interval", 0, "How often to read each managed tunnel's connection count from the Cloudflare API and export it as cftunnel_tunnel_connections. 0 disables polling.")
+	rootCmd.Flags().Duration("cloudflare-readiness-interval", 0, "How often to verify through the Cloudflare API that each managed tunnel's credentials are accepted and the tunnel exists; /readyz fails after --cloudflare-readiness-failure-threshold consecutive failures. 0 disables the check.")
+	rootCmd.Flags().Int("cloudflare-readiness-failure-threshold", controller.DefaultCloudflareReadinessFailureThreshold, "Consecutive failed Cloudflare checks before the controller reports not ready.")
 	rootCmd.Flags().String("monitoring-namespace-selector", "", "Label selector (kubectl syntax) for namespaces additionally allowed to reach a per-Gateway proxy's config-API/metrics port in the rendered NetworkPolicy. Empty allows the controller namespace only.")
 	rootCmd.Flags().Bool("render-network-policy", true, "Render the per-Gateway config-API NetworkPolicy (wired from the chart's proxy.networkPolicy.enabled). Set false on strict CNIs where node-sourced kubelet probes are blocked by the policy's namespaceSelector ingress rule; a previously-rendered policy is then deleted.")
 
@@ -99,6 +120,10 @@ func init() {
 
 	_ = viper.BindPFlags(rootCmd.Flags())
 	_ = viper.BindPFlags(rootCmd.PersistentFlags())
+
+	rootCmd.AddCommand(newConfigCmd())
+	rootCmd.AddCommand(newRenderCmd())
+	rootCmd.AddCommand(newDiffCmd())
 }
 
 func initConfig() {
@@ -115,6 +140,8 @@ func initConfig() {
 	viper.SetDefault("log-level", "info")
 	viper.SetDefault("log-format", "json")
 	viper.SetDefault("tunnel-protocol", "auto")
+	viper.SetDefault("shared-tunnel-policy", "warn")
+	viper.SetDefault("cloudflare-api-rate-limit", 0.0)
 	viper.SetDefault("leader-elect", false)
 	viper.SetDefault("leader-election-name", "cloudflare-tunnel-gateway-controller-leader")
 	viper.SetDefault("tracing-enabled", false)
@@ -171,7 +198,19 @@ func runController(_ *cobra.Command, _ []string) error {
 	shutdownTracing := setupTracing(ctx, logger, tracingEnabled)
 	defer shutdownTracing()
 
-	cfg := controller.Config{
+	cfg := controllerConfig(logger, tracingEnabled)
+
+	if err := controller.Run(ctx, &cfg); err != nil {
+		return errors.Wrap(err, "failed to run controller")
+	}
+
+	return nil
+}
+
+// controllerConfig builds the controller configuration from the resolved
+// flags and CF_* environment variables.
+func controllerConfig(logger *slog.Logger, tracingEnabled bool) controller.Config {
+	return controller.Config{
 		ClusterDomain:  resolveClusterDomain(logger),
 		ControllerName: viper.GetString("controller-name"),
 		MetricsAddr:    viper.GetString("metrics-addr"),
@@ -187,21 +226,35 @@ func runController(_ *cobra.Command, _ []string) error {
 		ProxyDeploymentLabel: viper.GetString("proxy-deployment-label"),
 		TunnelProtocol:       viper.GetString("tunnel-protocol"),
 		Tracing:              tracingEnabled,
-
-		ProxyImage: viper.GetString("proxy-image"),
-
-		HostnameOwnershipEnforce:           viper.GetBool("hostname-ownership-enforce"),
-		HostnameOwnershipLabelKey:          viper.GetString("hostname-ownership-label-key"),
-		HostnameOwnershipNamespaceSelector: viper.GetString("hostname-ownership-namespace-selector"),
-		MonitoringNamespaceSelector:        viper.GetString("monitoring-namespace-selector"),
-		RenderNetworkPolicy:                viper.GetBool("render-network-policy"),
+		SharedTunnelPolicy:   viper.GetString("shared-tunnel-policy"),
+		GRPCCatchAll:         viper.GetString("grpc-catch-all"),
+		DryRun:               viper.GetBool("dry-run"),
+
+		CloudflareAPIRateLimit: viper.GetFloat64("cloudflare-api-rate-limit"),
+
+		ProxyImage:        viper.GetString("proxy-image"),
+		CommonLabels:      viper.GetStringMapString("common-labels"),
+		CommonAnnotations: viper.GetStringMapString("common-annotations"),
+
+		HostnameOwnershipEnforce:            viper.GetBool("hostname-ownership-enforce"),
+		HostnameOwnershipLabelKey:           viper.GetString("hostname-ownership-label-key"),
+		HostnameOwnershipNamespaceSelector:  viper.GetString("hostname-ownership-namespace-selector"),
+		RouteOptIn:                          viper.GetBool("route-opt-in"),
+		RouteOptInAnnotation:                viper.GetString("route-opt-in-annotation"),
+		AllowForceAttach:                    viper.GetBool("allow-force-attach"),
+		GatewayDegradedThreshold:            viper.GetFloat64("gateway-degraded-threshold"),
+		SyncDebounce:                        viper.GetDuration("sync-debounce"),
+		APIErrorRequeueDelay:                viper.GetDuration("api-error-requeue-delay"),
+		StartupPendingRequeueDelay:          viper.GetDuration("startup-pending-requeue-delay"),
+		ConfigValidationRequeueDelay:        viper.GetDuration("config-validation-requeue-delay"),
+		FullResyncInterval:                  viper.GetDuration("full-resync-interval"),
+		VerifyTunnelExists:                  viper.GetBool("verify-tunnel-exists"),
+		TunnelConnectionsPollInterval:       viper.GetDuration("tunnel-connections-poll-interval"),
+		CloudflareReadinessInterval:         viper.GetDuration("cloudflare-readiness-interval"),
+		CloudflareReadinessFailureThreshold: viper.GetInt("cloudflare-readiness-failure-threshold"),
+		MonitoringNamespaceSelector:         viper.GetString("monitoring-namespace-selector"),
+		RenderNetworkPolicy:                 viper.GetBool("render-network-policy"),
 	}
-
-	if err := controller.Run(ctx, &cfg); err != nil {
-		return errors.Wrap(err, "failed to run controller")
-	}
-
-	return nil
 }
 
 // setupTracing installs a global OpenTelemetry TracerProvider + propagator via
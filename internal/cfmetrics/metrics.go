@@ -15,6 +15,9 @@ const (
 	labelMethod    = "method"
 	labelErrorType = "error_type"
 	labelResource  = "resource"
+	labelClass     = "gatewayclass"
+	labelCtrl      = "controller"
+	labelPod       = "pod"
 )
 
 // Collector provides metrics recording interface.
@@ -31,9 +34,20 @@ type Collector interface {
 	RecordAPICall(ctx context.Context, method, resource, status string, duration time.Duration)
 	RecordAPIError(ctx context.Context, method, errorType string)
 
+	// Tunnel health metrics
+	RecordTunnelConnections(ctx context.Context, gatewayClass string, count int)
+	ForgetTunnelConnections(ctx context.Context, gatewayClass string)
+
 	// Ingress builder metrics
 	RecordIngressBuildDuration(ctx context.Context, routeType string, duration time.Duration)
 	RecordBackendRefValidation(ctx context.Context, routeType, result, reason string)
+
+	// Reconciler metrics
+	RecordReconcileDuration(ctx context.Context, controller string, duration time.Duration)
+	RecordReconcileError(ctx context.Context, controller string)
+
+	// Leader election metrics
+	RecordLeader(ctx context.Context, pod string, leader bool)
 }
 
 // prometheusCollector implements Collector using Prometheus metrics.
@@ -50,9 +64,19 @@ type prometheusCollector struct {
 	apiCallsTotal  *prometheus.CounterVec
 	apiErrorsTotal *prometheus.CounterVec
 
+	// Tunnel health metrics
+	tunnelConnections *prometheus.GaugeVec
+
 	// Ingress builder metrics
 	ingressBuildDuration *prometheus.HistogramVec
 	backendRefValidation *prometheus.CounterVec
+
+	// Reconciler metrics
+	reconcileDuration    *prometheus.HistogramVec
+	reconcileErrorsTotal *prometheus.CounterVec
+
+	// Leader election metrics
+	leader *prometheus.GaugeVec
 }
 
 // NewCollector creates a new Prometheus metrics collector and registers metrics.
@@ -60,7 +84,10 @@ func NewCollector(reg prometheus.Registerer) Collector {
 	c := &prometheusCollector{}
 	c.initSyncMetrics()
 	c.initAPIMetrics()
+	c.initTunnelMetrics()
 	c.initIngressMetrics()
+	c.initReconcileMetrics()
+	c.initLeaderMetrics()
 	c.register(reg)
 
 	return c
@@ -106,6 +133,17 @@ func (c *prometheusCollector) RecordAPIError(_ context.Context, method, errorTyp
 	c.apiErrorsTotal.WithLabelValues(method, errorType).Inc()
 }
 
+// RecordTunnelConnections records the active edge connections of the tunnel
+// a GatewayClass writes to.
+func (c *prometheusCollector) RecordTunnelConnections(_ context.Context, gatewayClass string, count int) {
+	c.tunnelConnections.WithLabelValues(gatewayClass).Set(float64(count))
+}
+
+// ForgetTunnelConnections drops the series of a GatewayClass that is gone.
+func (c *prometheusCollector) ForgetTunnelConnections(_ context.Context, gatewayClass string) {
+	c.tunnelConnections.DeleteLabelValues(gatewayClass)
+}
+
 // RecordIngressBuildDuration records the duration of ingress rule building.
 func (c *prometheusCollector) RecordIngressBuildDuration(
 	_ context.Context,
@@ -120,6 +158,18 @@ func (c *prometheusCollector) RecordBackendRefValidation(_ context.Context, rout
 	c.backendRefValidation.WithLabelValues(routeType, result, reason).Inc()
 }
 
+// RecordReconcileDuration records the duration of one Reconcile call of a
+// controller.
+func (c *prometheusCollector) RecordReconcileDuration(_ context.Context, controller string, duration time.Duration) {
+	c.reconcileDuration.WithLabelValues(controller).Observe(duration.Seconds())
+}
+
+// RecordReconcileError records a Reconcile call of a controller that
+// returned an error.
+func (c *prometheusCollector) RecordReconcileError(_ context.Context, controller string) {
+	c.reconcileErrorsTotal.WithLabelValues(controller).Inc()
+}
+
 func (c *prometheusCollector) initSyncMetrics() {
 	c.syncDuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -183,6 +233,16 @@ func (c *prometheusCollector) initAPIMetrics() {
 	)
 }
 
+func (c *prometheusCollector) initTunnelMetrics() {
+	c.tunnelConnections = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cftunnel_tunnel_connections",
+			Help: "Active edge connections of the tunnel each GatewayClass writes to",
+		},
+		[]string{labelClass},
+	)
+}
+
 func (c *prometheusCollector) initIngressMetrics() {
 	c.ingressBuildDuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -201,6 +261,34 @@ func (c *prometheusCollector) initIngressMetrics() {
 	)
 }
 
+func (c *prometheusCollector) initReconcileMetrics() {
+	c.reconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "cftunnel_controller_reconcile_duration_seconds",
+			Help:    "Duration of Reconcile calls by controller",
+			Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{labelCtrl},
+	)
+	c.reconcileErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cftunnel_controller_reconcile_errors_total",
+			Help: "Total Reconcile calls that returned an error, by controller",
+		},
+		[]string{labelCtrl},
+	)
+}
+
+func (c *prometheusCollector) initLeaderMetrics() {
+	c.leader = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cftunnel_controller_leader",
+			Help: "1 on the replica holding the leader-election lease, 0 on the others",
+		},
+		[]string{labelPod},
+	)
+}
+
 func (c *prometheusCollector) register(reg prometheus.Registerer) {
 	reg.MustRegister(
 		c.syncDuration,
@@ -211,11 +299,26 @@ func (c *prometheusCollector) register(reg prometheus.Registerer) {
 		c.apiDuration,
 		c.apiCallsTotal,
 		c.apiErrorsTotal,
+		c.tunnelConnections,
 		c.ingressBuildDuration,
 		c.backendRefValidation,
+		c.reconcileDuration,
+		c.reconcileErrorsTotal,
+		c.leader,
 	)
 }
 
+// RecordLeader records whether the replica pod holds the leader-election
+// lease.
+func (c *prometheusCollector) RecordLeader(_ context.Context, pod string, leader bool) {
+	value := 0.0
+	if leader {
+		value = 1
+	}
+
+	c.leader.WithLabelValues(pod).Set(value)
+}
+
 // NoopCollector is a no-op implementation of Collector for testing.
 type NoopCollector struct{}
 
@@ -245,8 +348,23 @@ func (c *NoopCollector) RecordAPICall(_ context.Context, _, _, _ string, _ time.
 // RecordAPIError is a no-op.
 func (c *NoopCollector) RecordAPIError(_ context.Context, _, _ string) {}
 
+// RecordTunnelConnections is a no-op.
+func (c *NoopCollector) RecordTunnelConnections(_ context.Context, _ string, _ int) {}
+
+// ForgetTunnelConnections is a no-op.
+func (c *NoopCollector) ForgetTunnelConnections(_ context.Context, _ string) {}
+
 // RecordIngressBuildDuration is a no-op.
 func (c *NoopCollector) RecordIngressBuildDuration(_ context.Context, _ string, _ time.Duration) {}
 
 // RecordBackendRefValidation is a no-op.
 func (c *NoopCollector) RecordBackendRefValidation(_ context.Context, _, _, _ string) {}
+
+// RecordReconcileDuration is a no-op.
+func (c *NoopCollector) RecordReconcileDuration(_ context.Context, _ string, _ time.Duration) {}
+
+// RecordReconcileError is a no-op.
+func (c *NoopCollector) RecordReconcileError(_ context.Context, _ string) {}
+
+// RecordLeader is a no-op.
+func (c *NoopCollector) RecordLeader(_ context.Context, _ string, _ bool) {}
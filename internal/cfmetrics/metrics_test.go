@@ -46,8 +46,13 @@ func TestNoopCollector(t *testing.T) {
 		collector.RecordSyncError(ctx, "timeout")
 		collector.RecordAPICall(ctx, "get", "tunnel_config", "success", time.Second)
 		collector.RecordAPIError(ctx, "get", "auth")
+		collector.RecordTunnelConnections(ctx, "cloudflare-tunnel", 4)
+		collector.ForgetTunnelConnections(ctx, "cloudflare-tunnel")
 		collector.RecordIngressBuildDuration(ctx, "http", time.Millisecond*100)
 		collector.RecordBackendRefValidation(ctx, "http", "accepted", "")
+		collector.RecordReconcileDuration(ctx, "gateway", time.Millisecond)
+		collector.RecordReconcileError(ctx, "gateway")
+		collector.RecordLeader(ctx, "controller-0", true)
 	})
 }
 
@@ -66,8 +71,12 @@ func TestMetricsRegistration(t *testing.T) {
 	collector.RecordSyncError(ctx, "test")
 	collector.RecordAPICall(ctx, "get", "tunnel_config", "success", time.Second)
 	collector.RecordAPIError(ctx, "get", "test")
+	collector.RecordTunnelConnections(ctx, "cloudflare-tunnel", 4)
 	collector.RecordIngressBuildDuration(ctx, "http", time.Millisecond)
 	collector.RecordBackendRefValidation(ctx, "http", "accepted", "")
+	collector.RecordReconcileDuration(ctx, "gateway", time.Millisecond)
+	collector.RecordReconcileError(ctx, "gateway")
+	collector.RecordLeader(ctx, "controller-0", true)
 
 	// Verify metrics are registered
 	metricFamilies, err := reg.Gather()
@@ -82,8 +91,12 @@ func TestMetricsRegistration(t *testing.T) {
 		"cftunnel_cloudflare_api_duration_seconds",
 		"cftunnel_cloudflare_api_calls_total",
 		"cftunnel_cloudflare_api_errors_total",
+		"cftunnel_tunnel_connections",
 		"cftunnel_ingress_build_duration_seconds",
 		"cftunnel_backend_ref_validation_total",
+		"cftunnel_controller_reconcile_duration_seconds",
+		"cftunnel_controller_reconcile_errors_total",
+		"cftunnel_controller_leader",
 	}
 
 	// v3 retired the Helm-SDK metrics together with the internal/helm
@@ -216,6 +229,34 @@ func TestRecordAPIError(t *testing.T) {
 	assert.Equal(t, float64(1), count)
 }
 
+func TestRecordTunnelConnections(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	collector := NewCollector(reg).(*prometheusCollector)
+	ctx := context.Background()
+
+	collector.RecordTunnelConnections(ctx, "cloudflare-tunnel", 4)
+	assert.InDelta(t, 4, testutil.ToFloat64(collector.tunnelConnections.WithLabelValues("cloudflare-tunnel")), 0)
+
+	collector.ForgetTunnelConnections(ctx, "cloudflare-tunnel")
+	assert.Equal(t, 0, testutil.CollectAndCount(collector.tunnelConnections))
+}
+
+func TestRecordLeader(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	collector := NewCollector(reg).(*prometheusCollector)
+	ctx := context.Background()
+
+	collector.RecordLeader(ctx, "controller-0", true)
+	assert.InDelta(t, 1, testutil.ToFloat64(collector.leader.WithLabelValues("controller-0")), 0)
+
+	collector.RecordLeader(ctx, "controller-0", false)
+	assert.InDelta(t, 0, testutil.ToFloat64(collector.leader.WithLabelValues("controller-0")), 0)
+}
+
 func TestRecordIngressBuildDuration(t *testing.T) {
 	t.Parallel()
 
@@ -247,6 +288,22 @@ func TestRecordBackendRefValidation(t *testing.T) {
 	assert.Equal(t, float64(1), rejectedCount)
 }
 
+func TestRecordReconcile(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	collector := NewCollector(reg).(*prometheusCollector)
+	ctx := context.Background()
+
+	collector.RecordReconcileDuration(ctx, "gateway", time.Millisecond)
+	collector.RecordReconcileDuration(ctx, "httproute", time.Millisecond)
+	collector.RecordReconcileError(ctx, "gateway")
+
+	assert.Equal(t, 2, testutil.CollectAndCount(collector.reconcileDuration))
+	assert.Equal(t, float64(1), testutil.ToFloat64(collector.reconcileErrorsTotal.WithLabelValues("gateway")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(collector.reconcileErrorsTotal.WithLabelValues("httproute")))
+}
+
 func TestHistogramBuckets(t *testing.T) {
 	t.Parallel()
 
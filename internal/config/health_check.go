@@ -0,0 +1,37 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// HealthCheckHostnameAnnotation opts a Gateway into a synthetic health
+// endpoint: the controller reserves /__tunnel_health on this hostname in the
+// Gateway's tunnel document and in the proxy serving it, which answers with a
+// static 200, so uptime monitors can probe the tunnel path independent of any
+// backend.
+const HealthCheckHostnameAnnotation = "cf.k8s.lex.la/health-check-hostname"
+
+// HealthCheckHostname returns the lowercased hostname from the Gateway's
+// HealthCheckHostnameAnnotation. The bool reports whether the annotation is
+// set at all; a value that is not a DNS-1123 subdomain (wildcards included —
+// the rule must name one concrete host) errors with ErrInvalidParameters.
+func HealthCheckHostname(gateway *gatewayv1.Gateway) (string, bool, error) {
+	value, ok := gateway.Annotations[HealthCheckHostnameAnnotation]
+	if !ok {
+		return "", false, nil
+	}
+
+	hostname := strings.ToLower(value)
+
+	if problems := k8svalidation.IsDNS1123Subdomain(hostname); len(problems) > 0 {
+		return "", true, errors.Wrapf(ErrInvalidParameters,
+			"annotation %s=%q is not a valid hostname: %s",
+			HealthCheckHostnameAnnotation, value, strings.Join(problems, "; "))
+	}
+
+	return hostname, true, nil
+}
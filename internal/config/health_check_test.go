@@ -0,0 +1,78 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+func TestHealthCheckHostname(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		wantHostname string
+		wantSet      bool
+		wantErr      bool
+	}{
+		{
+			name: "no annotation",
+		},
+		{
+			name:         "hostname is returned",
+			annotations:  map[string]string{config.HealthCheckHostnameAnnotation: "health.example.com"},
+			wantHostname: "health.example.com",
+			wantSet:      true,
+		},
+		{
+			name:         "hostname is lowercased",
+			annotations:  map[string]string{config.HealthCheckHostnameAnnotation: "Health.Example.COM"},
+			wantHostname: "health.example.com",
+			wantSet:      true,
+		},
+		{
+			name:        "empty annotation is invalid",
+			annotations: map[string]string{config.HealthCheckHostnameAnnotation: ""},
+			wantSet:     true,
+			wantErr:     true,
+		},
+		{
+			name:        "wildcard is invalid",
+			annotations: map[string]string{config.HealthCheckHostnameAnnotation: "*.example.com"},
+			wantSet:     true,
+			wantErr:     true,
+		},
+		{
+			name:        "URL is invalid",
+			annotations: map[string]string{config.HealthCheckHostnameAnnotation: "https://health.example.com"},
+			wantSet:     true,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gateway := &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+
+			hostname, set, err := config.HealthCheckHostname(gateway)
+			assert.Equal(t, tt.wantSet, set)
+
+			if tt.wantErr {
+				require.ErrorIs(t, err, config.ErrInvalidParameters)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantHostname, hostname)
+		})
+	}
+}
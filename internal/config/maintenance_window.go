@@ -0,0 +1,113 @@
+package config
+
+import (
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/robfig/cron/v3"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// MaintenanceScheduleAnnotation sets, on a Gateway, the start times of its
+// maintenance windows as a five-field cron expression (minute hour
+// day-of-month month day-of-week, evaluated in UTC). While a window is open
+// the controller neither writes the tunnel ingress document nor pushes the
+// proxy config the Gateway's routes are served from. Requires
+// MaintenanceDurationAnnotation.
+const MaintenanceScheduleAnnotation = "cf.k8s.lex.la/maintenance-schedule"
+
+// MaintenanceDurationAnnotation is how long each maintenance window stays
+// open, as a Go duration between one minute and 24 hours.
+const MaintenanceDurationAnnotation = "cf.k8s.lex.la/maintenance-duration"
+
+const (
+	minMaintenanceDuration = time.Minute
+	maxMaintenanceDuration = 24 * time.Hour
+)
+
+// maintenanceScheduleParser parses the standard five cron fields. Descriptors
+// such as @weekly are rejected: @every has no fixed start times.
+var maintenanceScheduleParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+// MaintenanceWindow is a Gateway's parsed maintenance schedule.
+type MaintenanceWindow struct {
+	schedule cron.Schedule
+	duration time.Duration
+}
+
+// MaintenanceWindowFor parses the Gateway's maintenance annotations. It
+// returns nil without error when the Gateway has no schedule, and an error
+// when the schedule or duration is malformed or only one of them is set.
+func MaintenanceWindowFor(gateway *gatewayv1.Gateway) (*MaintenanceWindow, error) {
+	rawSchedule, hasSchedule := gateway.Annotations[MaintenanceScheduleAnnotation]
+	rawDuration, hasDuration := gateway.Annotations[MaintenanceDurationAnnotation]
+
+	switch {
+	case !hasSchedule && !hasDuration:
+		return nil, nil //nolint:nilnil // no schedule is not an error
+	case !hasSchedule:
+		return nil, errors.Newf("annotation %s requires %s", MaintenanceDurationAnnotation, MaintenanceScheduleAnnotation)
+	case !hasDuration:
+		return nil, errors.Newf("annotation %s requires %s", MaintenanceScheduleAnnotation, MaintenanceDurationAnnotation)
+	}
+
+	schedule, err := parseMaintenanceSchedule(rawSchedule)
+	if err != nil {
+		return nil, errors.Wrapf(err, "annotation %s=%q", MaintenanceScheduleAnnotation, rawSchedule)
+	}
+
+	duration, err := time.ParseDuration(rawDuration)
+	if err != nil {
+		return nil, errors.Wrapf(err, "annotation %s=%q", MaintenanceDurationAnnotation, rawDuration)
+	}
+
+	if duration < minMaintenanceDuration || duration > maxMaintenanceDuration {
+		return nil, errors.Newf("annotation %s=%q must be between %s and %s",
+			MaintenanceDurationAnnotation, rawDuration, minMaintenanceDuration, maxMaintenanceDuration)
+	}
+
+	return &MaintenanceWindow{schedule: schedule, duration: duration}, nil
+}
+
+// parseMaintenanceSchedule parses a five-field cron expression. A time zone
+// prefix (CRON_TZ= or TZ=) is rejected: windows are always evaluated in UTC.
+func parseMaintenanceSchedule(expr string) (cron.Schedule, error) {
+	trimmed := strings.TrimSpace(expr)
+	if strings.HasPrefix(trimmed, "CRON_TZ=") || strings.HasPrefix(trimmed, "TZ=") {
+		return nil, errors.New("time zone prefixes are not supported; the schedule is evaluated in UTC")
+	}
+
+	schedule, err := maintenanceScheduleParser.Parse(trimmed)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid cron expression")
+	}
+
+	return schedule, nil
+}
+
+// ActiveAt reports whether a window is open at now and, if so, when it
+// closes. Overlapping windows are reported by the latest start; the next
+// evaluation after it closes picks up any window still open.
+func (w *MaintenanceWindow) ActiveAt(now time.Time) (time.Time, bool) {
+	now = now.UTC()
+
+	start := w.schedule.Next(now.Add(-w.duration))
+	if start.IsZero() || start.After(now) {
+		return time.Time{}, false
+	}
+
+	for next := w.schedule.Next(start); !next.IsZero() && !next.After(now); next = w.schedule.Next(next) {
+		start = next
+	}
+
+	return start.Add(w.duration), true
+}
+
+// NextStart returns the first window start after now. It reports false only
+// for a schedule that never fires, such as February 30th.
+func (w *MaintenanceWindow) NextStart(now time.Time) (time.Time, bool) {
+	next := w.schedule.Next(now.UTC())
+
+	return next, !next.IsZero()
+}
@@ -0,0 +1,177 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+func maintenanceGateway(schedule, duration string) *gatewayv1.Gateway {
+	annotations := map[string]string{}
+	if schedule != "" {
+		annotations[config.MaintenanceScheduleAnnotation] = schedule
+	}
+
+	if duration != "" {
+		annotations[config.MaintenanceDurationAnnotation] = duration
+	}
+
+	return &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default", Annotations: annotations}}
+}
+
+func TestMaintenanceWindowFor_Validation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		schedule string
+		duration string
+		wantNil  bool
+		wantErr  string
+	}{
+		{name: "no annotations", wantNil: true},
+		{name: "valid", schedule: "0 2 * * 6", duration: "2h"},
+		{name: "lists, ranges and steps", schedule: "*/15 1-5 1,15 * 1-5/2", duration: "10m"},
+		{name: "day and month names", schedule: "0 2 * JAN-MAR SAT", duration: "1h"},
+		{name: "schedule without duration", schedule: "0 2 * * 6", wantErr: config.MaintenanceDurationAnnotation},
+		{name: "duration without schedule", duration: "1h", wantErr: config.MaintenanceScheduleAnnotation},
+		{name: "too few fields", schedule: "0 2 * *", duration: "1h", wantErr: "expected exactly 5 fields"},
+		{name: "value out of range", schedule: "60 2 * * *", duration: "1h", wantErr: "above maximum"},
+		{name: "reversed range", schedule: "0 5-1 * * *", duration: "1h", wantErr: "beyond end of range"},
+		{name: "zero step", schedule: "*/0 * * * *", duration: "1h", wantErr: "step of range"},
+		{name: "descriptors unsupported", schedule: "@weekly", duration: "1h", wantErr: "descriptors"},
+		{name: "time zone unsupported", schedule: "CRON_TZ=Europe/Berlin 0 2 * * 6", duration: "1h", wantErr: "UTC"},
+		{name: "malformed duration", schedule: "0 2 * * 6", duration: "soon", wantErr: "soon"},
+		{name: "duration too short", schedule: "0 2 * * 6", duration: "30s", wantErr: "must be between"},
+		{name: "duration too long", schedule: "0 2 * * 6", duration: "25h", wantErr: "must be between"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			window, err := config.MaintenanceWindowFor(maintenanceGateway(tt.schedule, tt.duration))
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNil, window == nil)
+		})
+	}
+}
+
+func TestMaintenanceWindow_ActiveAt(t *testing.T) {
+	t.Parallel()
+
+	// Saturdays 02:00-04:00 UTC. 2026-03-07 is a Saturday.
+	window, err := config.MaintenanceWindowFor(maintenanceGateway("0 2 * * 6", "2h"))
+	require.NoError(t, err)
+
+	opens := time.Date(2026, time.March, 7, 2, 0, 0, 0, time.UTC)
+	closes := opens.Add(2 * time.Hour)
+
+	tests := []struct {
+		name       string
+		now        time.Time
+		wantActive bool
+	}{
+		{name: "just before it opens", now: opens.Add(-time.Second)},
+		{name: "as it opens", now: opens, wantActive: true},
+		{name: "mid-window", now: opens.Add(75*time.Minute + 30*time.Second), wantActive: true},
+		{name: "as it closes", now: closes},
+		{name: "a day later", now: opens.Add(24 * time.Hour)},
+		{name: "non-UTC clock inside the window", now: opens.Add(time.Hour).In(time.FixedZone("UTC+3", 3*3600)), wantActive: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			end, active := window.ActiveAt(tt.now)
+
+			assert.Equal(t, tt.wantActive, active)
+
+			if tt.wantActive {
+				assert.True(t, end.Equal(closes), "window closes at %s, got %s", closes, end)
+			}
+		})
+	}
+}
+
+func TestMaintenanceWindow_NextStart(t *testing.T) {
+	t.Parallel()
+
+	window, err := config.MaintenanceWindowFor(maintenanceGateway("0 2 * * 6", "2h"))
+	require.NoError(t, err)
+
+	opens := time.Date(2026, time.March, 7, 2, 0, 0, 0, time.UTC)
+
+	next, found := window.NextStart(opens.Add(-90 * time.Minute))
+	require.True(t, found)
+	assert.True(t, next.Equal(opens))
+
+	next, found = window.NextStart(opens)
+	require.True(t, found)
+	assert.True(t, next.Equal(opens.Add(7*24*time.Hour)), "the following week's window")
+
+	// A sparse schedule is found however far ahead it lies: Feb 29 is almost
+	// two years away from March 2026.
+	leapDay, err := config.MaintenanceWindowFor(maintenanceGateway("0 0 29 2 *", "1h"))
+	require.NoError(t, err)
+
+	next, found = leapDay.NextStart(opens)
+	require.True(t, found)
+	assert.True(t, next.Equal(time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)))
+
+	_, active := leapDay.ActiveAt(next.Add(30 * time.Minute))
+	assert.True(t, active)
+
+	// A schedule that never fires has no next start.
+	never, err := config.MaintenanceWindowFor(maintenanceGateway("0 0 30 2 *", "1h"))
+	require.NoError(t, err)
+
+	_, found = never.NextStart(opens)
+	assert.False(t, found)
+}
+
+// TestMaintenanceWindow_DayFieldsOR pins classic cron semantics: with both
+// day-of-month and day-of-week restricted, either one matching qualifies.
+func TestMaintenanceWindow_DayFieldsOR(t *testing.T) {
+	t.Parallel()
+
+	// The 1st of the month OR any Monday, at 00:00.
+	window, err := config.MaintenanceWindowFor(maintenanceGateway("0 0 1 * 1", "1h"))
+	require.NoError(t, err)
+
+	_, onFirst := window.ActiveAt(time.Date(2026, time.April, 1, 0, 10, 0, 0, time.UTC)) // Wednesday
+	_, onMonday := window.ActiveAt(time.Date(2026, time.March, 9, 0, 10, 0, 0, time.UTC))
+	_, neither := window.ActiveAt(time.Date(2026, time.March, 10, 0, 10, 0, 0, time.UTC))
+
+	assert.True(t, onFirst)
+	assert.True(t, onMonday)
+	assert.False(t, neither)
+
+	// From Tuesday 2026-03-10 the next start is the following Monday, ahead
+	// of the 1st of April.
+	next, found := window.NextStart(time.Date(2026, time.March, 10, 0, 10, 0, 0, time.UTC))
+	require.True(t, found)
+	assert.True(t, next.Equal(time.Date(2026, time.March, 16, 0, 0, 0, 0, time.UTC)))
+
+	// With the day of week unrestricted, only the day of month counts.
+	monthly, err := config.MaintenanceWindowFor(maintenanceGateway("0 0 1 * *", "1h"))
+	require.NoError(t, err)
+
+	_, onMonday = monthly.ActiveAt(time.Date(2026, time.March, 9, 0, 10, 0, 0, time.UTC))
+	assert.False(t, onMonday)
+}
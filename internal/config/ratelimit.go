@@ -0,0 +1,39 @@
+package config
+
+import (
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"golang.org/x/time/rate"
+)
+
+// WithCloudflareRateLimit queues outbound Cloudflare API requests so at most
+// perSecond are dispatched per second, smoothing the bursts a sync produces
+// (one GET and one PUT per tunnel, plus account detection) into a steady
+// stream below the account's API limit. One limiter is shared by every client
+// the Resolver creates, so the limit holds across tunnels and credentials.
+// perSecond <= 0 disables the limit.
+func WithCloudflareRateLimit(perSecond float64) ResolverOption {
+	return func(r *Resolver) {
+		if perSecond > 0 {
+			r.limiter = rate.NewLimiter(rate.Limit(perSecond), 1)
+		}
+	}
+}
+
+// rateLimitedTransport holds each request until the limiter grants it a slot.
+// Waiting requests are dispatched in arrival order; a request whose context is
+// cancelled while queued fails without reaching the API.
+type rateLimitedTransport struct {
+	base    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, errors.Wrap(err, "waiting for Cloudflare API rate limit")
+	}
+
+	//nolint:wrapcheck // transport errors pass through unchanged
+	return t.base.RoundTrip(req)
+}
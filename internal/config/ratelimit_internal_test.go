@@ -0,0 +1,113 @@
+package config
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+)
+
+// TestNewResolver_WithCloudflareRateLimit pins the option wiring: no limiter
+// by default or for a non-positive rate, and an HTTP client option once set.
+func TestNewResolver_WithCloudflareRateLimit(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, NewResolver(nil, "default", cfmetrics.NewNoopCollector()).limiter,
+		"no rate limit by default")
+	assert.Nil(t, NewResolver(nil, "default", cfmetrics.NewNoopCollector(), WithCloudflareRateLimit(0)).limiter,
+		"zero disables the rate limit")
+
+	resolver := NewResolver(nil, "default", cfmetrics.NewNoopCollector(), WithCloudflareRateLimit(5))
+	require.NotNil(t, resolver.limiter)
+	assert.Len(t, cloudflareRequestOptions("token", false, resolver.limiter), 2,
+		"rate limit -> API-token + limited HTTP client options")
+}
+
+// TestRateLimitedTransport_DispatchesWithinRate queues a burst of requests and
+// asserts they reach the server no faster than the configured rate.
+func TestRateLimitedTransport_DispatchesWithinRate(t *testing.T) {
+	t.Parallel()
+
+	const (
+		perSecond = 20
+		requests  = 5
+	)
+
+	var served atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		served.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{Transport: &rateLimitedTransport{
+		base:    http.DefaultTransport,
+		limiter: rate.NewLimiter(perSecond, 1),
+	}}
+
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for range requests {
+		wg.Go(func() {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, http.NoBody)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			resp, err := client.Do(req)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			_ = resp.Body.Close()
+		})
+	}
+
+	wg.Wait()
+
+	// The first request takes the initial token; each later one waits 1/rate.
+	minElapsed := time.Duration(requests-1) * time.Second / perSecond
+	assert.GreaterOrEqual(t, time.Since(start), minElapsed-10*time.Millisecond,
+		"burst must be smoothed to the configured rate")
+	assert.Equal(t, int32(requests), served.Load(), "every queued request must be dispatched")
+}
+
+// TestRateLimitedTransport_CancelledWhileQueued pins that a request whose
+// context ends while waiting for a slot fails without reaching the server.
+func TestRateLimitedTransport_CancelledWhileQueued(t *testing.T) {
+	t.Parallel()
+
+	var served atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		served.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	limiter := rate.NewLimiter(rate.Every(time.Hour), 1)
+	require.True(t, limiter.Allow(), "drain the only token")
+
+	client := &http.Client{Transport: &rateLimitedTransport{base: http.DefaultTransport, limiter: limiter}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, http.NoBody)
+	require.NoError(t, err)
+
+	_, err = client.Do(req) //nolint:bodyclose // request never completes
+	require.Error(t, err)
+	assert.Zero(t, served.Load(), "cancelled request must not reach the API")
+}
@@ -10,7 +10,9 @@ import (
 	"github.com/cloudflare/cloudflare-go/v7/accounts"
 	"github.com/cloudflare/cloudflare-go/v7/option"
 	"github.com/cockroachdb/errors"
+	"golang.org/x/time/rate"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -27,6 +29,13 @@ const (
 	ParametersRefKind = "GatewayClassConfig"
 )
 
+// ErrGatewayClassConfigNotFound marks a resolve failure caused by the
+// GatewayClassConfig named in the GatewayClass parametersRef not existing —
+// typically deleted while Gateways still use the class. The Gateway
+// reconciler reports it as Accepted=False with reason ParametersDeleted
+// instead of the generic InvalidParameters.
+var ErrGatewayClassConfigNotFound = errors.New("GatewayClassConfig not found")
+
 // ResolvedConfig contains all configuration resolved from GatewayClassConfig and Secrets.
 type ResolvedConfig struct {
 	// Cloudflare API credentials
@@ -36,6 +45,21 @@ type ResolvedConfig struct {
 	// Tunnel configuration
 	TunnelID string
 
+	// ClusterDomain overrides the controller's cluster domain for backend
+	// Service origins. Empty means the controller default.
+	ClusterDomain string
+
+	// OriginRequestDefaults are the class-level originRequest defaults for
+	// generated ingress rules. The zero value means none are set.
+	OriginRequestDefaults v1alpha1.OriginRequestDefaults
+
+	// DefaultBackend is the fallback origin for requests that match no
+	// route. Nil keeps the HTTP 404.
+	DefaultBackend *v1alpha1.DefaultBackend
+
+	// ManageDNS enables the CNAME records for the tunnel's hostnames.
+	ManageDNS bool
+
 	// Reference to the source config for watch purposes
 	ConfigName string
 }
@@ -51,9 +75,26 @@ type Resolver struct {
 	// WithCloudflareTracing.
 	tracing bool
 
+	// limiter, when non-nil, paces every Cloudflare API request made through
+	// clients this Resolver creates. Set via WithCloudflareRateLimit.
+	limiter *rate.Limiter
+
 	// accountIDCache caches resolved account IDs by config name to avoid
 	// repeated API calls. Key is config name, value is account ID.
 	accountIDCache sync.Map
+
+	// tunnelNameCache caches, by config name, the tunnel a tunnelName
+	// resolved to, as a tunnelNameEntry.
+	tunnelNameCache sync.Map
+
+	// baseURL, when set, replaces the Cloudflare API endpoint. Tests point it
+	// at a fake server.
+	baseURL string
+
+	// resolved caches resolved class configs by config name, and clients
+	// caches the Cloudflare client built for each config's token.
+	resolved resolvedCache
+	clients  clientCache
 }
 
 // ResolverOption configures a Resolver at construction time.
@@ -105,12 +146,25 @@ func (r *Resolver) ResolveFromGatewayClass(
 
 	err := r.client.Get(ctx, types.NamespacedName{Name: ref.Name}, config)
 	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, errors.Wrapf(ErrGatewayClassConfigNotFound, "failed to get GatewayClassConfig %s", ref.Name)
+		}
+
 		return nil, errors.Wrapf(err, "failed to get GatewayClassConfig %s", ref.Name)
 	}
 
 	return r.resolveConfig(ctx, config)
 }
 
+// ResolveFromConfig resolves an already-fetched GatewayClassConfig, for
+// callers that hold the config rather than a GatewayClass referencing it.
+func (r *Resolver) ResolveFromConfig(
+	ctx context.Context,
+	config *v1alpha1.GatewayClassConfig,
+) (*ResolvedConfig, error) {
+	return r.resolveConfig(ctx, config)
+}
+
 // ResolveFromGatewayClassName resolves configuration by GatewayClass name.
 func (r *Resolver) ResolveFromGatewayClassName(
 	ctx context.Context,
@@ -128,14 +182,8 @@ func (r *Resolver) ResolveFromGatewayClassName(
 
 //nolint:funcorder,wrapcheck // private helper, errors.Newf creates new errors
 func (r *Resolver) resolveConfig(ctx context.Context, config *v1alpha1.GatewayClassConfig) (*ResolvedConfig, error) {
-	// Validate required TunnelID
-	if config.Spec.TunnelID == "" {
-		return nil, errors.New("tunnelID is required in GatewayClassConfig")
-	}
-
-	resolved := &ResolvedConfig{
-		TunnelID:   config.Spec.TunnelID,
-		ConfigName: config.Name,
+	if (config.Spec.TunnelID == "") == (config.Spec.TunnelName == "") {
+		return nil, errors.New("exactly one of tunnelID and tunnelName must be set in GatewayClassConfig")
 	}
 
 	// Resolve Cloudflare credentials from Secret
@@ -146,6 +194,34 @@ func (r *Resolver) resolveConfig(ctx context.Context, config *v1alpha1.GatewayCl
 		return nil, errors.Wrap(err, "failed to get Cloudflare credentials secret")
 	}
 
+	source := newResolvedSource(config, credentialsSecret)
+	if cached, ok := r.resolved.lookup(config.Name, source); ok {
+		return cached, nil
+	}
+
+	// The account a token detects and the tunnel a name resolves to may
+	// change with the objects, so an entry rebuilt from newer versions looks
+	// both up again.
+	if r.resolved.outdated(config.Name, source) {
+		r.accountIDCache.Delete(config.Name)
+		r.tunnelNameCache.Delete(config.Name)
+	}
+
+	resolved := &ResolvedConfig{
+		TunnelID:      config.Spec.TunnelID,
+		ClusterDomain: config.Spec.ClusterDomain,
+		ManageDNS:     config.Spec.ManageDNS,
+		ConfigName:    config.Name,
+	}
+
+	if config.Spec.OriginRequestDefaults != nil {
+		resolved.OriginRequestDefaults = *config.Spec.OriginRequestDefaults
+	}
+
+	if config.Spec.DefaultBackend != nil {
+		resolved.DefaultBackend = config.Spec.DefaultBackend.DeepCopy()
+	}
+
 	apiTokenKey := credentialsRef.GetAPITokenKey()
 
 	apiToken, ok := credentialsSecret.Data[apiTokenKey]
@@ -168,6 +244,17 @@ func (r *Resolver) resolveConfig(ctx context.Context, config *v1alpha1.GatewayCl
 		resolved.AccountID = string(accountID)
 	}
 
+	if config.Spec.TunnelName != "" {
+		tunnelID, err := r.resolveTunnelName(ctx, resolved, config.Spec.TunnelName)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved.TunnelID = tunnelID
+	}
+
+	r.resolved.store(config.Name, source, resolved)
+
 	return resolved, nil
 }
 
@@ -187,25 +274,41 @@ func (r *Resolver) getSecret(ctx context.Context, name, namespace string) (*core
 	return secret, nil
 }
 
-// CreateCloudflareClient creates a Cloudflare API client from resolved config.
+// CreateCloudflareClient returns a Cloudflare API client for resolved config.
+// The client is reused across calls until the config's API token changes.
 func (r *Resolver) CreateCloudflareClient(resolved *ResolvedConfig) *cloudflare.Client {
-	return cloudflare.NewClient(cloudflareRequestOptions(resolved.APIToken, r.tracing)...)
+	return r.clients.get(resolved.ConfigName, resolved.APIToken, func() *cloudflare.Client {
+		opts := cloudflareRequestOptions(resolved.APIToken, r.tracing, r.limiter)
+		if r.baseURL != "" {
+			opts = append(opts, option.WithBaseURL(r.baseURL))
+		}
+
+		return cloudflare.NewClient(opts...)
+	})
 }
 
 // cloudflareRequestOptions builds the cloudflare-go request options. When
 // tracing is enabled it adds a traced HTTP client so outbound API calls emit
-// client spans; when disabled it passes only the API token so cloudflare-go
-// keeps its own default client (outbound path unchanged).
-func cloudflareRequestOptions(token string, tracing bool) []option.RequestOption {
+// client spans, and a limiter queues every request behind the rate limit;
+// with neither it passes only the API token so cloudflare-go keeps its own
+// default client (outbound path unchanged).
+func cloudflareRequestOptions(token string, tracing bool, limiter *rate.Limiter) []option.RequestOption {
 	opts := []option.RequestOption{option.WithAPIToken(token)}
 
+	if !tracing && limiter == nil {
+		return opts
+	}
+
+	transport := http.DefaultTransport
 	if tracing {
-		opts = append(opts, option.WithHTTPClient(&http.Client{
-			Transport: tracingpkg.WrapTransport(http.DefaultTransport, true),
-		}))
+		transport = tracingpkg.WrapTransport(transport, true)
+	}
+
+	if limiter != nil {
+		transport = &rateLimitedTransport{base: transport, limiter: limiter}
 	}
 
-	return opts
+	return append(opts, option.WithHTTPClient(&http.Client{Transport: transport}))
 }
 
 //nolint:wrapcheck // errors.Newf creates new errors
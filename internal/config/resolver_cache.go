@@ -0,0 +1,125 @@
+package config
+
+import (
+	"sync"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/api/v1alpha1"
+)
+
+// resolvedSource identifies the object versions a ResolvedConfig was built
+// from. Any write to the GatewayClassConfig or its credentials Secret, or
+// either being recreated, changes it. The config's resourceVersion is used
+// rather than its generation: a status-only write costs one rebuild, while a
+// missed generation bump would keep serving a stale tunnel ID.
+type resolvedSource struct {
+	configUID     types.UID
+	configVersion string
+	secretUID     types.UID
+	secretVersion string
+}
+
+func newResolvedSource(config *v1alpha1.GatewayClassConfig, secret *corev1.Secret) resolvedSource {
+	return resolvedSource{
+		configUID:     config.UID,
+		configVersion: config.ResourceVersion,
+		secretUID:     secret.UID,
+		secretVersion: secret.ResourceVersion,
+	}
+}
+
+type resolvedEntry struct {
+	source   resolvedSource
+	resolved ResolvedConfig
+}
+
+// resolvedCache keeps, per GatewayClassConfig name, the config last resolved
+// and the versions it came from, so a reconcile whose config and Secret are
+// unchanged skips re-parsing them. The objects themselves still come from
+// the client on every resolve: comparing their versions is what invalidates
+// an entry when the Secret watch delivers a change.
+type resolvedCache struct {
+	mu      sync.Mutex
+	entries map[string]resolvedEntry
+}
+
+// lookup returns a copy of the entry for name when it was built from source.
+func (c *resolvedCache) lookup(name string, source resolvedSource) (*ResolvedConfig, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[name]
+	if !ok || entry.source != source {
+		return nil, false
+	}
+
+	return entry.resolved.clone(), true
+}
+
+// outdated reports whether name has an entry built from versions other than
+// source.
+func (c *resolvedCache) outdated(name string, source resolvedSource) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[name]
+
+	return ok && entry.source != source
+}
+
+// store records resolved for name as built from source.
+func (c *resolvedCache) store(name string, source resolvedSource, resolved *ResolvedConfig) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]resolvedEntry)
+	}
+
+	c.entries[name] = resolvedEntry{source: source, resolved: *resolved.clone()}
+}
+
+// clone copies the config so a caller cannot change a cached entry.
+func (r *ResolvedConfig) clone() *ResolvedConfig {
+	copied := *r
+	copied.DefaultBackend = r.DefaultBackend.DeepCopy()
+
+	return &copied
+}
+
+// clientEntry is a Cloudflare client and the API token it was built with.
+type clientEntry struct {
+	token  string
+	client *cloudflare.Client
+}
+
+// clientCache keeps one Cloudflare client per config name and rebuilds it
+// when the config's API token changes, so reconciles share one client (and
+// its HTTP connection pool) instead of creating one each time.
+type clientCache struct {
+	mu      sync.Mutex
+	entries map[string]clientEntry
+}
+
+// get returns the client for name built with token, creating it with build
+// when there is none or the token changed.
+func (c *clientCache) get(name, token string, build func() *cloudflare.Client) *cloudflare.Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[name]; ok && entry.token == token {
+		return entry.client
+	}
+
+	if c.entries == nil {
+		c.entries = make(map[string]clientEntry)
+	}
+
+	cfClient := build()
+	c.entries[name] = clientEntry{token: token, client: cfClient}
+
+	return cfClient
+}
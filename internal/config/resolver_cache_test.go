@@ -0,0 +1,70 @@
+package config_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+// TestResolver_CachesUntilSecretChanges pins the resolver caches: reconciles
+// against an unchanged config and Secret share one resolved config and one
+// Cloudflare client, a caller cannot change the cached entry, and updating
+// the Secret yields the new token and a new client.
+func TestResolver_CachesUntilSecretChanges(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cf-credentials", Namespace: "default"},
+		Data:       map[string][]byte{"api-token": []byte("first-token")},
+	}
+
+	gatewayClassConfig := &v1alpha1.GatewayClassConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-config"},
+		Spec: v1alpha1.GatewayClassConfigSpec{
+			CloudflareCredentialsSecretRef: v1alpha1.SecretReference{Name: "cf-credentials", Namespace: "default"},
+			AccountID:                      "test-account-id",
+			TunnelID:                       "12345678-1234-1234-1234-123456789abc",
+		},
+	}
+
+	gatewayClass := newGatewayClass("test-class", "test-config")
+
+	fakeClient := setupFakeClient(secret, gatewayClassConfig, gatewayClass)
+	resolver := config.NewResolver(fakeClient, "default", cfmetrics.NewNoopCollector())
+
+	first, err := resolver.ResolveFromGatewayClass(ctx, gatewayClass)
+	require.NoError(t, err)
+
+	firstClient := resolver.CreateCloudflareClient(first)
+
+	first.TunnelID = "changed-by-caller"
+
+	second, err := resolver.ResolveFromGatewayClass(ctx, gatewayClass)
+	require.NoError(t, err)
+
+	assert.Equal(t, "12345678-1234-1234-1234-123456789abc", second.TunnelID, "a caller must not change the cached entry")
+	assert.Same(t, firstClient, resolver.CreateCloudflareClient(second), "an unchanged token reuses the client")
+
+	var current corev1.Secret
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(secret), &current))
+
+	current.Data["api-token"] = []byte("rotated-token")
+	require.NoError(t, fakeClient.Update(ctx, &current))
+
+	rotated, err := resolver.ResolveFromGatewayClass(ctx, gatewayClass)
+	require.NoError(t, err)
+
+	assert.Equal(t, "rotated-token", rotated.APIToken)
+	assert.NotSame(t, firstClient, resolver.CreateCloudflareClient(rotated), "a rotated token builds a new client")
+}
@@ -177,6 +177,7 @@ func TestResolveFromGatewayClass_ConfigNotFound(t *testing.T) {
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to get GatewayClassConfig")
+	assert.ErrorIs(t, err, config.ErrGatewayClassConfigNotFound)
 }
 
 func TestResolveFromGatewayClass_SecretNotFound(t *testing.T) {
@@ -422,6 +423,132 @@ func TestResolveConfig_AccountIDFromSpec(t *testing.T) {
 	assert.Equal(t, "spec-account-id", resolved.AccountID)
 }
 
+func TestResolveConfig_ClusterDomainFromSpec(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		clusterDomain string
+	}{
+		{name: "unset leaves the controller default", clusterDomain: ""},
+		{name: "set is carried on the resolved config", clusterDomain: "east.example"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "cf-credentials", Namespace: "default"},
+				Data:       map[string][]byte{"api-token": []byte("test-api-token")},
+			}
+
+			gatewayClassConfig := &v1alpha1.GatewayClassConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-config"},
+				Spec: v1alpha1.GatewayClassConfigSpec{
+					CloudflareCredentialsSecretRef: v1alpha1.SecretReference{Name: "cf-credentials", Namespace: "default"},
+					TunnelID:                       "12345678-1234-1234-1234-123456789abc",
+					ClusterDomain:                  tt.clusterDomain,
+				},
+			}
+
+			gatewayClass := newGatewayClass("test-class", "test-config")
+
+			fakeClient := setupFakeClient(secret, gatewayClassConfig, gatewayClass)
+			resolver := config.NewResolver(fakeClient, "default", cfmetrics.NewNoopCollector())
+
+			resolved, err := resolver.ResolveFromGatewayClass(context.Background(), gatewayClass)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.clusterDomain, resolved.ClusterDomain)
+		})
+	}
+}
+
+func TestResolveConfig_OriginRequestDefaultsFromSpec(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		defaults *v1alpha1.OriginRequestDefaults
+		want     v1alpha1.OriginRequestDefaults
+	}{
+		{name: "unset resolves to the zero value"},
+		{
+			name:     "set is carried on the resolved config",
+			defaults: &v1alpha1.OriginRequestDefaults{ConnectTimeout: "30s"},
+			want:     v1alpha1.OriginRequestDefaults{ConnectTimeout: "30s"},
+		},
+		{
+			name:     "disableHappyEyeballs copied",
+			defaults: &v1alpha1.OriginRequestDefaults{DisableHappyEyeballs: true},
+			want:     v1alpha1.OriginRequestDefaults{DisableHappyEyeballs: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "cf-credentials", Namespace: "default"},
+				Data:       map[string][]byte{"api-token": []byte("test-api-token")},
+			}
+
+			gatewayClassConfig := &v1alpha1.GatewayClassConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-config"},
+				Spec: v1alpha1.GatewayClassConfigSpec{
+					CloudflareCredentialsSecretRef: v1alpha1.SecretReference{Name: "cf-credentials", Namespace: "default"},
+					TunnelID:                       "12345678-1234-1234-1234-123456789abc",
+					OriginRequestDefaults:          tt.defaults,
+				},
+			}
+
+			gatewayClass := newGatewayClass("test-class", "test-config")
+
+			fakeClient := setupFakeClient(secret, gatewayClassConfig, gatewayClass)
+			resolver := config.NewResolver(fakeClient, "default", cfmetrics.NewNoopCollector())
+
+			resolved, err := resolver.ResolveFromGatewayClass(context.Background(), gatewayClass)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, resolved.OriginRequestDefaults)
+		})
+	}
+}
+
+func TestResolveConfig_DefaultBackendFromSpec(t *testing.T) {
+	t.Parallel()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cf-credentials", Namespace: "default"},
+		Data:       map[string][]byte{"api-token": []byte("test-api-token")},
+	}
+
+	backend := &v1alpha1.DefaultBackend{
+		ServiceRef: &v1alpha1.ServiceReference{Name: "maintenance", Namespace: "web", Port: 8080},
+	}
+
+	gatewayClassConfig := &v1alpha1.GatewayClassConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-config"},
+		Spec: v1alpha1.GatewayClassConfigSpec{
+			CloudflareCredentialsSecretRef: v1alpha1.SecretReference{Name: "cf-credentials", Namespace: "default"},
+			TunnelID:                       "12345678-1234-1234-1234-123456789abc",
+			DefaultBackend:                 backend,
+		},
+	}
+
+	gatewayClass := newGatewayClass("test-class", "test-config")
+
+	fakeClient := setupFakeClient(secret, gatewayClassConfig, gatewayClass)
+	resolver := config.NewResolver(fakeClient, "default", cfmetrics.NewNoopCollector())
+
+	resolved, err := resolver.ResolveFromGatewayClass(context.Background(), gatewayClass)
+
+	require.NoError(t, err)
+	assert.Equal(t, backend, resolved.DefaultBackend)
+}
+
 func TestResolveConfig_CustomAPITokenKey(t *testing.T) {
 	t.Parallel()
 
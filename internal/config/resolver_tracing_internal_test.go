@@ -25,8 +25,8 @@ func TestNewResolver_WithCloudflareTracing(t *testing.T) {
 func TestCloudflareRequestOptions_TracingTogglesHTTPClient(t *testing.T) {
 	t.Parallel()
 
-	assert.Len(t, cloudflareRequestOptions("token", false), 1,
+	assert.Len(t, cloudflareRequestOptions("token", false, nil), 1,
 		"no tracing -> only the API-token option")
-	assert.Len(t, cloudflareRequestOptions("token", true), 2,
+	assert.Len(t, cloudflareRequestOptions("token", true, nil), 2,
 		"tracing -> API-token + traced HTTP client options")
 }
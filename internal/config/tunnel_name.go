@@ -0,0 +1,79 @@
+package config
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/zero_trust"
+	"github.com/cockroachdb/errors"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+)
+
+// ErrTunnelNotFound marks a resolve failure caused by no live tunnel in the
+// account carrying the GatewayClassConfig's tunnelName.
+var ErrTunnelNotFound = errors.New("tunnel not found")
+
+// tunnelNameEntry is a tunnelName and the tunnel ID it resolved to.
+type tunnelNameEntry struct {
+	name     string
+	tunnelID string
+}
+
+// resolveTunnelName returns the ID of the live tunnel called name in the
+// config's account. The lookup is cached per config until its entry is
+// rebuilt from newer objects, so a tunnel recreated under the same name is
+// picked up no later than the next write to the config or its Secret.
+func (r *Resolver) resolveTunnelName(ctx context.Context, resolved *ResolvedConfig, name string) (string, error) {
+	if cached, ok := r.tunnelNameCache.Load(resolved.ConfigName); ok {
+		if entry, valid := cached.(tunnelNameEntry); valid && entry.name == name {
+			return entry.tunnelID, nil
+		}
+	}
+
+	cfClient := r.CreateCloudflareClient(resolved)
+
+	accountID, err := r.ResolveAccountID(ctx, cfClient, resolved)
+	if err != nil {
+		return "", errors.Wrapf(err, "resolving account for tunnel %q", name)
+	}
+
+	startTime := time.Now()
+
+	page, err := cfClient.ZeroTrust.Tunnels.Cloudflared.List(ctx, zero_trust.TunnelCloudflaredListParams{
+		AccountID: cloudflare.String(accountID),
+		Name:      cloudflare.String(name),
+		IsDeleted: cloudflare.Bool(false),
+	})
+	if err != nil {
+		r.metrics.RecordAPICall(ctx, "list", "tunnel", "error", time.Since(startTime))
+		r.metrics.RecordAPIError(ctx, "list", cfmetrics.ClassifyCloudflareError(err))
+
+		return "", errors.Wrapf(err, "failed to look up tunnel %q", name)
+	}
+
+	r.metrics.RecordAPICall(ctx, "list", "tunnel", "success", time.Since(startTime))
+
+	// The name filter is applied by the API; matching again keeps a loose
+	// server-side match from picking a different tunnel.
+	var tunnelIDs []string
+
+	for i := range page.Result {
+		if page.Result[i].Name == name {
+			tunnelIDs = append(tunnelIDs, page.Result[i].ID)
+		}
+	}
+
+	switch len(tunnelIDs) {
+	case 0:
+		return "", errors.Wrapf(ErrTunnelNotFound, "no tunnel named %q in account %s", name, accountID)
+	case 1:
+	default:
+		return "", errors.Newf("%d tunnels named %q in account %s, set tunnelID instead", len(tunnelIDs), name, accountID)
+	}
+
+	r.tunnelNameCache.Store(resolved.ConfigName, tunnelNameEntry{name: name, tunnelID: tunnelIDs[0]})
+
+	return tunnelIDs[0], nil
+}
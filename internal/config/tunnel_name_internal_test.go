@@ -0,0 +1,176 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+)
+
+const tunnelNameTestAccount = "0123456789abcdef0123456789abcdef"
+
+// fakeTunnelList serves the Cloudflare tunnel list endpoint from tunnels
+// (name -> IDs) and counts the lookups it answers.
+type fakeTunnelList struct {
+	server  *httptest.Server
+	lookups atomic.Int32
+}
+
+func newFakeTunnelList(t *testing.T, tunnels map[string][]string) *fakeTunnelList {
+	t.Helper()
+
+	list := &fakeTunnelList{}
+	list.server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/accounts/"+tunnelNameTestAccount+"/cfd_tunnel" {
+			http.NotFound(writer, req)
+
+			return
+		}
+
+		list.lookups.Add(1)
+
+		name := req.URL.Query().Get("name")
+		result := []map[string]string{}
+
+		for _, id := range tunnels[name] {
+			result = append(result, map[string]string{"id": id, "name": name})
+		}
+
+		writer.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(writer).Encode(map[string]any{
+			"success":  true,
+			"errors":   []any{},
+			"messages": []any{},
+			"result":   result,
+			"result_info": map[string]int{
+				"page": 1, "per_page": 20, "count": len(result), "total_count": len(result),
+			},
+		})
+	}))
+	t.Cleanup(list.server.Close)
+
+	return list
+}
+
+// newTunnelNameResolver returns a resolver whose Cloudflare clients talk to
+// list, with credentials for one config of the given spec.
+func newTunnelNameResolver(
+	t *testing.T,
+	list *fakeTunnelList,
+	spec v1alpha1.GatewayClassConfigSpec,
+) (*Resolver, client.Client, *v1alpha1.GatewayClassConfig) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+
+	spec.AccountID = tunnelNameTestAccount
+	spec.CloudflareCredentialsSecretRef = v1alpha1.SecretReference{Name: "cf-credentials", Namespace: "default"}
+
+	gatewayClassConfig := &v1alpha1.GatewayClassConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-config"},
+		Spec:       spec,
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(gatewayClassConfig, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "cf-credentials", Namespace: "default"},
+			Data:       map[string][]byte{"api-token": []byte("test-token")},
+		}).
+		Build()
+
+	resolver := NewResolver(fakeClient, "default", cfmetrics.NewNoopCollector())
+	resolver.baseURL = list.server.URL
+
+	return resolver, fakeClient, gatewayClassConfig
+}
+
+// TestResolveConfig_TunnelName pins tunnelName resolution: the name is looked
+// up once per version of the config, a name no tunnel carries fails with
+// ErrTunnelNotFound, and an ambiguous name fails without guessing.
+func TestResolveConfig_TunnelName(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	list := newFakeTunnelList(t, map[string][]string{
+		"prod": {"aaaaaaaa-bbbb-4ccc-8ddd-eeeeeeeeeeee"},
+		"dup":  {"11111111-1111-4111-8111-111111111111", "22222222-2222-4222-8222-222222222222"},
+	})
+
+	resolver, fakeClient, gatewayClassConfig := newTunnelNameResolver(t, list, v1alpha1.GatewayClassConfigSpec{TunnelName: "prod"})
+
+	resolved, err := resolver.ResolveFromConfig(ctx, gatewayClassConfig)
+	require.NoError(t, err)
+	assert.Equal(t, "aaaaaaaa-bbbb-4ccc-8ddd-eeeeeeeeeeee", resolved.TunnelID)
+	assert.Equal(t, int32(1), list.lookups.Load())
+
+	resolved, err = resolver.ResolveFromConfig(ctx, gatewayClassConfig)
+	require.NoError(t, err)
+	assert.Equal(t, "aaaaaaaa-bbbb-4ccc-8ddd-eeeeeeeeeeee", resolved.TunnelID)
+	assert.Equal(t, int32(1), list.lookups.Load(), "an unchanged config reuses the lookup")
+
+	// Any write to the config looks the name up again, so a tunnel recreated
+	// under the same name is picked up.
+	gatewayClassConfig.Labels = map[string]string{"touched": "true"}
+	require.NoError(t, fakeClient.Update(ctx, gatewayClassConfig))
+
+	_, err = resolver.ResolveFromConfig(ctx, gatewayClassConfig)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), list.lookups.Load())
+
+	gatewayClassConfig.Spec.TunnelName = "missing"
+	require.NoError(t, fakeClient.Update(ctx, gatewayClassConfig))
+
+	_, err = resolver.ResolveFromConfig(ctx, gatewayClassConfig)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrTunnelNotFound), "got %v", err)
+
+	gatewayClassConfig.Spec.TunnelName = "dup"
+	require.NoError(t, fakeClient.Update(ctx, gatewayClassConfig))
+
+	_, err = resolver.ResolveFromConfig(ctx, gatewayClassConfig)
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrTunnelNotFound))
+	assert.Contains(t, err.Error(), "2 tunnels named \"dup\"")
+}
+
+// TestResolveConfig_TunnelIDAndNameExclusive pins that a config must name its
+// tunnel exactly one way, and that neither form reaches the API.
+func TestResolveConfig_TunnelIDAndNameExclusive(t *testing.T) {
+	t.Parallel()
+
+	for name, spec := range map[string]v1alpha1.GatewayClassConfigSpec{
+		"both":    {TunnelID: "aaaaaaaa-bbbb-4ccc-8ddd-eeeeeeeeeeee", TunnelName: "prod"},
+		"neither": {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			list := newFakeTunnelList(t, nil)
+			resolver, _, gatewayClassConfig := newTunnelNameResolver(t, list, spec)
+
+			_, err := resolver.ResolveFromConfig(context.Background(), gatewayClassConfig)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "exactly one of tunnelID and tunnelName")
+			assert.Zero(t, list.lookups.Load())
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// TunnelIDAnnotation overrides, for one shared-mode Gateway, the tunnel its
+// routes are written to and its status address points at. It exists for
+// blue/green tunnel migrations: annotate a Gateway with the new tunnel, move
+// traffic, then flip the GatewayClassConfig. A Gateway with a dedicated data
+// plane (infrastructure.parametersRef) ignores it — its tunnel identity comes
+// from the connector token.
+const TunnelIDAnnotation = "cf.k8s.lex.la/tunnel-id"
+
+// TunnelIDOverride returns the canonical tunnel ID from the Gateway's
+// TunnelIDAnnotation. The bool reports whether the annotation is set at all;
+// a value that is not a UUID errors with ErrInvalidParameters.
+func TunnelIDOverride(gateway *gatewayv1.Gateway) (string, bool, error) {
+	value, ok := gateway.Annotations[TunnelIDAnnotation]
+	if !ok {
+		return "", false, nil
+	}
+
+	parsed, err := uuid.Parse(value)
+	if err != nil {
+		return "", true, errors.Wrapf(ErrInvalidParameters,
+			"annotation %s=%q is not a valid tunnel UUID: %v", TunnelIDAnnotation, value, err)
+	}
+
+	return parsed.String(), true, nil
+}
+
+// WithTunnelOverride returns the class-resolved config with the Gateway's
+// TunnelIDAnnotation applied. The result is a copy: resolved is shared
+// across Gateways of the class and must not be mutated.
+func WithTunnelOverride(resolved *ResolvedConfig, gateway *gatewayv1.Gateway) (*ResolvedConfig, error) {
+	tunnelID, ok, err := TunnelIDOverride(gateway)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return resolved, nil
+	}
+
+	override := *resolved
+	override.TunnelID = tunnelID
+
+	return &override, nil
+}
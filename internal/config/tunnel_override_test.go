@@ -0,0 +1,71 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+func TestWithTunnelOverride(t *testing.T) {
+	t.Parallel()
+
+	const classTunnel = "12345678-1234-1234-1234-123456789abc"
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantTunnel  string
+		wantErr     bool
+	}{
+		{
+			name:       "no annotation keeps the class tunnel",
+			wantTunnel: classTunnel,
+		},
+		{
+			name:        "annotation overrides the class tunnel",
+			annotations: map[string]string{config.TunnelIDAnnotation: "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee"},
+			wantTunnel:  "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+		},
+		{
+			name:        "annotation is canonicalized to lowercase",
+			annotations: map[string]string{config.TunnelIDAnnotation: "AAAAAAAA-BBBB-CCCC-DDDD-EEEEEEEEEEEE"},
+			wantTunnel:  "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+		},
+		{
+			name:        "empty annotation is invalid",
+			annotations: map[string]string{config.TunnelIDAnnotation: ""},
+			wantErr:     true,
+		},
+		{
+			name:        "non-UUID annotation is invalid",
+			annotations: map[string]string{config.TunnelIDAnnotation: "my-tunnel"},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			class := &config.ResolvedConfig{TunnelID: classTunnel, APIToken: "token", AccountID: "acct"}
+			gateway := &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+
+			resolved, err := config.WithTunnelOverride(class, gateway)
+			if tt.wantErr {
+				require.ErrorIs(t, err, config.ErrInvalidParameters)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTunnel, resolved.TunnelID)
+			assert.Equal(t, "token", resolved.APIToken, "credentials always come from the class")
+			assert.Equal(t, classTunnel, class.TunnelID, "the class config must not be mutated")
+		})
+	}
+}
@@ -0,0 +1,596 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/zero_trust"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+// AccessPoliciesAnnotation puts the hostnames of an HTTPRoute or GRPCRoute
+// behind Cloudflare Access. The value is a comma-separated list of reusable
+// Access policy IDs, applied in the order given.
+const AccessPoliciesAnnotation = "cf.k8s.lex.la/access-policies"
+
+// accessApplicationNameSuffix ends the name of every Access application the
+// controller creates for a tunnel. Only applications carrying a tunnel's
+// suffix are updated or deleted by that tunnel's sync.
+func accessApplicationNameSuffix(tunnelID string) string {
+	return " (managed by cloudflare-tunnel-gateway-controller for tunnel " + tunnelID + ")"
+}
+
+// accessApplication is a self-hosted Access application protecting one
+// hostname with reusable policies.
+type accessApplication struct {
+	ID        string
+	Name      string
+	Domain    string
+	PolicyIDs []string
+}
+
+// accessApplicationAPI is the part of the Cloudflare Access API the
+// application sync uses, scoped to one account. It is an interface so the
+// sync can be tested without the Cloudflare API.
+type accessApplicationAPI interface {
+	ListApplications(ctx context.Context) ([]accessApplication, error)
+	CreateApplication(ctx context.Context, app accessApplication) error
+	UpdateApplication(ctx context.Context, app accessApplication) error
+	DeleteApplication(ctx context.Context, appID string) error
+}
+
+// cloudflareAccessApplications manages applications through the Cloudflare
+// API.
+type cloudflareAccessApplications struct {
+	client    *cloudflare.Client
+	accountID string
+	metrics   cfmetrics.Collector
+}
+
+// record reports one API call to the metrics collector.
+func (c *cloudflareAccessApplications) record(ctx context.Context, method string, start time.Time, err error) {
+	if err != nil {
+		c.metrics.RecordAPICall(ctx, method, "access_application", "error", time.Since(start))
+		c.metrics.RecordAPIError(ctx, method, cfmetrics.ClassifyCloudflareError(err))
+
+		return
+	}
+
+	c.metrics.RecordAPICall(ctx, method, "access_application", "success", time.Since(start))
+}
+
+func (c *cloudflareAccessApplications) ListApplications(ctx context.Context) ([]accessApplication, error) {
+	start := time.Now()
+
+	pager := c.client.ZeroTrust.Access.Applications.ListAutoPaging(ctx, zero_trust.AccessApplicationListParams{
+		AccountID: cloudflare.F(c.accountID),
+	})
+
+	var result []accessApplication
+
+	for pager.Next() {
+		app := pager.Current()
+		result = append(result, accessApplication{
+			ID:        app.ID,
+			Name:      app.Name,
+			Domain:    app.Domain,
+			PolicyIDs: policyIDsFromJSON(app.JSON.Policies.Raw()),
+		})
+	}
+
+	err := pager.Err()
+	c.record(ctx, "list", start, err)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "listing Access applications")
+	}
+
+	return result, nil
+}
+
+// applicationPolicy is one policy of an application as the API returns it.
+type applicationPolicy struct {
+	ID         string `json:"id"`
+	Precedence int64  `json:"precedence"`
+}
+
+// policyIDsFromJSON returns the IDs of an application's policies ordered by
+// precedence. The SDK leaves the policies undecoded.
+func policyIDsFromJSON(raw string) []string {
+	var policies []applicationPolicy
+
+	if raw == "" || json.Unmarshal([]byte(raw), &policies) != nil {
+		return nil
+	}
+
+	slices.SortStableFunc(policies, func(a, b applicationPolicy) int {
+		return cmp.Compare(a.Precedence, b.Precedence)
+	})
+
+	ids := make([]string, 0, len(policies))
+	for _, policy := range policies {
+		ids = append(ids, policy.ID)
+	}
+
+	return ids
+}
+
+func (c *cloudflareAccessApplications) CreateApplication(ctx context.Context, app accessApplication) error {
+	policies := make([]zero_trust.AccessApplicationNewParamsBodySelfHostedApplicationPolicyUnion, 0, len(app.PolicyIDs))
+	for i, id := range app.PolicyIDs {
+		policies = append(policies, zero_trust.AccessApplicationNewParamsBodySelfHostedApplicationPoliciesAccessAppPolicyLink{
+			ID:         cloudflare.F(id),
+			Precedence: cloudflare.F(int64(i + 1)),
+		})
+	}
+
+	start := time.Now()
+
+	_, err := c.client.ZeroTrust.Access.Applications.New(ctx, zero_trust.AccessApplicationNewParams{
+		AccountID: cloudflare.F(c.accountID),
+		Body: zero_trust.AccessApplicationNewParamsBodySelfHostedApplication{
+			Domain:   cloudflare.F(app.Domain),
+			Type:     cloudflare.F(zero_trust.ApplicationTypeSelfHosted),
+			Name:     cloudflare.F(app.Name),
+			Policies: cloudflare.F(policies),
+		},
+	})
+	c.record(ctx, "create", start, err)
+
+	return errors.Wrapf(err, "creating Access application for %s", app.Domain)
+}
+
+func (c *cloudflareAccessApplications) UpdateApplication(ctx context.Context, app accessApplication) error {
+	policies := make([]zero_trust.AccessApplicationUpdateParamsBodySelfHostedApplicationPolicyUnion, 0, len(app.PolicyIDs))
+	for i, id := range app.PolicyIDs {
+		policies = append(policies, zero_trust.AccessApplicationUpdateParamsBodySelfHostedApplicationPoliciesAccessAppPolicyLink{
+			ID:         cloudflare.F(id),
+			Precedence: cloudflare.F(int64(i + 1)),
+		})
+	}
+
+	start := time.Now()
+
+	_, err := c.client.ZeroTrust.Access.Applications.Update(ctx, app.ID, zero_trust.AccessApplicationUpdateParams{
+		AccountID: cloudflare.F(c.accountID),
+		Body: zero_trust.AccessApplicationUpdateParamsBodySelfHostedApplication{
+			Domain:   cloudflare.F(app.Domain),
+			Type:     cloudflare.F(zero_trust.ApplicationTypeSelfHosted),
+			Name:     cloudflare.F(app.Name),
+			Policies: cloudflare.F(policies),
+		},
+	})
+	c.record(ctx, "update", start, err)
+
+	return errors.Wrapf(err, "updating Access application for %s", app.Domain)
+}
+
+func (c *cloudflareAccessApplications) DeleteApplication(ctx context.Context, appID string) error {
+	start := time.Now()
+
+	_, err := c.client.ZeroTrust.Access.Applications.Delete(ctx, appID, zero_trust.AccessApplicationDeleteParams{
+		AccountID: cloudflare.F(c.accountID),
+	})
+	c.record(ctx, "delete", start, err)
+
+	return errors.Wrapf(err, "deleting Access application %s", appID)
+}
+
+// accessApplications returns the Access API for the resolved config's
+// account, via the injected factory when set.
+func (s *RouteSyncer) accessApplications(
+	ctx context.Context,
+	resolved *config.ResolvedConfig,
+) (accessApplicationAPI, error) {
+	if s.accessApplicationsFactory != nil {
+		return s.accessApplicationsFactory(resolved), nil
+	}
+
+	cfClient := s.cloudflareClient(resolved)
+
+	accountID, err := s.ConfigResolver.ResolveAccountID(ctx, cfClient, resolved)
+	if err != nil {
+		return nil, errors.Wrapf(err, "resolving account for tunnel %s", resolved.TunnelID)
+	}
+
+	return &cloudflareAccessApplications{client: cfClient, accountID: accountID, metrics: s.Metrics}, nil
+}
+
+// parseAccessPolicies returns the policy IDs of an AccessPoliciesAnnotation
+// value, or an error naming the first entry that is not a UUID.
+func parseAccessPolicies(value string) ([]string, error) {
+	var ids []string
+
+	for entry := range strings.SplitSeq(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if _, err := uuid.Parse(entry); err != nil {
+			return nil, errors.Newf("%q is not an Access policy ID", entry)
+		}
+
+		if !slices.Contains(ids, entry) {
+			ids = append(ids, entry)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, errors.New("no Access policy IDs")
+	}
+
+	return ids, nil
+}
+
+// desiredAccessApplications maps each hostname an annotated route of the
+// group declares, and the tunnel serves, to the policies protecting it.
+// Routes sharing a hostname contribute their policies in namespace/name
+// order. A route whose annotation does not parse is skipped with a warning.
+func desiredAccessApplications(logger *slog.Logger, group *tunnelGroup, served []string) map[string][]string {
+	type annotatedRoute struct {
+		key       string
+		meta      metav1.Object
+		hostnames []gatewayv1.Hostname
+	}
+
+	httpRoutes, grpcRoutes := groupRoutes(group)
+
+	var routes []annotatedRoute
+
+	for i := range httpRoutes {
+		route := &httpRoutes[i]
+		routes = append(routes, annotatedRoute{
+			key: route.Namespace + "/" + route.Name, meta: route, hostnames: route.Spec.Hostnames,
+		})
+	}
+
+	for i := range grpcRoutes {
+		route := &grpcRoutes[i]
+		routes = append(routes, annotatedRoute{
+			key: route.Namespace + "/" + route.Name, meta: route, hostnames: route.Spec.Hostnames,
+		})
+	}
+
+	slices.SortStableFunc(routes, func(a, b annotatedRoute) int { return strings.Compare(a.key, b.key) })
+
+	desired := make(map[string][]string)
+
+	for _, route := range routes {
+		value, ok := route.meta.GetAnnotations()[AccessPoliciesAnnotation]
+		if !ok {
+			continue
+		}
+
+		policyIDs, err := parseAccessPolicies(value)
+		if err != nil {
+			logger.Warn("ignoring invalid Access policies annotation",
+				"route", route.key, "annotation", AccessPoliciesAnnotation, "error", err)
+
+			continue
+		}
+
+		for _, routeHostname := range route.hostnames {
+			hostname := string(routeHostname)
+			if !slices.Contains(served, hostname) {
+				continue
+			}
+
+			for _, id := range policyIDs {
+				if !slices.Contains(desired[hostname], id) {
+					desired[hostname] = append(desired[hostname], id)
+				}
+			}
+		}
+	}
+
+	return desired
+}
+
+// syncAccessApplications reconciles the Access applications one tunnel
+// owns against desired (hostname -> policy IDs): a hostname without an
+// application gets one, an application whose policies differ is updated,
+// and an application whose hostname is no longer desired is deleted.
+// Applications without the tunnel's name suffix are never touched. Every
+// change is attempted; the returned error reports the ones that failed.
+func (s *RouteSyncer) syncAccessApplications(
+	ctx context.Context,
+	logger *slog.Logger,
+	api accessApplicationAPI,
+	tunnelID string,
+	desired map[string][]string,
+) error {
+	apps, err := api.ListApplications(ctx)
+	if err != nil {
+		return err
+	}
+
+	suffix := accessApplicationNameSuffix(tunnelID)
+	owned := make(map[string]accessApplication)
+
+	for _, app := range apps {
+		if strings.HasSuffix(app.Name, suffix) {
+			owned[app.Domain] = app
+		}
+	}
+
+	var errs []error
+
+	apply := func(action, hostname string, write func() error) {
+		if s.DryRun {
+			logger.Info("dry run: Access application change not applied", "action", action, "hostname", hostname)
+
+			return
+		}
+
+		if err := write(); err != nil {
+			logger.Error("failed to change Access application", "action", action, "hostname", hostname, "error", err)
+			errs = append(errs, err)
+
+			return
+		}
+
+		logger.Info("Access application changed", "action", action, "hostname", hostname, "tunnel", tunnelID)
+	}
+
+	hostnames := make([]string, 0, len(desired))
+	for hostname := range desired {
+		hostnames = append(hostnames, hostname)
+	}
+
+	slices.Sort(hostnames)
+
+	for _, hostname := range hostnames {
+		want := accessApplication{
+			Name: hostname + suffix, Domain: hostname, PolicyIDs: desired[hostname],
+		}
+
+		app, ok := owned[hostname]
+
+		switch {
+		case !ok:
+			apply("create", hostname, func() error { return api.CreateApplication(ctx, want) })
+		case !slices.Equal(app.PolicyIDs, want.PolicyIDs):
+			want.ID = app.ID
+			apply("update", hostname, func() error { return api.UpdateApplication(ctx, want) })
+		}
+	}
+
+	for hostname, app := range owned {
+		if _, ok := desired[hostname]; ok {
+			continue
+		}
+
+		apply("delete", hostname, func() error { return api.DeleteApplication(ctx, app.ID) })
+	}
+
+	if len(errs) > 0 {
+		return errors.Wrapf(errors.Join(errs...), "syncing Access applications for tunnel %s", tunnelID)
+	}
+
+	return nil
+}
+
+// appliedAccess is the application set last confirmed for a tunnel, and the
+// config it was synced with.
+type appliedAccess struct {
+	desired  map[string][]string
+	resolved config.ResolvedConfig
+	storedAt time.Time
+	// unverified marks an entry for a tunnel with no annotated routes whose
+	// listing failed, so the tunnel may still own applications.
+	unverified bool
+}
+
+// appliedAccessCache remembers, per tunnel ID, the applications the last
+// Access sync confirmed. A sync with the same set makes no Access API
+// calls. A tunnel has no entry until its first sync after startup, which
+// always lists, so applications created before a restart are still found;
+// once that listing confirmed the tunnel owns nothing, a tunnel with no
+// annotated routes makes no Access calls at all. Entries expire like
+// appliedConfigCache's, and an unverified one is retried once it expires.
+// Like appliedDNSCache, an entry is also how a tunnel that stops being
+// synced is found, so its applications can be deleted (see
+// syncDepartedAccess).
+type appliedAccessCache struct {
+	mu      sync.Mutex
+	entries map[string]appliedAccess
+}
+
+// lookup reports whether the entry for tunnelID makes a sync of desired
+// unnecessary.
+func (c *appliedAccessCache) lookup(tunnelID string, desired map[string][]string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[tunnelID]
+	if !ok {
+		return false
+	}
+
+	if len(desired) == 0 && len(entry.desired) == 0 && !entry.unverified {
+		return true
+	}
+
+	same := len(entry.desired) == len(desired)
+
+	for hostname, policyIDs := range desired {
+		same = same && slices.Equal(entry.desired[hostname], policyIDs)
+	}
+
+	return same && time.Since(entry.storedAt) <= appliedConfigMaxAge
+}
+
+// store records desired as confirmed for the resolved config's tunnel.
+func (c *appliedAccessCache) store(resolved *config.ResolvedConfig, desired map[string][]string) {
+	c.put(resolved, appliedAccess{desired: desired, resolved: *resolved, storedAt: time.Now()})
+}
+
+// storeUnverified records that the tunnel, with no annotated routes, could
+// not be listed, so the listing is retried after appliedConfigMaxAge rather
+// than on every sync.
+func (c *appliedAccessCache) storeUnverified(resolved *config.ResolvedConfig) {
+	c.put(resolved, appliedAccess{resolved: *resolved, storedAt: time.Now(), unverified: true})
+}
+
+func (c *appliedAccessCache) put(resolved *config.ResolvedConfig, entry appliedAccess) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]appliedAccess)
+	}
+
+	c.entries[resolved.TunnelID] = entry
+}
+
+// forget drops the entry for tunnelID.
+func (c *appliedAccessCache) forget(tunnelID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, tunnelID)
+}
+
+// expire marks every entry stale, forcing the next Access sync of each
+// tunnel to read. An entry with no applications is dropped, so the tunnel
+// is listed again as after startup.
+func (c *appliedAccessCache) expire() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for tunnelID, entry := range c.entries {
+		if len(entry.desired) == 0 {
+			delete(c.entries, tunnelID)
+
+			continue
+		}
+
+		entry.storedAt = time.Time{}
+		c.entries[tunnelID] = entry
+	}
+}
+
+// departed returns the configs of the entries with applications whose
+// tunnel is not in tunnelIDs. A departed entry without applications has
+// nothing to delete and is dropped.
+func (c *appliedAccessCache) departed(tunnelIDs map[string]struct{}) []config.ResolvedConfig {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var result []config.ResolvedConfig
+
+	for tunnelID, entry := range c.entries {
+		if _, ok := tunnelIDs[tunnelID]; ok {
+			continue
+		}
+
+		if len(entry.desired) == 0 {
+			delete(c.entries, tunnelID)
+
+			continue
+		}
+
+		result = append(result, entry.resolved)
+	}
+
+	slices.SortFunc(result, func(a, b config.ResolvedConfig) int {
+		return strings.Compare(a.TunnelID, b.TunnelID)
+	})
+
+	return result
+}
+
+// syncGroupAccess runs the Access application sync for a group after its
+// ingress sync succeeded. Like syncGroupDNS it is skipped while the group
+// is paused or rate limited, and a failure is logged and retried on the
+// next sync without failing the routes. A tunnel with no annotated routes
+// whose listing fails (a token without Access permissions, typically) is
+// retried only after appliedConfigMaxAge.
+func (s *RouteSyncer) syncGroupAccess(
+	ctx context.Context,
+	logger *slog.Logger,
+	group *tunnelGroup,
+	hostnames []string,
+) {
+	tunnelID := group.resolved.TunnelID
+	desired := desiredAccessApplications(logger, group, hostnames)
+
+	if s.appliedAccess.lookup(tunnelID, desired) {
+		return
+	}
+
+	if !group.pausedUntil.IsZero() || !s.rateLimitHold().IsZero() {
+		return
+	}
+
+	api, err := s.accessApplications(ctx, group.resolved)
+	if err == nil {
+		err = s.syncAccessApplications(ctx, logger, api, tunnelID, desired)
+	}
+
+	if err != nil && len(desired) == 0 {
+		logger.Info("could not check the tunnel for Access applications to delete; retrying later",
+			"tunnel", tunnelID, "error", err)
+		s.holdForRateLimit(logger, err)
+
+		if !s.DryRun {
+			s.appliedAccess.storeUnverified(group.resolved)
+		}
+
+		return
+	}
+
+	if err != nil {
+		logger.Error("failed to sync Access applications", "tunnel", tunnelID, "error", err)
+		s.holdForRateLimit(logger, err)
+
+		return
+	}
+
+	if !s.DryRun {
+		s.appliedAccess.store(group.resolved, desired)
+	}
+}
+
+// syncDepartedAccess deletes the applications of every tunnel that owned
+// some but that no group in tunnelIDs serves any more, e.g. after its
+// Gateway was deleted or the config's tunnelID changed. It is the Access
+// counterpart of syncDepartedDNS.
+func (s *RouteSyncer) syncDepartedAccess(ctx context.Context, logger *slog.Logger, tunnelIDs map[string]struct{}) {
+	for _, resolved := range s.appliedAccess.departed(tunnelIDs) {
+		if !s.rateLimitHold().IsZero() {
+			return
+		}
+
+		api, err := s.accessApplications(ctx, &resolved)
+		if err == nil {
+			err = s.syncAccessApplications(ctx, logger, api, resolved.TunnelID, nil)
+		}
+
+		if err != nil {
+			logger.Error("failed to delete Access applications of a departed tunnel",
+				"tunnel", resolved.TunnelID, "error", err)
+			s.holdForRateLimit(logger, err)
+
+			continue
+		}
+
+		if !s.DryRun {
+			s.appliedAccess.forget(resolved.TunnelID)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package controller
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+const (
+	testAccessPolicyA = "aaaaaaaa-0000-4000-8000-000000000001"
+	testAccessPolicyB = "bbbbbbbb-0000-4000-8000-000000000002"
+)
+
+// fakeAccessApplications is an in-memory accessApplicationAPI; calls logs
+// every call.
+type fakeAccessApplications struct {
+	apps   []accessApplication
+	calls  []string
+	nextID int
+}
+
+func (f *fakeAccessApplications) ListApplications(_ context.Context) ([]accessApplication, error) {
+	f.calls = append(f.calls, "list")
+
+	return append([]accessApplication(nil), f.apps...), nil
+}
+
+func (f *fakeAccessApplications) CreateApplication(_ context.Context, app accessApplication) error {
+	f.calls = append(f.calls, "create "+app.Domain)
+	f.nextID++
+	app.ID = "app-" + strconv.Itoa(f.nextID)
+	f.apps = append(f.apps, app)
+
+	return nil
+}
+
+func (f *fakeAccessApplications) UpdateApplication(_ context.Context, app accessApplication) error {
+	f.calls = append(f.calls, "update "+app.Domain)
+
+	for i := range f.apps {
+		if f.apps[i].ID == app.ID {
+			f.apps[i] = app
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeAccessApplications) DeleteApplication(_ context.Context, appID string) error {
+	for i := range f.apps {
+		if f.apps[i].ID == appID {
+			f.calls = append(f.calls, "delete "+f.apps[i].Domain)
+			f.apps = append(f.apps[:i], f.apps[i+1:]...)
+
+			break
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeAccessApplications) byDomain(domain string) []accessApplication {
+	var result []accessApplication
+
+	for _, app := range f.apps {
+		if app.Domain == domain {
+			result = append(result, app)
+		}
+	}
+
+	return result
+}
+
+// TestSyncAllRoutes_AccessPolicies pins the Access application lifecycle: an
+// annotated route's hostname gets an application with the policies in
+// order, a changed annotation updates it, an unchanged one makes no calls,
+// and removing the route deletes it. Applications the tunnel does not own
+// are never touched, and a tunnel without annotated routes calls the Access
+// API only for the listing of its first sync.
+func TestSyncAllRoutes_AccessPolicies(t *testing.T) {
+	t.Parallel()
+
+	const classTunnel = "99999999-9999-4999-8999-999999999999"
+
+	ctx := context.Background()
+	syncer := newPartitionSyncSyncer(t, newRecordingTunnelAPI(t), classTunnel)
+
+	fake := &fakeAccessApplications{apps: []accessApplication{
+		{ID: "manual", Name: "web.example.com", Domain: "web.example.com"},
+		{
+			ID: "other-tunnel", Name: "old.example.com" + accessApplicationNameSuffix("88888888-8888-4888-8888-888888888888"),
+			Domain: "old.example.com",
+		},
+	}}
+	syncer.accessApplicationsFactory = tenantAccessSeparated(fake)
+
+	_, _, err := syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"list"}, fake.calls, "the first sync lists what the tunnel owns")
+
+	fake.calls = nil
+
+	_, _, err = syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, fake.calls, "no annotated route, no Access calls")
+
+	route := partitionSyncRoute("web", "shared-gw", "web.example.com")
+	route.Annotations = map[string]string{AccessPoliciesAnnotation: testAccessPolicyB + ", " + testAccessPolicyA}
+	require.NoError(t, syncer.Client.Create(ctx, route))
+
+	_, _, err = syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"list", "create web.example.com"}, fake.calls)
+
+	apps := fake.byDomain("web.example.com")
+	require.Len(t, apps, 2, "the unmanaged application is kept beside the new one")
+	assert.Equal(t, []string{testAccessPolicyB, testAccessPolicyA}, apps[1].PolicyIDs)
+	assert.Equal(t, "web.example.com"+accessApplicationNameSuffix(classTunnel), apps[1].Name)
+
+	fake.calls = nil
+
+	_, _, err = syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, fake.calls, "an unchanged set makes no Access calls")
+
+	require.NoError(t, syncer.Client.Get(ctx, client.ObjectKeyFromObject(route), route))
+	route.Annotations[AccessPoliciesAnnotation] = testAccessPolicyA
+	require.NoError(t, syncer.Client.Update(ctx, route))
+
+	_, _, err = syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"list", "update web.example.com"}, fake.calls)
+	assert.Equal(t, []string{testAccessPolicyA}, fake.byDomain("web.example.com")[1].PolicyIDs)
+
+	fake.calls = nil
+
+	require.NoError(t, syncer.Client.Delete(ctx, route))
+
+	_, _, err = syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"list", "delete web.example.com"}, fake.calls)
+	assert.Len(t, fake.byDomain("web.example.com"), 1, "the unmanaged application survives")
+	assert.Len(t, fake.byDomain("old.example.com"), 1, "another tunnel's application survives")
+
+	fake.calls = nil
+
+	_, _, err = syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, fake.calls, "a tunnel that owns nothing any more stops calling Access")
+}
+
+// TestSyncAllRoutes_AccessPolicies_Restart pins that a restarted controller,
+// which remembers nothing, still deletes the application of a route whose
+// annotation was removed while it was down.
+func TestSyncAllRoutes_AccessPolicies_Restart(t *testing.T) {
+	t.Parallel()
+
+	const classTunnel = "99999999-9999-4999-8999-999999999999"
+
+	ctx := context.Background()
+	syncer := newPartitionSyncSyncer(t, newRecordingTunnelAPI(t), classTunnel)
+
+	fake := &fakeAccessApplications{}
+	syncer.accessApplicationsFactory = tenantAccessSeparated(fake)
+
+	route := partitionSyncRoute("web", "shared-gw", "web.example.com")
+	route.Annotations = map[string]string{AccessPoliciesAnnotation: testAccessPolicyA}
+	require.NoError(t, syncer.Client.Create(ctx, route))
+
+	_, _, err := syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+	require.Len(t, fake.byDomain("web.example.com"), 1)
+
+	delete(route.Annotations, AccessPoliciesAnnotation)
+	require.NoError(t, syncer.Client.Update(ctx, route))
+
+	syncer.appliedAccess = appliedAccessCache{}
+	fake.calls = nil
+
+	_, _, err = syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"list", "delete web.example.com"}, fake.calls)
+	assert.Empty(t, fake.apps)
+}
+
+// TestSyncAllRoutes_AccessPolicies_DepartedTunnel pins cleanup after a
+// tunnel stops being synced: the new tunnel creates its own application and
+// the one the old tunnel owned is deleted.
+func TestSyncAllRoutes_AccessPolicies_DepartedTunnel(t *testing.T) {
+	t.Parallel()
+
+	const (
+		oldTunnel = "99999999-9999-4999-8999-999999999999"
+		newTunnel = "77777777-7777-4777-8777-777777777777"
+	)
+
+	ctx := context.Background()
+	syncer := newPartitionSyncSyncer(t, newRecordingTunnelAPI(t), oldTunnel)
+
+	fake := &fakeAccessApplications{}
+	syncer.accessApplicationsFactory = tenantAccessSeparated(fake)
+
+	route := partitionSyncRoute("web", "shared-gw", "web.example.com")
+	route.Annotations = map[string]string{AccessPoliciesAnnotation: testAccessPolicyA}
+	require.NoError(t, syncer.Client.Create(ctx, route))
+
+	_, _, err := syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	var gatewayClassConfig v1alpha1.GatewayClassConfig
+	require.NoError(t, syncer.Client.Get(ctx, client.ObjectKey{Name: "cfg"}, &gatewayClassConfig))
+
+	gatewayClassConfig.Spec.TunnelID = newTunnel
+	require.NoError(t, syncer.Client.Update(ctx, &gatewayClassConfig))
+
+	fake.calls = nil
+
+	_, _, err = syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"list", "create web.example.com", "list", "delete web.example.com"}, fake.calls)
+
+	apps := fake.byDomain("web.example.com")
+	require.Len(t, apps, 1)
+	assert.Equal(t, "web.example.com"+accessApplicationNameSuffix(newTunnel), apps[0].Name)
+
+	fake.calls = nil
+
+	_, _, err = syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, fake.calls, "a cleaned-up tunnel is not revisited")
+}
+
+// tenantAccessSeparated serves fake for every tunnel except the dedicated
+// Gateway's of the partition-sync world, which gets a fake of its own.
+func tenantAccessSeparated(fake *fakeAccessApplications) func(*config.ResolvedConfig) accessApplicationAPI {
+	tenant := &fakeAccessApplications{}
+
+	return func(resolved *config.ResolvedConfig) accessApplicationAPI {
+		if resolved.TunnelID == tenantTunnelUUID {
+			return tenant
+		}
+
+		return fake
+	}
+}
+
+func TestParseAccessPolicies(t *testing.T) {
+	t.Parallel()
+
+	ids, err := parseAccessPolicies(" " + testAccessPolicyA + ",," + testAccessPolicyB + "," + testAccessPolicyA)
+	require.NoError(t, err)
+	assert.Equal(t, []string{testAccessPolicyA, testAccessPolicyB}, ids, "order kept, duplicates dropped")
+
+	_, err = parseAccessPolicies("allow-everyone")
+	require.ErrorContains(t, err, `"allow-everyone" is not an Access policy ID`)
+
+	_, err = parseAccessPolicies(" , ")
+	require.Error(t, err)
+}
+
+func TestPolicyIDsFromJSON(t *testing.T) {
+	t.Parallel()
+
+	raw := `[{"id":"` + testAccessPolicyB + `","precedence":2},{"id":"` + testAccessPolicyA + `","precedence":1}]`
+
+	assert.Equal(t, []string{testAccessPolicyA, testAccessPolicyB}, policyIDsFromJSON(raw))
+	assert.Nil(t, policyIDsFromJSON(""))
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v7/zero_trust"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/ingress"
+)
+
+// appliedConfigMaxAge bounds how long a cached document short-circuits the
+// sync. Past it the next sync reads the deployed document again, so an
+// out-of-band edit (dashboard, API) is still repaired without a route change.
+const appliedConfigMaxAge = 10 * time.Minute
+
+// appliedConfig is the desired ingress document last confirmed deployed for a
+// tunnel, either written by the syncer or found already in place.
+type appliedConfig struct {
+	desired   []ingress.Rule
+	ruleCount int
+	storedAt  time.Time
+}
+
+// appliedConfigCache remembers, per tunnel ID, the last desired document the
+// syncer confirmed deployed. A sync whose freshly built document matches the
+// entry skips the Cloudflare GET and PUT entirely. The cache lives in memory
+// only, so a controller restart always starts with a read.
+type appliedConfigCache struct {
+	mu      sync.Mutex
+	entries map[string]appliedConfig
+	now     func() time.Time
+}
+
+// desiredDocument flattens the rules the builders produced and the configured
+// catch-all into the comparison form the cache stores.
+func desiredDocument(
+	rules []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress,
+	catchAll zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress,
+) []ingress.Rule {
+	doc := make([]ingress.Rule, 0, len(rules)+1)
+
+	for i := range rules {
+		doc = append(doc, ingress.RuleFromUpdate(&rules[i]))
+	}
+
+	return append(doc, ingress.RuleFromUpdate(&catchAll))
+}
+
+func (c *appliedConfigCache) clock() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+
+	return time.Now()
+}
+
+// lookup reports whether desired equals the fresh entry for tunnelID and, if
+// so, the rule count of the deployed document.
+func (c *appliedConfigCache) lookup(tunnelID string, desired []ingress.Rule) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[tunnelID]
+	if !ok || c.clock().Sub(entry.storedAt) > appliedConfigMaxAge {
+		return 0, false
+	}
+
+	if !slices.Equal(entry.desired, desired) {
+		return 0, false
+	}
+
+	return entry.ruleCount, true
+}
+
+// store records desired as deployed for tunnelID.
+func (c *appliedConfigCache) store(tunnelID string, desired []ingress.Rule, ruleCount int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]appliedConfig)
+	}
+
+	c.entries[tunnelID] = appliedConfig{desired: desired, ruleCount: ruleCount, storedAt: c.clock()}
+}
+
+// forget drops the entry for tunnelID, forcing the next sync to read.
+func (c *appliedConfigCache) forget(tunnelID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, tunnelID)
+}
+
+// clear drops every entry, forcing the next sync of each tunnel to read.
+func (c *appliedConfigCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+}
+
+// retain drops every entry whose tunnel is not in tunnelIDs. A tunnel the
+// resolved configuration no longer points at is read again if it ever comes
+// back, since it may have been written by someone else in the meantime.
+func (c *appliedConfigCache) retain(tunnelIDs map[string]struct{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for tunnelID := range c.entries {
+		if _, ok := tunnelIDs[tunnelID]; !ok {
+			delete(c.entries, tunnelID)
+		}
+	}
+}
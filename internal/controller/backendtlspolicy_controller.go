@@ -823,7 +823,7 @@ func ancestorRefKey(ref gatewayv1.ParentReference, policyNamespace string) clien
 // for updating status conditions on Gateway API resources we don't otherwise
 // drive (GatewayClass acceptance, BackendTLSPolicy ancestry). Extracted from
 // the top-level Run() to keep its cyclomatic complexity within budget.
-func setupStatusReconcilers(mgr ctrl.Manager, controllerName string) error {
+func setupStatusReconcilers(mgr ctrl.Manager, controllerName, sharedTunnelPolicy string) error {
 	gatewayClassReconciler := &GatewayClassReconciler{
 		Client:         mgr.GetClient(),
 		Scheme:         mgr.GetScheme(),
@@ -831,6 +831,7 @@ func setupStatusReconcilers(mgr ctrl.Manager, controllerName string) error {
 		// APIReader is uncached: the SupportedVersion check reads a single CRD
 		// on demand, so there is no reason to watch every CRD cluster-wide.
 		BundleVersionReader: mgr.GetAPIReader(),
+		SharedTunnelPolicy:  sharedTunnelPolicy,
 	}
 
 	if err := gatewayClassReconciler.SetupWithManager(mgr); err != nil {
@@ -0,0 +1,228 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/zero_trust"
+	"github.com/cockroachdb/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+// DefaultCloudflareReadinessFailureThreshold is how many consecutive failed
+// Cloudflare checks flip the controller's readiness to false.
+const DefaultCloudflareReadinessFailureThreshold = 3
+
+// errTunnelDeleted marks a probe of a tunnel the API still returns but has
+// deleted.
+var errTunnelDeleted = errors.New("tunnel deleted")
+
+// tunnelProber verifies that the Cloudflare API accepts a tunnel's
+// credentials and that the tunnel exists. It is an interface so the
+// readiness check can be tested without the Cloudflare API.
+type tunnelProber interface {
+	ProbeTunnel(ctx context.Context, resolved *config.ResolvedConfig) error
+}
+
+// cloudflareTunnelProber probes tunnels through the Cloudflare API.
+type cloudflareTunnelProber struct {
+	resolver *config.Resolver
+	metrics  cfmetrics.Collector
+}
+
+// ProbeTunnel reads the tunnel with the resolved credentials. A rejected
+// token, a missing tunnel and a deleted tunnel all fail the probe.
+func (p *cloudflareTunnelProber) ProbeTunnel(ctx context.Context, resolved *config.ResolvedConfig) error {
+	cfClient := p.resolver.CreateCloudflareClient(resolved)
+
+	accountID, err := p.resolver.ResolveAccountID(ctx, cfClient, resolved)
+	if err != nil {
+		return errors.Wrapf(err, "resolving account for tunnel %s", resolved.TunnelID)
+	}
+
+	start := time.Now()
+
+	tunnel, err := cfClient.ZeroTrust.Tunnels.Cloudflared.Get(
+		ctx,
+		resolved.TunnelID,
+		zero_trust.TunnelCloudflaredGetParams{
+			AccountID: cloudflare.String(accountID),
+		},
+	)
+	if err != nil {
+		p.metrics.RecordAPICall(ctx, "get", "tunnel", "error", time.Since(start))
+		p.metrics.RecordAPIError(ctx, "get", cfmetrics.ClassifyCloudflareError(err))
+
+		return errors.Wrapf(err, "reading tunnel %s", resolved.TunnelID)
+	}
+
+	p.metrics.RecordAPICall(ctx, "get", "tunnel", "success", time.Since(start))
+
+	if !tunnel.DeletedAt.IsZero() {
+		return errors.Mark(
+			errors.Newf("tunnel %s was deleted at %s", resolved.TunnelID, tunnel.DeletedAt.Format(time.RFC3339)),
+			errTunnelDeleted)
+	}
+
+	return nil
+}
+
+// CloudflareReadinessCheck reports the controller not ready once the tunnel
+// behind a managed GatewayClass cannot be reached through the Cloudflare API
+// FailureThreshold times in a row. It checks every Interval, and /readyz
+// runs a check itself when the last one is older than Interval. A class
+// whose GatewayClassConfig cannot be resolved is skipped: that is a
+// configuration problem the GatewayClass status already reports, not a
+// connectivity one.
+type CloudflareReadinessCheck struct {
+	Client           client.Reader
+	ControllerName   string
+	ConfigResolver   *config.Resolver
+	Interval         time.Duration
+	FailureThreshold int
+	Logger           *slog.Logger
+
+	// prober is set by NewCloudflareReadinessCheck; tests inject a fake.
+	prober tunnelProber
+
+	// mu serializes checks and guards the fields below, so concurrent
+	// probes share one Cloudflare round trip.
+	mu        sync.Mutex
+	failures  int
+	lastErr   error
+	checkedAt time.Time
+}
+
+// NewCloudflareReadinessCheck creates a readiness check that probes tunnels
+// through the Cloudflare API.
+func NewCloudflareReadinessCheck(
+	c client.Reader,
+	controllerName string,
+	resolver *config.Resolver,
+	metrics cfmetrics.Collector,
+	interval time.Duration,
+	failureThreshold int,
+	logger *slog.Logger,
+) *CloudflareReadinessCheck {
+	return &CloudflareReadinessCheck{
+		Client:           c,
+		ControllerName:   controllerName,
+		ConfigResolver:   resolver,
+		Interval:         interval,
+		FailureThreshold: failureThreshold,
+		Logger:           logger.With("component", "cloudflare-readiness"),
+		prober:           &cloudflareTunnelProber{resolver: resolver, metrics: metrics},
+	}
+}
+
+// NeedLeaderElection runs the check on every replica: each one reports its
+// own readiness.
+func (r *CloudflareReadinessCheck) NeedLeaderElection() bool {
+	return false
+}
+
+// Start checks once immediately and then every Interval until ctx is done.
+func (r *CloudflareReadinessCheck) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	for {
+		r.refresh(ctx, true)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check is the /readyz checker. It refreshes a result older than Interval
+// and fails once FailureThreshold consecutive checks have failed.
+func (r *CloudflareReadinessCheck) Check(req *http.Request) error {
+	r.refresh(req.Context(), false)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.failures < r.FailureThreshold {
+		return nil
+	}
+
+	return errors.Wrapf(r.lastErr, "Cloudflare check failed %d consecutive times", r.failures)
+}
+
+// refresh runs a check and records its outcome. Unless force is set, a
+// result younger than Interval is kept instead.
+func (r *CloudflareReadinessCheck) refresh(ctx context.Context, force bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !force && !r.checkedAt.IsZero() && time.Since(r.checkedAt) < r.Interval {
+		return
+	}
+
+	err := r.check(ctx)
+	r.checkedAt = time.Now()
+
+	if err == nil {
+		if r.failures >= r.FailureThreshold {
+			r.Logger.Info("Cloudflare reachable again; reporting ready")
+		}
+
+		r.failures = 0
+		r.lastErr = nil
+
+		return
+	}
+
+	r.failures++
+	r.lastErr = err
+
+	if r.failures == r.FailureThreshold {
+		r.Logger.Error("Cloudflare unreachable; reporting not ready",
+			"consecutiveFailures", r.failures, "error", err)
+	} else {
+		r.Logger.Warn("Cloudflare check failed",
+			"consecutiveFailures", r.failures, "error", err)
+	}
+}
+
+// check probes the tunnel of every managed GatewayClass and returns the
+// first failure.
+func (r *CloudflareReadinessCheck) check(ctx context.Context) error {
+	var classList gatewayv1.GatewayClassList
+
+	if err := r.Client.List(ctx, &classList); err != nil {
+		return errors.Wrap(err, "failed to list GatewayClasses")
+	}
+
+	for i := range classList.Items {
+		class := &classList.Items[i]
+		if string(class.Spec.ControllerName) != r.ControllerName {
+			continue
+		}
+
+		resolved, err := r.ConfigResolver.ResolveFromGatewayClass(ctx, class)
+		if err != nil {
+			r.Logger.Debug("skipping GatewayClass without resolvable config",
+				"gatewayClass", class.Name, "error", err)
+
+			continue
+		}
+
+		if err := r.prober.ProbeTunnel(ctx, resolved); err != nil {
+			return errors.Wrapf(err, "GatewayClass %s", class.Name)
+		}
+	}
+
+	return nil
+}
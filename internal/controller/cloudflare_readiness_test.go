@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+// fakeTunnelProber fails every probe while err is set and counts probes.
+type fakeTunnelProber struct {
+	mu      sync.Mutex
+	err     error
+	tunnels []string
+}
+
+func (f *fakeTunnelProber) ProbeTunnel(_ context.Context, resolved *config.ResolvedConfig) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.tunnels = append(f.tunnels, resolved.TunnelID)
+
+	return f.err
+}
+
+func (f *fakeTunnelProber) fail(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.err = err
+}
+
+func newTestCloudflareReadinessCheck(
+	fakeClient client.Client,
+	prober tunnelProber,
+	interval time.Duration,
+) *CloudflareReadinessCheck {
+	return &CloudflareReadinessCheck{
+		Client:           fakeClient,
+		ControllerName:   "cloudflare-tunnel",
+		ConfigResolver:   config.NewResolver(fakeClient, "default", cfmetrics.NewNoopCollector()),
+		Interval:         interval,
+		FailureThreshold: 2,
+		Logger:           slog.Default(),
+		prober:           prober,
+	}
+}
+
+func readyz(check *CloudflareReadinessCheck) error {
+	return check.Check(httptest.NewRequest("GET", "/readyz", nil))
+}
+
+// TestCloudflareReadinessCheck_Transitions pins the readiness state machine:
+// ready until FailureThreshold consecutive failures, ready again after the
+// first success, and a single failure never flips it.
+func TestCloudflareReadinessCheck_Transitions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	prober := &fakeTunnelProber{}
+	check := newTestCloudflareReadinessCheck(newTunnelConnectionsTestClient(t), prober, time.Hour)
+
+	check.refresh(ctx, true)
+	require.NoError(t, readyz(check))
+	assert.Equal(t, []string{"aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee"}, prober.tunnels,
+		"only the tunnel of this controller's class is probed")
+
+	prober.fail(errors.New("401 Unauthorized"))
+
+	check.refresh(ctx, true)
+	require.NoError(t, readyz(check), "one failure stays below the threshold")
+
+	check.refresh(ctx, true)
+	err := readyz(check)
+	require.Error(t, err, "the threshold of consecutive failures reports not ready")
+	assert.Contains(t, err.Error(), "401 Unauthorized")
+	assert.Contains(t, err.Error(), "GatewayClass cloudflare-tunnel")
+
+	prober.fail(nil)
+
+	check.refresh(ctx, true)
+	require.NoError(t, readyz(check), "the first success reports ready again")
+
+	prober.fail(errors.New("timeout"))
+
+	check.refresh(ctx, true)
+	assert.NoError(t, readyz(check), "a success resets the consecutive count")
+}
+
+// TestCloudflareReadinessCheck_ReadyzRefreshesStaleResult pins that /readyz
+// checks Cloudflare itself once the last result is older than Interval, and
+// reuses a fresh result otherwise.
+func TestCloudflareReadinessCheck_ReadyzRefreshesStaleResult(t *testing.T) {
+	t.Parallel()
+
+	prober := &fakeTunnelProber{err: errors.New("unreachable")}
+
+	fresh := newTestCloudflareReadinessCheck(newTunnelConnectionsTestClient(t), prober, time.Hour)
+	require.NoError(t, readyz(fresh), "the first probe checks once")
+	require.NoError(t, readyz(fresh), "a fresh result is reused")
+	assert.Len(t, prober.tunnels, 1)
+
+	stale := newTestCloudflareReadinessCheck(newTunnelConnectionsTestClient(t), prober, time.Nanosecond)
+	require.NoError(t, readyz(stale))
+	time.Sleep(time.Millisecond)
+	assert.Error(t, readyz(stale), "each stale probe runs its own check")
+}
+
+// TestCloudflareReadinessCheck_SkipsUnresolvableClass pins that a class whose
+// GatewayClassConfig is missing does not fail readiness: the GatewayClass
+// status reports that, and it is not a connectivity problem.
+func TestCloudflareReadinessCheck_SkipsUnresolvableClass(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fakeClient := newTunnelConnectionsTestClient(t)
+	prober := &fakeTunnelProber{err: errors.New("unreachable")}
+	check := newTestCloudflareReadinessCheck(fakeClient, prober, time.Hour)
+
+	var class gatewayv1.GatewayClass
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: "cloudflare-tunnel"}, &class))
+	class.Spec.ParametersRef.Name = "missing"
+	require.NoError(t, fakeClient.Update(ctx, &class))
+
+	require.NoError(t, fakeClient.Create(ctx, &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "unconfigured"},
+		Spec:       gatewayv1.GatewayClassSpec{ControllerName: "cloudflare-tunnel"},
+	}))
+
+	check.refresh(ctx, true)
+	check.refresh(ctx, true)
+
+	assert.NoError(t, readyz(check))
+	assert.Empty(t, prober.tunnels)
+}
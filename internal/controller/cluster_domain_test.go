@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+// TestClassClusterDomain pins that a GatewayClassConfig's clusterDomain wins
+// over the controller's --cluster-domain for both views of a backend: the
+// origin in the tunnel ingress rules and the URL the proxy dials.
+func TestClassClusterDomain(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		classDomain string
+		wantOrigin  string
+	}{
+		{
+			name:       "unset uses the controller default",
+			wantOrigin: "http://web.default.svc.cluster.local:80",
+		},
+		{
+			name:        "class domain overrides the default",
+			classDomain: "east.example",
+			wantOrigin:  "http://web.default.svc.east.example:80",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, clientgoscheme.AddToScheme(scheme))
+			require.NoError(t, gatewayv1.Install(scheme))
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+				Spec: corev1.ServiceSpec{
+					ClusterIP: "10.0.0.10",
+					Ports:     []corev1.ServicePort{{Port: 80}},
+				},
+			}).Build()
+
+			syncer := NewRouteSyncer(fakeClient, scheme, "cluster.local", "test-controller",
+				config.NewResolver(fakeClient, "default", cfmetrics.NewNoopCollector()),
+				cfmetrics.NewNoopCollector(), nil)
+
+			domain := syncer.effectiveClusterDomain(&config.ResolvedConfig{ClusterDomain: tt.classDomain})
+
+			route := clusterDomainTestRoute()
+
+			httpBuilder, _ := syncer.buildersFor(domain)
+			build := httpBuilder.Build(ctx, []gatewayv1.HTTPRoute{route})
+			require.NotEmpty(t, build.Rules)
+			assert.Equal(t, tt.wantOrigin, build.Rules[0].Service.Value, "tunnel ingress origin")
+
+			proxySyncer := NewProxySyncer("cluster.local", "", "", fakeClient, nil)
+			cfg := proxySyncer.buildProxyConfig(ctx, domain, []*gatewayv1.HTTPRoute{&route}, nil, nil, nil)
+			require.NotEmpty(t, cfg.Rules)
+			require.NotEmpty(t, cfg.Rules[0].Backends)
+			assert.Equal(t, tt.wantOrigin, cfg.Rules[0].Backends[0].URL, "proxy backend URL")
+		})
+	}
+}
+
+func clusterDomainTestRoute() gatewayv1.HTTPRoute {
+	port := gatewayv1.PortNumber(80)
+
+	return gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Hostnames: []gatewayv1.Hostname{"web.example.com"},
+			Rules: []gatewayv1.HTTPRouteRule{{
+				BackendRefs: []gatewayv1.HTTPBackendRef{{
+					BackendRef: gatewayv1.BackendRef{
+						BackendObjectReference: gatewayv1.BackendObjectReference{Name: "web", Port: &port},
+					},
+				}},
+			}},
+		},
+	}
+}
@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+// install is one controller install of TestControllerName_InstallsCoexist:
+// its controller name, GatewayClass, Gateway and tunnel.
+type install struct {
+	controllerName string
+	class          string
+	gateway        string
+	tunnelID       string
+}
+
+func (i install) objects() []client.Object {
+	return []client.Object{
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: i.class},
+			Spec: gatewayv1.GatewayClassSpec{
+				ControllerName: gatewayv1.GatewayController(i.controllerName),
+				ParametersRef: &gatewayv1.ParametersReference{
+					Group: config.ParametersRefGroup, Kind: config.ParametersRefKind, Name: i.class,
+				},
+			},
+		},
+		&v1alpha1.GatewayClassConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: i.class},
+			Spec: v1alpha1.GatewayClassConfigSpec{
+				CloudflareCredentialsSecretRef: v1alpha1.SecretReference{Name: "creds", Namespace: "default"},
+				AccountID:                      "test-account",
+				TunnelID:                       i.tunnelID,
+			},
+		},
+		&gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: i.gateway, Namespace: "default"},
+			Spec:       gatewayv1.GatewaySpec{GatewayClassName: gatewayv1.ObjectName(i.class), Listeners: httpListener()},
+		},
+	}
+}
+
+// TestControllerName_InstallsCoexist pins that two installs with different
+// controller names share a cluster without stealing routes: each writes only
+// the routes of its own Gateways to its own tunnel, and a route attached to
+// both Gateways carries one parent status per install, neither overwriting
+// the other's.
+func TestControllerName_InstallsCoexist(t *testing.T) {
+	t.Parallel()
+
+	installs := []install{
+		{controllerName: "example.com/team-a", class: "tunnel-a", gateway: "gw-a", tunnelID: "aaaaaaaa-aaaa-4aaa-8aaa-aaaaaaaaaaaa"},
+		{controllerName: "example.com/team-b", class: "tunnel-b", gateway: "gw-b", tunnelID: "bbbbbbbb-bbbb-4bbb-8bbb-bbbbbbbbbbbb"},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	routeA := partitionSyncRoute("route-a", "gw-a", "a.example.com")
+	routeB := partitionSyncRoute("route-b", "gw-b", "b.example.com")
+	routeBoth := partitionSyncRoute("route-both", "gw-a", "both.example.com")
+	routeBoth.Spec.ParentRefs = append(routeBoth.Spec.ParentRefs, gatewayv1.ParentReference{Name: "gw-b"})
+
+	objects := []client.Object{
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "default"},
+			Data:       map[string][]byte{"api-token": []byte("test-token")},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default"},
+			Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 80}}},
+		},
+		routeA, routeB, routeBoth,
+	}
+
+	for _, inst := range installs {
+		objects = append(objects, inst.objects()...)
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&gatewayv1.HTTPRoute{}).
+		Build()
+
+	api := newRecordingTunnelAPI(t)
+	ctx := context.Background()
+
+	for _, inst := range installs {
+		resolver := config.NewResolver(fakeClient, "default", cfmetrics.NewNoopCollector())
+		syncer := NewRouteSyncer(fakeClient, scheme, "cluster.local", inst.controllerName, resolver,
+			cfmetrics.NewNoopCollector(), nil)
+		syncer.cloudflareClientFactory = func(_ *config.ResolvedConfig) *cloudflare.Client {
+			return cloudflare.NewClient(option.WithAPIToken("test-token"), option.WithBaseURL(api.server.URL))
+		}
+
+		reconciler := &HTTPRouteReconciler{
+			Client:         fakeClient,
+			Scheme:         scheme,
+			ControllerName: inst.controllerName,
+			RouteSyncer:    syncer,
+		}
+
+		_, result, err := syncer.SyncAllRoutes(ctx)
+		require.NoError(t, err, inst.controllerName)
+		require.NoError(t, updateRoutesStatus(ctx, slog.Default(),
+			result.httpStatusEntries(nil, reconciler.updateRouteStatus), nil), inst.controllerName)
+	}
+
+	assert.ElementsMatch(t, []string{"a.example.com", "both.example.com"}, api.hostnamesFor(installs[0].tunnelID))
+	assert.ElementsMatch(t, []string{"b.example.com", "both.example.com"}, api.hostnamesFor(installs[1].tunnelID))
+
+	wantParents := map[string]map[string]string{
+		"route-a":    {"gw-a": "example.com/team-a"},
+		"route-b":    {"gw-b": "example.com/team-b"},
+		"route-both": {"gw-a": "example.com/team-a", "gw-b": "example.com/team-b"},
+	}
+
+	for name, want := range wantParents {
+		var route gatewayv1.HTTPRoute
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, &route))
+
+		got := make(map[string]string, len(route.Status.Parents))
+
+		for _, parent := range route.Status.Parents {
+			got[string(parent.ParentRef.Name)] = string(parent.ControllerName)
+
+			accepted := metav1.Condition{}
+			for _, condition := range parent.Conditions {
+				if condition.Type == string(gatewayv1.RouteConditionAccepted) {
+					accepted = condition
+				}
+			}
+
+			assert.Equal(t, metav1.ConditionTrue, accepted.Status, "%s on %s", name, parent.ParentRef.Name)
+		}
+
+		assert.Equal(t, want, got, name)
+	}
+}
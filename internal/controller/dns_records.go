@@ -0,0 +1,593 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/dns"
+	"github.com/cloudflare/cloudflare-go/v7/zero_trust"
+	"github.com/cloudflare/cloudflare-go/v7/zones"
+	"github.com/cockroachdb/errors"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+// dnsRecordComment marks the CNAME records the controller created. Only
+// records carrying it are updated or deleted, so a record someone else
+// manages for the same hostname is left alone.
+const dnsRecordComment = "managed by cloudflare-tunnel-gateway-controller"
+
+// tunnelCNAMETarget is the hostname a proxied CNAME points at to route
+// through the tunnel.
+func tunnelCNAMETarget(tunnelID string) string {
+	return tunnelID + ".cfargotunnel.com"
+}
+
+// dnsZone is a Cloudflare zone the account owns.
+type dnsZone struct {
+	ID   string
+	Name string
+}
+
+// dnsRecord is a CNAME record in a zone.
+type dnsRecord struct {
+	ID      string
+	Name    string
+	Content string
+	Proxied bool
+}
+
+// dnsRecordAPI is the part of the Cloudflare DNS API the record sync uses,
+// scoped to one account. It is an interface so the sync can be tested
+// without the Cloudflare API.
+type dnsRecordAPI interface {
+	ListZones(ctx context.Context) ([]dnsZone, error)
+	// ListManagedCNAMEs returns the CNAME records in the zone that carry
+	// dnsRecordComment.
+	ListManagedCNAMEs(ctx context.Context, zoneID string) ([]dnsRecord, error)
+	CreateCNAME(ctx context.Context, zoneID, name, target string) error
+	UpdateCNAME(ctx context.Context, zoneID, recordID, name, target string) error
+	DeleteRecord(ctx context.Context, zoneID, recordID string) error
+}
+
+// cloudflareDNSRecords manages records through the Cloudflare API.
+type cloudflareDNSRecords struct {
+	client    *cloudflare.Client
+	accountID string
+	metrics   cfmetrics.Collector
+}
+
+// record reports one API call to the metrics collector.
+func (c *cloudflareDNSRecords) record(ctx context.Context, method, resource string, start time.Time, err error) {
+	if err != nil {
+		c.metrics.RecordAPICall(ctx, method, resource, "error", time.Since(start))
+		c.metrics.RecordAPIError(ctx, method, cfmetrics.ClassifyCloudflareError(err))
+
+		return
+	}
+
+	c.metrics.RecordAPICall(ctx, method, resource, "success", time.Since(start))
+}
+
+func (c *cloudflareDNSRecords) ListZones(ctx context.Context) ([]dnsZone, error) {
+	start := time.Now()
+
+	pager := c.client.Zones.ListAutoPaging(ctx, zones.ZoneListParams{
+		Account: cloudflare.F(zones.ZoneListParamsAccount{ID: cloudflare.F(c.accountID)}),
+	})
+
+	var result []dnsZone
+
+	for pager.Next() {
+		zone := pager.Current()
+		result = append(result, dnsZone{ID: zone.ID, Name: zone.Name})
+	}
+
+	err := pager.Err()
+	c.record(ctx, "list", "zone", start, err)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "listing zones")
+	}
+
+	return result, nil
+}
+
+func (c *cloudflareDNSRecords) ListManagedCNAMEs(ctx context.Context, zoneID string) ([]dnsRecord, error) {
+	start := time.Now()
+
+	pager := c.client.DNS.Records.ListAutoPaging(ctx, dns.RecordListParams{
+		ZoneID:  cloudflare.F(zoneID),
+		Type:    cloudflare.F(dns.RecordListParamsTypeCNAME),
+		Comment: cloudflare.F(dns.RecordListParamsComment{Exact: cloudflare.F(dnsRecordComment)}),
+	})
+
+	var result []dnsRecord
+
+	for pager.Next() {
+		record := pager.Current()
+		result = append(result, dnsRecord{
+			ID: record.ID, Name: record.Name, Content: record.Content, Proxied: record.Proxied,
+		})
+	}
+
+	err := pager.Err()
+	c.record(ctx, "list", "dns_record", start, err)
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing DNS records in zone %s", zoneID)
+	}
+
+	return result, nil
+}
+
+// cnameBody is the record the controller writes: proxied, automatic TTL,
+// and marked as managed.
+func cnameBody(name, target string) dns.CNAMERecordParam {
+	return dns.CNAMERecordParam{
+		Name:    cloudflare.F(name),
+		TTL:     cloudflare.F(dns.TTL1),
+		Type:    cloudflare.F(dns.CNAMERecordTypeCNAME),
+		Content: cloudflare.F(target),
+		Proxied: cloudflare.F(true),
+		Comment: cloudflare.F(dnsRecordComment),
+	}
+}
+
+func (c *cloudflareDNSRecords) CreateCNAME(ctx context.Context, zoneID, name, target string) error {
+	start := time.Now()
+
+	_, err := c.client.DNS.Records.New(ctx, dns.RecordNewParams{
+		ZoneID: cloudflare.F(zoneID),
+		Body:   cnameBody(name, target),
+	})
+	c.record(ctx, "create", "dns_record", start, err)
+
+	return errors.Wrapf(err, "creating DNS record %s", name)
+}
+
+func (c *cloudflareDNSRecords) UpdateCNAME(ctx context.Context, zoneID, recordID, name, target string) error {
+	start := time.Now()
+
+	_, err := c.client.DNS.Records.Update(ctx, recordID, dns.RecordUpdateParams{
+		ZoneID: cloudflare.F(zoneID),
+		Body:   cnameBody(name, target),
+	})
+	c.record(ctx, "update", "dns_record", start, err)
+
+	return errors.Wrapf(err, "updating DNS record %s", name)
+}
+
+func (c *cloudflareDNSRecords) DeleteRecord(ctx context.Context, zoneID, recordID string) error {
+	start := time.Now()
+
+	_, err := c.client.DNS.Records.Delete(ctx, recordID, dns.RecordDeleteParams{
+		ZoneID: cloudflare.F(zoneID),
+	})
+	c.record(ctx, "delete", "dns_record", start, err)
+
+	return errors.Wrapf(err, "deleting DNS record %s", recordID)
+}
+
+// dnsRecords returns the DNS API for the resolved config's account, via the
+// injected factory when set.
+func (s *RouteSyncer) dnsRecords(ctx context.Context, resolved *config.ResolvedConfig) (dnsRecordAPI, error) {
+	if s.dnsRecordsFactory != nil {
+		return s.dnsRecordsFactory(resolved), nil
+	}
+
+	cfClient := s.cloudflareClient(resolved)
+
+	accountID, err := s.ConfigResolver.ResolveAccountID(ctx, cfClient, resolved)
+	if err != nil {
+		return nil, errors.Wrapf(err, "resolving account for tunnel %s", resolved.TunnelID)
+	}
+
+	return &cloudflareDNSRecords{client: cfClient, accountID: accountID, metrics: s.Metrics}, nil
+}
+
+// ruleHostnames returns the distinct hostnames of the rules, sorted. Rules
+// without a hostname (the catch-all) contribute nothing.
+func ruleHostnames(rules []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress) []string {
+	var hostnames []string
+
+	for i := range rules {
+		if hostname := rules[i].Hostname.Value; hostname != "" {
+			hostnames = append(hostnames, hostname)
+		}
+	}
+
+	slices.Sort(hostnames)
+
+	return slices.Compact(hostnames)
+}
+
+// zoneFor returns the zone with the longest name that hostname falls in.
+func zoneFor(hostname string, zoneList []dnsZone) (dnsZone, bool) {
+	var (
+		best  dnsZone
+		found bool
+	)
+
+	for _, zone := range zoneList {
+		if hostname != zone.Name && !strings.HasSuffix(hostname, "."+zone.Name) {
+			continue
+		}
+
+		if !found || len(zone.Name) > len(best.Name) {
+			best, found = zone, true
+		}
+	}
+
+	return best, found
+}
+
+// syncDNSRecords reconciles the managed CNAME records of one tunnel against
+// hostnames: a hostname without a managed record gets one, a managed record
+// not pointing at the tunnel (or not proxied) is corrected, and a managed
+// record pointing at the tunnel whose hostname is gone is deleted. A
+// hostname in none of the account's zones is skipped. Every change is
+// attempted; the returned error reports the ones that failed.
+//
+// Only the zones of hostnames and the zones where the previous sync left
+// the tunnel's records are read. Without a previous sync (after a restart) every
+// zone is read once, so records left behind while the controller was down
+// are still found. The returned entry carries the zones to read next time.
+func (s *RouteSyncer) syncDNSRecords(
+	ctx context.Context,
+	logger *slog.Logger,
+	api dnsRecordAPI,
+	tunnelID string,
+	hostnames []string,
+	previous appliedDNS,
+) (appliedDNS, error) {
+	target := tunnelCNAMETarget(tunnelID)
+	result := appliedDNS{hostnames: hostnames, zoneIDs: previous.zoneIDs, zonesKnown: previous.zonesKnown}
+
+	zoneList, err := api.ListZones(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	desiredByZone := make(map[string][]string, len(zoneList))
+
+	for _, hostname := range hostnames {
+		zone, ok := zoneFor(hostname, zoneList)
+		if !ok {
+			logger.Warn("no Cloudflare zone in the account covers the hostname; DNS record not managed",
+				"tunnel", tunnelID, "hostname", hostname)
+
+			continue
+		}
+
+		desiredByZone[zone.ID] = append(desiredByZone[zone.ID], hostname)
+	}
+
+	var (
+		errs    []error
+		zoneIDs []string
+	)
+
+	// A zone the tunnel managed records in before is read even without a
+	// desired hostname: its last route may have gone away, leaving records
+	// to delete.
+	for _, zone := range zoneList {
+		_, desired := desiredByZone[zone.ID]
+		if previous.zonesKnown && !desired && !slices.Contains(previous.zoneIDs, zone.ID) {
+			continue
+		}
+
+		records, err := api.ListManagedCNAMEs(ctx, zone.ID)
+		if err != nil {
+			errs = append(errs, err)
+			zoneIDs = append(zoneIDs, zone.ID)
+
+			continue
+		}
+
+		zoneErrs := s.syncZoneRecords(ctx, logger, api, zone, target, desiredByZone[zone.ID], records)
+		errs = append(errs, zoneErrs...)
+
+		// After the sync the tunnel's records are those of its hostnames,
+		// plus any whose deletion failed.
+		if desired || len(zoneErrs) > 0 {
+			zoneIDs = append(zoneIDs, zone.ID)
+		}
+	}
+
+	result.zoneIDs, result.zonesKnown = zoneIDs, true
+
+	if len(errs) > 0 {
+		return result, errors.Wrapf(errors.Join(errs...), "syncing DNS records for tunnel %s", tunnelID)
+	}
+
+	return result, nil
+}
+
+// syncZoneRecords applies the record changes for one zone. In dry-run mode
+// the changes are only logged.
+func (s *RouteSyncer) syncZoneRecords(
+	ctx context.Context,
+	logger *slog.Logger,
+	api dnsRecordAPI,
+	zone dnsZone,
+	target string,
+	desired []string,
+	records []dnsRecord,
+) []error {
+	byName := make(map[string]dnsRecord, len(records))
+	for _, record := range records {
+		byName[record.Name] = record
+	}
+
+	var errs []error
+
+	apply := func(action, name string, write func() error) {
+		if s.DryRun {
+			logger.Info("dry run: DNS record change not applied", "action", action, "zone", zone.Name, "name", name)
+
+			return
+		}
+
+		if err := write(); err != nil {
+			logger.Error("failed to change DNS record", "action", action, "zone", zone.Name, "name", name, "error", err)
+			errs = append(errs, err)
+
+			return
+		}
+
+		logger.Info("DNS record changed", "action", action, "zone", zone.Name, "name", name, "target", target)
+	}
+
+	for _, name := range desired {
+		record, ok := byName[name]
+
+		switch {
+		case !ok:
+			apply("create", name, func() error { return api.CreateCNAME(ctx, zone.ID, name, target) })
+		case record.Content != target || !record.Proxied:
+			apply("update", name, func() error { return api.UpdateCNAME(ctx, zone.ID, record.ID, name, target) })
+		}
+	}
+
+	for _, record := range records {
+		// A managed record pointing at another tunnel belongs to that
+		// tunnel's sync.
+		if record.Content != target || slices.Contains(desired, record.Name) {
+			continue
+		}
+
+		apply("delete", record.Name, func() error { return api.DeleteRecord(ctx, zone.ID, record.ID) })
+	}
+
+	return errs
+}
+
+// dnsRetryBaseDelay is how long a DNS sync that left records to fix waits
+// before it is retried with the same hostnames. The delay doubles with every
+// consecutive failed sync, up to appliedConfigMaxAge.
+const dnsRetryBaseDelay = 30 * time.Second
+
+// appliedDNS is the hostname set last synced to DNS for a tunnel, and the
+// config it was synced with.
+type appliedDNS struct {
+	hostnames []string
+	// zoneIDs are the zones that may hold the tunnel's managed records. They
+	// are only meaningful when zonesKnown is set; otherwise every zone is
+	// read.
+	zoneIDs    []string
+	zonesKnown bool
+	// failed marks a sync that left records to fix; attempts counts the
+	// consecutive failed syncs.
+	failed   bool
+	attempts int
+	resolved config.ResolvedConfig
+	storedAt time.Time
+}
+
+// maxAge is how long the entry stands in for a DNS read: appliedConfigMaxAge
+// after a clean sync, and a backoff doubling from dnsRetryBaseDelay after a
+// failed one.
+func (e appliedDNS) maxAge() time.Duration {
+	if !e.failed {
+		return appliedConfigMaxAge
+	}
+
+	return min(dnsRetryBaseDelay<<min(e.attempts-1, 10), appliedConfigMaxAge)
+}
+
+// fresh reports whether the entry is younger than its maxAge.
+func (e appliedDNS) fresh() bool {
+	return time.Since(e.storedAt) <= e.maxAge()
+}
+
+// appliedDNSCache remembers, per tunnel ID, the hostnames the last DNS sync
+// handled and the zones holding their records, so a sync with the same
+// hostnames makes no DNS API calls and a changed set reads only those
+// zones. Like appliedConfigCache, an entry expires after appliedConfigMaxAge
+// so an out-of-band record change is still repaired; an entry for a failed
+// sync expires sooner and is retried with backoff, so a record Cloudflare
+// keeps refusing does not cost a read of every zone on every sync.
+// An expired entry is kept: it is also how a tunnel that stops being synced
+// is found, so its records can be deleted (see syncDepartedDNS).
+type appliedDNSCache struct {
+	mu      sync.Mutex
+	entries map[string]appliedDNS
+}
+
+// lookup returns the entry for tunnelID, zero when there is none, and
+// whether it is fresh and was synced with hostnames.
+func (c *appliedDNSCache) lookup(tunnelID string, hostnames []string) (appliedDNS, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[tunnelID]
+
+	return entry, ok && entry.fresh() && slices.Equal(entry.hostnames, hostnames)
+}
+
+// store records the outcome of a DNS sync for the resolved config's tunnel
+// and returns the stored entry. A failed sync extends the run of
+// consecutive failures; a clean one ends it.
+func (c *appliedDNSCache) store(resolved *config.ResolvedConfig, entry appliedDNS, failed bool) appliedDNS {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]appliedDNS)
+	}
+
+	entry.failed, entry.attempts = failed, 0
+	if failed {
+		entry.attempts = c.entries[resolved.TunnelID].attempts + 1
+	}
+
+	entry.resolved, entry.storedAt = *resolved, time.Now()
+	c.entries[resolved.TunnelID] = entry
+
+	return entry
+}
+
+// forget drops the entry for tunnelID.
+func (c *appliedDNSCache) forget(tunnelID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, tunnelID)
+}
+
+// expire marks every entry stale, forcing the next DNS sync of each tunnel
+// to read.
+func (c *appliedDNSCache) expire() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for tunnelID, entry := range c.entries {
+		entry.storedAt = time.Time{}
+		c.entries[tunnelID] = entry
+	}
+}
+
+// departed returns the entries whose tunnel is not in tunnelIDs.
+func (c *appliedDNSCache) departed(tunnelIDs map[string]struct{}) []appliedDNS {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var result []appliedDNS
+
+	for tunnelID, entry := range c.entries {
+		if _, ok := tunnelIDs[tunnelID]; !ok {
+			result = append(result, entry)
+		}
+	}
+
+	slices.SortFunc(result, func(a, b appliedDNS) int {
+		return strings.Compare(a.resolved.TunnelID, b.resolved.TunnelID)
+	})
+
+	return result
+}
+
+// syncGroupDNS runs the DNS record sync for a group that opted in with
+// manageDNS, after its ingress sync succeeded. It is skipped while the group
+// is paused or Cloudflare rate limits the controller, and when the
+// hostnames match the last synced set. A failure is logged and retried with
+// backoff, or sooner when the hostnames change; it does not fail the routes,
+// which the tunnel already serves.
+func (s *RouteSyncer) syncGroupDNS(
+	ctx context.Context,
+	logger *slog.Logger,
+	group *tunnelGroup,
+	hostnames []string,
+) {
+	tunnelID := group.resolved.TunnelID
+
+	// A tunnel whose config turned manageDNS off keeps its records.
+	if !group.resolved.ManageDNS {
+		s.appliedDNS.forget(tunnelID)
+
+		return
+	}
+
+	if !group.pausedUntil.IsZero() || !s.rateLimitHold().IsZero() {
+		return
+	}
+
+	previous, fresh := s.appliedDNS.lookup(tunnelID, hostnames)
+	if fresh {
+		return
+	}
+
+	result := appliedDNS{hostnames: hostnames, zoneIDs: previous.zoneIDs, zonesKnown: previous.zonesKnown}
+
+	api, err := s.dnsRecords(ctx, group.resolved)
+	if err == nil {
+		result, err = s.syncDNSRecords(ctx, logger, api, tunnelID, hostnames, previous)
+	}
+
+	// A dry run applied nothing, so there is nothing to remember.
+	if s.DryRun {
+		if err != nil {
+			logger.Error("failed to sync DNS records", "tunnel", tunnelID, "error", err)
+			s.holdForRateLimit(logger, err)
+		}
+
+		return
+	}
+
+	stored := s.appliedDNS.store(group.resolved, result, err != nil)
+
+	if err != nil {
+		logger.Error("failed to sync DNS records", "tunnel", tunnelID, "retryIn", stored.maxAge(), "error", err)
+		s.holdForRateLimit(logger, err)
+	}
+}
+
+// syncDepartedDNS deletes the managed records of every tunnel whose DNS was
+// synced before but that no group in tunnelIDs serves any more, e.g. after
+// the config's tunnelID changed. Hostnames the new tunnel serves were
+// already pointed at it by its own sync; what is left points at a tunnel
+// that no longer receives the traffic. Only the zones the tunnel's records
+// were last seen in are read, and a failed cleanup is retried with backoff.
+// The cache lives in memory only, so a tunnel that departs while the
+// controller is down keeps its records.
+func (s *RouteSyncer) syncDepartedDNS(ctx context.Context, logger *slog.Logger, tunnelIDs map[string]struct{}) {
+	for _, entry := range s.appliedDNS.departed(tunnelIDs) {
+		if !s.rateLimitHold().IsZero() {
+			return
+		}
+
+		if entry.failed && entry.fresh() {
+			continue
+		}
+
+		resolved := entry.resolved
+		result := appliedDNS{zoneIDs: entry.zoneIDs, zonesKnown: entry.zonesKnown}
+
+		api, err := s.dnsRecords(ctx, &resolved)
+		if err == nil {
+			result, err = s.syncDNSRecords(ctx, logger, api, resolved.TunnelID, nil, entry)
+		}
+
+		if err != nil {
+			logger.Error("failed to delete DNS records of a departed tunnel", "tunnel", resolved.TunnelID, "error", err)
+			s.holdForRateLimit(logger, err)
+
+			if !s.DryRun {
+				s.appliedDNS.store(&resolved, result, true)
+			}
+
+			continue
+		}
+
+		if !s.DryRun {
+			s.appliedDNS.forget(resolved.TunnelID)
+		}
+	}
+}
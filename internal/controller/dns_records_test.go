@@ -0,0 +1,436 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+// fakeDNSRecords is an in-memory dnsRecordAPI. records holds every CNAME by
+// zone ID, managed or not; calls logs the writes and reads the zone IDs whose
+// records were listed. Creating a name in refuse fails.
+type fakeDNSRecords struct {
+	zones   []dnsZone
+	records map[string][]fakeDNSRecord
+	calls   []string
+	reads   []string
+	refuse  map[string]bool
+	nextID  int
+}
+
+type fakeDNSRecord struct {
+	dnsRecord
+
+	managed bool
+}
+
+func newFakeDNSRecords(zoneNames ...string) *fakeDNSRecords {
+	fake := &fakeDNSRecords{records: make(map[string][]fakeDNSRecord)}
+
+	for _, name := range zoneNames {
+		fake.zones = append(fake.zones, dnsZone{ID: "zone-" + name, Name: name})
+	}
+
+	return fake
+}
+
+func (f *fakeDNSRecords) add(zoneID, name, content string, proxied, managed bool) {
+	f.nextID++
+	f.records[zoneID] = append(f.records[zoneID], fakeDNSRecord{
+		dnsRecord: dnsRecord{ID: "rec-" + strconv.Itoa(f.nextID), Name: name, Content: content, Proxied: proxied},
+		managed:   managed,
+	})
+}
+
+// find returns the record named name in zoneID.
+func (f *fakeDNSRecords) find(zoneID, name string) (fakeDNSRecord, bool) {
+	for _, record := range f.records[zoneID] {
+		if record.Name == name {
+			return record, true
+		}
+	}
+
+	return fakeDNSRecord{}, false
+}
+
+func (f *fakeDNSRecords) ListZones(_ context.Context) ([]dnsZone, error) {
+	return f.zones, nil
+}
+
+func (f *fakeDNSRecords) ListManagedCNAMEs(_ context.Context, zoneID string) ([]dnsRecord, error) {
+	f.reads = append(f.reads, zoneID)
+
+	var result []dnsRecord
+
+	for _, record := range f.records[zoneID] {
+		if record.managed {
+			result = append(result, record.dnsRecord)
+		}
+	}
+
+	return result, nil
+}
+
+func (f *fakeDNSRecords) CreateCNAME(_ context.Context, zoneID, name, target string) error {
+	f.calls = append(f.calls, "create "+name)
+
+	if f.refuse[name] {
+		return errors.Newf("record %s refused", name)
+	}
+
+	f.add(zoneID, name, target, true, true)
+
+	return nil
+}
+
+func (f *fakeDNSRecords) UpdateCNAME(_ context.Context, zoneID, recordID, name, target string) error {
+	f.calls = append(f.calls, "update "+name)
+
+	for i, record := range f.records[zoneID] {
+		if record.ID == recordID {
+			f.records[zoneID][i].dnsRecord = dnsRecord{ID: recordID, Name: name, Content: target, Proxied: true}
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeDNSRecords) DeleteRecord(_ context.Context, zoneID, recordID string) error {
+	for i, record := range f.records[zoneID] {
+		if record.ID == recordID {
+			f.calls = append(f.calls, "delete "+record.Name)
+			f.records[zoneID] = append(f.records[zoneID][:i], f.records[zoneID][i+1:]...)
+
+			break
+		}
+	}
+
+	return nil
+}
+
+// TestSyncDNSRecords_CreateUpdateDelete pins the record reconcile: missing
+// records are created in the most specific zone, managed records pointing
+// elsewhere or unproxied are corrected, managed records of this tunnel whose
+// hostname is gone are deleted, and records the controller does not manage
+// or that belong to another tunnel are left alone.
+func TestSyncDNSRecords_CreateUpdateDelete(t *testing.T) {
+	t.Parallel()
+
+	const tunnelID = "99999999-9999-4999-8999-999999999999"
+
+	target := tunnelCNAMETarget(tunnelID)
+	otherTarget := tunnelCNAMETarget("88888888-8888-4888-8888-888888888888")
+
+	fake := newFakeDNSRecords("example.com", "sub.example.com", "other.org")
+	fake.add("zone-example.com", "moved.example.com", otherTarget, true, true)
+	fake.add("zone-example.com", "unproxied.example.com", target, false, true)
+	fake.add("zone-example.com", "ok.example.com", target, true, true)
+	fake.add("zone-example.com", "gone.example.com", target, true, true)
+	fake.add("zone-other.org", "gone.other.org", target, true, true)
+	fake.add("zone-example.com", "foreign-tunnel.example.com", otherTarget, true, true)
+	fake.add("zone-example.com", "manual.example.com", target, true, false)
+
+	syncer := &RouteSyncer{}
+
+	_, err := syncer.syncDNSRecords(context.Background(), slog.Default(), fake, tunnelID, []string{
+		"a.sub.example.com",
+		"moved.example.com",
+		"new.example.com",
+		"nozone.net",
+		"ok.example.com",
+		"unproxied.example.com",
+	}, appliedDNS{})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{
+		"create new.example.com",
+		"create a.sub.example.com",
+		"update moved.example.com",
+		"update unproxied.example.com",
+		"delete gone.example.com",
+		"delete gone.other.org",
+	}, fake.calls)
+
+	created, ok := fake.find("zone-sub.example.com", "a.sub.example.com")
+	require.True(t, ok, "a hostname goes to its most specific zone")
+	assert.Equal(t, target, created.Content)
+	assert.True(t, created.Proxied)
+
+	moved, _ := fake.find("zone-example.com", "moved.example.com")
+	assert.Equal(t, target, moved.Content)
+
+	_, ok = fake.find("zone-example.com", "foreign-tunnel.example.com")
+	assert.True(t, ok, "another tunnel's record is not deleted")
+
+	_, ok = fake.find("zone-example.com", "manual.example.com")
+	assert.True(t, ok, "an unmanaged record is not deleted")
+
+	fake.calls = nil
+
+	_, err = syncer.syncDNSRecords(context.Background(), slog.Default(), fake, tunnelID, []string{
+		"a.sub.example.com", "moved.example.com", "new.example.com", "ok.example.com", "unproxied.example.com",
+	}, appliedDNS{})
+	require.NoError(t, err)
+	assert.Empty(t, fake.calls, "records in place need no writes")
+}
+
+// TestSyncDNSRecords_ZoneScope pins which zones a sync reads: every zone
+// without a previous sync, and afterwards only the zones of the desired
+// hostnames and of the records the previous sync left, so a zone whose
+// last hostname went away is still cleaned up.
+func TestSyncDNSRecords_ZoneScope(t *testing.T) {
+	t.Parallel()
+
+	const tunnelID = "99999999-9999-4999-8999-999999999999"
+
+	ctx := context.Background()
+	syncer := &RouteSyncer{}
+	fake := newFakeDNSRecords("example.com", "other.org", "unrelated.net")
+
+	first, err := syncer.syncDNSRecords(ctx, slog.Default(), fake, tunnelID,
+		[]string{"a.example.com", "b.other.org"}, appliedDNS{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"zone-example.com", "zone-other.org", "zone-unrelated.net"}, fake.reads)
+	assert.True(t, first.zonesKnown)
+	assert.Equal(t, []string{"zone-example.com", "zone-other.org"}, first.zoneIDs)
+
+	fake.calls, fake.reads = nil, nil
+
+	second, err := syncer.syncDNSRecords(ctx, slog.Default(), fake, tunnelID, []string{"a.example.com"}, first)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"zone-example.com", "zone-other.org"}, fake.reads)
+	assert.Equal(t, []string{"delete b.other.org"}, fake.calls)
+	assert.Equal(t, []string{"zone-example.com"}, second.zoneIDs)
+
+	fake.calls, fake.reads = nil, nil
+
+	_, err = syncer.syncDNSRecords(ctx, slog.Default(), fake, tunnelID, []string{"a.example.com", "c.example.com"}, second)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"zone-example.com"}, fake.reads)
+	assert.Equal(t, []string{"create c.example.com"}, fake.calls)
+}
+
+// TestSyncAllRoutes_ManageDNS_FailureBackoff pins that a record Cloudflare
+// keeps refusing is retried with backoff instead of on every sync, while a
+// hostname change still syncs at once.
+func TestSyncAllRoutes_ManageDNS_FailureBackoff(t *testing.T) {
+	t.Parallel()
+
+	const classTunnel = "99999999-9999-4999-8999-999999999999"
+
+	ctx := context.Background()
+	syncer, fake := newManageDNSSyncer(t, classTunnel)
+	fake.refuse = map[string]bool{"web.example.com": true}
+
+	route := partitionSyncRoute("web", "shared-gw", "web.example.com")
+	require.NoError(t, syncer.Client.Create(ctx, route))
+
+	_, _, err := syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err, "a DNS failure does not fail the routes")
+	assert.ElementsMatch(t, []string{"create shared.example.com", "create web.example.com"}, fake.calls)
+
+	fake.calls, fake.reads = nil, nil
+
+	_, _, err = syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, fake.reads, "a failed sync is not retried before its backoff")
+
+	entry, _ := syncer.appliedDNS.lookup(classTunnel, nil)
+	assert.Equal(t, dnsRetryBaseDelay, entry.maxAge())
+
+	syncer.appliedDNS.expire()
+
+	_, _, err = syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"create web.example.com"}, fake.calls)
+	assert.Equal(t, []string{"zone-example.com"}, fake.reads)
+
+	entry, _ = syncer.appliedDNS.lookup(classTunnel, nil)
+	assert.Equal(t, 2*dnsRetryBaseDelay, entry.maxAge(), "the backoff doubles")
+
+	fake.calls, fake.reads = nil, nil
+
+	require.NoError(t, syncer.Client.Get(ctx, client.ObjectKeyFromObject(route), route))
+	route.Spec.Hostnames = []gatewayv1.Hostname{"www.example.com"}
+	require.NoError(t, syncer.Client.Update(ctx, route))
+
+	_, _, err = syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"create www.example.com"}, fake.calls, "a hostname change syncs at once")
+
+	entry, _ = syncer.appliedDNS.lookup(classTunnel, nil)
+	assert.False(t, entry.failed)
+	assert.Equal(t, appliedConfigMaxAge, entry.maxAge())
+}
+
+func TestAppliedDNS_MaxAge(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, appliedConfigMaxAge, appliedDNS{}.maxAge())
+	assert.Equal(t, dnsRetryBaseDelay, appliedDNS{failed: true, attempts: 1}.maxAge())
+	assert.Equal(t, 4*dnsRetryBaseDelay, appliedDNS{failed: true, attempts: 3}.maxAge())
+	assert.Equal(t, appliedConfigMaxAge, appliedDNS{failed: true, attempts: 50}.maxAge())
+}
+
+// TestSyncAllRoutes_ManageDNS pins the sync hook: with manageDNS on, the
+// class tunnel's route hostnames get records and lose them with their route,
+// an unchanged hostname set makes no DNS calls, and the per-Gateway tunnel,
+// which the class config does not manage, is never touched.
+func TestSyncAllRoutes_ManageDNS(t *testing.T) {
+	t.Parallel()
+
+	const classTunnel = "99999999-9999-4999-8999-999999999999"
+
+	ctx := context.Background()
+	api := newRecordingTunnelAPI(t)
+	syncer := newPartitionSyncSyncer(t, api, classTunnel)
+
+	var gatewayClassConfig v1alpha1.GatewayClassConfig
+	require.NoError(t, syncer.Client.Get(ctx, client.ObjectKey{Name: "cfg"}, &gatewayClassConfig))
+
+	gatewayClassConfig.Spec.ManageDNS = true
+	require.NoError(t, syncer.Client.Update(ctx, &gatewayClassConfig))
+
+	fake := newFakeDNSRecords("example.com")
+	factoryCalls := 0
+	syncer.dnsRecordsFactory = func(resolved *config.ResolvedConfig) dnsRecordAPI {
+		factoryCalls++
+
+		assert.Equal(t, classTunnel, resolved.TunnelID, "only the managed class tunnel syncs DNS")
+
+		return fake
+	}
+
+	route := partitionSyncRoute("web", "shared-gw", "web.example.com")
+	require.NoError(t, syncer.Client.Create(ctx, route))
+
+	_, _, err := syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"create shared.example.com", "create web.example.com"}, fake.calls)
+
+	_, _, err = syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, factoryCalls, "an unchanged hostname set makes no DNS calls")
+
+	require.NoError(t, syncer.Client.Delete(ctx, route))
+
+	_, _, err = syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	assert.Contains(t, fake.calls, "delete web.example.com")
+
+	_, ok := fake.find("zone-example.com", "web.example.com")
+	assert.False(t, ok)
+
+	_, ok = fake.find("zone-example.com", "shared.example.com")
+	assert.True(t, ok)
+}
+
+// TestSyncAllRoutes_ManageDNS_HostnameChange pins orphan cleanup: changing a
+// route's hostname deletes the old record and creates the new one in the
+// same sync, and an unmanaged record next to them is never touched.
+func TestSyncAllRoutes_ManageDNS_HostnameChange(t *testing.T) {
+	t.Parallel()
+
+	const classTunnel = "99999999-9999-4999-8999-999999999999"
+
+	ctx := context.Background()
+	syncer, fake := newManageDNSSyncer(t, classTunnel)
+	fake.add("zone-example.com", "manual.example.com", tunnelCNAMETarget(classTunnel), true, false)
+
+	route := partitionSyncRoute("web", "shared-gw", "web.example.com")
+	require.NoError(t, syncer.Client.Create(ctx, route))
+
+	_, _, err := syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	fake.calls = nil
+
+	require.NoError(t, syncer.Client.Get(ctx, client.ObjectKeyFromObject(route), route))
+	route.Spec.Hostnames = []gatewayv1.Hostname{"www.example.com"}
+	require.NoError(t, syncer.Client.Update(ctx, route))
+
+	_, _, err = syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"delete web.example.com", "create www.example.com"}, fake.calls)
+
+	_, ok := fake.find("zone-example.com", "manual.example.com")
+	assert.True(t, ok, "an unmanaged record is not deleted")
+}
+
+// TestSyncAllRoutes_ManageDNS_DepartedTunnel pins cleanup after a tunnelID
+// change: hostnames still routed are repointed at the new tunnel, and the
+// records left pointing at the old tunnel are deleted.
+func TestSyncAllRoutes_ManageDNS_DepartedTunnel(t *testing.T) {
+	t.Parallel()
+
+	const (
+		oldTunnel = "99999999-9999-4999-8999-999999999999"
+		newTunnel = "77777777-7777-4777-8777-777777777777"
+	)
+
+	ctx := context.Background()
+	syncer, fake := newManageDNSSyncer(t, oldTunnel)
+
+	route := partitionSyncRoute("web", "shared-gw", "web.example.com")
+	require.NoError(t, syncer.Client.Create(ctx, route))
+
+	_, _, err := syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, syncer.Client.Delete(ctx, route))
+
+	var gatewayClassConfig v1alpha1.GatewayClassConfig
+	require.NoError(t, syncer.Client.Get(ctx, client.ObjectKey{Name: "cfg"}, &gatewayClassConfig))
+
+	gatewayClassConfig.Spec.TunnelID = newTunnel
+	require.NoError(t, syncer.Client.Update(ctx, &gatewayClassConfig))
+
+	fake.calls = nil
+
+	_, _, err = syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"update shared.example.com", "delete web.example.com"}, fake.calls)
+
+	shared, ok := fake.find("zone-example.com", "shared.example.com")
+	require.True(t, ok)
+	assert.Equal(t, tunnelCNAMETarget(newTunnel), shared.Content)
+
+	fake.calls = nil
+
+	_, _, err = syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, fake.calls, "a cleaned-up tunnel is not revisited")
+}
+
+// newManageDNSSyncer returns the partition-sync world with manageDNS on for
+// the class config and DNS served by a fake holding the example.com zone.
+func newManageDNSSyncer(t *testing.T, classTunnel string) (*RouteSyncer, *fakeDNSRecords) {
+	t.Helper()
+
+	syncer := newPartitionSyncSyncer(t, newRecordingTunnelAPI(t), classTunnel)
+
+	var gatewayClassConfig v1alpha1.GatewayClassConfig
+	require.NoError(t, syncer.Client.Get(context.Background(), client.ObjectKey{Name: "cfg"}, &gatewayClassConfig))
+
+	gatewayClassConfig.Spec.ManageDNS = true
+	require.NoError(t, syncer.Client.Update(context.Background(), &gatewayClassConfig))
+
+	fake := newFakeDNSRecords("example.com")
+	syncer.dnsRecordsFactory = func(_ *config.ResolvedConfig) dnsRecordAPI { return fake }
+
+	return syncer, fake
+}
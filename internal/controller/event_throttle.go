@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+)
+
+// eventThrottleWindow is how long an identical Event on the same object is
+// suppressed. Every route sync re-derives the outcome of every route, so
+// without it a hot reconcile loop repeats the same Events on each pass.
+const eventThrottleWindow = 10 * time.Minute
+
+// throttledRecorder drops an Event identical to one already emitted for the
+// same object within the window: same type, reason, action and note. A
+// changed note (a different backend, a new error) goes through at once.
+type throttledRecorder struct {
+	inner  events.EventRecorder
+	window time.Duration
+	clock  func() time.Time
+
+	mu        sync.Mutex
+	lastSeen  map[string]time.Time
+	lastSweep time.Time
+}
+
+// newThrottledRecorder wraps inner so identical Events are emitted at most
+// once per window.
+func newThrottledRecorder(inner events.EventRecorder, window time.Duration) *throttledRecorder {
+	return &throttledRecorder{
+		inner:    inner,
+		window:   window,
+		clock:    time.Now,
+		lastSeen: make(map[string]time.Time),
+	}
+}
+
+// Eventf implements events.EventRecorder.
+func (r *throttledRecorder) Eventf(
+	regarding runtime.Object,
+	related runtime.Object,
+	eventtype, reason, action, note string,
+	args ...any,
+) {
+	message := note
+	if len(args) > 0 {
+		message = fmt.Sprintf(note, args...)
+	}
+
+	if !r.allow(eventObjectKey(regarding) + "\x00" + eventtype + "\x00" + reason + "\x00" + action + "\x00" + message) {
+		return
+	}
+
+	r.inner.Eventf(regarding, related, eventtype, reason, action, "%s", message)
+}
+
+// allow reports whether an Event with key may be emitted now, recording it if
+// so. Entries older than the window are swept at most once per window so the
+// map stays bounded by the Events of one window.
+func (r *throttledRecorder) allow(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.clock()
+
+	if now.Sub(r.lastSweep) >= r.window {
+		for seenKey, seenAt := range r.lastSeen {
+			if now.Sub(seenAt) >= r.window {
+				delete(r.lastSeen, seenKey)
+			}
+		}
+
+		r.lastSweep = now
+	}
+
+	if seenAt, seen := r.lastSeen[key]; seen && now.Sub(seenAt) < r.window {
+		return false
+	}
+
+	r.lastSeen[key] = now
+
+	return true
+}
+
+// eventObjectKey identifies the regarding object: its UID, or namespace/name
+// for objects not yet persisted.
+func eventObjectKey(obj runtime.Object) string {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return fmt.Sprintf("%T", obj)
+	}
+
+	if uid := accessor.GetUID(); uid != "" {
+		return string(uid)
+	}
+
+	return fmt.Sprintf("%T/%s/%s", obj, accessor.GetNamespace(), accessor.GetName())
+}
@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// TestThrottledRecorder pins that an identical Event on the same object is
+// emitted once per window, while a different note, a different object or the
+// next window goes through.
+func TestThrottledRecorder(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	inner := events.NewFakeRecorder(10)
+	rec := newThrottledRecorder(inner, time.Minute)
+	rec.clock = func() time.Time { return now }
+
+	web := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "uid-web"}}
+	api := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default", UID: "uid-api"}}
+
+	rec.Eventf(web, nil, corev1.EventTypeWarning, "BackendNotFound", "Sync", "backend %s not found", "a")
+	rec.Eventf(web, nil, corev1.EventTypeWarning, "BackendNotFound", "Sync", "backend %s not found", "a")
+	rec.Eventf(web, nil, corev1.EventTypeWarning, "BackendNotFound", "Sync", "backend %s not found", "b")
+	rec.Eventf(api, nil, corev1.EventTypeWarning, "BackendNotFound", "Sync", "backend %s not found", "a")
+
+	assert.Equal(t, []string{
+		"Warning BackendNotFound backend a not found",
+		"Warning BackendNotFound backend b not found",
+		"Warning BackendNotFound backend a not found",
+	}, drainEvents(inner))
+
+	now = now.Add(30 * time.Second)
+	rec.Eventf(web, nil, corev1.EventTypeWarning, "BackendNotFound", "Sync", "backend %s not found", "a")
+	assert.Empty(t, drainEvents(inner))
+
+	now = now.Add(time.Minute)
+	rec.Eventf(web, nil, corev1.EventTypeWarning, "BackendNotFound", "Sync", "backend %s not found", "a")
+	assert.Equal(t, []string{"Warning BackendNotFound backend a not found"}, drainEvents(inner))
+}
@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// FullResyncer re-runs the full route sync every Interval with no route event
+// behind it, so an out-of-band edit of a tunnel document (dashboard, API) is
+// repaired on a timer. Each tick drops the syncer's applied-config cache first,
+// so the sync reads every deployed document instead of trusting the last one
+// it wrote. The sync goes through the same entry point as route reconciles and
+// therefore joins an open --sync-debounce window rather than adding a write.
+type FullResyncer struct {
+	Interval time.Duration
+	Syncer   *RouteSyncer
+	Sync     func(ctx context.Context) error
+	Logger   *slog.Logger
+}
+
+// NewFullResyncer creates a FullResyncer that runs sync every interval.
+func NewFullResyncer(
+	interval time.Duration,
+	syncer *RouteSyncer,
+	sync func(ctx context.Context) error,
+	logger *slog.Logger,
+) *FullResyncer {
+	return &FullResyncer{
+		Interval: interval,
+		Syncer:   syncer,
+		Sync:     sync,
+		Logger:   logger.With("component", "full-resync"),
+	}
+}
+
+// NeedLeaderElection keeps tunnel writes to the leader.
+func (r *FullResyncer) NeedLeaderElection() bool {
+	return true
+}
+
+// Start resyncs every Interval until ctx is done. The first resync waits a
+// full interval: the startup sync already covers the moment the manager
+// starts.
+func (r *FullResyncer) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			r.resync(ctx)
+		}
+	}
+}
+
+// resync forgets the applied documents, DNS records and Access applications
+// and runs one full sync. A failure is logged; the route reconciles retry it
+// on their own schedule and the next tick tries again.
+func (r *FullResyncer) resync(ctx context.Context) {
+	r.Syncer.appliedConfigs.clear()
+	r.Syncer.appliedDNS.expire()
+	r.Syncer.appliedAccess.expire()
+
+	if err := r.Sync(ctx); err != nil {
+		r.Logger.Error("periodic full resync failed", "error", err)
+
+		return
+	}
+
+	r.Logger.Debug("periodic full resync completed")
+}
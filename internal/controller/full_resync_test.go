@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFullResyncer_RepairsDriftWithoutRouteEvents pins the --full-resync-interval
+// timer: with no route event, a tick reads the deployed document again despite
+// the applied-config cache, and rewrites a tunnel edited out of band.
+func TestFullResyncer_RepairsDriftWithoutRouteEvents(t *testing.T) {
+	t.Parallel()
+
+	const classTunnel = "99999999-9999-4999-8999-999999999999"
+
+	ctx := context.Background()
+	api := newRecordingTunnelAPI(t)
+	api.serveWrittenConfigs()
+
+	syncer := newPartitionSyncSyncer(t, api, classTunnel)
+	require.NoError(t, syncer.Client.Create(ctx, partitionSyncRoute("web", "shared-gw", "web.example.com")))
+
+	_, _, err := syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, api.writesTo(classTunnel))
+
+	// Someone edits the tunnel in the dashboard: web.example.com is gone.
+	api.mu.Lock()
+	api.written[classTunnel] = json.RawMessage(`[{"service":"http_status:404"}]`)
+	api.mu.Unlock()
+
+	// An event-driven sync trusts the cache and does not notice.
+	_, _, err = syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, api.writesTo(classTunnel))
+
+	resyncer := NewFullResyncer(10*time.Millisecond, syncer, func(ctx context.Context) error {
+		_, _, err := syncer.requestSync(ctx)
+
+		return err
+	}, slog.Default())
+	require.True(t, resyncer.NeedLeaderElection())
+
+	resyncCtx, cancel := context.WithCancel(ctx)
+	done := make(chan error, 1)
+
+	go func() { done <- resyncer.Start(resyncCtx) }()
+
+	assert.Eventually(t, func() bool {
+		return api.writesTo(classTunnel) >= 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+
+	assert.Contains(t, api.hostnamesFor(classTunnel), "web.example.com")
+}
@@ -0,0 +1,226 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/logging"
+)
+
+// Gateway-level summary of the routes' backend references. Domain-prefixed
+// rather than the spec's Gateway ResolvedRefs: that condition is defined for
+// the Gateway's own references (the client certificate, see
+// buildClientCertResolvedRefsCondition), and folding route backends into it
+// would report a Gateway whose own refs are fine as unresolved.
+const (
+	gatewayConditionBackendRefsResolved = "cf.k8s.lex.la/BackendRefsResolved"
+	gatewayReasonBackendRefsResolved    = "ResolvedRefs"
+	gatewayReasonUnresolvedBackendRefs  = "UnresolvedBackendRefs"
+
+	// gatewayConditionDegraded flags a systemic backend problem: more than
+	// the configured fraction of the attached routes have unresolved backend
+	// references at once, which points at something shared (cluster DNS, a
+	// deleted namespace, a broken ReferenceGrant) rather than one route.
+	gatewayConditionDegraded    = "cf.k8s.lex.la/Degraded"
+	gatewayReasonBackendsFailed = "UnresolvedBackendRefsAboveThreshold"
+	gatewayReasonHealthy        = "Healthy"
+
+	// maxUnresolvedRoutesListed caps the route names quoted in the condition
+	// message; the count still covers every route.
+	maxUnresolvedRoutesListed = 5
+)
+
+// routeParentsView is the part of a route the Gateway summary reads: its
+// namespace/name and the parent statuses the route controllers wrote.
+type routeParentsView struct {
+	key     string
+	parents []gatewayv1.RouteParentStatus
+}
+
+// backendRefsConditions summarises, from the route statuses already written
+// by the route controllers, how many HTTPRoutes and GRPCRoutes attached to the
+// Gateway report ResolvedRefs=False for it: the BackendRefsResolved summary,
+// plus the Degraded verdict when DegradedThreshold is set. Returns nil when the
+// routes cannot be listed, so the previous verdicts stay in place.
+func (r *GatewayReconciler) backendRefsConditions(
+	ctx context.Context,
+	gateway *gatewayv1.Gateway,
+	now metav1.Time,
+) []metav1.Condition {
+	var httpRoutes gatewayv1.HTTPRouteList
+	if err := r.List(ctx, &httpRoutes); err != nil {
+		logging.FromContext(ctx).Error("failed to list HTTPRoutes for backend refs summary", "error", err)
+
+		return nil
+	}
+
+	var grpcRoutes gatewayv1.GRPCRouteList
+	if err := r.List(ctx, &grpcRoutes); err != nil {
+		logging.FromContext(ctx).Error("failed to list GRPCRoutes for backend refs summary", "error", err)
+
+		return nil
+	}
+
+	views := make([]routeParentsView, 0, len(httpRoutes.Items)+len(grpcRoutes.Items))
+
+	for i := range httpRoutes.Items {
+		route := &httpRoutes.Items[i]
+		views = append(views, routeParentsView{key: route.Namespace + "/" + route.Name, parents: route.Status.Parents})
+	}
+
+	for i := range grpcRoutes.Items {
+		route := &grpcRoutes.Items[i]
+		views = append(views, routeParentsView{key: route.Namespace + "/" + route.Name, parents: route.Status.Parents})
+	}
+
+	conditions := []metav1.Condition{gatewayBackendRefsCondition(gateway, r.ControllerName, views, now)}
+
+	if r.DegradedThreshold > 0 {
+		conditions = append(conditions,
+			gatewayDegradedCondition(gateway, r.ControllerName, views, r.DegradedThreshold, now))
+	}
+
+	return conditions
+}
+
+// unresolvedAttachedRoutes counts the routes attached to the Gateway and
+// lists, sorted, those that are unresolved. A route counts once however many
+// of its parent entries point at the Gateway; it is unresolved when any of
+// them carries ResolvedRefs=False.
+func unresolvedAttachedRoutes(
+	gateway *gatewayv1.Gateway,
+	controllerName string,
+	routes []routeParentsView,
+) (int, []string) {
+	attached := 0
+
+	var unresolved []string
+
+	for _, route := range routes {
+		matched, failed := false, false
+
+		for i := range route.parents {
+			parent := &route.parents[i]
+			if string(parent.ControllerName) != controllerName || !parentStatusTargetsGateway(parent, gateway) {
+				continue
+			}
+
+			matched = true
+
+			if meta.IsStatusConditionFalse(parent.Conditions, string(gatewayv1.RouteConditionResolvedRefs)) {
+				failed = true
+			}
+		}
+
+		if !matched {
+			continue
+		}
+
+		attached++
+
+		if failed {
+			unresolved = append(unresolved, route.key)
+		}
+	}
+
+	slices.Sort(unresolved)
+
+	return attached, unresolved
+}
+
+// gatewayBackendRefsCondition builds the summary condition (see
+// unresolvedAttachedRoutes for what counts).
+func gatewayBackendRefsCondition(
+	gateway *gatewayv1.Gateway,
+	controllerName string,
+	routes []routeParentsView,
+	now metav1.Time,
+) metav1.Condition {
+	attached, unresolved := unresolvedAttachedRoutes(gateway, controllerName, routes)
+
+	condition := metav1.Condition{
+		Type:               gatewayConditionBackendRefsResolved,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: gateway.Generation,
+		LastTransitionTime: now,
+		Reason:             gatewayReasonBackendRefsResolved,
+		Message:            fmt.Sprintf("Backend references of all %d attached routes resolved", attached),
+	}
+
+	if len(unresolved) == 0 {
+		return condition
+	}
+
+	listed := unresolved
+	if len(listed) > maxUnresolvedRoutesListed {
+		listed = listed[:maxUnresolvedRoutesListed]
+	}
+
+	names := strings.Join(listed, ", ")
+	if extra := len(unresolved) - len(listed); extra > 0 {
+		names += fmt.Sprintf(" and %d more", extra)
+	}
+
+	condition.Status = metav1.ConditionFalse
+	condition.Reason = gatewayReasonUnresolvedBackendRefs
+	condition.Message = truncateMessage(fmt.Sprintf(
+		"%d of %d attached routes have unresolved backend references: %s", len(unresolved), attached, names))
+
+	return condition
+}
+
+// gatewayDegradedCondition is True while the unresolved share of the attached
+// routes exceeds threshold (a fraction in (0, 1)), False otherwise, including
+// when nothing is attached.
+func gatewayDegradedCondition(
+	gateway *gatewayv1.Gateway,
+	controllerName string,
+	routes []routeParentsView,
+	threshold float64,
+	now metav1.Time,
+) metav1.Condition {
+	attached, unresolved := unresolvedAttachedRoutes(gateway, controllerName, routes)
+
+	condition := metav1.Condition{
+		Type:               gatewayConditionDegraded,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: gateway.Generation,
+		LastTransitionTime: now,
+		Reason:             gatewayReasonHealthy,
+		Message: fmt.Sprintf("%d of %d attached routes have unresolved backend references (threshold %g%%)",
+			len(unresolved), attached, threshold*100),
+	}
+
+	if attached == 0 || float64(len(unresolved))/float64(attached) <= threshold {
+		return condition
+	}
+
+	condition.Status = metav1.ConditionTrue
+	condition.Reason = gatewayReasonBackendsFailed
+	condition.Message = condition.Message + "; a shared cause such as cluster DNS is likely"
+
+	return condition
+}
+
+// parentStatusTargetsGateway reports whether a route parent status entry
+// refers to the Gateway itself. The route controllers always write the
+// namespace, so an entry without one is not ours.
+func parentStatusTargetsGateway(parent *gatewayv1.RouteParentStatus, gateway *gatewayv1.Gateway) bool {
+	ref := parent.ParentRef
+
+	if ref.Kind != nil && *ref.Kind != "Gateway" {
+		return false
+	}
+
+	if ref.Namespace == nil || string(*ref.Namespace) != gateway.Namespace {
+		return false
+	}
+
+	return string(ref.Name) == gateway.Name
+}
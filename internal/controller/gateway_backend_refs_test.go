@@ -0,0 +1,287 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+// backendRefsParentStatus is a route parent entry as the route controllers
+// write it for gateway default/<gatewayName>.
+func backendRefsParentStatus(controllerName, gatewayName string, resolved metav1.ConditionStatus) gatewayv1.RouteParentStatus {
+	return gatewayv1.RouteParentStatus{
+		ParentRef: gatewayv1.ParentReference{
+			Kind:      new(gatewayv1.Kind("Gateway")),
+			Namespace: new(gatewayv1.Namespace("default")),
+			Name:      gatewayv1.ObjectName(gatewayName),
+		},
+		ControllerName: gatewayv1.GatewayController(controllerName),
+		Conditions: []metav1.Condition{
+			{
+				Type:               string(gatewayv1.RouteConditionResolvedRefs),
+				Status:             resolved,
+				LastTransitionTime: metav1.Now(),
+				Reason:             string(gatewayv1.RouteReasonResolvedRefs),
+			},
+		},
+	}
+}
+
+func backendRefsHTTPRoute(name string, parents ...gatewayv1.RouteParentStatus) *gatewayv1.HTTPRoute {
+	return &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "test-gateway"}},
+			},
+		},
+		Status: gatewayv1.HTTPRouteStatus{
+			RouteStatus: gatewayv1.RouteStatus{Parents: parents},
+		},
+	}
+}
+
+func TestGatewayReconciler_BackendRefsResolvedSummary(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	gatewayKey := types.NamespacedName{Name: "test-gateway", Namespace: "default"}
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-gateway", Namespace: "default", Generation: 1},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "cloudflare-tunnel",
+			Listeners: []gatewayv1.Listener{
+				{Name: "http", Port: 80, Protocol: "HTTP"},
+			},
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cf-credentials", Namespace: "default"},
+		Data:       map[string][]byte{"api-token": []byte("test-token")},
+	}
+
+	gatewayClassConfig := &v1alpha1.GatewayClassConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-config"},
+		Spec: v1alpha1.GatewayClassConfigSpec{
+			CloudflareCredentialsSecretRef: v1alpha1.SecretReference{Name: "cf-credentials", Namespace: "default"},
+			TunnelID:                       "12345678-1234-1234-1234-123456789abc",
+		},
+	}
+
+	gatewayClass := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "cloudflare-tunnel"},
+		Spec: gatewayv1.GatewayClassSpec{
+			ControllerName: "test-controller",
+			ParametersRef: &gatewayv1.ParametersReference{
+				Group: config.ParametersRefGroup,
+				Kind:  config.ParametersRefKind,
+				Name:  "test-config",
+			},
+		},
+	}
+
+	resolved := backendRefsHTTPRoute("resolved",
+		backendRefsParentStatus("test-controller", "test-gateway", metav1.ConditionTrue))
+	broken := backendRefsHTTPRoute("broken",
+		backendRefsParentStatus("test-controller", "test-gateway", metav1.ConditionFalse))
+	// Unresolved, but for another Gateway and another controller: neither
+	// may count against test-gateway.
+	otherGateway := backendRefsHTTPRoute("other-gateway",
+		backendRefsParentStatus("test-controller", "elsewhere", metav1.ConditionFalse))
+	otherController := backendRefsHTTPRoute("other-controller",
+		backendRefsParentStatus("someone-else", "test-gateway", metav1.ConditionFalse))
+
+	fakeClient := setupGatewayFakeClient(gateway, secret, gatewayClassConfig, gatewayClass,
+		resolved, broken, otherGateway, otherController)
+	reconciler := &GatewayReconciler{
+		Client:         fakeClient,
+		Scheme:         fakeClient.Scheme(),
+		ControllerName: "test-controller",
+		ConfigResolver: config.NewResolver(fakeClient, "default", cfmetrics.NewNoopCollector()),
+	}
+
+	reconcileAndGetCondition := func() *metav1.Condition {
+		t.Helper()
+
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: gatewayKey})
+		require.NoError(t, err)
+
+		var updated gatewayv1.Gateway
+		require.NoError(t, fakeClient.Get(ctx, gatewayKey, &updated))
+
+		return meta.FindStatusCondition(updated.Status.Conditions, gatewayConditionBackendRefsResolved)
+	}
+
+	cond := reconcileAndGetCondition()
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, gatewayReasonUnresolvedBackendRefs, cond.Reason)
+	assert.Equal(t, "1 of 2 attached routes have unresolved backend references: default/broken", cond.Message)
+
+	// Fixing the backend flips the route's ResolvedRefs, which clears the
+	// Gateway summary on the next reconcile.
+	var fixed gatewayv1.HTTPRoute
+	require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: "broken", Namespace: "default"}, &fixed))
+	fixed.Status.Parents = []gatewayv1.RouteParentStatus{
+		backendRefsParentStatus("test-controller", "test-gateway", metav1.ConditionTrue),
+	}
+	require.NoError(t, fakeClient.Update(ctx, &fixed))
+
+	cond = reconcileAndGetCondition()
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, gatewayReasonBackendRefsResolved, cond.Reason)
+	assert.Equal(t, "Backend references of all 2 attached routes resolved", cond.Message)
+}
+
+func TestGatewayBackendRefsCondition_CapsListedRoutes(t *testing.T) {
+	t.Parallel()
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-gateway", Namespace: "default", Generation: 4},
+	}
+
+	routes := make([]routeParentsView, 0, maxUnresolvedRoutesListed+2)
+	for i := range maxUnresolvedRoutesListed + 2 {
+		routes = append(routes, routeParentsView{
+			key: fmt.Sprintf("default/route-%d", i),
+			parents: []gatewayv1.RouteParentStatus{
+				backendRefsParentStatus("test-controller", "test-gateway", metav1.ConditionFalse),
+			},
+		})
+	}
+
+	cond := gatewayBackendRefsCondition(gateway, "test-controller", routes, metav1.Now())
+
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, int64(4), cond.ObservedGeneration)
+	assert.Equal(t,
+		"7 of 7 attached routes have unresolved backend references: "+
+			"default/route-0, default/route-1, default/route-2, default/route-3, default/route-4 and 2 more",
+		cond.Message)
+}
+
+// TestGatewayReconciler_DegradedThreshold pins that Degraded flips True only
+// once the unresolved share of attached routes exceeds the threshold, and
+// back once it drops to it.
+func TestGatewayReconciler_DegradedThreshold(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	gatewayKey := types.NamespacedName{Name: "test-gateway", Namespace: "default"}
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-gateway", Namespace: "default", Generation: 1},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "cloudflare-tunnel",
+			Listeners: []gatewayv1.Listener{
+				{Name: "http", Port: 80, Protocol: "HTTP"},
+			},
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cf-credentials", Namespace: "default"},
+		Data:       map[string][]byte{"api-token": []byte("test-token")},
+	}
+
+	gatewayClassConfig := &v1alpha1.GatewayClassConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-config"},
+		Spec: v1alpha1.GatewayClassConfigSpec{
+			CloudflareCredentialsSecretRef: v1alpha1.SecretReference{Name: "cf-credentials", Namespace: "default"},
+			TunnelID:                       "12345678-1234-1234-1234-123456789abc",
+		},
+	}
+
+	gatewayClass := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "cloudflare-tunnel"},
+		Spec: gatewayv1.GatewayClassSpec{
+			ControllerName: "test-controller",
+			ParametersRef: &gatewayv1.ParametersReference{
+				Group: config.ParametersRefGroup,
+				Kind:  config.ParametersRefKind,
+				Name:  "test-config",
+			},
+		},
+	}
+
+	fakeClient := setupGatewayFakeClient(gateway, secret, gatewayClassConfig, gatewayClass,
+		backendRefsHTTPRoute("first", backendRefsParentStatus("test-controller", "test-gateway", metav1.ConditionFalse)),
+		backendRefsHTTPRoute("second", backendRefsParentStatus("test-controller", "test-gateway", metav1.ConditionTrue)))
+	reconciler := &GatewayReconciler{
+		Client:            fakeClient,
+		Scheme:            fakeClient.Scheme(),
+		ControllerName:    "test-controller",
+		ConfigResolver:    config.NewResolver(fakeClient, "default", cfmetrics.NewNoopCollector()),
+		DegradedThreshold: 0.5,
+	}
+
+	setResolved := func(name string, resolved metav1.ConditionStatus) {
+		t.Helper()
+
+		var route gatewayv1.HTTPRoute
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, &route))
+		route.Status.Parents = []gatewayv1.RouteParentStatus{
+			backendRefsParentStatus("test-controller", "test-gateway", resolved),
+		}
+		require.NoError(t, fakeClient.Update(ctx, &route))
+	}
+
+	degraded := func() *metav1.Condition {
+		t.Helper()
+
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: gatewayKey})
+		require.NoError(t, err)
+
+		var updated gatewayv1.Gateway
+		require.NoError(t, fakeClient.Get(ctx, gatewayKey, &updated))
+
+		cond := meta.FindStatusCondition(updated.Status.Conditions, gatewayConditionDegraded)
+		require.NotNil(t, cond)
+
+		return cond
+	}
+
+	// 1 of 2 is exactly the threshold: not above it.
+	cond := degraded()
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, gatewayReasonHealthy, cond.Reason)
+
+	setResolved("second", metav1.ConditionFalse)
+
+	cond = degraded()
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, gatewayReasonBackendsFailed, cond.Reason)
+	assert.Contains(t, cond.Message, "2 of 2 attached routes")
+
+	setResolved("first", metav1.ConditionTrue)
+
+	cond = degraded()
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, gatewayReasonHealthy, cond.Reason)
+
+	// Disabling the threshold drops the condition altogether.
+	reconciler.DegradedThreshold = 0
+
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: gatewayKey})
+	require.NoError(t, err)
+
+	var updated gatewayv1.Gateway
+	require.NoError(t, fakeClient.Get(ctx, gatewayKey, &updated))
+	assert.Nil(t, meta.FindStatusCondition(updated.Status.Conditions, gatewayConditionDegraded))
+}
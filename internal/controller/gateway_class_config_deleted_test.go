@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+// TestSyncAllRoutes_GatewayClassConfigDeletedKeepsTunnelConfig pins the
+// retention half of the ParametersDeleted contract: once the class
+// GatewayClassConfig is gone the sync fails without writing, so the tunnel
+// keeps serving the last applied ingress document — route changes made in
+// the meantime are not half-applied and nothing is wiped.
+func TestSyncAllRoutes_GatewayClassConfigDeletedKeepsTunnelConfig(t *testing.T) {
+	t.Parallel()
+
+	const classTunnel = "99999999-9999-4999-8999-999999999999"
+
+	ctx := context.Background()
+	api := newRecordingTunnelAPI(t)
+	syncer := newPartitionSyncSyncer(t, api, classTunnel)
+
+	_, _, err := syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	applied := api.hostnamesFor(classTunnel)
+	require.Contains(t, applied, "shared.example.com")
+
+	require.NoError(t, syncer.Client.Delete(ctx, &v1alpha1.GatewayClassConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "cfg"},
+	}))
+	require.NoError(t, syncer.Client.Create(ctx, partitionSyncRoute("late-route", "shared-gw", "late.example.com")))
+
+	_, result, err := syncer.SyncAllRoutes(ctx)
+	require.ErrorIs(t, err, config.ErrGatewayClassConfigNotFound)
+	require.NotNil(t, result, "routes still get their error status")
+
+	assert.Equal(t, applied, api.hostnamesFor(classTunnel), "the last applied document stays in place")
+}
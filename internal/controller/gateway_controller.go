@@ -11,6 +11,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -25,6 +26,7 @@ import (
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
 	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
 	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/logging"
 	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/render"
@@ -54,6 +56,13 @@ const (
 		"with no hostname pin, so any namespace can claim any hostname on it — pin the listener hostname or scope " +
 		"allowedRoutes to a namespace selector to bound the capture surface"
 
+	// gatewayReasonParametersDeleted is the Accepted=False reason for a
+	// Gateway whose GatewayClass points at a GatewayClassConfig that no longer
+	// exists. Distinct from InvalidParameters so the cause is obvious: the
+	// tunnel keeps its last applied configuration (the credentials to change
+	// it went with the object) until the GatewayClassConfig is restored.
+	gatewayReasonParametersDeleted = "ParametersDeleted"
+
 	// configErrorRequeueDelay is the delay before retrying when config resolution fails.
 	configErrorRequeueDelay = 30 * time.Second
 
@@ -133,9 +142,35 @@ type GatewayReconciler struct {
 	// ViewStore caches the per-Gateway ListenerSet merge view across reconciles.
 	// Shared with the route and ListenerSet reconcilers (issue #332). May be nil.
 	ViewStore *mergeViewStore
+
+	// DegradedThreshold is the fraction of attached routes with unresolved
+	// backend references above which the Gateway reports
+	// cf.k8s.lex.la/Degraded=True. Zero disables the condition.
+	DegradedThreshold float64
+
+	// Metrics records per-Reconcile duration and errors. May be nil.
+	Metrics cfmetrics.Collector
+
+	// now overrides the clock maintenance windows are evaluated against;
+	// nil uses time.Now. Tests inject a fixed time.
+	now func() time.Time
+}
+
+func (r *GatewayReconciler) clock() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+
+	return time.Now()
 }
 
 func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return instrumentReconcile(ctx, r.Metrics, reconcileMetricsGateway, func() (ctrl.Result, error) {
+		return r.reconcile(ctx, req)
+	})
+}
+
+func (r *GatewayReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
 	var gateway gatewayv1.Gateway
@@ -213,12 +248,20 @@ func (r *GatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, errors.Wrap(err, "failed to update gateway status")
 	}
 
+	// Flip the Paused condition when the maintenance window opens or closes.
+	// The extra second keeps the requeue from landing on the boundary minute
+	// before it has started.
+	if _, flip := maintenanceWindowStatus(&gateway, r.clock()); flip > 0 {
+		return ctrl.Result{RequeueAfter: flip + time.Second, Priority: new(priorityGateway)}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
 // resolveGatewayConfig resolves the Gateway's effective configuration: the
 // per-Gateway data plane (infrastructure.parametersRef → tunnel identity from
-// the connector token) when opted in, the GatewayClass chain otherwise. The
+// the connector token) when opted in, the GatewayClass chain (with the
+// Gateway's config.TunnelIDAnnotation applied) otherwise. The
 // bool reports per-Gateway mode so the status writer gates Programmed on the
 // rendered Deployment. An invalid parametersRef errors with
 // config.ErrInvalidParameters, surfacing as Accepted=False/InvalidParameters.
@@ -257,7 +300,15 @@ func (r *GatewayReconciler) resolveGatewayConfig(
 		return nil, false, errors.Wrap(err, "GatewayClass configuration")
 	}
 
-	return classConfig, false, nil
+	// A shared-mode Gateway may pin its own tunnel (blue/green migration);
+	// the status address then points at that tunnel, matching where the
+	// route syncer writes the Gateway's routes.
+	resolved, err := config.WithTunnelOverride(classConfig, gateway)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "tunnel override")
+	}
+
+	return resolved, false, nil
 }
 
 //nolint:funlen // status update logic with retry
@@ -331,6 +382,22 @@ func (r *GatewayReconciler) updateStatus(
 			programmed,
 		}, buildClientCertResolvedRefsCondition(freshGateway.Generation, now, clientCertErr))
 
+		// Route status changes re-enqueue the Gateway (see the route watches
+		// in SetupWithManager), so the summary follows the route controllers.
+		for _, condition := range r.backendRefsConditions(ctx, &freshGateway, now) {
+			meta.SetStatusCondition(&freshGateway.Status.Conditions, condition)
+		}
+
+		if r.DegradedThreshold <= 0 {
+			meta.RemoveStatusCondition(&freshGateway.Status.Conditions, gatewayConditionDegraded)
+		}
+
+		if paused, _ := maintenanceWindowStatus(&freshGateway, r.clock()); paused != nil {
+			meta.SetStatusCondition(&freshGateway.Status.Conditions, *paused)
+		} else {
+			meta.RemoveStatusCondition(&freshGateway.Status.Conditions, gatewayConditionPaused)
+		}
+
 		listenerStatuses := make([]gatewayv1.ListenerStatus, 0, len(freshGateway.Spec.Listeners))
 
 		// The merged view (cached) annotates each Gateway-owned listener that
@@ -522,6 +589,13 @@ func (r *GatewayReconciler) setConfigErrorStatus(
 
 		now := metav1.Now()
 		errMsg := truncateMessage("Failed to resolve Gateway configuration: " + configErr.Error())
+		acceptedReason := string(gatewayv1.GatewayReasonInvalidParameters)
+
+		if errors.Is(configErr, config.ErrGatewayClassConfigNotFound) {
+			acceptedReason = gatewayReasonParametersDeleted
+			errMsg = truncateMessage("GatewayClassConfig referenced by the GatewayClass is missing: " +
+				configErr.Error() + "; the tunnel keeps its last applied configuration until it is restored")
+		}
 
 		// Clear addresses on config error (no valid tunnel to point to)
 		freshGateway.Status.Addresses = nil
@@ -534,7 +608,7 @@ func (r *GatewayReconciler) setConfigErrorStatus(
 				Status:             metav1.ConditionFalse,
 				ObservedGeneration: freshGateway.Generation,
 				LastTransitionTime: now,
-				Reason:             string(gatewayv1.GatewayReasonInvalidParameters),
+				Reason:             acceptedReason,
 				Message:            errMsg,
 			},
 			{
@@ -1058,22 +1132,12 @@ func buildListenerAcceptedCondition(protocol gatewayv1.ProtocolType, generation
 }
 
 // servableListenerProtocol reports whether this controller has a data plane for
-// the listener protocol. Only HTTP and HTTPS carry HTTPRoute / GRPCRoute through
-// the in-process proxy; TCP, TLS, UDP, and any unrecognised protocol have none
-// (Cloudflare Tunnel is HTTP-focused and terminates TLS at the edge). The single
-// source of truth for both the per-listener Accepted condition and the
-// Gateway-level ListenersNotValid aggregation below.
+// the listener protocol (routebinding.ServableProtocol). The single source of
+// truth for the per-listener Accepted condition, the Gateway-level
+// ListenersNotValid aggregation below, and route binding, which refuses every
+// listener this rejects.
 func servableListenerProtocol(protocol gatewayv1.ProtocolType) bool {
-	switch protocol {
-	case gatewayv1.HTTPProtocolType, gatewayv1.HTTPSProtocolType:
-		return true
-	case gatewayv1.TCPProtocolType, gatewayv1.TLSProtocolType, gatewayv1.UDPProtocolType:
-		return false
-	default:
-		// Any unrecognised protocol (e.g. the conformance suite's INVALID) has
-		// no data plane here either.
-		return false
-	}
+	return routebinding.ServableProtocol(protocol)
 }
 
 // gatewayUnsupportedProtocolListeners summarises, across a Gateway's own
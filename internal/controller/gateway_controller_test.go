@@ -2402,7 +2402,9 @@ func TestGatewayReconciler_Reconcile_ConfigError_SetsStatus(t *testing.T) {
 
 	require.Len(t, updated.Status.Conditions, 3)
 	assert.Equal(t, metav1.ConditionFalse, updated.Status.Conditions[0].Status)
-	assert.Equal(t, string(gatewayv1.GatewayReasonInvalidParameters), updated.Status.Conditions[0].Reason)
+	assert.Equal(t, gatewayReasonParametersDeleted, updated.Status.Conditions[0].Reason)
+	assert.Contains(t, updated.Status.Conditions[0].Message, "nonexistent-config")
+	assert.Contains(t, updated.Status.Conditions[0].Message, "keeps its last applied configuration")
 	assert.Nil(t, updated.Status.Addresses)
 }
 
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/api/v1alpha1"
+)
+
+// TestGatewayInfraReconciler_DisruptionBudgetLifecycle pins the PDB
+// lifecycle: an enabled budget on the default two replicas renders an owned
+// PDB; scaling down to a single replica deletes it.
+func TestGatewayInfraReconciler_DisruptionBudgetLifecycle(t *testing.T) {
+	t.Parallel()
+
+	objects := infraFixtures(t)
+	for _, obj := range objects {
+		if gwConfig, ok := obj.(*v1alpha1.GatewayConfig); ok {
+			gwConfig.Spec.PodDisruptionBudget = &v1alpha1.ProxyPodDisruptionBudget{Enabled: true}
+		}
+	}
+
+	reconciler := newInfraReconciler(t, objects...)
+	reconcileEdge(t, reconciler)
+
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "cf-proxy-edge", Namespace: infraNamespace}
+
+	var pdb policyv1.PodDisruptionBudget
+	require.NoError(t, reconciler.Get(ctx, key, &pdb))
+	require.NotNil(t, pdb.Spec.MinAvailable)
+	assert.Equal(t, 1, pdb.Spec.MinAvailable.IntValue())
+	require.Len(t, pdb.OwnerReferences, 1)
+	assert.Equal(t, "edge", pdb.OwnerReferences[0].Name)
+
+	// A single replica must not keep a budget that would block every drain.
+	var gwConfig v1alpha1.GatewayConfig
+	require.NoError(t, reconciler.Get(ctx, types.NamespacedName{Name: "edge-config", Namespace: infraNamespace}, &gwConfig))
+	gwConfig.Spec.Replicas = new(int32(1))
+	require.NoError(t, reconciler.Update(ctx, &gwConfig))
+
+	reconcileEdge(t, reconciler)
+
+	err := reconciler.Get(ctx, key, &pdb)
+	assert.Error(t, err, "the PDB must be deleted once the proxy runs a single replica")
+}
@@ -13,6 +13,7 @@ import (
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -51,7 +52,8 @@ var errRefusedAdoption = errors.New("refusing to adopt an existing object not ow
 // GatewayInfraReconciler owns the per-Gateway data plane: for every managed
 // Gateway carrying infrastructure.parametersRef it renders and keeps in sync
 // a dedicated proxy Deployment and headless config Service (and, when
-// autoscaling is configured, an HPA). Resources are controller-owned via
+// autoscaling is configured, an HPA; when a disruption budget is enabled, a
+// PodDisruptionBudget). Resources are controller-owned via
 // ownerReferences, so Gateway deletion garbage-collects them; opting back out
 // (removing the parametersRef) is cleaned up explicitly because the owner
 // stays alive.
@@ -228,8 +230,14 @@ func (r *GatewayInfraReconciler) ensureGeneratedAuthSecret(
 		return errors.Wrap(err, "generating config-api auth token")
 	}
 
+	// Create-only like the token: the common metadata is stamped at creation
+	// and not reconciled afterwards (the controller holds no Secret update).
+	meta := render.GeneratedSecretMetadata(r.RenderDefaults.CommonMetadata)
+	meta.Name = name
+	meta.Namespace = gateway.Namespace
+
 	secret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: gateway.Namespace},
+		ObjectMeta: meta,
 		Data:       map[string][]byte{generatedAuthTokenKey: []byte(token)},
 	}
 
@@ -324,6 +332,12 @@ func (r *GatewayInfraReconciler) applyRendered(
 		return err
 	}
 
+	if _, err := r.applyDisruptionBudget(ctx, gateway, &input); err != nil {
+		r.event(gateway, corev1.EventTypeWarning, eventReasonRenderFailed, err.Error())
+
+		return err
+	}
+
 	if _, err := r.applyNetworkPolicy(ctx, gateway); err != nil {
 		r.event(gateway, corev1.EventTypeWarning, eventReasonRenderFailed, err.Error())
 
@@ -540,6 +554,7 @@ func (r *GatewayInfraReconciler) applyNetworkPolicy(
 		Gateway:                     gateway,
 		ControllerNamespace:         r.ControllerNamespace,
 		MonitoringNamespaceSelector: r.MonitoringNamespaceSelector,
+		CommonMetadata:              r.RenderDefaults.CommonMetadata,
 	})
 
 	existing := &networkingv1.NetworkPolicy{
@@ -602,6 +617,45 @@ func (r *GatewayInfraReconciler) applyAutoscaler(
 	return operation, nil
 }
 
+// applyDisruptionBudget renders and applies the PodDisruptionBudget when it is
+// enabled for a multi-replica proxy, and deletes a previously-rendered (owned)
+// one otherwise.
+func (r *GatewayInfraReconciler) applyDisruptionBudget(
+	ctx context.Context,
+	gateway *gatewayv1.Gateway,
+	input *render.Input,
+) (controllerutil.OperationResult, error) {
+	desired := render.DisruptionBudget(input)
+	if desired == nil {
+		return controllerutil.OperationResultNone, r.deleteIfOwned(ctx, gateway, &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: render.DeploymentName(gateway), Namespace: gateway.Namespace,
+			},
+		})
+	}
+
+	existing := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace},
+	}
+
+	operation, err := controllerutil.CreateOrUpdate(ctx, r.Client, existing, func() error {
+		if err := assertAdoptable(existing, gateway); err != nil {
+			return err
+		}
+
+		existing.Labels = desired.Labels
+		existing.Annotations = mergeManagedAnnotations(existing.Annotations, desired.Annotations)
+		existing.Spec = desired.Spec
+
+		return controllerutil.SetControllerReference(gateway, existing, r.Scheme)
+	})
+	if err != nil {
+		return operation, errors.Wrap(err, "failed to apply per-gateway disruption budget")
+	}
+
+	return operation, nil
+}
+
 // cleanupRendered removes the per-Gateway resources after an opt-out. Missing
 // objects are fine; objects NOT owned by this Gateway are left untouched so a
 // name collision with user resources cannot turn into a deletion.
@@ -629,6 +683,9 @@ func (r *GatewayInfraReconciler) cleanupRendered(ctx context.Context, gateway *g
 		&autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: metav1.ObjectMeta{
 			Name: render.DeploymentName(gateway), Namespace: gateway.Namespace,
 		}},
+		&policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{
+			Name: render.DeploymentName(gateway), Namespace: gateway.Namespace,
+		}},
 		&networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{
 			Name: render.NetworkPolicyName(gateway), Namespace: gateway.Namespace,
 		}},
@@ -693,6 +750,7 @@ func (r *GatewayInfraReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.Service{}).
 		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
 		Owns(&networkingv1.NetworkPolicy{}).
 		Watches(
 			&v1alpha1.GatewayConfig{},
@@ -12,6 +12,7 @@ import (
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -114,6 +115,7 @@ func newInfraReconciler(t *testing.T, objects ...runtime.Object) *GatewayInfraRe
 	require.NoError(t, corev1.AddToScheme(scheme))
 	require.NoError(t, appsv1.AddToScheme(scheme))
 	require.NoError(t, autoscalingv2.AddToScheme(scheme))
+	require.NoError(t, policyv1.AddToScheme(scheme))
 	require.NoError(t, networkingv1.AddToScheme(scheme))
 
 	builder := fake.NewClientBuilder().WithScheme(scheme)
@@ -236,6 +238,7 @@ func TestGatewayInfraReconciler_TransientClassReadErrorRequeuesWithoutTeardown(t
 	require.NoError(t, corev1.AddToScheme(scheme))
 	require.NoError(t, appsv1.AddToScheme(scheme))
 	require.NoError(t, autoscalingv2.AddToScheme(scheme))
+	require.NoError(t, policyv1.AddToScheme(scheme))
 	require.NoError(t, networkingv1.AddToScheme(scheme))
 
 	builder := fake.NewClientBuilder().WithScheme(scheme)
@@ -460,6 +463,7 @@ func TestGatewayInfraReconciler_OptOutSucceedsWithoutSecretDelete(t *testing.T)
 	require.NoError(t, corev1.AddToScheme(scheme))
 	require.NoError(t, appsv1.AddToScheme(scheme))
 	require.NoError(t, autoscalingv2.AddToScheme(scheme))
+	require.NoError(t, policyv1.AddToScheme(scheme))
 	require.NoError(t, networkingv1.AddToScheme(scheme))
 
 	builder := fake.NewClientBuilder().WithScheme(scheme)
@@ -726,6 +730,35 @@ func TestGatewayInfraReconciler_GeneratesAuthSecret(t *testing.T) {
 	assert.True(t, wired, "PROXY_AUTH_TOKEN must reference the generated Secret")
 }
 
+// TestGatewayInfraReconciler_StampsCommonMetadata pins that the operator's
+// --common-labels/--common-annotations reach every object the reconciler
+// creates, including the create-only generated auth Secret.
+func TestGatewayInfraReconciler_StampsCommonMetadata(t *testing.T) {
+	t.Parallel()
+
+	reconciler := newInfraReconciler(t, infraFixtures(t)...)
+	reconciler.RenderDefaults.CommonMetadata = render.Metadata{
+		Labels:      map[string]string{"team": "platform"},
+		Annotations: map[string]string{"example.com/owner": "sre"},
+	}
+	reconcileEdge(t, reconciler)
+
+	objects := map[string]client.Object{
+		"cf-proxy-edge":        &appsv1.Deployment{},
+		"cf-proxy-edge-config": &corev1.Service{},
+		"cf-proxy-edge-netpol": &networkingv1.NetworkPolicy{},
+		"cf-proxy-edge-auth":   &corev1.Secret{},
+	}
+
+	for name, obj := range objects {
+		require.NoError(t, reconciler.Get(context.Background(),
+			types.NamespacedName{Name: name, Namespace: infraNamespace}, obj), name)
+
+		assert.Equal(t, "platform", obj.GetLabels()["team"], "%s must carry the common label", name)
+		assert.Equal(t, "sre", obj.GetAnnotations()["example.com/owner"], "%s must carry the common annotation", name)
+	}
+}
+
 // TestGatewayInfraReconciler_NeverRotatesGeneratedAuthToken pins the
 // create-only contract: a second reconcile must NOT mint a fresh token (that
 // would roll the proxy pods on every reconcile).
@@ -954,6 +987,7 @@ func TestGatewayInfraReconciler_RefusesForeignAuthSecretOnCreateRace(t *testing.
 	require.NoError(t, corev1.AddToScheme(scheme))
 	require.NoError(t, appsv1.AddToScheme(scheme))
 	require.NoError(t, autoscalingv2.AddToScheme(scheme))
+	require.NoError(t, policyv1.AddToScheme(scheme))
 	require.NoError(t, networkingv1.AddToScheme(scheme))
 
 	foreign := &corev1.Secret{
@@ -1033,6 +1067,7 @@ func TestGatewayInfraReconciler_TokenlessOwnedAuthSecretFailsClosedWithoutUpdate
 	require.NoError(t, corev1.AddToScheme(scheme))
 	require.NoError(t, appsv1.AddToScheme(scheme))
 	require.NoError(t, autoscalingv2.AddToScheme(scheme))
+	require.NoError(t, policyv1.AddToScheme(scheme))
 	require.NoError(t, networkingv1.AddToScheme(scheme))
 
 	owned := &corev1.Secret{
@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+// TestGatewayReconciler_ListenerStatus pins status.listeners for a
+// two-listener Gateway: one entry per listener, in spec order, with the
+// routes attached to that listener counted (a route without sectionName
+// counts toward every listener) and the per-listener conditions set.
+func TestGatewayReconciler_ListenerStatus(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	gatewayKey := types.NamespacedName{Name: "test-gateway", Namespace: "default"}
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-gateway", Namespace: "default", Generation: 1},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "cloudflare-tunnel",
+			Listeners: []gatewayv1.Listener{
+				{Name: "api", Hostname: new(gatewayv1.Hostname("api.example.com")), Port: 80, Protocol: gatewayv1.HTTPProtocolType},
+				{Name: "web", Hostname: new(gatewayv1.Hostname("web.example.com")), Port: 80, Protocol: gatewayv1.HTTPProtocolType},
+			},
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cf-credentials", Namespace: "default"},
+		Data:       map[string][]byte{"api-token": []byte("test-token")},
+	}
+
+	gatewayClassConfig := &v1alpha1.GatewayClassConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-config"},
+		Spec: v1alpha1.GatewayClassConfigSpec{
+			CloudflareCredentialsSecretRef: v1alpha1.SecretReference{Name: "cf-credentials", Namespace: "default"},
+			TunnelID:                       "12345678-1234-1234-1234-123456789abc",
+		},
+	}
+
+	gatewayClass := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "cloudflare-tunnel"},
+		Spec: gatewayv1.GatewayClassSpec{
+			ControllerName: "test-controller",
+			ParametersRef: &gatewayv1.ParametersReference{
+				Group: config.ParametersRefGroup,
+				Kind:  config.ParametersRefKind,
+				Name:  "test-config",
+			},
+		},
+	}
+
+	route := func(name string, sections ...string) *gatewayv1.HTTPRoute {
+		r := backendRefsHTTPRoute(name)
+		if len(sections) == 0 {
+			return r
+		}
+
+		r.Spec.ParentRefs = nil
+		for _, section := range sections {
+			r.Spec.ParentRefs = append(r.Spec.ParentRefs, gatewayv1.ParentReference{
+				Name:        "test-gateway",
+				SectionName: new(gatewayv1.SectionName(section)),
+			})
+		}
+
+		return r
+	}
+
+	// api: api-1, api-2, both, everywhere = 4; web: web-1, both, everywhere = 3.
+	fakeClient := setupGatewayFakeClient(gateway, secret, gatewayClassConfig, gatewayClass,
+		route("api-1", "api"),
+		route("api-2", "api"),
+		route("web-1", "web"),
+		route("both", "api", "web"),
+		route("everywhere"),
+	)
+	reconciler := &GatewayReconciler{
+		Client:         fakeClient,
+		Scheme:         fakeClient.Scheme(),
+		ControllerName: "test-controller",
+		ConfigResolver: config.NewResolver(fakeClient, "default", cfmetrics.NewNoopCollector()),
+	}
+
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: gatewayKey})
+	require.NoError(t, err)
+
+	var updated gatewayv1.Gateway
+	require.NoError(t, fakeClient.Get(ctx, gatewayKey, &updated))
+	require.Len(t, updated.Status.Listeners, 2)
+
+	want := map[gatewayv1.SectionName]int32{"api": 4, "web": 3}
+
+	for i, listener := range updated.Status.Listeners {
+		assert.Equal(t, gateway.Spec.Listeners[i].Name, listener.Name, "status follows spec order")
+		assert.Equal(t, want[listener.Name], listener.AttachedRoutes, "listener %s", listener.Name)
+
+		kinds := make([]gatewayv1.Kind, 0, len(listener.SupportedKinds))
+		for _, kind := range listener.SupportedKinds {
+			kinds = append(kinds, kind.Kind)
+		}
+
+		assert.Contains(t, kinds, gatewayv1.Kind("HTTPRoute"), "listener %s", listener.Name)
+
+		for _, condType := range []gatewayv1.ListenerConditionType{
+			gatewayv1.ListenerConditionAccepted,
+			gatewayv1.ListenerConditionProgrammed,
+			gatewayv1.ListenerConditionResolvedRefs,
+		} {
+			cond := meta.FindStatusCondition(listener.Conditions, string(condType))
+			require.NotNil(t, cond, "listener %s: %s condition", listener.Name, condType)
+			assert.Equal(t, metav1.ConditionTrue, cond.Status, "listener %s: %s", listener.Name, condType)
+			assert.Equal(t, int64(1), cond.ObservedGeneration)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+const (
+	overrideClassTunnel = "12345678-1234-1234-1234-123456789abc"
+	overrideGreenTunnel = "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee"
+)
+
+// TestGatewayReconciler_TunnelOverrideStatusAddress pins that the Gateway's
+// status address follows its tunnel-id annotation, so the CNAME target an
+// operator publishes matches the tunnel the routes are written to. A
+// malformed value is a user-fixable spec error surfaced as InvalidParameters.
+func TestGatewayReconciler_TunnelOverrideStatusAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		annotation   *string
+		wantAddress  string
+		wantAccepted metav1.ConditionStatus
+	}{
+		{
+			name:         "no annotation uses the class tunnel",
+			wantAddress:  overrideClassTunnel + cfArgotunnelSuffix,
+			wantAccepted: metav1.ConditionTrue,
+		},
+		{
+			name:         "annotation overrides the class tunnel",
+			annotation:   new(overrideGreenTunnel),
+			wantAddress:  overrideGreenTunnel + cfArgotunnelSuffix,
+			wantAccepted: metav1.ConditionTrue,
+		},
+		{
+			name:         "annotation is canonicalized",
+			annotation:   new("AAAAAAAA-BBBB-CCCC-DDDD-EEEEEEEEEEEE"),
+			wantAddress:  overrideGreenTunnel + cfArgotunnelSuffix,
+			wantAccepted: metav1.ConditionTrue,
+		},
+		{
+			name:         "malformed annotation is invalid parameters",
+			annotation:   new("not-a-uuid"),
+			wantAccepted: metav1.ConditionFalse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			gateway := overrideTestGateway(tt.annotation)
+			fakeClient := setupGatewayFakeClient(append(overrideTestClassObjects(), gateway)...)
+
+			reconciler := &GatewayReconciler{
+				Client:         fakeClient,
+				Scheme:         fakeClient.Scheme(),
+				ControllerName: "test-controller",
+				ConfigResolver: config.NewResolver(fakeClient, "default", cfmetrics.NewNoopCollector()),
+			}
+
+			_, err := reconciler.Reconcile(ctx, ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: gateway.Name, Namespace: gateway.Namespace},
+			})
+			require.NoError(t, err)
+
+			var updated gatewayv1.Gateway
+			require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: gateway.Name, Namespace: gateway.Namespace}, &updated))
+
+			accepted := meta.FindStatusCondition(updated.Status.Conditions, string(gatewayv1.GatewayConditionAccepted))
+			require.NotNil(t, accepted)
+			assert.Equal(t, tt.wantAccepted, accepted.Status)
+
+			if tt.wantAddress == "" {
+				assert.Empty(t, updated.Status.Addresses)
+				assert.Equal(t, string(gatewayv1.GatewayReasonInvalidParameters), accepted.Reason)
+				assert.Contains(t, accepted.Message, config.TunnelIDAnnotation)
+
+				return
+			}
+
+			require.Len(t, updated.Status.Addresses, 1)
+			assert.Equal(t, tt.wantAddress, updated.Status.Addresses[0].Value)
+		})
+	}
+}
+
+// TestBuildTunnelGroups_TunnelOverrideApplyTarget pins the apply target: a
+// route on a pinned Gateway is written to the pinned tunnel with the class
+// credentials, a route on an un-pinned Gateway stays on the class tunnel, and
+// a route on both lands in both documents. The shared document is always
+// written so it converges when the last un-pinned route leaves.
+func TestBuildTunnelGroups_TunnelOverrideApplyTarget(t *testing.T) {
+	t.Parallel()
+
+	httpResult := &httpRouteResult{
+		accepted: []gatewayv1.HTTPRoute{partRoute("blue"), partRoute("green"), partRoute("both")},
+		bindings: map[string]routeBindingInfo{
+			"default/blue":  bindingOn("default/blue-gw"),
+			"default/green": bindingOn("default/green-gw"),
+			"default/both":  bindingOn("default/blue-gw", "default/green-gw"),
+		},
+	}
+	grpcResult := &grpcRouteResult{
+		accepted: []gatewayv1.GRPCRoute{partGRPCRoute("grpc-green")},
+		bindings: map[string]routeBindingInfo{"default/grpc-green": bindingOn("default/green-gw")},
+	}
+	infra := &infraGateways{tunnelOverrides: map[string]string{"default/green-gw": overrideGreenTunnel}}
+
+	partitions := partitionRoutes(httpResult, grpcResult, infra)
+	require.Len(t, partitions, 1, "a tunnel override does not create a proxy push partition")
+
+	class := &config.ResolvedConfig{TunnelID: overrideClassTunnel, APIToken: "class-token", AccountID: "acct"}
+	groups := buildTunnelGroups(class, splitSharedTunnelOverrides(partitions, httpResult, grpcResult, infra))
+	require.Len(t, groups, 2)
+
+	assert.Equal(t, overrideClassTunnel, groups[0].resolved.TunnelID)
+	sharedHTTP, sharedGRPC := groupRoutes(&groups[0])
+	assert.ElementsMatch(t, []string{"blue", "both"}, routeNames(sharedHTTP))
+	assert.Empty(t, sharedGRPC)
+
+	assert.Equal(t, overrideGreenTunnel, groups[1].resolved.TunnelID)
+	assert.Equal(t, "class-token", groups[1].resolved.APIToken, "override keeps the class credentials")
+	greenHTTP, greenGRPC := groupRoutes(&groups[1])
+	assert.ElementsMatch(t, []string{"green", "both"}, routeNames(greenHTTP))
+	assert.ElementsMatch(t, []string{"grpc-green"}, grpcRouteNames(greenGRPC))
+
+	assert.Equal(t, overrideClassTunnel, class.TunnelID, "the class config must not be mutated")
+}
+
+// TestGatewaySyncError_TunnelOverride pins per-parent failure attribution: a
+// failed pinned tunnel flips only the parents on the pinned Gateway, and a
+// malformed annotation fails its parents closed.
+func TestGatewaySyncError_TunnelOverride(t *testing.T) {
+	t.Parallel()
+
+	infra := &infraGateways{
+		tunnelOverrides:  map[string]string{"default/green-gw": overrideGreenTunnel},
+		invalidOverrides: map[string]bool{"default/bad-gw": true},
+	}
+	greenErr := errors.New("green tunnel write failed")
+	failed := map[string]error{tunnelOverridePartitionKey(overrideGreenTunnel): greenErr}
+
+	require.ErrorIs(t, gatewaySyncError("default/green-gw", failed, infra), greenErr)
+	require.NoError(t, gatewaySyncError("default/blue-gw", failed, infra))
+	require.ErrorIs(t, gatewaySyncError("default/bad-gw", failed, infra), errInvalidTunnelOverride)
+
+	assert.Empty(t, partitionKeysFor(bindingOn("default/bad-gw"), infra), "malformed override serves nowhere")
+}
+
+func overrideTestGateway(annotation *string) *gatewayv1.Gateway {
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "green-gw", Namespace: "default"},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "cloudflare-tunnel",
+			Listeners:        []gatewayv1.Listener{{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType}},
+		},
+	}
+
+	if annotation != nil {
+		gateway.Annotations = map[string]string{config.TunnelIDAnnotation: *annotation}
+	}
+
+	return gateway
+}
+
+func overrideTestClassObjects() []client.Object {
+	return []client.Object{
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "cf-credentials", Namespace: "default"},
+			Data:       map[string][]byte{"api-token": []byte("test-token")},
+		},
+		&v1alpha1.GatewayClassConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-config"},
+			Spec: v1alpha1.GatewayClassConfigSpec{
+				CloudflareCredentialsSecretRef: v1alpha1.SecretReference{Name: "cf-credentials", Namespace: "default"},
+				TunnelID:                       overrideClassTunnel,
+			},
+		},
+		&gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "cloudflare-tunnel"},
+			Spec: gatewayv1.GatewayClassSpec{
+				ControllerName: "test-controller",
+				ParametersRef: &gatewayv1.ParametersReference{
+					Group: config.ParametersRefGroup,
+					Kind:  config.ParametersRefKind,
+					Name:  "test-config",
+				},
+			},
+		},
+	}
+}
@@ -24,6 +24,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	"sigs.k8s.io/gateway-api/pkg/consts"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/api/v1alpha1"
 )
 
 // gatewayClassCRDName is the Gateway API CRD probed for the bundle-version
@@ -51,6 +53,10 @@ type GatewayClassReconciler struct {
 	// set to False with reason UnsupportedVersion ("no reader configured"),
 	// not left unset.
 	BundleVersionReader client.Reader
+
+	// SharedTunnelPolicy is reported in the TunnelShared condition's message
+	// so the class tells the operator whether a shared tunnel is synced.
+	SharedTunnelPolicy string
 }
 
 func (r *GatewayClassReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -162,6 +168,14 @@ func (r *GatewayClassReconciler) updateStatus(
 
 		bundleErr := r.setAcceptedConditions(ctx, &freshClass)
 
+		// Advisory: flag another class of this controller naming the same
+		// tunnel through a different GatewayClassConfig. A list failure leaves
+		// the previous verdict standing rather than clearing it on a cache blip.
+		if classes, err := listGatewayClassesForController(ctx, r.Client, r.ControllerName); err == nil {
+			setTunnelSharedCondition(&freshClass,
+				tunnelSharingClasses(ctx, r.Client, classes, freshClass.Name), r.SharedTunnelPolicy, metav1.Now())
+		}
+
 		if err := r.Status().Update(ctx, &freshClass); err != nil {
 			return errors.Wrap(err, "failed to update GatewayClass status")
 		}
@@ -340,9 +354,34 @@ func (r *GatewayClassReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&gatewayv1.Gateway{},
 			handler.EnqueueRequestsFromMapFunc(gatewayClassForGateway),
 			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// The TunnelShared condition depends on the sibling classes and on the
+		// tunnelID of every referenced GatewayClassConfig, so a change to any
+		// of them re-evaluates every class of this controller.
+		Watches(&gatewayv1.GatewayClass{},
+			handler.EnqueueRequestsFromMapFunc(r.allManagedClasses),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&v1alpha1.GatewayClassConfig{},
+			handler.EnqueueRequestsFromMapFunc(r.allManagedClasses),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Complete(r)
 }
 
+// allManagedClasses maps any event to a reconcile request per GatewayClass of
+// this controller.
+func (r *GatewayClassReconciler) allManagedClasses(ctx context.Context, _ client.Object) []reconcile.Request {
+	classes, err := listGatewayClassesForController(ctx, r.Client, r.ControllerName)
+	if err != nil {
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(classes))
+	for i := range classes {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: classes[i].Name}})
+	}
+
+	return requests
+}
+
 // gatewayClassForGateway maps a Gateway event to a reconcile request for the
 // GatewayClass it references. The Reconcile controllerName check filters out
 // foreign classes, so no filtering is needed here.
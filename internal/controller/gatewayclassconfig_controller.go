@@ -3,22 +3,30 @@ package controller
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/cockroachdb/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
 )
 
 const (
@@ -28,8 +36,20 @@ const (
 	// ConditionTypeSecretsResolved indicates whether all referenced secrets exist.
 	ConditionTypeSecretsResolved = "SecretsResolved"
 
-	// configValidationRequeueDelay is the delay before re-validating config.
+	// configValidationRequeueDelay is the default delay before re-validating
+	// config (--config-validation-requeue-delay).
 	configValidationRequeueDelay = 5 * time.Minute
+
+	// eventReasonConfigInvalid / eventActionValidate mirror Valid=False as a
+	// Warning Event.
+	eventReasonConfigInvalid = "ConfigInvalid"
+	eventActionValidate      = "Validate"
+
+	// Valid=False reasons. InvalidTunnelID and TunnelNotFound name a tunnelID
+	// problem; Invalid covers everything else.
+	validReasonInvalid         = "Invalid"
+	validReasonInvalidTunnelID = "InvalidTunnelID"
+	validReasonTunnelNotFound  = "TunnelNotFound"
 )
 
 // GatewayClassConfigReconciler reconciles GatewayClassConfig resources.
@@ -39,9 +59,39 @@ type GatewayClassConfigReconciler struct {
 
 	Scheme           *runtime.Scheme
 	DefaultNamespace string
+
+	// Metrics records per-Reconcile duration and errors. May be nil.
+	Metrics cfmetrics.Collector
+
+	// Recorder emits a ConfigInvalid Event while the config fails
+	// validation. Nil is a no-op (unit tests).
+	Recorder events.EventRecorder
+
+	// ValidationRequeueDelay is how often a config is re-validated, picking up
+	// Secrets that appeared or vanished. Zero uses configValidationRequeueDelay.
+	ValidationRequeueDelay time.Duration
+
+	// ConfigResolver resolves the credentials for the live tunnel check.
+	ConfigResolver *config.Resolver
+
+	// ControllerName and SharedTunnelPolicy identify the GatewayClasses whose
+	// configs may be merged into one tunnel; a config that loses the merge
+	// carries the SettingsIgnored condition. Empty ControllerName skips it.
+	ControllerName     string
+	SharedTunnelPolicy string
+
+	// tunnelProber, when set (--verify-tunnel-exists), reads the tunnel
+	// through the Cloudflare API on every validation. Nil validates offline.
+	tunnelProber tunnelProber
 }
 
 func (r *GatewayClassConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return instrumentReconcile(ctx, r.Metrics, reconcileMetricsGatewayClassConfig, func() (ctrl.Result, error) {
+		return r.reconcile(ctx, req)
+	})
+}
+
+func (r *GatewayClassConfigReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
 	var config v1alpha1.GatewayClassConfig
@@ -60,6 +110,8 @@ func (r *GatewayClassConfigReconciler) Reconcile(ctx context.Context, req ctrl.R
 	// Update status with retry to handle concurrent modifications
 	configKey := req.NamespacedName
 
+	var conditions []metav1.Condition
+
 	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		// Get fresh copy to avoid conflict errors
 		var freshConfig v1alpha1.GatewayClassConfig
@@ -74,7 +126,7 @@ func (r *GatewayClassConfigReconciler) Reconcile(ctx context.Context, req ctrl.R
 		}
 
 		// Validate secrets and collect conditions
-		conditions := r.validateConfig(ctx, &freshConfig)
+		conditions = r.validateConfig(ctx, &freshConfig)
 		freshConfig.Status.Conditions = conditions
 
 		if err := r.Status().Update(ctx, &freshConfig); err != nil {
@@ -87,8 +139,36 @@ func (r *GatewayClassConfigReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, errors.Wrap(err, "failed to update GatewayClassConfig status after retries")
 	}
 
+	r.emitInvalidEvent(&config, conditions)
+
 	// Requeue periodically to re-validate (secrets might be created/deleted)
-	return ctrl.Result{RequeueAfter: configValidationRequeueDelay, Priority: new(priorityGatewayClassConfig)}, nil
+	return ctrl.Result{RequeueAfter: r.validationRequeueDelay(), Priority: new(priorityGatewayClassConfig)}, nil
+}
+
+// validationRequeueDelay is ValidationRequeueDelay, or
+// configValidationRequeueDelay when unset.
+func (r *GatewayClassConfigReconciler) validationRequeueDelay() time.Duration {
+	if r.ValidationRequeueDelay <= 0 {
+		return configValidationRequeueDelay
+	}
+
+	return r.ValidationRequeueDelay
+}
+
+// emitInvalidEvent emits a ConfigInvalid Warning when the Valid condition
+// just written is False. The periodic re-validation repeats it, which the
+// throttled recorder folds into one Event per window.
+func (r *GatewayClassConfigReconciler) emitInvalidEvent(config *v1alpha1.GatewayClassConfig, conditions []metav1.Condition) {
+	if r.Recorder == nil {
+		return
+	}
+
+	valid := meta.FindStatusCondition(conditions, ConditionTypeValid)
+	if valid == nil || valid.Status != metav1.ConditionFalse {
+		return
+	}
+
+	r.Recorder.Eventf(config, nil, corev1.EventTypeWarning, eventReasonConfigInvalid, eventActionValidate, "%s", valid.Message)
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -101,24 +181,84 @@ func (r *GatewayClassConfigReconciler) SetupWithManager(mgr ctrl.Manager) error
 			&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(r.secretToConfigs),
 		).
+		// SettingsIgnored compares a config with the one its tunnel is merged
+		// under, so a change to any class or config of this controller
+		// re-evaluates every config its classes reference.
+		Watches(&gatewayv1.GatewayClass{},
+			handler.EnqueueRequestsFromMapFunc(r.managedClassConfigs),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&v1alpha1.GatewayClassConfig{},
+			handler.EnqueueRequestsFromMapFunc(r.managedClassConfigs),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Complete(r)
 }
 
+// managedClassConfigs maps any event to a reconcile request per
+// GatewayClassConfig referenced by a GatewayClass of this controller.
+func (r *GatewayClassConfigReconciler) managedClassConfigs(ctx context.Context, _ client.Object) []reconcile.Request {
+	if r.ControllerName == "" {
+		return nil
+	}
+
+	classes, err := listGatewayClassesForController(ctx, r.Client, r.ControllerName)
+	if err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for i := range classes {
+		ref := classes[i].Spec.ParametersRef
+		if ref == nil || string(ref.Group) != config.ParametersRefGroup || string(ref.Kind) != config.ParametersRefKind {
+			continue
+		}
+
+		request := reconcile.Request{NamespacedName: types.NamespacedName{Name: ref.Name}}
+		if !slices.Contains(requests, request) {
+			requests = append(requests, request)
+		}
+	}
+
+	return requests
+}
+
 func (r *GatewayClassConfigReconciler) validateConfig(
 	ctx context.Context,
 	config *v1alpha1.GatewayClassConfig,
 ) []metav1.Condition {
 	now := metav1.Now()
 
-	// Validate credentials secret. In v3 this is the only spec-level
-	// validation the controller performs — the legacy tunnel-token check
-	// is gone since the proxy receives its token directly from chart values.
+	// The legacy tunnel-token check is gone since the proxy receives its
+	// token directly from chart values.
 	credResolved, validationErrors := r.validateCredentialsSecret(ctx, config)
 
-	return []metav1.Condition{
+	// The CRD rejects a malformed or missing tunnel reference at admission,
+	// but a config applied before those rules existed (or with validation
+	// bypassed) would otherwise only fail at the Cloudflare API on the first
+	// sync. The live check needs the credentials, so it runs only once they
+	// resolved.
+	reason := validReasonInvalid
+
+	if msg := tunnelRefError(&config.Spec); msg != "" {
+		reason = validReasonInvalidTunnelID
+		validationErrors = append([]string{msg}, validationErrors...)
+	} else if credResolved {
+		if msg := r.checkTunnelExists(ctx, config); msg != "" {
+			reason = validReasonTunnelNotFound
+			validationErrors = append(validationErrors, msg)
+		}
+	}
+
+	conditions := []metav1.Condition{
 		r.buildSecretsCondition(credResolved, config.Generation, now),
-		r.buildValidCondition(validationErrors, config.Generation, now),
+		r.buildValidCondition(reason, validationErrors, config.Generation, now),
 	}
+
+	if ignored := r.settingsIgnoredCondition(ctx, config, now); ignored != nil {
+		conditions = append(conditions, *ignored)
+	}
+
+	return conditions
 }
 
 func (r *GatewayClassConfigReconciler) validateCredentialsSecret(
@@ -182,7 +322,10 @@ func (r *GatewayClassConfigReconciler) buildSecretsCondition(
 	}
 }
 
+// buildValidCondition reports Valid=True when there are no validation errors,
+// and Valid=False with reason and the first error otherwise.
 func (r *GatewayClassConfigReconciler) buildValidCondition(
+	reason string,
 	validationErrors []string,
 	generation int64,
 	now metav1.Time,
@@ -211,7 +354,7 @@ func (r *GatewayClassConfigReconciler) buildValidCondition(
 		Status:             metav1.ConditionFalse,
 		ObservedGeneration: generation,
 		LastTransitionTime: now,
-		Reason:             "Invalid",
+		Reason:             reason,
 		Message:            errMsg,
 	}
 }
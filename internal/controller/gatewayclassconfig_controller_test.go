@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -11,6 +12,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -70,7 +72,7 @@ func TestGatewayClassConfigReconciler_Reconcile_Valid(t *testing.T) {
 			Generation: 1,
 		},
 		Spec: v1alpha1.GatewayClassConfigSpec{
-			TunnelID: "test-tunnel-id",
+			TunnelID: "11111111-2222-4333-8444-555555555555",
 			CloudflareCredentialsSecretRef: v1alpha1.SecretReference{
 				Name:      "cf-credentials",
 				Namespace: "default",
@@ -144,7 +146,7 @@ func TestGatewayClassConfigReconciler_Reconcile_SkipsObservedGenerationRegressio
 	config := &v1alpha1.GatewayClassConfig{
 		ObjectMeta: metav1.ObjectMeta{Name: "test-config", Generation: 3},
 		Spec: v1alpha1.GatewayClassConfigSpec{
-			TunnelID: "test-tunnel-id",
+			TunnelID: "11111111-2222-4333-8444-555555555555",
 			CloudflareCredentialsSecretRef: v1alpha1.SecretReference{
 				Name:      "cf-credentials",
 				Namespace: "default",
@@ -198,7 +200,7 @@ func TestGatewayClassConfigReconciler_Reconcile_MissingCredentialsSecret(t *test
 			Generation: 1,
 		},
 		Spec: v1alpha1.GatewayClassConfigSpec{
-			TunnelID: "test-tunnel-id",
+			TunnelID: "11111111-2222-4333-8444-555555555555",
 			CloudflareCredentialsSecretRef: v1alpha1.SecretReference{
 				Name:      "non-existent-secret",
 				Namespace: "default",
@@ -212,10 +214,13 @@ func TestGatewayClassConfigReconciler_Reconcile_MissingCredentialsSecret(t *test
 		WithStatusSubresource(config).
 		Build()
 
+	rec := events.NewFakeRecorder(10)
+
 	r := &GatewayClassConfigReconciler{
 		Client:           fakeClient,
 		Scheme:           scheme,
 		DefaultNamespace: "default",
+		Recorder:         rec,
 	}
 
 	req := ctrl.Request{
@@ -229,6 +234,11 @@ func TestGatewayClassConfigReconciler_Reconcile_MissingCredentialsSecret(t *test
 	assert.NoError(t, err)
 	assert.Equal(t, configValidationRequeueDelay, result.RequeueAfter)
 
+	got := drainEvents(rec)
+	require.Len(t, got, 1)
+	assert.True(t, strings.HasPrefix(got[0], "Warning ConfigInvalid "), got[0])
+	assert.Contains(t, got[0], "non-existent-secret")
+
 	// Verify status reflects missing secret
 	var updatedConfig v1alpha1.GatewayClassConfig
 	err = fakeClient.Get(context.Background(), types.NamespacedName{Name: "test-config"}, &updatedConfig)
@@ -247,6 +257,37 @@ func TestGatewayClassConfigReconciler_Reconcile_MissingCredentialsSecret(t *test
 	assert.Equal(t, "SecretsMissing", secretsCondition.Reason)
 }
 
+func TestGatewayClassConfigReconciler_Reconcile_ConfiguredRequeueDelay(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	config := &v1alpha1.GatewayClassConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-config", Generation: 1},
+		Spec: v1alpha1.GatewayClassConfigSpec{
+			TunnelID: "11111111-2222-4333-8444-555555555555",
+			CloudflareCredentialsSecretRef: v1alpha1.SecretReference{
+				Name:      "non-existent-secret",
+				Namespace: "default",
+			},
+		},
+	}
+
+	r := &GatewayClassConfigReconciler{
+		Client:                 fake.NewClientBuilder().WithScheme(scheme).WithObjects(config).WithStatusSubresource(config).Build(),
+		Scheme:                 scheme,
+		DefaultNamespace:       "default",
+		ValidationRequeueDelay: 30 * time.Second,
+	}
+
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "test-config"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, result.RequeueAfter)
+}
+
 func TestGatewayClassConfigReconciler_Reconcile_MissingAPITokenKey(t *testing.T) {
 	t.Parallel()
 
@@ -271,7 +312,7 @@ func TestGatewayClassConfigReconciler_Reconcile_MissingAPITokenKey(t *testing.T)
 			Generation: 1,
 		},
 		Spec: v1alpha1.GatewayClassConfigSpec{
-			TunnelID: "test-tunnel-id",
+			TunnelID: "11111111-2222-4333-8444-555555555555",
 			CloudflareCredentialsSecretRef: v1alpha1.SecretReference{
 				Name:      "cf-credentials",
 				Namespace: "default",
@@ -487,7 +528,7 @@ func TestGatewayClassConfigReconciler_BuildValidCondition(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			condition := r.buildValidCondition(tt.errors, 1, now)
+			condition := r.buildValidCondition(validReasonInvalid, tt.errors, 1, now)
 
 			assert.Equal(t, ConditionTypeValid, condition.Type)
 			assert.Equal(t, tt.expectedStatus, condition.Status)
@@ -507,7 +548,7 @@ func TestGatewayClassConfigReconciler_BuildValidCondition_LongMessage(t *testing
 	// Create a very long error message using strings.Repeat
 	longError := strings.Repeat("x", 300)
 
-	condition := r.buildValidCondition([]string{longError}, 1, now)
+	condition := r.buildValidCondition(validReasonInvalid, []string{longError}, 1, now)
 
 	assert.LessOrEqual(t, len(condition.Message), maxConditionMessageLength)
 	assert.NotEmpty(t, condition.Message)
@@ -546,7 +587,7 @@ func TestGatewayClassConfigReconciler_DefaultNamespace(t *testing.T) {
 			Generation: 1,
 		},
 		Spec: v1alpha1.GatewayClassConfigSpec{
-			TunnelID: "test-tunnel-id",
+			TunnelID: "11111111-2222-4333-8444-555555555555",
 			CloudflareCredentialsSecretRef: v1alpha1.SecretReference{
 				Name: "cf-credentials",
 				// No namespace specified
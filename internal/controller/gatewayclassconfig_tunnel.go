@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+// tunnelRefError describes what is wrong with the spec's tunnel reference:
+// exactly one of tunnelID and tunnelName must be set, and a tunnelID must be a
+// UUID in the canonical lowercase form the CRD pattern requires. Empty means
+// the reference is well formed.
+func tunnelRefError(spec *v1alpha1.GatewayClassConfigSpec) string {
+	if (spec.TunnelID == "") == (spec.TunnelName == "") {
+		return "exactly one of tunnelID and tunnelName must be set"
+	}
+
+	if spec.TunnelID != "" && !validTunnelID(spec.TunnelID) {
+		return fmt.Sprintf("tunnelID %q is not a lowercase Cloudflare tunnel UUID", spec.TunnelID)
+	}
+
+	return ""
+}
+
+// validTunnelID reports whether id is a UUID in the canonical lowercase form.
+// uuid.Parse alone also accepts braced, URN and upper-case forms the
+// Cloudflare API does not.
+func validTunnelID(id string) bool {
+	parsed, err := uuid.Parse(id)
+
+	return err == nil && parsed.String() == id
+}
+
+// checkTunnelExists reads the config's tunnel through the Cloudflare API when
+// the live check is enabled, and returns a validation error when the API
+// reports the tunnel missing or deleted, or no tunnel carries the tunnelName.
+// Any other failure (rejected token, unreachable API) says nothing about the
+// spec: it is logged and the config stays valid, and the Cloudflare readiness
+// check reports it instead.
+func (r *GatewayClassConfigReconciler) checkTunnelExists(ctx context.Context, cfg *v1alpha1.GatewayClassConfig) string {
+	if r.tunnelProber == nil || r.ConfigResolver == nil {
+		return ""
+	}
+
+	logger := log.FromContext(ctx)
+
+	tunnel := "tunnel " + cfg.Spec.TunnelID
+	if cfg.Spec.TunnelName != "" {
+		tunnel = fmt.Sprintf("tunnel named %q", cfg.Spec.TunnelName)
+	}
+
+	resolved, err := r.ConfigResolver.ResolveFromConfig(ctx, cfg)
+	if err == nil {
+		err = r.tunnelProber.ProbeTunnel(ctx, resolved)
+	}
+
+	if err == nil {
+		return ""
+	}
+
+	if !isTunnelNotFound(err) {
+		logger.Error(err, "tunnel existence check failed", "tunnel", tunnel)
+
+		return ""
+	}
+
+	return tunnel + " was not found in the Cloudflare account"
+}
+
+// isTunnelNotFound reports whether a probe failed because the tunnel does not
+// exist: the API answered 404, returned it marked deleted, or listed no
+// tunnel with the configured name.
+func isTunnelNotFound(err error) bool {
+	if errors.Is(err, errTunnelDeleted) || errors.Is(err, config.ErrTunnelNotFound) {
+		return true
+	}
+
+	var apiErr *cloudflare.Error
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
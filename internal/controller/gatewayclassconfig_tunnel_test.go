@@ -0,0 +1,233 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+const validationTestTunnelID = "11111111-2222-4333-8444-555555555555"
+
+// newTunnelValidationReconciler returns a reconciler for one config with the
+// given tunnelID and a resolvable credentials Secret. A nil prober keeps the
+// live check off.
+func newTunnelValidationReconciler(t *testing.T, tunnelID string, prober tunnelProber) (*GatewayClassConfigReconciler, client.Client) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cf-credentials", Namespace: "default"},
+		Data:       map[string][]byte{"api-token": []byte("test-token")},
+	}
+
+	gatewayClassConfig := &v1alpha1.GatewayClassConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-config", Generation: 1},
+		Spec: v1alpha1.GatewayClassConfigSpec{
+			TunnelID:                       tunnelID,
+			AccountID:                      "0123456789abcdef0123456789abcdef",
+			CloudflareCredentialsSecretRef: v1alpha1.SecretReference{Name: "cf-credentials", Namespace: "default"},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(gatewayClassConfig, secret).
+		WithStatusSubresource(gatewayClassConfig).
+		Build()
+
+	return &GatewayClassConfigReconciler{
+		Client:           fakeClient,
+		Scheme:           scheme,
+		DefaultNamespace: "default",
+		ConfigResolver:   config.NewResolver(fakeClient, "default", cfmetrics.NewNoopCollector()),
+		tunnelProber:     prober,
+	}, fakeClient
+}
+
+// reconcileValidCondition reconciles test-config and returns its Valid condition.
+func reconcileValidCondition(t *testing.T, r *GatewayClassConfigReconciler, cli client.Client) *metav1.Condition {
+	t.Helper()
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "test-config"}})
+	require.NoError(t, err)
+
+	var updated v1alpha1.GatewayClassConfig
+	require.NoError(t, cli.Get(context.Background(), types.NamespacedName{Name: "test-config"}, &updated))
+
+	valid := meta.FindStatusCondition(updated.Status.Conditions, ConditionTypeValid)
+	require.NotNil(t, valid)
+
+	return valid
+}
+
+// cloudflareStatusError is the error the Cloudflare SDK returns for an API
+// response with status.
+func cloudflareStatusError(status int) error {
+	return &cloudflare.Error{
+		StatusCode: status,
+		Request:    httptest.NewRequest(http.MethodGet, "https://api.cloudflare.com/client/v4/accounts/a/cfd_tunnel/t", nil),
+		Response:   &http.Response{StatusCode: status},
+	}
+}
+
+// TestGatewayClassConfigReconciler_Reconcile_InvalidTunnelID pins that a
+// tunnelID outside the canonical UUID form is Valid=False/InvalidTunnelID even
+// with resolvable credentials, and that the live check is not attempted.
+func TestGatewayClassConfigReconciler_Reconcile_InvalidTunnelID(t *testing.T) {
+	t.Parallel()
+
+	for _, tunnelID := range []string{
+		"not-a-uuid",
+		"11111111-2222-4333-8444-55555555555",
+		"11111111-2222-4333-8444-55555555555G",
+		"11111111-2222-4333-8444-555555555555X",
+		"11111111222243338444555555555555",
+		"11111111-2222-4333-8444-AAAAAAAAAAAA",
+		"{11111111-2222-4333-8444-555555555555}",
+	} {
+		t.Run(tunnelID, func(t *testing.T) {
+			t.Parallel()
+
+			prober := &fakeTunnelProber{}
+			r, cli := newTunnelValidationReconciler(t, tunnelID, prober)
+
+			valid := reconcileValidCondition(t, r, cli)
+
+			assert.Equal(t, metav1.ConditionFalse, valid.Status)
+			assert.Equal(t, validReasonInvalidTunnelID, valid.Reason)
+			assert.Contains(t, valid.Message, "is not a lowercase Cloudflare tunnel UUID")
+			assert.Empty(t, prober.tunnels, "a malformed tunnelID must not reach the API")
+		})
+	}
+}
+
+// TestGatewayClassConfigReconciler_Reconcile_TunnelExistence pins the optional
+// live check: a tunnel the API reports missing or deleted is
+// Valid=False/TunnelNotFound, while an API failure that says nothing about the
+// tunnel, or a disabled check, leaves the config valid.
+func TestGatewayClassConfigReconciler_Reconcile_TunnelExistence(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		prober     *fakeTunnelProber
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:       "exists",
+			prober:     &fakeTunnelProber{},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: "Valid",
+		},
+		{
+			name:       "not_found",
+			prober:     &fakeTunnelProber{err: errors.Wrap(cloudflareStatusError(http.StatusNotFound), "reading tunnel")},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: validReasonTunnelNotFound,
+		},
+		{
+			name:       "deleted",
+			prober:     &fakeTunnelProber{err: errors.Mark(errors.New("tunnel was deleted"), errTunnelDeleted)},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: validReasonTunnelNotFound,
+		},
+		{
+			name:       "api_unavailable",
+			prober:     &fakeTunnelProber{err: cloudflareStatusError(http.StatusServiceUnavailable)},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: "Valid",
+		},
+		{
+			name:       "token_rejected",
+			prober:     &fakeTunnelProber{err: cloudflareStatusError(http.StatusForbidden)},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: "Valid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r, cli := newTunnelValidationReconciler(t, validationTestTunnelID, tt.prober)
+
+			valid := reconcileValidCondition(t, r, cli)
+
+			assert.Equal(t, tt.wantStatus, valid.Status)
+			assert.Equal(t, tt.wantReason, valid.Reason)
+			assert.Equal(t, []string{validationTestTunnelID}, tt.prober.tunnels)
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		t.Parallel()
+
+		r, cli := newTunnelValidationReconciler(t, validationTestTunnelID, nil)
+
+		valid := reconcileValidCondition(t, r, cli)
+
+		assert.Equal(t, metav1.ConditionTrue, valid.Status)
+	})
+}
+
+// TestTunnelRefError pins the spec-level tunnel reference check: exactly one
+// of tunnelID and tunnelName, and a tunnelID in canonical form.
+func TestTunnelRefError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		spec    v1alpha1.GatewayClassConfigSpec
+		wantMsg string
+	}{
+		{name: "id", spec: v1alpha1.GatewayClassConfigSpec{TunnelID: validationTestTunnelID}},
+		{name: "name", spec: v1alpha1.GatewayClassConfigSpec{TunnelName: "production"}},
+		{
+			name:    "both",
+			spec:    v1alpha1.GatewayClassConfigSpec{TunnelID: validationTestTunnelID, TunnelName: "production"},
+			wantMsg: "exactly one of tunnelID and tunnelName must be set",
+		},
+		{name: "neither", wantMsg: "exactly one of tunnelID and tunnelName must be set"},
+		{
+			name:    "malformed_id",
+			spec:    v1alpha1.GatewayClassConfigSpec{TunnelID: "not-a-uuid"},
+			wantMsg: "is not a lowercase Cloudflare tunnel UUID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			msg := tunnelRefError(&tt.spec)
+
+			if tt.wantMsg == "" {
+				assert.Empty(t, msg)
+			} else {
+				assert.Contains(t, msg, tt.wantMsg)
+			}
+		})
+	}
+}
@@ -180,13 +180,16 @@ type GRPCRouteReconciler struct {
 }
 
 func (r *GRPCRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	return reconcileRoute(ctx, req, &gatewayv1.GRPCRoute{}, reconcileRouteParams[*gatewayv1.GRPCRoute]{
-		startupComplete: &r.startupComplete,
-		k8sClient:       r.Client,
-		controllerName:  r.ControllerName,
-		componentName:   "grpcroute",
-		wrapRoute:       func(route *gatewayv1.GRPCRoute) Route { return GRPCRouteWrapper{route} },
-		syncAndUpdate:   r.syncAndUpdateStatus,
+	return instrumentReconcile(ctx, syncerMetrics(r.RouteSyncer), reconcileMetricsGRPCRoute, func() (ctrl.Result, error) {
+		return reconcileRoute(ctx, req, &gatewayv1.GRPCRoute{}, reconcileRouteParams[*gatewayv1.GRPCRoute]{
+			startupComplete:     &r.startupComplete,
+			startupPendingDelay: r.RouteSyncer.startupPendingDelay(),
+			k8sClient:           r.Client,
+			controllerName:      r.ControllerName,
+			componentName:       "grpcroute",
+			wrapRoute:           func(route *gatewayv1.GRPCRoute) Route { return GRPCRouteWrapper{route} },
+			syncAndUpdate:       r.syncAndUpdateStatus,
+		})
 	})
 }
 
@@ -225,6 +228,9 @@ func (r *GRPCRouteReconciler) updateRouteStatus(
 	syncErr error,
 ) error {
 	emitDiagnosticEvents(r.Recorder, route, diagnostics)
+	emitListenerMismatchEvents(r.Recorder, route, bindingInfo)
+	emitForceAttachEvents(r.Recorder, route, bindingInfo)
+	emitSyncOutcomeEvents(r.Recorder, route, bindingInfo, failedRefs, diagnostics, syncErr)
 
 	params := &routeStatusUpdateParams{
 		k8sClient:            r.Client,
@@ -291,6 +297,7 @@ func (r *GRPCRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		getAllRelevantRoutes:         r.getAllRelevantRoutes,
 		watchBackendTLS:              true,
 		watchNamespaceLabels:         r.RouteSyncer.HostnameOwnership != nil,
+		watchRouteAnnotations:        r.RouteSyncer.RouteOptInAnnotation != "" || r.RouteSyncer.AllowForceAttach,
 	})
 }
 
@@ -328,7 +335,9 @@ func (r *GRPCRouteReconciler) Start(ctx context.Context) error {
 
 	ctx = logging.WithLogger(ctx, logger)
 
-	runStartupSync(ctx, logger, startupSyncRetryInterval,
+	// Retries use the reconcile path's API-error delay, so transient API
+	// failures are retried at the same rate on both paths.
+	runStartupSync(ctx, logger, r.RouteSyncer.apiErrorDelay(),
 		func() { r.startupComplete.Store(true) },
 		startupSyncAttempt(r.syncParams()),
 	)
@@ -14,13 +14,21 @@ import (
 	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/proxy"
 )
 
+// DirectEndpointsAnnotation is the Service annotation that, set to "true",
+// makes the proxy dial the Service's ready endpoints directly, as it does for
+// a headless Service, instead of its ClusterIP. It skips the kube-proxy hop
+// for latency-sensitive backends; the proxy then balances across the pods
+// itself and follows endpoint changes on the next reconcile.
+const DirectEndpointsAnnotation = "cf.k8s.lex.la/direct-endpoints"
+
 // expandHeadlessBackends replaces the Service-FQDN backend of every headless
 // Service (spec.clusterIP: None) with one backend per ready endpoint, dialing the
 // endpoint targetPort instead of the Service port. A headless Service has no VIP,
 // so its FQDN resolves straight to the pod IPs and dialing the Service port
 // (8080) reaches a pod listening on the targetPort (3000) and fails (502). This
 // pass resolves the EndpointSlices and hands the addresses to the proxy's
-// weighted multi-backend selection.
+// weighted multi-backend selection. A ClusterIP Service carrying
+// DirectEndpointsAnnotation is expanded the same way.
 //
 // It runs after the 500 invalid-ref marking and before the 503 zero-endpoint
 // marking: a headless Service with ready endpoints is expanded (so its FQDN host
@@ -51,9 +59,10 @@ func expandHeadlessBackends(
 }
 
 // expandBackendIfHeadless expands the backend when the named Service is headless
-// (clusterIP: None) and has at least one ready endpoint. A normal Service keeps
-// routing through its VIP; an ExternalName Service is never expanded; a headless
-// Service with no ready endpoints is left for the 503 pass.
+// (clusterIP: None) or opted in with DirectEndpointsAnnotation, and has at
+// least one ready endpoint. Any other Service keeps routing through its VIP; an
+// ExternalName Service is never expanded; a Service with no ready endpoints is
+// left for the 503 pass.
 func expandBackendIfHeadless(
 	ctx context.Context,
 	cli client.Client,
@@ -68,7 +77,7 @@ func expandBackendIfHeadless(
 		return
 	}
 
-	if svc.Spec.Type == corev1.ServiceTypeExternalName || svc.Spec.ClusterIP != corev1.ClusterIPNone {
+	if !dialsEndpointsDirectly(&svc) {
 		return
 	}
 
@@ -91,6 +100,17 @@ func expandBackendIfHeadless(
 	// No ready endpoints at all: leave the FQDN backend for the zero-endpoint pass.
 }
 
+// dialsEndpointsDirectly reports whether the proxy dials svc's endpoints
+// rather than its FQDN: a headless Service has no VIP to dial, and
+// DirectEndpointsAnnotation opts a ClusterIP Service in.
+func dialsEndpointsDirectly(svc *corev1.Service) bool {
+	if svc.Spec.Type == corev1.ServiceTypeExternalName {
+		return false
+	}
+
+	return svc.Spec.ClusterIP == corev1.ClusterIPNone || svc.Annotations[DirectEndpointsAnnotation] == "true"
+}
+
 // resolveHeadlessEndpoints lists the Service's EndpointSlices and returns one
 // ResolvedEndpoint per ready endpoint address, dialing the endpoint port that
 // corresponds (by port name) to the Service port the backendRef selected. The
@@ -143,6 +143,78 @@ func TestExpandHeadlessBackends_NonHeadlessUnchanged(t *testing.T) {
 		backendURLsCtrl(cfg.Rules[0].Backends), "a ClusterIP Service must keep routing through its VIP")
 }
 
+// directEndpointsSvc builds a ClusterIP Service opted into direct endpoint
+// dialing with DirectEndpointsAnnotation.
+func directEndpointsSvc(name, namespace string, ports ...corev1.ServicePort) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: map[string]string{DirectEndpointsAnnotation: "true"},
+		},
+		Spec: corev1.ServiceSpec{
+			Type:      corev1.ServiceTypeClusterIP,
+			ClusterIP: "10.0.0.1",
+			Ports:     ports,
+		},
+	}
+}
+
+// TestExpandHeadlessBackends_DirectEndpointsAnnotation proves a ClusterIP
+// Service opted in with DirectEndpointsAnnotation is expanded like a headless
+// one: the proxy dials each ready pod IP at the targetPort, not the VIP.
+func TestExpandHeadlessBackends_DirectEndpointsAnnotation(t *testing.T) {
+	t.Parallel()
+
+	cli := fake.NewClientBuilder().WithScheme(zeScheme(t)).WithObjects(
+		directEndpointsSvc("app", "default", svcPort("first-port", 8080, 3000)),
+		headlessSlice("app-ip4", "default", "app", discoveryv1.AddressTypeIPv4,
+			[]discoveryv1.EndpointPort{epPort("first-port", 3000)},
+			[]epAddr{{"10.1.0.1", true}, {"10.1.0.2", true}, {"10.1.0.3", false}}),
+	).Build()
+
+	cfg := &proxy.Config{Rules: []proxy.RouteRule{{Backends: []proxy.BackendRef{
+		{URL: "http://app.default.svc.cluster.local:8080", Weight: 1},
+	}}}}
+
+	routes := []*gatewayv1.HTTPRoute{httpRouteToSvc("r", "default", "app", 8080)}
+
+	expandHeadlessBackends(context.Background(), cli, cfg, "cluster.local", routes, nil)
+
+	assert.ElementsMatch(t, []string{
+		"http://10.1.0.1:3000",
+		"http://10.1.0.2:3000",
+	}, backendURLsCtrl(cfg.Rules[0].Backends), "an opted-in Service dials each ready endpoint directly")
+}
+
+// TestExpandHeadlessBackends_DirectEndpointsNoReadyEndpoints proves an
+// opted-in Service with no ready endpoints keeps its FQDN backend, which the
+// zero-endpoint pass then marks 503, rather than losing its traffic fraction.
+func TestExpandHeadlessBackends_DirectEndpointsNoReadyEndpoints(t *testing.T) {
+	t.Parallel()
+
+	cli := fake.NewClientBuilder().WithScheme(zeScheme(t)).WithObjects(
+		directEndpointsSvc("app", "default", svcPort("first-port", 8080, 3000)),
+		headlessSlice("app-ip4", "default", "app", discoveryv1.AddressTypeIPv4,
+			[]discoveryv1.EndpointPort{epPort("first-port", 3000)},
+			[]epAddr{{"10.1.0.1", false}}),
+	).Build()
+
+	cfg := &proxy.Config{Rules: []proxy.RouteRule{{Backends: []proxy.BackendRef{
+		{URL: "http://app.default.svc.cluster.local:8080", Weight: 1},
+	}}}}
+
+	routes := []*gatewayv1.HTTPRoute{httpRouteToSvc("r", "default", "app", 8080)}
+
+	expandHeadlessBackends(context.Background(), cli, cfg, "cluster.local", routes, nil)
+	markZeroEndpointBackends(context.Background(), cli, cfg, "cluster.local", routes, nil)
+
+	require.Len(t, cfg.Rules[0].Backends, 1)
+	assert.Equal(t, "http://app.default.svc.cluster.local:8080", cfg.Rules[0].Backends[0].URL)
+	assert.Equal(t, http.StatusServiceUnavailable, cfg.Rules[0].Backends[0].UnavailableStatus,
+		"no ready endpoints → 503 for the backend's traffic fraction")
+}
+
 // TestExpandHeadlessBackends_ExternalNameUnchanged proves an ExternalName Service
 // is never expanded (it has no ClusterIP and no EndpointSlices).
 func TestExpandHeadlessBackends_ExternalNameUnchanged(t *testing.T) {
@@ -20,10 +20,12 @@ import (
 )
 
 const (
-	// apiErrorRequeueDelay is the delay before retrying when Cloudflare API calls fail.
+	// apiErrorRequeueDelay is the default delay before retrying when Cloudflare
+	// API calls fail (--api-error-requeue-delay).
 	apiErrorRequeueDelay = 15 * time.Second
 
-	// startupPendingRequeueDelay is the delay before retrying when startup sync is not yet complete.
+	// startupPendingRequeueDelay is the default delay before retrying when
+	// startup sync is not yet complete (--startup-pending-requeue-delay).
 	startupPendingRequeueDelay = 1 * time.Second
 
 	// maxIngressRules is the maximum number of ingress rules allowed per Cloudflare Tunnel.
@@ -98,13 +100,16 @@ type HTTPRouteReconciler struct {
 }
 
 func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	return reconcileRoute(ctx, req, &gatewayv1.HTTPRoute{}, reconcileRouteParams[*gatewayv1.HTTPRoute]{
-		startupComplete: &r.startupComplete,
-		k8sClient:       r.Client,
-		controllerName:  r.ControllerName,
-		componentName:   "httproute",
-		wrapRoute:       func(route *gatewayv1.HTTPRoute) Route { return HTTPRouteWrapper{route} },
-		syncAndUpdate:   r.syncAndUpdateStatus,
+	return instrumentReconcile(ctx, syncerMetrics(r.RouteSyncer), reconcileMetricsHTTPRoute, func() (ctrl.Result, error) {
+		return reconcileRoute(ctx, req, &gatewayv1.HTTPRoute{}, reconcileRouteParams[*gatewayv1.HTTPRoute]{
+			startupComplete:     &r.startupComplete,
+			startupPendingDelay: r.RouteSyncer.startupPendingDelay(),
+			k8sClient:           r.Client,
+			controllerName:      r.ControllerName,
+			componentName:       "httproute",
+			wrapRoute:           func(route *gatewayv1.HTTPRoute) Route { return HTTPRouteWrapper{route} },
+			syncAndUpdate:       r.syncAndUpdateStatus,
+		})
 	})
 }
 
@@ -158,6 +163,9 @@ func (r *HTTPRouteReconciler) updateRouteStatus(
 	syncErr error,
 ) error {
 	emitDiagnosticEvents(r.Recorder, route, diagnostics)
+	emitListenerMismatchEvents(r.Recorder, route, bindingInfo)
+	emitForceAttachEvents(r.Recorder, route, bindingInfo)
+	emitSyncOutcomeEvents(r.Recorder, route, bindingInfo, failedRefs, diagnostics, syncErr)
 
 	return updateRouteStatusGeneric(
 		ctx,
@@ -193,6 +201,7 @@ func (r *HTTPRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		findRoutesForExternalBackend: r.findRoutesForExternalBackend,
 		watchBackendTLS:              true,
 		watchNamespaceLabels:         r.RouteSyncer.HostnameOwnership != nil,
+		watchRouteAnnotations:        r.RouteSyncer.RouteOptInAnnotation != "" || r.RouteSyncer.AllowForceAttach,
 		getAllRelevantRoutes:         r.getAllRelevantRoutes,
 	})
 }
@@ -235,7 +244,9 @@ func (r *HTTPRouteReconciler) Start(ctx context.Context) error {
 
 	ctx = logging.WithLogger(ctx, logger)
 
-	runStartupSync(ctx, logger, startupSyncRetryInterval,
+	// Retries use the reconcile path's API-error delay, so transient API
+	// failures are retried at the same rate on both paths.
+	runStartupSync(ctx, logger, r.RouteSyncer.apiErrorDelay(),
 		func() { r.startupComplete.Store(true) },
 		startupSyncAttempt(r.syncParams()),
 	)
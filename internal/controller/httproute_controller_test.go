@@ -4,6 +4,7 @@ import (
 	"context"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -94,6 +95,47 @@ func TestHTTPRouteReconciler_Reconcile_WaitForStartup(t *testing.T) {
 	assert.Equal(t, startupPendingRequeueDelay, result.RequeueAfter)
 }
 
+// TestHTTPRouteReconciler_Reconcile_ConfiguredRequeueDelays pins that the
+// --api-error-requeue-delay and --startup-pending-requeue-delay values set on
+// the route syncer replace the built-in delays.
+func TestHTTPRouteReconciler_Reconcile_ConfiguredRequeueDelays(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		Build()
+
+	configResolver := config.NewResolver(fakeClient, "default", cfmetrics.NewNoopCollector())
+	routeSyncer := NewRouteSyncer(fakeClient, scheme, "cluster.local", "test-controller", configResolver, cfmetrics.NewNoopCollector(), nil)
+	routeSyncer.APIErrorRequeueDelay = 42 * time.Second
+	routeSyncer.StartupPendingRequeueDelay = 7 * time.Second
+
+	r := &HTTPRouteReconciler{
+		Client:         fakeClient,
+		Scheme:         scheme,
+		ControllerName: "test-controller",
+		RouteSyncer:    routeSyncer,
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "test-route", Namespace: "default"}}
+
+	result, err := r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 7*time.Second, result.RequeueAfter, "startup still pending")
+
+	r.startupComplete.Store(true)
+
+	// No GatewayClass exists, so the sync fails and requeues.
+	result, err = r.Reconcile(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 42*time.Second, result.RequeueAfter, "failed sync")
+}
+
 func TestHTTPRouteReconciler_Reconcile_WrongGatewayClass(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+)
+
+// LeaderReporter exports whether this replica is the active one. The manager
+// starts it only once the leader-election lease is acquired (at once without
+// --leader-elect), so Start marks the replica leader and clears the mark when
+// the manager stops it. A standby replica reports 0 from construction on.
+type LeaderReporter struct {
+	Pod     string
+	Metrics cfmetrics.Collector
+	Logger  *slog.Logger
+}
+
+// NewLeaderReporter creates a LeaderReporter for pod and records it as not
+// leading until the manager starts it.
+func NewLeaderReporter(pod string, metrics cfmetrics.Collector, logger *slog.Logger) *LeaderReporter {
+	metrics.RecordLeader(context.Background(), pod, false)
+
+	return &LeaderReporter{
+		Pod:     pod,
+		Metrics: metrics,
+		Logger:  logger.With("component", "leader-election"),
+	}
+}
+
+// NeedLeaderElection makes the manager start the reporter on the leader only.
+func (r *LeaderReporter) NeedLeaderElection() bool {
+	return true
+}
+
+// Start records the replica as leader until ctx is done.
+func (r *LeaderReporter) Start(ctx context.Context) error {
+	r.Metrics.RecordLeader(ctx, r.Pod, true)
+	r.Logger.Info("acquired leadership", "pod", r.Pod)
+
+	<-ctx.Done()
+
+	r.Metrics.RecordLeader(context.WithoutCancel(ctx), r.Pod, false)
+	r.Logger.Info("gave up leadership", "pod", r.Pod)
+
+	return nil
+}
+
+// podName identifies this replica: POD_NAME when the downward API sets it,
+// otherwise the hostname, which Kubernetes sets to the pod name.
+func podName() string {
+	if name := os.Getenv("POD_NAME"); name != "" {
+		return name
+	}
+
+	name, err := os.Hostname()
+	if err != nil {
+		return "unknown"
+	}
+
+	return name
+}
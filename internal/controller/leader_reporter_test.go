@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/logging"
+)
+
+// leaderRecorder records the last leader value per pod.
+type leaderRecorder struct {
+	cfmetrics.NoopCollector
+
+	mu     sync.Mutex
+	leader map[string]bool
+}
+
+func (r *leaderRecorder) RecordLeader(_ context.Context, pod string, leader bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.leader[pod] = leader
+}
+
+func (r *leaderRecorder) value(pod string) (bool, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	leader, ok := r.leader[pod]
+
+	return leader, ok
+}
+
+// TestLeaderReporter pins the leader gauge wiring: a replica reports 0 until
+// the manager starts the reporter on acquiring the lease, 1 while it leads,
+// and 0 again once it stops, with a log line at each transition.
+func TestLeaderReporter(t *testing.T) {
+	t.Parallel()
+
+	recorder := &leaderRecorder{leader: map[string]bool{}}
+	logger, buf := logging.TestLogger(t)
+
+	reporter := NewLeaderReporter("controller-0", recorder, logger)
+	require.True(t, reporter.NeedLeaderElection(), "only the leader may report itself as leader")
+
+	leader, ok := recorder.value("controller-0")
+	require.True(t, ok, "a standby replica must export 0, not nothing")
+	assert.False(t, leader)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() { done <- reporter.Start(ctx) }()
+
+	assert.Eventually(t, func() bool {
+		leader, _ := recorder.value("controller-0")
+
+		return leader
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+
+	leader, _ = recorder.value("controller-0")
+	assert.False(t, leader)
+
+	logs := buf.String()
+	assert.Contains(t, logs, "acquired leadership")
+	assert.Contains(t, logs, "gave up leadership")
+	assert.Contains(t, logs, `"pod":"controller-0"`)
+}
@@ -0,0 +1,173 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/routebinding"
+)
+
+// TestEmitListenerMismatchEvents pins which binding rejections raise the
+// NoMatchingListener Warning: only those where the route reached a managed
+// Gateway and no listener took it, not later policy rejections.
+func TestEmitListenerMismatchEvents(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		result    routebinding.BindingResult
+		wantEvent bool
+	}{
+		{
+			name:      "hostname mismatch",
+			result:    routebinding.BindingResult{Reason: gatewayv1.RouteReasonNoMatchingListenerHostname, Message: "No listener hostname matches route hostnames"},
+			wantEvent: true,
+		},
+		{
+			name:      "not allowed by listeners",
+			result:    routebinding.BindingResult{Reason: gatewayv1.RouteReasonNotAllowedByListeners, Message: "Route not allowed by listener allowedRoutes policy"},
+			wantEvent: true,
+		},
+		{
+			name:      "no matching section",
+			result:    routebinding.BindingResult{Reason: gatewayv1.RouteReasonNoMatchingParent, Message: "No matching listener found"},
+			wantEvent: true,
+		},
+		{
+			name:   "accepted",
+			result: routebinding.BindingResult{Accepted: true, Reason: gatewayv1.RouteReasonAccepted},
+		},
+		{
+			name:   "conflicted is a policy rejection",
+			result: routebinding.BindingResult{Reason: routeReasonConflicted, Message: crossTypeConflictMessage},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			route := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+			bindingInfo := routeBindingInfo{
+				bindingResults: map[int]routebinding.BindingResult{0: tt.result},
+				parentGateways: map[int]string{0: "infra/edge"},
+			}
+
+			rec := events.NewFakeRecorder(5)
+			emitListenerMismatchEvents(rec, route, bindingInfo)
+
+			got := drainEvents(rec)
+			if !tt.wantEvent {
+				assert.Empty(t, got)
+
+				return
+			}
+
+			require.Len(t, got, 1)
+			assert.Contains(t, got[0], corev1.EventTypeWarning+" "+eventReasonNoMatchingListener)
+			assert.Contains(t, got[0], "infra/edge", "event must name the Gateway the route reached")
+			assert.Contains(t, got[0], string(tt.result.Reason))
+			assert.Contains(t, got[0], tt.result.Message)
+		})
+	}
+
+	assert.NotPanics(t, func() {
+		emitListenerMismatchEvents(nil, &gatewayv1.HTTPRoute{}, routeBindingInfo{})
+	})
+}
+
+// TestHTTPRouteReconciler_ListenerMismatchEventAndStatus binds a route that
+// names a managed Gateway but whose hostname no listener accepts, and pins
+// both surfaces: Accepted=False/NoMatchingListenerHostname on the route and a
+// NoMatchingListener Warning Event.
+func TestHTTPRouteReconciler_ListenerMismatchEventAndStatus(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	gatewayClass := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "cloudflare-tunnel"},
+		Spec:       gatewayv1.GatewayClassSpec{ControllerName: "test-controller"},
+	}
+
+	listenerHostname := gatewayv1.Hostname("*.example.com")
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "edge", Namespace: "default"},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "cloudflare-tunnel",
+			Listeners: []gatewayv1.Listener{{
+				Name:     "http",
+				Port:     80,
+				Protocol: gatewayv1.HTTPProtocolType,
+				Hostname: &listenerHostname,
+			}},
+		},
+	}
+
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "edge"}},
+			},
+			Hostnames: []gatewayv1.Hostname{"web.other.org"},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(gatewayClass, gateway, route).
+		WithStatusSubresource(route).
+		Build()
+
+	result, err := routebinding.NewValidator(fakeClient).ValidateBinding(ctx, gateway, &routebinding.RouteInfo{
+		Name:      route.Name,
+		Namespace: route.Namespace,
+		Hostnames: route.Spec.Hostnames,
+		Kind:      "HTTPRoute",
+	})
+	require.NoError(t, err)
+	require.False(t, result.Accepted)
+
+	rec := events.NewFakeRecorder(5)
+	reconciler := &HTTPRouteReconciler{
+		Client:         fakeClient,
+		Scheme:         scheme,
+		ControllerName: "test-controller",
+		Recorder:       rec,
+	}
+
+	bindingInfo := routeBindingInfo{
+		bindingResults: map[int]routebinding.BindingResult{0: result},
+		parentGateways: map[int]string{0: "default/edge"},
+	}
+	require.NoError(t, reconciler.updateRouteStatus(ctx, route, bindingInfo, nil, nil, nil))
+
+	var updated gatewayv1.HTTPRoute
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(route), &updated))
+	require.Len(t, updated.Status.Parents, 1)
+
+	accepted := findCondition(updated.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionAccepted))
+	require.NotNil(t, accepted)
+	assert.Equal(t, metav1.ConditionFalse, accepted.Status)
+	assert.Equal(t, string(gatewayv1.RouteReasonNoMatchingListenerHostname), accepted.Reason)
+
+	got := drainEvents(rec)
+	require.Len(t, got, 1)
+	assert.Contains(t, got[0], corev1.EventTypeWarning+" "+eventReasonNoMatchingListener)
+	assert.Contains(t, got[0], "route references Gateway default/edge but no listener accepted it")
+}
@@ -0,0 +1,206 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+// Gateway condition reporting the maintenance schedule set with
+// config.MaintenanceScheduleAnnotation. Present only on annotated Gateways.
+const (
+	gatewayConditionPaused                = "cf.k8s.lex.la/Paused"
+	gatewayReasonMaintenanceWindow        = "MaintenanceWindow"
+	gatewayReasonOutsideMaintenanceWindow = "OutsideMaintenanceWindow"
+	gatewayReasonInvalidMaintenanceWindow = "InvalidMaintenanceWindow"
+)
+
+// maintenanceWindowStatus evaluates the Gateway's maintenance schedule at
+// now. It returns the Paused condition (nil when the Gateway has no schedule,
+// so the condition is removed) and when the condition next flips, zero when
+// it never does.
+func maintenanceWindowStatus(gateway *gatewayv1.Gateway, now time.Time) (*metav1.Condition, time.Duration) {
+	window, err := config.MaintenanceWindowFor(gateway)
+	if window == nil && err == nil {
+		return nil, 0
+	}
+
+	condition := &metav1.Condition{
+		Type:               gatewayConditionPaused,
+		ObservedGeneration: gateway.Generation,
+		LastTransitionTime: metav1.NewTime(now),
+	}
+
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = gatewayReasonInvalidMaintenanceWindow
+		condition.Message = truncateMessage("Maintenance schedule ignored: " + err.Error())
+
+		return condition, 0
+	}
+
+	if end, active := window.ActiveAt(now); active {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = gatewayReasonMaintenanceWindow
+		condition.Message = "Tunnel ingress and proxy route sync paused until " + end.Format(time.RFC3339)
+
+		return condition, end.Sub(now)
+	}
+
+	condition.Status = metav1.ConditionFalse
+	condition.Reason = gatewayReasonOutsideMaintenanceWindow
+
+	next, found := window.NextStart(now)
+	if !found {
+		condition.Message = "The maintenance schedule never starts a window"
+
+		return condition, 0
+	}
+
+	condition.Message = "Next maintenance window starts at " + next.Format(time.RFC3339)
+
+	return condition, next.Sub(now)
+}
+
+// pausedGateways returns, for every Gateway inside a maintenance window, the
+// time its window closes. A malformed schedule is logged and never pauses;
+// the Gateway reports it on its Paused condition.
+func (s *RouteSyncer) pausedGateways(ctx context.Context, logger *slog.Logger, now time.Time) map[string]time.Time {
+	var gateways gatewayv1.GatewayList
+	if err := s.List(ctx, &gateways); err != nil {
+		logger.Error("failed to list gateways for maintenance windows; not pausing", "error", err)
+
+		return nil
+	}
+
+	paused := make(map[string]time.Time)
+
+	for i := range gateways.Items {
+		gateway := &gateways.Items[i]
+
+		window, err := config.MaintenanceWindowFor(gateway)
+		if err != nil {
+			logger.Warn("ignoring invalid maintenance schedule",
+				"gateway", gateway.Namespace+"/"+gateway.Name, "error", err)
+
+			continue
+		}
+
+		if window == nil {
+			continue
+		}
+
+		if end, active := window.ActiveAt(now); active {
+			paused[gateway.Namespace+"/"+gateway.Name] = end
+		}
+	}
+
+	return paused
+}
+
+// routeBindings holds the per-kind route bindings of one sync, keyed by route
+// "namespace/name".
+type routeBindings struct {
+	http, grpc map[string]routeBindingInfo
+}
+
+// markMaintenancePauses pauses every tunnel group serving a Gateway inside a
+// maintenance window. The ingress document is per tunnel, so a paused Gateway
+// holds back the whole document it shares with other Gateways, and the proxy
+// config of every partition on that tunnel (see proxyPausedPartitions). A
+// dedicated partition is served for its own Gateway only; a shared partition
+// for every non-dedicated Gateway its routes are accepted on.
+func markMaintenancePauses(
+	groups []tunnelGroup,
+	paused map[string]time.Time,
+	infra *infraGateways,
+	bindings routeBindings,
+) {
+	if len(paused) == 0 {
+		return
+	}
+
+	for i := range groups {
+		group := &groups[i]
+
+		for _, partition := range group.partitions {
+			for _, gatewayKey := range partitionGatewayKeys(partition, infra, bindings) {
+				end, ok := paused[gatewayKey]
+				if !ok {
+					continue
+				}
+
+				if !slices.Contains(group.pausedBy, gatewayKey) {
+					group.pausedBy = append(group.pausedBy, gatewayKey)
+				}
+
+				if end.After(group.pausedUntil) {
+					group.pausedUntil = end
+				}
+			}
+		}
+
+		slices.Sort(group.pausedBy)
+	}
+}
+
+// proxyPausedPartitions returns the proxy partition keys whose config push is
+// held back by a maintenance window: every partition of a paused tunnel
+// group. Tunnel-override views are served by the shared proxy, so pausing one
+// holds back the shared partition.
+func proxyPausedPartitions(groups []tunnelGroup) map[string]bool {
+	paused := make(map[string]bool)
+
+	for i := range groups {
+		if groups[i].pausedUntil.IsZero() {
+			continue
+		}
+
+		for _, partition := range groups[i].partitions {
+			key := sharedPartitionKey
+			if partition.PerGateway != nil {
+				key = partition.Key
+			}
+
+			paused[key] = true
+		}
+	}
+
+	return paused
+}
+
+// partitionGatewayKeys lists the Gateways a partition's routes are served
+// for.
+func partitionGatewayKeys(partition *routePartition, infra *infraGateways, bindings routeBindings) []string {
+	if partition.Gateway != nil {
+		return []string{partition.Key}
+	}
+
+	var keys []string
+
+	collect := func(binding routeBindingInfo) {
+		for gatewayKey := range binding.acceptedGateways {
+			if infra.isResolved(gatewayKey) || slices.Contains(keys, gatewayKey) {
+				continue
+			}
+
+			keys = append(keys, gatewayKey)
+		}
+	}
+
+	for i := range partition.HTTPRoutes {
+		collect(bindings.http[partition.HTTPRoutes[i].Namespace+"/"+partition.HTTPRoutes[i].Name])
+	}
+
+	for i := range partition.GRPCRoutes {
+		collect(bindings.grpc[partition.GRPCRoutes[i].Namespace+"/"+partition.GRPCRoutes[i].Name])
+	}
+
+	return keys
+}
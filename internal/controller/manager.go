@@ -5,11 +5,15 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/go-logr/logr"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
@@ -24,6 +28,7 @@ import (
 	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
 	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
 	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/hostnameownership"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/ingress"
 	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/render"
 )
 
@@ -115,6 +120,66 @@ type Config struct {
 	// everywhere). Must match the admission policy binding's namespaceSelector.
 	HostnameOwnershipNamespaceSelector string
 
+	// RouteOptIn limits management to routes annotated with
+	// RouteOptInAnnotation set to "true"; other routes are ignored even when
+	// they reference our Gateways. Lets teams migrate routes incrementally.
+	RouteOptIn bool
+
+	// RouteOptInAnnotation is the opt-in annotation key. Empty means
+	// DefaultRouteOptInAnnotation.
+	RouteOptInAnnotation string
+
+	// GatewayDegradedThreshold is the fraction of a Gateway's attached
+	// routes with unresolved backend references above which the Gateway
+	// reports cf.k8s.lex.la/Degraded=True. Zero disables the condition.
+	GatewayDegradedThreshold float64
+
+	// AllowForceAttach lets routes annotated with ForceAttachAnnotation bind
+	// to Gateways whose listeners rejected them. A staging debugging aid.
+	AllowForceAttach bool
+
+	// SyncDebounce collapses the full syncs route reconciles request within
+	// this window into one, so a bulk apply writes the tunnel once. Zero
+	// syncs on every request.
+	SyncDebounce time.Duration
+
+	// APIErrorRequeueDelay is how long a route reconcile waits before retrying
+	// a failed Cloudflare API sync. Zero uses the built-in 15s.
+	APIErrorRequeueDelay time.Duration
+
+	// StartupPendingRequeueDelay is how long a route reconcile that arrives
+	// before the startup sync finished waits to be retried. Zero uses the
+	// built-in 1s.
+	StartupPendingRequeueDelay time.Duration
+
+	// ConfigValidationRequeueDelay is how often each GatewayClassConfig is
+	// re-validated. Zero uses the built-in 5m.
+	ConfigValidationRequeueDelay time.Duration
+
+	// FullResyncInterval is how often the full route sync runs with no route
+	// event behind it, rereading every tunnel document to repair out-of-band
+	// edits. Zero disables the timer.
+	FullResyncInterval time.Duration
+
+	// VerifyTunnelExists makes GatewayClassConfig validation read the tunnel
+	// through the Cloudflare API and report Valid=False/TunnelNotFound when
+	// it is missing. Off, validation makes no network calls.
+	VerifyTunnelExists bool
+
+	// TunnelConnectionsPollInterval is how often the connection count of each
+	// managed tunnel is read from the Cloudflare API and exported as the
+	// cftunnel_tunnel_connections gauge. Zero disables polling.
+	TunnelConnectionsPollInterval time.Duration
+
+	// CloudflareReadinessInterval is how often /readyz verifies, through the
+	// Cloudflare API, that each managed tunnel's credentials are accepted
+	// and the tunnel exists. Zero disables the check.
+	CloudflareReadinessInterval time.Duration
+
+	// CloudflareReadinessFailureThreshold is how many consecutive failed
+	// Cloudflare checks report the controller not ready.
+	CloudflareReadinessFailureThreshold int
+
 	// MonitoringNamespaceSelector (kubectl label-selector syntax; empty =
 	// controller namespace only) additionally admits the per-Gateway proxy's
 	// config-API port — which also serves /metrics — in the rendered
@@ -134,6 +199,14 @@ type Config struct {
 	// release's proxy image; GatewayConfig.spec.image overrides per Gateway.
 	ProxyImage string
 
+	// CommonLabels and CommonAnnotations are stamped on every object the
+	// controller creates (per-Gateway Deployment, Service, HPA,
+	// NetworkPolicy, generated auth Secret) for GitOps and ownership tooling.
+	// Gateway infrastructure metadata and controller-owned keys take
+	// precedence on a shared key.
+	CommonLabels      map[string]string
+	CommonAnnotations map[string]string
+
 	// ProxyTokenSecret identifies the Secret holding the tunnel token used by
 	// the proxy. Format: "<namespace>/<name>". When set, the controller
 	// watches the named Secret and patches the proxy Deployment's pod
@@ -158,6 +231,26 @@ type Config struct {
 	// TracerProvider is installed by the binary entrypoint; this flag only
 	// gates whether those clients wrap their transports with otelhttp.
 	Tracing bool
+
+	// SharedTunnelPolicy decides how GatewayClasses whose different
+	// GatewayClassConfigs name the same tunnel are handled: SharedTunnelPolicyWarn
+	// (default) refuses to sync and flags the classes, SharedTunnelPolicyMerge
+	// writes their routes into one ingress document.
+	SharedTunnelPolicy string
+
+	// DryRun computes and logs the tunnel ingress documents without writing
+	// them to Cloudflare.
+	DryRun bool
+
+	// GRPCCatchAll decides how gRPC calls to a gRPC-only hostname that match
+	// none of its rules are answered: "none" (default, Unimplemented) or
+	// "unavailable" (Unavailable).
+	GRPCCatchAll string
+
+	// CloudflareAPIRateLimit caps outbound Cloudflare API requests per second
+	// across all tunnels. Requests beyond the limit are queued rather than
+	// dropped. Zero (the default) leaves requests unthrottled.
+	CloudflareAPIRateLimit float64
 }
 
 // Run initializes and starts the controller manager with the provided configuration.
@@ -187,6 +280,28 @@ func Run(ctx context.Context, cfg *Config) error {
 		return errors.New("--proxy-endpoints is required: v3 controller cannot run without a configured L7 proxy data plane")
 	}
 
+	if err := ValidateSharedTunnelPolicy(cfg.SharedTunnelPolicy); err != nil {
+		return errors.Wrap(err, "invalid --shared-tunnel-policy")
+	}
+
+	grpcCatchAll, err := ingress.ParseGRPCCatchAll(cfg.GRPCCatchAll)
+	if err != nil {
+		return errors.Wrap(err, "invalid --grpc-catch-all")
+	}
+
+	if cfg.CloudflareAPIRateLimit < 0 {
+		return errors.Newf("invalid --cloudflare-api-rate-limit %v: must not be negative", cfg.CloudflareAPIRateLimit)
+	}
+
+	if cfg.GatewayDegradedThreshold < 0 || cfg.GatewayDegradedThreshold >= 1 {
+		return errors.Newf("invalid --gateway-degraded-threshold %v: must be at least 0 and below 1",
+			cfg.GatewayDegradedThreshold)
+	}
+
+	if err := validateCommonMetadata(cfg.CommonLabels, cfg.CommonAnnotations); err != nil {
+		return err
+	}
+
 	mgrOptions := ctrl.Options{
 		Metrics: server.Options{
 			BindAddress: cfg.MetricsAddr,
@@ -225,12 +340,17 @@ func Run(ctx context.Context, cfg *Config) error {
 	defaultNamespace := getControllerNamespace()
 
 	// Create config resolver. When tracing is on, instrument the Cloudflare
-	// API client so outbound API calls emit client spans.
+	// API client so outbound API calls emit client spans; a rate limit queues
+	// those calls behind one shared limiter.
 	var resolverOpts []config.ResolverOption
 	if cfg.Tracing {
 		resolverOpts = append(resolverOpts, config.WithCloudflareTracing())
 	}
 
+	if cfg.CloudflareAPIRateLimit > 0 {
+		resolverOpts = append(resolverOpts, config.WithCloudflareRateLimit(cfg.CloudflareAPIRateLimit))
+	}
+
 	configResolver := config.NewResolver(mgr.GetClient(), defaultNamespace, metricsCollector, resolverOpts...)
 
 	// Create base logger for component injection
@@ -243,12 +363,14 @@ func Run(ctx context.Context, cfg *Config) error {
 	viewStore := newMergeViewStore()
 
 	gatewayReconciler := &GatewayReconciler{
-		Client:         mgr.GetClient(),
-		Scheme:         mgr.GetScheme(),
-		ControllerName: cfg.ControllerName,
-		ConfigResolver: configResolver,
-		ProxyImage:     cfg.ProxyImage,
-		ViewStore:      viewStore,
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		ControllerName:    cfg.ControllerName,
+		ConfigResolver:    configResolver,
+		ProxyImage:        cfg.ProxyImage,
+		ViewStore:         viewStore,
+		DegradedThreshold: cfg.GatewayDegradedThreshold,
+		Metrics:           metricsCollector,
 	}
 
 	if err := gatewayReconciler.SetupWithManager(mgr); err != nil {
@@ -268,10 +390,14 @@ func Run(ctx context.Context, cfg *Config) error {
 		Scheme:         mgr.GetScheme(),
 		ControllerName: cfg.ControllerName,
 		ConfigResolver: configResolver,
-		Recorder:       mgr.GetEventRecorder("gateway-infra-controller"),
+		Recorder:       newThrottledRecorder(mgr.GetEventRecorder("gateway-infra-controller"), eventThrottleWindow),
 		RenderDefaults: render.Defaults{
 			ProxyImage:     cfg.ProxyImage,
 			TunnelProtocol: cfg.TunnelProtocol,
+			CommonMetadata: render.Metadata{
+				Labels:      cfg.CommonLabels,
+				Annotations: cfg.CommonAnnotations,
+			},
 		},
 		ControllerNamespace:         defaultNamespace,
 		MonitoringNamespaceSelector: monitoringSelector,
@@ -293,6 +419,16 @@ func Run(ctx context.Context, cfg *Config) error {
 		baseLogger,
 	)
 	routeSyncer.ViewStore = viewStore
+	routeSyncer.SharedTunnelPolicy = cfg.SharedTunnelPolicy
+	routeSyncer.GRPCCatchAll = grpcCatchAll
+	routeSyncer.DryRun = cfg.DryRun
+	routeSyncer.SyncDebounce = cfg.SyncDebounce
+	routeSyncer.APIErrorRequeueDelay = cfg.APIErrorRequeueDelay
+	routeSyncer.StartupPendingRequeueDelay = cfg.StartupPendingRequeueDelay
+
+	if cfg.DryRun {
+		logger.Info("dry-run mode enabled; tunnel ingress and proxy config changes are logged but not applied")
+	}
 
 	if cfg.HostnameOwnershipEnforce {
 		ownershipPolicy, ownershipErr := hostnameownership.New(
@@ -310,9 +446,27 @@ func Run(ctx context.Context, cfg *Config) error {
 			"namespaceSelector", cfg.HostnameOwnershipNamespaceSelector)
 	}
 
+	if cfg.RouteOptIn {
+		routeSyncer.RouteOptInAnnotation = cfg.RouteOptInAnnotation
+		if routeSyncer.RouteOptInAnnotation == "" {
+			routeSyncer.RouteOptInAnnotation = DefaultRouteOptInAnnotation
+		}
+
+		logger.Info("route opt-in enabled; only annotated routes are managed",
+			"annotation", routeSyncer.RouteOptInAnnotation)
+	}
+
+	if cfg.AllowForceAttach {
+		routeSyncer.AllowForceAttach = true
+
+		logger.Info("force-attach enabled; annotated routes bind despite listener rejection",
+			"annotation", ForceAttachAnnotation)
+	}
+
 	// Create proxy syncer for L7 proxy config push (mandatory in v3)
 	proxySyncer := initProxySyncer(cfg, proxyEndpoints, mgr.GetClient(), baseLogger, logger)
 	proxySyncer.ViewStore = viewStore
+	proxySyncer.GRPCCatchAll = grpcCatchAll
 
 	httpRouteReconciler := &HTTPRouteReconciler{
 		Client:         mgr.GetClient(),
@@ -321,7 +475,7 @@ func Run(ctx context.Context, cfg *Config) error {
 		RouteSyncer:    routeSyncer,
 		ProxySyncer:    proxySyncer,
 		ProxyEndpoints: proxyEndpoints,
-		Recorder:       mgr.GetEventRecorder("httproute-controller"),
+		Recorder:       newThrottledRecorder(mgr.GetEventRecorder("httproute-controller"), eventThrottleWindow),
 		ViewStore:      viewStore,
 	}
 
@@ -349,7 +503,7 @@ func Run(ctx context.Context, cfg *Config) error {
 		ProxySyncer:    proxySyncer,
 		ProxyEndpoints: proxyEndpoints,
 		TunnelProtocol: cfg.TunnelProtocol,
-		Recorder:       mgr.GetEventRecorder("grpcroute-controller"),
+		Recorder:       newThrottledRecorder(mgr.GetEventRecorder("grpcroute-controller"), eventThrottleWindow),
 		ViewStore:      viewStore,
 	}
 
@@ -357,6 +511,19 @@ func Run(ctx context.Context, cfg *Config) error {
 		return errors.Wrap(err, "failed to setup grpcroute controller")
 	}
 
+	tcpRouteReconciler := &TCPRouteReconciler{
+		Client:         mgr.GetClient(),
+		Scheme:         mgr.GetScheme(),
+		ControllerName: cfg.ControllerName,
+		RouteSyncer:    routeSyncer,
+		Recorder:       newThrottledRecorder(mgr.GetEventRecorder("tcproute-controller"), eventThrottleWindow),
+		ViewStore:      viewStore,
+	}
+
+	if err := tcpRouteReconciler.SetupWithManager(mgr); err != nil {
+		return errors.Wrap(err, "failed to setup tcproute controller")
+	}
+
 	if err := setupListenerSetReconciler(mgr, cfg.ControllerName, viewStore); err != nil {
 		return err
 	}
@@ -366,6 +533,17 @@ func Run(ctx context.Context, cfg *Config) error {
 		Client:           mgr.GetClient(),
 		Scheme:           mgr.GetScheme(),
 		DefaultNamespace: defaultNamespace,
+		Metrics:          metricsCollector,
+		Recorder:         newThrottledRecorder(mgr.GetEventRecorder("gatewayclassconfig-controller"), eventThrottleWindow),
+
+		ValidationRequeueDelay: cfg.ConfigValidationRequeueDelay,
+		ConfigResolver:         configResolver,
+		ControllerName:         cfg.ControllerName,
+		SharedTunnelPolicy:     cfg.SharedTunnelPolicy,
+	}
+
+	if cfg.VerifyTunnelExists {
+		configReconciler.tunnelProber = &cloudflareTunnelProber{resolver: configResolver, metrics: metricsCollector}
 	}
 
 	if err := configReconciler.SetupWithManager(mgr); err != nil {
@@ -380,10 +558,41 @@ func Run(ctx context.Context, cfg *Config) error {
 		return err
 	}
 
-	if err := setupStatusReconcilers(mgr, cfg.ControllerName); err != nil {
+	if err := setupStatusReconcilers(mgr, cfg.ControllerName, cfg.SharedTunnelPolicy); err != nil {
 		return err
 	}
 
+	if err := mgr.Add(NewLeaderReporter(podName(), metricsCollector, baseLogger)); err != nil {
+		return errors.Wrap(err, "failed to add leader reporter")
+	}
+
+	if cfg.SyncDebounce > 0 {
+		if err := mgr.Add(routeSyncer.debounce()); err != nil {
+			return errors.Wrap(err, "failed to add sync debouncer")
+		}
+	}
+
+	if cfg.FullResyncInterval > 0 {
+		resyncer := NewFullResyncer(cfg.FullResyncInterval, routeSyncer, func(ctx context.Context) error {
+			_, err := httpRouteReconciler.syncAndUpdateStatus(ctx)
+
+			return err
+		}, baseLogger)
+
+		if err := mgr.Add(resyncer); err != nil {
+			return errors.Wrap(err, "failed to add full resyncer")
+		}
+	}
+
+	if cfg.TunnelConnectionsPollInterval > 0 {
+		reporter := NewTunnelConnectionReporter(mgr.GetClient(), cfg.ControllerName, configResolver,
+			metricsCollector, cfg.TunnelConnectionsPollInterval, baseLogger)
+
+		if err := mgr.Add(reporter); err != nil {
+			return errors.Wrap(err, "failed to add tunnel connection reporter")
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		return errors.Wrap(err, "failed to set up health check")
 	}
@@ -392,6 +601,24 @@ func Run(ctx context.Context, cfg *Config) error {
 		return errors.Wrap(err, "failed to set up ready check")
 	}
 
+	if cfg.CloudflareReadinessInterval > 0 {
+		if cfg.CloudflareReadinessFailureThreshold < 1 {
+			return errors.Newf("--cloudflare-readiness-failure-threshold must be at least 1, got %d",
+				cfg.CloudflareReadinessFailureThreshold)
+		}
+
+		readiness := NewCloudflareReadinessCheck(mgr.GetClient(), cfg.ControllerName, configResolver,
+			metricsCollector, cfg.CloudflareReadinessInterval, cfg.CloudflareReadinessFailureThreshold, baseLogger)
+
+		if err := mgr.Add(readiness); err != nil {
+			return errors.Wrap(err, "failed to add Cloudflare readiness check")
+		}
+
+		if err := mgr.AddReadyzCheck("cloudflare", readiness.Check); err != nil {
+			return errors.Wrap(err, "failed to set up Cloudflare ready check")
+		}
+	}
+
 	logger.Info("starting manager")
 
 	if err := mgr.Start(ctx); err != nil {
@@ -530,6 +757,23 @@ func parseNamespaceSelector(selectorStr string) (*metav1.LabelSelector, error) {
 	return selector, nil
 }
 
+// validateCommonMetadata rejects --common-labels/--common-annotations entries
+// the apiserver would refuse, so a typo fails at startup rather than on every
+// per-Gateway apply.
+func validateCommonMetadata(labels, annotations map[string]string) error {
+	if errs := metav1validation.ValidateLabels(labels, field.NewPath("labels")); len(errs) > 0 {
+		return errors.Wrap(errs.ToAggregate(), "invalid --common-labels")
+	}
+
+	for key := range annotations {
+		if msgs := k8svalidation.IsQualifiedName(strings.ToLower(key)); len(msgs) > 0 {
+			return errors.Newf("invalid --common-annotations key %q: %s", key, strings.Join(msgs, "; "))
+		}
+	}
+
+	return nil
+}
+
 // getControllerNamespace returns the namespace where the controller is running.
 // It first checks CONTROLLER_NAMESPACE environment variable, then reads from
 // the standard Kubernetes downward API file, falling back to "default".
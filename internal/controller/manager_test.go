@@ -96,6 +96,43 @@ func TestSanitiseProxyEndpoints(t *testing.T) {
 	}
 }
 
+func TestValidateCommonMetadata(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		labels      map[string]string
+		annotations map[string]string
+		wantErr     string
+	}{
+		{name: "empty"},
+		{
+			name:        "valid",
+			labels:      map[string]string{"team": "platform", "argocd.argoproj.io/instance": "edge"},
+			annotations: map[string]string{"example.com/Owner": "free-form value, anything goes"},
+		},
+		{name: "label key", labels: map[string]string{"bad key": "x"}, wantErr: "--common-labels"},
+		{name: "label value", labels: map[string]string{"team": "has spaces"}, wantErr: "--common-labels"},
+		{name: "annotation key", annotations: map[string]string{"/no-name": "x"}, wantErr: "--common-annotations"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateCommonMetadata(tt.labels, tt.annotations)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
 func TestGetControllerNamespace(t *testing.T) {
 	// Cannot use t.Parallel() because t.Setenv() requires sequential execution.
 	tests := []struct {
@@ -701,6 +701,49 @@ func (w GRPCRouteWrapper) ReferencesExternalBackend(namespace, name string) bool
 	return false
 }
 
+// TCPRouteWrapper wraps TCPRoute to implement Route.
+type TCPRouteWrapper struct {
+	*gatewayv1.TCPRoute
+}
+
+// GetCrossNamespaceBackendNamespaces returns namespaces of backends in other namespaces.
+func (w TCPRouteWrapper) GetCrossNamespaceBackendNamespaces() []string {
+	var refs []gatewayv1.BackendRef
+	for _, rule := range w.Spec.Rules {
+		refs = append(refs, rule.BackendRefs...)
+	}
+
+	return extractCrossNamespaceBackends(w.Namespace, refs)
+}
+
+// ReferencesService reports whether this TCPRoute has any Service backendRef
+// matching (namespace, name).
+func (w TCPRouteWrapper) ReferencesService(namespace, name string) bool {
+	for ruleIdx := range w.Spec.Rules {
+		for refIdx := range w.Spec.Rules[ruleIdx].BackendRefs {
+			if backendRefMatchesService(&w.Spec.Rules[ruleIdx].BackendRefs[refIdx], w.Namespace, namespace, name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ReferencesExternalBackend reports whether this TCPRoute has any
+// ExternalBackend backendRef matching (namespace, name).
+func (w TCPRouteWrapper) ReferencesExternalBackend(namespace, name string) bool {
+	for ruleIdx := range w.Spec.Rules {
+		for refIdx := range w.Spec.Rules[ruleIdx].BackendRefs {
+			if backendRefMatchesExternalBackend(&w.Spec.Rules[ruleIdx].BackendRefs[refIdx], w.Namespace, namespace, name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // GetHostnames returns the hostnames from the HTTPRoute spec.
 func (w HTTPRouteWrapper) GetHostnames() []gatewayv1.Hostname {
 	return w.Spec.Hostnames
@@ -731,6 +774,22 @@ func (w GRPCRouteWrapper) GetRouteKind() gatewayv1.Kind {
 	return routebinding.KindGRPCRoute
 }
 
+// GetHostnames returns nil: TCPRoute has no hostnames, so it binds to a
+// listener regardless of the listener's hostname.
+func (w TCPRouteWrapper) GetHostnames() []gatewayv1.Hostname {
+	return nil
+}
+
+// GetParentRefs returns the parent references from the TCPRoute spec.
+func (w TCPRouteWrapper) GetParentRefs() []gatewayv1.ParentReference {
+	return w.Spec.ParentRefs
+}
+
+// GetRouteKind returns the route kind for TCPRoute.
+func (w TCPRouteWrapper) GetRouteKind() gatewayv1.Kind {
+	return routebinding.KindTCPRoute
+}
+
 // FindRoutesForGateway returns reconcile requests for routes that reference the given Gateway.
 // It checks whether the Gateway's GatewayClass is managed by the given controllerName.
 func FindRoutesForGateway(
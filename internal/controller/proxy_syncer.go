@@ -10,6 +10,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -25,6 +26,7 @@ import (
 	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/logging"
 	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/proxy"
 	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/referencegrant"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/routebinding"
 	tracingpkg "github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/tracing"
 )
 
@@ -81,6 +83,11 @@ type ProxySyncer struct {
 	// (issue #332). Set by the manager after construction and shared with the
 	// other reconcilers. nil disables cross-reconcile reuse.
 	ViewStore *mergeViewStore
+
+	// GRPCCatchAll is the --grpc-catch-all mode. In unavailable mode the
+	// pushed config lists the gRPC-only hostnames on which an unmatched call
+	// answers Unavailable (see proxyGRPCUnavailableHostnames).
+	GRPCCatchAll ingress.GRPCCatchAll
 }
 
 // pushTarget is one partition's push state: the cache that lets a resync
@@ -710,6 +717,36 @@ func (s *ProxySyncer) SyncPartition(
 	failedRefs []ingress.BackendRefError,
 	grpcFailedRefs []ingress.BackendRefError,
 ) ([]proxy.RouteDiagnostic, error) {
+	return s.syncPartitionInDomain(ctx, s.clusterDomain, key, authToken, endpoints, routes, grpcRoutes, failedRefs, grpcFailedRefs,
+		nil, ingress.CatchAllTarget{}, proxy.OriginConfig{})
+}
+
+// syncPartitionInDomain is SyncPartition with backend URLs built in
+// clusterDomain instead of the controller default — the route syncer passes
+// the GatewayClassConfig's clusterDomain so the proxy dials the same origins
+// the ingress rules name. An empty clusterDomain means the default.
+// healthCheckHostnames are the synthetic health hostnames this partition's
+// connector must answer (see prependHealthCheckRules); catchAll is the
+// class-level defaultBackend serving unmatched requests (see proxyFallback);
+// originDefaults are the class-level originRequestDefaults.
+func (s *ProxySyncer) syncPartitionInDomain(
+	ctx context.Context,
+	clusterDomain string,
+	key string,
+	authToken string,
+	endpoints []string,
+	routes []*gatewayv1.HTTPRoute,
+	grpcRoutes []*gatewayv1.GRPCRoute,
+	failedRefs []ingress.BackendRefError,
+	grpcFailedRefs []ingress.BackendRefError,
+	healthCheckHostnames []string,
+	catchAll ingress.CatchAllTarget,
+	originDefaults proxy.OriginConfig,
+) ([]proxy.RouteDiagnostic, error) {
+	if clusterDomain == "" {
+		clusterDomain = s.clusterDomain
+	}
+
 	// Resolve headless service DNS names before acquiring the lock
 	// to avoid blocking concurrent reconciles during slow DNS lookups.
 	resolved := resolveEndpoints(ctx, endpoints)
@@ -719,7 +756,8 @@ func (s *ProxySyncer) SyncPartition(
 		logger = s.logger
 	}
 
-	prep := s.preparePush(ctx, key, authToken, endpoints, resolved, routes, grpcRoutes, failedRefs, grpcFailedRefs)
+	prep := s.preparePush(ctx, clusterDomain, key, authToken, endpoints, resolved, routes, grpcRoutes,
+		failedRefs, grpcFailedRefs, healthCheckHostnames, catchAll, originDefaults)
 	if prep.skip {
 		logger.Debug("proxy config unchanged; skipping push",
 			"partition", key, "endpoints", len(resolved), "rules", len(prep.cfg.Rules))
@@ -752,11 +790,14 @@ type preparedPush struct {
 // itself runs lock-free.
 func (s *ProxySyncer) preparePush(
 	ctx context.Context,
-	key, authToken string,
+	clusterDomain, key, authToken string,
 	endpoints, resolved []string,
 	routes []*gatewayv1.HTTPRoute,
 	grpcRoutes []*gatewayv1.GRPCRoute,
 	failedRefs, grpcFailedRefs []ingress.BackendRefError,
+	healthCheckHostnames []string,
+	catchAll ingress.CatchAllTarget,
+	originDefaults proxy.OriginConfig,
 ) preparedPush {
 	s.syncMu.Lock()
 	defer s.syncMu.Unlock()
@@ -773,7 +814,8 @@ func (s *ProxySyncer) preparePush(
 	logger.Info("syncing proxy config",
 		"partition", key, "httpRoutes", len(routes), "grpcRoutes", len(grpcRoutes))
 
-	cfg := s.buildProxyConfig(ctx, routes, grpcRoutes, failedRefs, grpcFailedRefs)
+	cfg := s.buildPartitionConfig(ctx, clusterDomain, routes, grpcRoutes, failedRefs, grpcFailedRefs,
+		healthCheckHostnames, catchAll, originDefaults)
 
 	// Diagnostics are computed by the converter and are valid regardless of
 	// whether the push below succeeds — they describe the route specs, not the
@@ -798,6 +840,62 @@ func (s *ProxySyncer) preparePush(
 	return preparedPush{cfg: cfg, cfgHash: cfgHash, diagnostics: diagnostics, skip: false}
 }
 
+// buildPartitionConfig builds the complete config a partition's proxy serves:
+// the converted routes, the gRPC catch-all hostnames, the synthetic health
+// rules and the catch-all fallback, with the class-level origin defaults filled into every backend.
+// Caller must hold syncMu.
+func (s *ProxySyncer) buildPartitionConfig(
+	ctx context.Context,
+	clusterDomain string,
+	routes []*gatewayv1.HTTPRoute,
+	grpcRoutes []*gatewayv1.GRPCRoute,
+	failedRefs, grpcFailedRefs []ingress.BackendRefError,
+	healthCheckHostnames []string,
+	catchAll ingress.CatchAllTarget,
+	originDefaults proxy.OriginConfig,
+) *proxy.Config {
+	cfg := s.buildProxyConfig(ctx, clusterDomain, routes, grpcRoutes, failedRefs, grpcFailedRefs)
+	cfg.GRPCUnavailableHostnames = proxyGRPCUnavailableHostnames(cfg, s.GRPCCatchAll)
+	prependHealthCheckRules(cfg, healthCheckHostnames)
+	cfg.Fallback = proxyFallback(catchAll, clusterDomain)
+	cfg.ApplyOriginDefaults(originDefaults)
+
+	return cfg
+}
+
+// previewPartition builds the partition's config like a push would, without
+// pushing it or touching the push state, for a sync whose proxy push is held
+// back. It returns the converter diagnostics, which describe the route specs
+// whether or not the config is pushed, and whether the config differs from
+// the one the partition's proxies last received.
+func (s *ProxySyncer) previewPartition(
+	ctx context.Context,
+	clusterDomain, key string,
+	routes []*gatewayv1.HTTPRoute,
+	grpcRoutes []*gatewayv1.GRPCRoute,
+	failedRefs, grpcFailedRefs []ingress.BackendRefError,
+	healthCheckHostnames []string,
+	catchAll ingress.CatchAllTarget,
+	originDefaults proxy.OriginConfig,
+) ([]proxy.RouteDiagnostic, bool) {
+	if clusterDomain == "" {
+		clusterDomain = s.clusterDomain
+	}
+
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	cfg := s.buildPartitionConfig(ctx, clusterDomain, routes, grpcRoutes, failedRefs, grpcFailedRefs,
+		healthCheckHostnames, catchAll, originDefaults)
+
+	changed := true
+	if target, ok := s.targets[key]; ok && target.lastPushedHash != "" {
+		changed = hashProxyConfig(cfg) != target.lastPushedHash
+	}
+
+	return cfg.Diagnostics, changed
+}
+
 // recordPush updates the partition's in-memory push state after a lock-free
 // push. It does NOT create the target on demand: RetainPartitions may have
 // evicted the partition during the push window, and resurrecting a dropped entry
@@ -1043,6 +1141,7 @@ func endpointSetsEqual(previous map[string]struct{}, resolved []string) bool {
 // under the funlen budget.
 func (s *ProxySyncer) buildProxyConfig(
 	ctx context.Context,
+	clusterDomain string,
 	routes []*gatewayv1.HTTPRoute,
 	grpcRoutes []*gatewayv1.GRPCRoute,
 	failedRefs []ingress.BackendRefError,
@@ -1071,13 +1170,13 @@ func (s *ProxySyncer) buildProxyConfig(
 	// Convert to proxy config with cross-namespace validation, backend
 	// protocol resolution (e.g. h2c from Service appProtocol), and
 	// BackendTLSPolicy lookup for the proxy → backend TLS hop.
-	cfg := proxy.ConvertHTTPRoutes(ctx, routes, s.clusterDomain, s.backendValidator, s.protocolResolver, s.tlsResolver, s.gatewayCertResolver)
+	cfg := proxy.ConvertHTTPRoutes(ctx, routes, clusterDomain, s.backendValidator, s.protocolResolver, s.tlsResolver, s.gatewayCertResolver)
 
 	// Mark each invalid backendRef (a nonexistent Service) so the proxy returns
 	// 500 for that backend's traffic fraction instead of dialing a dead address
 	// and surfacing a 502. The backend stays in the weighted pool so the
 	// fraction is preserved per the Gateway API spec.
-	markUnavailableBackends(cfg, s.clusterDomain, failedRefs)
+	markUnavailableBackends(cfg, clusterDomain, failedRefs)
 
 	// Append GRPCRoute rules. gRPC method matching maps onto the same proxy
 	// path matcher; backends are dialed h2c unless a BackendTLSPolicy puts TLS on
@@ -1093,7 +1192,7 @@ func (s *ProxySyncer) buildProxyConfig(
 		// (including hostnames owned by other routes).
 		grpcRoutes = withEffectiveHostnamesGRPC(ctx, s.k8sClient, grpcRoutes, views)
 
-		grpcCfg := proxy.ConvertGRPCRoutes(ctx, grpcRoutes, s.clusterDomain, s.grpcBackendValidator, s.protocolResolver, s.tlsResolver, s.gatewayCertResolver)
+		grpcCfg := proxy.ConvertGRPCRoutes(ctx, grpcRoutes, clusterDomain, s.grpcBackendValidator, s.protocolResolver, s.tlsResolver, s.gatewayCertResolver)
 		cfg.Rules = append(cfg.Rules, grpcCfg.Rules...)
 		// Provenance MUST grow in lockstep with Rules (parallel slices) so the
 		// shadow detection below attributes every flattened rule correctly.
@@ -1103,7 +1202,7 @@ func (s *ProxySyncer) buildProxyConfig(
 		// Mark invalid gRPC backendRefs the same way as HTTP. Matching is by
 		// service host:port across all rules, so no rule-offset bookkeeping is
 		// needed.
-		markUnavailableBackends(cfg, s.clusterDomain, grpcFailedRefs)
+		markUnavailableBackends(cfg, clusterDomain, grpcFailedRefs)
 	}
 
 	// Expand each headless Service (clusterIP: None) into one backend per ready
@@ -1113,13 +1212,13 @@ func (s *ProxySyncer) buildProxyConfig(
 	// dropped/invalid ref is never expanded) and before the 503 marking (so a
 	// headless Service with ready endpoints loses its FQDN host before the 503 pass
 	// looks, while one with no ready endpoints keeps it and gets marked 503).
-	expandHeadlessBackends(ctx, s.k8sClient, cfg, s.clusterDomain, routes, grpcRoutes)
+	expandHeadlessBackends(ctx, s.k8sClient, cfg, clusterDomain, routes, grpcRoutes)
 
 	// After the 500 (invalid-ref) markings, mark any backend whose Service
 	// exists but has no ready endpoints with 503 (Gateway API SHOULD). Runs
 	// last so the first-marking-wins rule keeps 500 for a backend that is both
 	// nonexistent and endpoint-less.
-	markZeroEndpointBackends(ctx, s.k8sClient, cfg, s.clusterDomain, routes, grpcRoutes)
+	markZeroEndpointBackends(ctx, s.k8sClient, cfg, clusterDomain, routes, grpcRoutes)
 
 	// Rewrite ExternalBackend sentinel URLs to the real scheme://host:port/path
 	// from each ExternalBackend's spec (the converter has no client and emits a
@@ -1142,6 +1241,98 @@ func (s *ProxySyncer) buildProxyConfig(
 	return cfg
 }
 
+// prependHealthCheckRules adds the synthetic health endpoint for each
+// hostname: an exact-path rule with no backends that the proxy answers with a
+// static 200. The connector hands every request to the proxy, so the tunnel
+// document's http_status:200 rule alone is never consulted — the proxy must
+// answer it too. Prepended so a route rule of equal precedence (same hostname,
+// same exact path) loses the router's earlier-rule tie-break; it runs after
+// shadow detection because the path is reserved, not route-owned.
+func prependHealthCheckRules(cfg *proxy.Config, hostnames []string) {
+	if len(hostnames) == 0 {
+		return
+	}
+
+	rules := make([]proxy.RouteRule, 0, len(hostnames)+len(cfg.Rules))
+	provenance := make([]proxy.RuleProvenance, 0, len(hostnames)+len(cfg.Provenance))
+
+	for _, hostname := range hostnames {
+		rules = append(rules, proxy.RouteRule{
+			Hostnames: []string{hostname},
+			Matches: []proxy.RouteMatch{{
+				Path: &proxy.PathMatch{Type: proxy.PathMatchExact, Value: ingress.HealthCheckPath},
+			}},
+			Backends:          []proxy.BackendRef{},
+			UnavailableStatus: http.StatusOK,
+		})
+		// Provenance stays parallel to Rules; the zero value names no route.
+		provenance = append(provenance, proxy.RuleProvenance{})
+	}
+
+	cfg.Rules = append(rules, cfg.Rules...)
+	cfg.Provenance = append(provenance, cfg.Provenance...)
+}
+
+// proxyFallback is the proxy-side counterpart of the ingress catch-all: the
+// connector hands every request to the proxy, so the tunnel document's
+// catch-all is never consulted and the proxy must serve the defaultBackend
+// itself. A URL target is sent with its own Host, as the ingress rule's
+// httpHostHeader does. Returns nil for the default 404.
+func proxyFallback(catchAll ingress.CatchAllTarget, clusterDomain string) *proxy.RouteRule {
+	if catchAll.IsZero() {
+		return nil
+	}
+
+	fallback := &proxy.RouteRule{
+		Backends: []proxy.BackendRef{{URL: catchAll.Service(clusterDomain), Weight: 1}},
+	}
+
+	if host := catchAll.HostHeader(); host != "" {
+		fallback.Filters = []proxy.RouteFilter{{
+			Type:       proxy.FilterURLRewrite,
+			URLRewrite: &proxy.URLRewriteConfig{Hostname: &host},
+		}}
+	}
+
+	return fallback
+}
+
+// proxyGRPCUnavailableHostnames is the proxy-side counterpart of the
+// --grpc-catch-all=unavailable ingress rules, which the connector never
+// consults: the hostnames of cfg's GRPCRoute rules that no HTTPRoute rule
+// shares, sorted. Returns nil in any other mode.
+func proxyGRPCUnavailableHostnames(cfg *proxy.Config, mode ingress.GRPCCatchAll) []string {
+	if mode != ingress.GRPCCatchAllUnavailable {
+		return nil
+	}
+
+	grpcHosts := make(map[string]bool)
+	httpHosts := make(map[string]bool)
+
+	for i := range cfg.Rules {
+		hosts := httpHosts
+		if i < len(cfg.Provenance) && cfg.Provenance[i].Kind == string(routebinding.KindGRPCRoute) {
+			hosts = grpcHosts
+		}
+
+		for _, hostname := range cfg.Rules[i].Hostnames {
+			hosts[strings.ToLower(hostname)] = true
+		}
+	}
+
+	var hostnames []string
+
+	for hostname := range grpcHosts {
+		if !httpHosts[hostname] {
+			hostnames = append(hostnames, hostname)
+		}
+	}
+
+	slices.Sort(hostnames)
+
+	return hostnames
+}
+
 // ResyncEndpoints replays the most recent successfully-pushed config to the
 // supplied endpoints without rebuilding from HTTPRoutes. The endpoint
 // watcher uses this to bring a newly-joined proxy pod up to date when the
@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+)
+
+// Controller label values of the per-controller reconcile metrics.
+const (
+	reconcileMetricsHTTPRoute          = "httproute"
+	reconcileMetricsGRPCRoute          = "grpcroute"
+	reconcileMetricsGateway            = "gateway"
+	reconcileMetricsGatewayClassConfig = "gatewayclassconfig"
+)
+
+// instrumentReconcile runs one Reconcile call of the named controller and
+// records its duration, plus an error when the call returns one. A nil
+// collector only runs the call.
+func instrumentReconcile(
+	ctx context.Context,
+	metrics cfmetrics.Collector,
+	controllerName string,
+	reconcile func() (ctrl.Result, error),
+) (ctrl.Result, error) {
+	if metrics == nil {
+		return reconcile()
+	}
+
+	start := time.Now()
+	result, err := reconcile()
+
+	metrics.RecordReconcileDuration(ctx, controllerName, time.Since(start))
+
+	if err != nil {
+		metrics.RecordReconcileError(ctx, controllerName)
+	}
+
+	return result, err
+}
+
+// syncerMetrics returns the collector of a route reconciler's RouteSyncer,
+// or nil when the reconciler has none.
+func syncerMetrics(syncer *RouteSyncer) cfmetrics.Collector {
+	if syncer == nil {
+		return nil
+	}
+
+	return syncer.Metrics
+}
@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+// reconcileCollector records the per-controller reconcile metrics and
+// drops everything else.
+type reconcileCollector struct {
+	cfmetrics.NoopCollector
+
+	mu        sync.Mutex
+	durations map[string][]time.Duration
+	errors    map[string]int
+}
+
+func newReconcileCollector() *reconcileCollector {
+	return &reconcileCollector{durations: map[string][]time.Duration{}, errors: map[string]int{}}
+}
+
+func (c *reconcileCollector) RecordReconcileDuration(_ context.Context, controller string, duration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.durations[controller] = append(c.durations[controller], duration)
+}
+
+func (c *reconcileCollector) RecordReconcileError(_ context.Context, controller string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.errors[controller]++
+}
+
+// TestReconcile_RecordsPerControllerMetrics pins that each instrumented
+// reconciler records one duration per Reconcile call under its own
+// controller label, and one error only when the call returns an error.
+func TestReconcile_RecordsPerControllerMetrics(t *testing.T) {
+	t.Parallel()
+
+	newReconciler := func(cli client.Client, metrics cfmetrics.Collector, name string) reconcile.Reconciler {
+		switch name {
+		case reconcileMetricsGateway:
+			return &GatewayReconciler{Client: cli, Scheme: cli.Scheme(), Metrics: metrics}
+		case reconcileMetricsGatewayClassConfig:
+			return &GatewayClassConfigReconciler{Client: cli, Scheme: cli.Scheme(), Metrics: metrics}
+		}
+
+		resolver := config.NewResolver(cli, "default", metrics)
+		syncer := NewRouteSyncer(cli, cli.Scheme(), "cluster.local", "test-controller", resolver, metrics, nil)
+
+		if name == reconcileMetricsHTTPRoute {
+			r := &HTTPRouteReconciler{Client: cli, Scheme: cli.Scheme(), ControllerName: "test-controller", RouteSyncer: syncer}
+			r.startupComplete.Store(true)
+
+			return r
+		}
+
+		r := &GRPCRouteReconciler{Client: cli, Scheme: cli.Scheme(), ControllerName: "test-controller", RouteSyncer: syncer}
+		r.startupComplete.Store(true)
+
+		return r
+	}
+
+	controllers := []string{
+		reconcileMetricsHTTPRoute,
+		reconcileMetricsGRPCRoute,
+		reconcileMetricsGateway,
+		reconcileMetricsGatewayClassConfig,
+	}
+
+	for _, name := range controllers {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			base := setupGatewayFakeClient()
+			failing := fake.NewClientBuilder().
+				WithScheme(base.Scheme()).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Get: func(context.Context, client.WithWatch, client.ObjectKey, client.Object, ...client.GetOption) error {
+						return errSimulatedCacheMiss
+					},
+				}).
+				Build()
+
+			metrics := newReconcileCollector()
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "missing", Namespace: "default"}}
+
+			_, err := newReconciler(base, metrics, name).Reconcile(context.Background(), req)
+			require.NoError(t, err, "a missing object reconciles cleanly")
+
+			_, err = newReconciler(failing, metrics, name).Reconcile(context.Background(), req)
+			require.Error(t, err, "a failing read is returned")
+
+			assert.Len(t, metrics.durations[name], 2, "one duration per Reconcile call")
+			assert.Equal(t, 1, metrics.errors[name], "only the failed call counts as an error")
+
+			for _, duration := range metrics.durations[name] {
+				assert.Positive(t, duration)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package controller
 import (
 	"context"
 	"sync/atomic"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -26,11 +27,13 @@ import (
 // reconcileRouteParams holds common parameters for the generic route reconcile loop.
 type reconcileRouteParams[T client.Object] struct {
 	startupComplete *atomic.Bool
-	k8sClient       client.Client
-	controllerName  string
-	componentName   string
-	wrapRoute       func(T) Route
-	syncAndUpdate   func(ctx context.Context) (ctrl.Result, error)
+	// startupPendingDelay is the requeue delay while the startup sync runs.
+	startupPendingDelay time.Duration
+	k8sClient           client.Client
+	controllerName      string
+	componentName       string
+	wrapRoute           func(T) Route
+	syncAndUpdate       func(ctx context.Context) (ctrl.Result, error)
 }
 
 // reconcileRoute is the generic Reconcile implementation shared by
@@ -44,7 +47,7 @@ func reconcileRoute[T client.Object](
 	params reconcileRouteParams[T],
 ) (ctrl.Result, error) {
 	if !params.startupComplete.Load() {
-		return ctrl.Result{RequeueAfter: startupPendingRequeueDelay, Priority: new(priorityRoute)}, nil
+		return ctrl.Result{RequeueAfter: params.startupPendingDelay, Priority: new(priorityRoute)}, nil
 	}
 
 	ctx = logging.WithReconcileID(ctx)
@@ -108,7 +111,12 @@ type routeControllerSetupParams struct {
 	// this watch carries its own predicate. Off when ownership enforcement is
 	// disabled — namespace labels then influence nothing.
 	watchNamespaceLabels bool
-	getAllRelevantRoutes RequestsFunc
+	// watchRouteAnnotations lets route annotation edits through the route
+	// watch: with route opt-in enabled, adding or removing the opt-in
+	// annotation changes whether the route is managed, and annotation edits
+	// do not bump generation either.
+	watchRouteAnnotations bool
+	getAllRelevantRoutes  RequestsFunc
 }
 
 // namespaceScopedRequests narrows getAllRelevantRoutes to the routes of the
@@ -145,12 +153,25 @@ func setupRouteController(mgr ctrl.Manager, params *routeControllerSetupParams)
 	// would eat — namespace label edits do not bump generation.
 	generationChanged := ctrlbuilder.WithPredicates(predicate.GenerationChangedPredicate{})
 
+	routeChanged := generationChanged
+	if params.watchRouteAnnotations {
+		routeChanged = ctrlbuilder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+		))
+	}
+
 	builder := ctrl.NewControllerManagedBy(mgr).
-		For(params.routeObject, generationChanged).
+		For(params.routeObject, routeChanged).
+		// Gateway annotations carry the tunnel-id override, which moves the
+		// Gateway's routes to another tunnel document without a spec change.
 		Watches(
 			&gatewayv1.Gateway{},
 			handler.EnqueueRequestsFromMapFunc(params.findRoutesForGateway),
-			generationChanged,
+			ctrlbuilder.WithPredicates(predicate.Or(
+				predicate.GenerationChangedPredicate{},
+				predicate.AnnotationChangedPredicate{},
+			)),
 		).
 		Watches(
 			&gatewayv1.ListenerSet{},
@@ -167,6 +188,9 @@ func setupRouteController(mgr ctrl.Manager, params *routeControllerSetupParams)
 			handler.EnqueueRequestsFromMapFunc(mapper.MapSecretToRequests(params.getAllRelevantRoutes)),
 			generationChanged,
 		).
+		// GenerationChangedPredicate filters updates only: a deleted grant
+		// (tombstones included) still enqueues the routes it permitted, so
+		// their cross-namespace backends are revoked promptly.
 		Watches(
 			&gatewayv1beta1.ReferenceGrant{},
 			handler.EnqueueRequestsFromMapFunc(params.findRoutesForRefGrant),
@@ -9,7 +9,13 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
@@ -17,6 +23,7 @@ import (
 	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
 	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/ingress"
 	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/referencegrant"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/routebinding"
 )
 
 // TestRouteSyncer_CrossNamespaceRef_WithoutGrant tests that cross-namespace
@@ -559,6 +566,102 @@ func TestRouteSyncer_ReferenceGrant_SpecificName(t *testing.T) {
 	assert.Contains(t, buildResult.Rules[0].Service.Value, "allowed-service")
 }
 
+// TestHTTPRouteReconciler_ReferenceGrantDeletionRevokesAccess pins the
+// revoke path: deleting a ReferenceGrant — including one delivered as a
+// tombstone after a missed watch event — enqueues the routes it permitted,
+// and the rebuild then reports their cross-namespace backend as
+// RefNotPermitted instead of programming it.
+func TestHTTPRouteReconciler_ReferenceGrantDeletionRevokesAccess(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, gatewayv1beta1.Install(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	gatewayClass := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "cloudflare-tunnel"},
+		Spec:       gatewayv1.GatewayClassSpec{ControllerName: "test-controller"},
+	}
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-gateway", Namespace: "default"},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "cloudflare-tunnel",
+			Listeners:        []gatewayv1.Listener{{Name: "http", Port: 80, Protocol: gatewayv1.HTTPProtocolType}},
+		},
+	}
+
+	backendNs := gatewayv1.Namespace("backend-ns")
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "cross-ns-route", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "test-gateway"}},
+			},
+			Hostnames: []gatewayv1.Hostname{"app.example.com"},
+			Rules: []gatewayv1.HTTPRouteRule{{
+				BackendRefs: []gatewayv1.HTTPBackendRef{{
+					BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+						Name: "svc", Namespace: &backendNs, Port: portNumPtr(8080),
+					}},
+				}},
+			}},
+		},
+	}
+	grant := &gatewayv1beta1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-backend", Namespace: "backend-ns"},
+		Spec: gatewayv1beta1.ReferenceGrantSpec{
+			From: []gatewayv1beta1.ReferenceGrantFrom{{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Namespace: "default"}},
+			To:   []gatewayv1beta1.ReferenceGrantTo{{Group: "", Kind: "Service"}},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(gatewayClass, gateway, route, grant).
+		Build()
+
+	reconciler := &HTTPRouteReconciler{
+		Client:           fakeClient,
+		Scheme:           scheme,
+		ControllerName:   "test-controller",
+		bindingValidator: routebinding.NewValidator(fakeClient),
+	}
+
+	builder := ingress.NewBuilder("cluster.local", referencegrant.NewValidator(fakeClient), nil, nil, nil)
+
+	before := builder.Build(ctx, []gatewayv1.HTTPRoute{*route})
+	require.Empty(t, before.FailedRefs, "the grant permits the cross-namespace backend")
+
+	require.NoError(t, fakeClient.Delete(ctx, grant))
+
+	// The route watch filters updates by generation; deletes must pass.
+	deleteEvent := event.DeleteEvent{Object: grant, DeleteStateUnknown: true}
+	assert.True(t, predicate.GenerationChangedPredicate{}.Delete(deleteEvent),
+		"the ReferenceGrant watch predicate must let deletions through")
+
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	t.Cleanup(queue.ShutDown)
+
+	handler.EnqueueRequestsFromMapFunc(reconciler.findRoutesForReferenceGrant).Delete(ctx, deleteEvent, queue)
+
+	require.Equal(t, 1, queue.Len(), "the previously-permitted route must be enqueued")
+
+	request, _ := queue.Get()
+	assert.Equal(t, client.ObjectKeyFromObject(route), request.NamespacedName)
+
+	after := builder.Build(ctx, []gatewayv1.HTTPRoute{*route})
+	require.Len(t, after.FailedRefs, 1, "without the grant the backend must be denied")
+	assert.Equal(t, string(gatewayv1.RouteReasonRefNotPermitted), after.FailedRefs[0].Reason)
+	assert.Equal(t, "svc", after.FailedRefs[0].BackendName)
+
+	for _, rule := range after.Rules {
+		assert.NotContains(t, rule.Service.Value, "backend-ns", "a revoked backend must not be programmed")
+	}
+}
+
 // portNumPtr returns a pointer to a PortNumber.
 func portNumPtr(p int32) *gatewayv1.PortNumber {
 	return new(p)
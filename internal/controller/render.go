@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/cloudflare/cloudflare-go/v7/zero_trust"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/ingress"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/referencegrant"
+)
+
+// RenderedIngress is the tunnel ingress document RenderIngress built, with
+// the backend references that failed validation along the way.
+type RenderedIngress struct {
+	Rules      []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress
+	FailedRefs []ingress.BackendRefError
+	// InvalidRules are the route rules left out because Cloudflare would
+	// reject them.
+	InvalidRules []ingress.InvalidRule
+}
+
+// RenderIngress builds the tunnel ingress document for routes the way a sync
+// does: the HTTP and gRPC builders run against c, their rules are merged
+// and sorted, and the 404 catch-all closes the document. It
+// never calls Cloudflare. Gateway health rules, listener-scoped catch-alls and
+// GatewayClassConfig origin defaults need the cluster's Gateways and are not
+// applied.
+func RenderIngress(
+	ctx context.Context,
+	c client.Client,
+	clusterDomain string,
+	httpRoutes []gatewayv1.HTTPRoute,
+	grpcRoutes []gatewayv1.GRPCRoute,
+) RenderedIngress {
+	validator := referencegrant.NewValidator(c)
+	metrics := cfmetrics.NewNoopCollector()
+	logger := slog.New(slog.DiscardHandler)
+
+	httpBuild := ingress.NewBuilder(clusterDomain, validator, c, metrics, logger).Build(ctx, httpRoutes)
+	grpcBuild := ingress.NewGRPCBuilder(clusterDomain, validator, c, metrics, logger).Build(ctx, grpcRoutes)
+
+	rules := mergeAndSortRules(httpBuild.Rules, grpcBuild.Rules)
+
+	failedRefs := make([]ingress.BackendRefError, 0,
+		len(httpBuild.FailedRefs)+len(grpcBuild.FailedRefs))
+	failedRefs = append(failedRefs, httpBuild.FailedRefs...)
+	failedRefs = append(failedRefs, grpcBuild.FailedRefs...)
+
+	return RenderedIngress{
+		Rules:        ingress.EnsureCatchAllRule(rules, ingress.CatchAllTarget{}.Rule(clusterDomain)),
+		FailedRefs:   failedRefs,
+		InvalidRules: append(httpBuild.InvalidRules, grpcBuild.InvalidRules...),
+	}
+}
@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"fmt"
+	"log/slog"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/routebinding"
+)
+
+// RouteDisabledAnnotation takes a route out of the tunnel and the proxy
+// without deleting it. Only the value "true" disables.
+const RouteDisabledAnnotation = "cf.k8s.lex.la/disabled"
+
+// routeReasonDisabled is the Accepted=False reason of every parent a
+// disabled route would otherwise bind to.
+const routeReasonDisabled = "RouteDisabled"
+
+// disableIfAnnotated rejects every accepted parent of a route carrying
+// RouteDisabledAnnotation with reason RouteDisabled, so the route is written
+// to status like any rejected route but contributes no rules. Parents already
+// rejected keep their own, more specific reason. Returns whether the route
+// was disabled.
+func disableIfAnnotated(logger *slog.Logger, route metav1.Object, bindingInfo *routeBindingInfo) bool {
+	if route.GetAnnotations()[RouteDisabledAnnotation] != "true" {
+		return false
+	}
+
+	for refIdx, result := range bindingInfo.bindingResults {
+		if !result.Accepted {
+			continue
+		}
+
+		bindingInfo.bindingResults[refIdx] = routebinding.BindingResult{
+			Accepted: false,
+			Reason:   routeReasonDisabled,
+			Message: fmt.Sprintf("Route disabled by the %s annotation; its rules are not programmed",
+				RouteDisabledAnnotation),
+		}
+	}
+
+	clear(bindingInfo.acceptedGateways)
+
+	logger.Debug("route disabled by annotation; skipping its rules",
+		"route", route.GetNamespace()+"/"+route.GetName())
+
+	return true
+}
@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestSyncAllRoutes_DisabledRoute pins that a route annotated disabled
+// contributes no tunnel rules and no proxy routes, and is reported
+// Accepted=False with reason RouteDisabled; any other value leaves it served.
+func TestSyncAllRoutes_DisabledRoute(t *testing.T) {
+	t.Parallel()
+
+	const classTunnel = "99999999-9999-4999-8999-999999999999"
+
+	tests := []struct {
+		name         string
+		annotation   string
+		wantDisabled bool
+	}{
+		{name: "no annotation"},
+		{name: "false", annotation: "false"},
+		{name: "true", annotation: "true", wantDisabled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			api := newRecordingTunnelAPI(t)
+			syncer := newPartitionSyncSyncer(t, api, classTunnel)
+
+			route := partitionSyncRoute("paused-route", "shared-gw", "paused.example.com")
+			if tt.annotation != "" {
+				route.Annotations = map[string]string{RouteDisabledAnnotation: tt.annotation}
+			}
+
+			require.NoError(t, syncer.Client.Create(ctx, route))
+			require.NoError(t, syncer.Client.Create(ctx,
+				partitionSyncRoute("live-route", "shared-gw", "live.example.com")))
+
+			_, result, err := syncer.SyncAllRoutes(ctx)
+			require.NoError(t, err)
+
+			hostnames := api.hostnamesFor(classTunnel)
+			assert.Contains(t, hostnames, "live.example.com", "other routes are unaffected")
+
+			binding := result.HTTPRouteBindings["default/paused-route"]
+			accepted := buildAcceptedCondition(1, metav1.Now(), binding, 0, nil, nil)
+
+			if !tt.wantDisabled {
+				assert.Contains(t, hostnames, "paused.example.com")
+				assert.Equal(t, metav1.ConditionTrue, accepted.Status)
+
+				return
+			}
+
+			assert.NotContains(t, hostnames, "paused.example.com")
+			assert.Equal(t, metav1.ConditionFalse, accepted.Status)
+			assert.Equal(t, routeReasonDisabled, accepted.Reason)
+			assert.Contains(t, accepted.Message, RouteDisabledAnnotation)
+
+			for i := range result.HTTPRoutes {
+				assert.NotEqual(t, "paused-route", result.HTTPRoutes[i].Name,
+					"a disabled route must not reach the proxy config")
+			}
+
+			require.Len(t, result.RejectedHTTPRoutes, 1, "the disabled route still gets a status entry")
+			assert.Equal(t, "paused-route", result.RejectedHTTPRoutes[0].Name)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/routebinding"
+)
+
+// ForceAttachAnnotation forces a route onto the managed Gateways whose
+// listeners rejected it, when the controller runs with --allow-force-attach.
+// Only the value "true" forces. A debugging aid for staging: it shows what
+// the route would do if it bound, without editing the Gateway.
+const ForceAttachAnnotation = "cf.k8s.lex.la/force-attach"
+
+const (
+	// routeReasonForceAttached is the Accepted=True reason of a parent the
+	// route was forced onto, so a forced binding never reads as a normal one.
+	routeReasonForceAttached = "ForceAttached"
+	// eventReasonForceAttached marks the Warning Event emitted for each
+	// forced parent.
+	eventReasonForceAttached = "ForceAttached"
+)
+
+// forceAttachIfAnnotated binds a rejected route to the Gateways that
+// rejected it when force-attach is allowed and the route carries
+// ForceAttachAnnotation. Only listener mismatches (hostname, allowedRoutes,
+// sectionName or port) are overridden: a policy rejection such as
+// HostnameNotPermitted or Conflicted stays in force. Each forced parent is
+// recorded as accepted with reason ForceAttached and the original rejection
+// in its message. Returns whether any parent was forced.
+func (s *RouteSyncer) forceAttachIfAnnotated(logger *slog.Logger, route metav1.Object, bindingInfo *routeBindingInfo) bool {
+	if !s.AllowForceAttach || route.GetAnnotations()[ForceAttachAnnotation] != "true" {
+		return false
+	}
+
+	forced := false
+
+	for refIdx, result := range bindingInfo.bindingResults {
+		gatewayKey := bindingInfo.parentGateways[refIdx]
+		if result.Accepted || gatewayKey == "" || !isListenerMismatchReason(result.Reason) {
+			continue
+		}
+
+		bindingInfo.bindingResults[refIdx] = routebinding.BindingResult{
+			Accepted: true,
+			Reason:   routeReasonForceAttached,
+			Message: fmt.Sprintf("Forced onto Gateway %s by the %s annotation despite binding rejection (%s): %s",
+				gatewayKey, ForceAttachAnnotation, result.Reason, result.Message),
+		}
+		bindingInfo.acceptedGateways[gatewayKey] = true
+		forced = true
+
+		logger.Warn("route force-attached despite binding rejection",
+			"route", route.GetNamespace()+"/"+route.GetName(),
+			"gateway", gatewayKey,
+			"rejection", string(result.Reason))
+	}
+
+	return forced
+}
+
+// emitForceAttachEvents emits a Warning Event for each parent the route was
+// forced onto, in parentRef order. A nil recorder is a no-op.
+func emitForceAttachEvents(recorder events.EventRecorder, route runtime.Object, bindingInfo routeBindingInfo) {
+	if recorder == nil {
+		return
+	}
+
+	for _, refIdx := range slices.Sorted(maps.Keys(bindingInfo.bindingResults)) {
+		result := bindingInfo.bindingResults[refIdx]
+		if !result.Accepted || result.Reason != routeReasonForceAttached {
+			continue
+		}
+
+		recorder.Eventf(route, nil, corev1.EventTypeWarning, eventReasonForceAttached, eventActionRouteSync,
+			"%s", result.Message)
+	}
+}
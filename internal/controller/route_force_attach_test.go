@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// TestSyncAllRoutes_ForceAttach pins that a route rejected by every listener
+// is programmed only when the controller allows force-attach AND the route
+// carries the annotation, and that the forced parent reads as ForceAttached
+// rather than a normal acceptance.
+func TestSyncAllRoutes_ForceAttach(t *testing.T) {
+	t.Parallel()
+
+	const classTunnel = "99999999-9999-4999-8999-999999999999"
+
+	tests := []struct {
+		name       string
+		allow      bool
+		annotation bool
+		wantForced bool
+	}{
+		{name: "neither"},
+		{name: "flag only", allow: true},
+		{name: "annotation only", annotation: true},
+		{name: "flag and annotation", allow: true, annotation: true, wantForced: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			api := newRecordingTunnelAPI(t)
+			syncer := newPartitionSyncSyncer(t, api, classTunnel)
+			syncer.AllowForceAttach = tt.allow
+
+			// sectionName names no listener of shared-gw: NoMatchingParent.
+			route := partitionSyncRoute("debug-route", "shared-gw", "debug.example.com")
+			route.Spec.ParentRefs[0].SectionName = new(gatewayv1.SectionName("missing"))
+
+			if tt.annotation {
+				route.Annotations = map[string]string{ForceAttachAnnotation: "true"}
+			}
+
+			require.NoError(t, syncer.Client.Create(ctx, route))
+
+			_, result, err := syncer.SyncAllRoutes(ctx)
+			require.NoError(t, err)
+
+			hostnames := api.hostnamesFor(classTunnel)
+			binding := result.HTTPRouteBindings["default/debug-route"]
+			accepted := buildAcceptedCondition(1, metav1.Now(), binding, 0, nil, nil)
+
+			if !tt.wantForced {
+				assert.NotContains(t, hostnames, "debug.example.com")
+				assert.Equal(t, metav1.ConditionFalse, accepted.Status)
+				assert.Equal(t, string(gatewayv1.RouteReasonNoMatchingParent), accepted.Reason)
+
+				return
+			}
+
+			assert.Contains(t, hostnames, "debug.example.com")
+			assert.Contains(t, hostnames, "shared.example.com", "regular routes are unaffected")
+			assert.Equal(t, metav1.ConditionTrue, accepted.Status)
+			assert.Equal(t, routeReasonForceAttached, accepted.Reason)
+			assert.Contains(t, accepted.Message, string(gatewayv1.RouteReasonNoMatchingParent))
+		})
+	}
+}
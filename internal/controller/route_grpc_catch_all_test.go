@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/ingress"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/proxy"
+)
+
+// TestSyncAllRoutes_GRPCCatchAll pins the --grpc-catch-all wiring: by default
+// a gRPC hostname has only its method rules, and in unavailable mode the
+// written document closes it with an HTTP 503 rule after them.
+func TestSyncAllRoutes_GRPCCatchAll(t *testing.T) {
+	t.Parallel()
+
+	const classTunnel = "99999999-9999-4999-8999-999999999999"
+
+	tests := []struct {
+		name     string
+		mode     ingress.GRPCCatchAll
+		wantRule bool
+	}{
+		{name: "default", mode: ""},
+		{name: "none", mode: ingress.GRPCCatchAllNone},
+		{name: "unavailable", mode: ingress.GRPCCatchAllUnavailable, wantRule: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			api := newRecordingTunnelAPI(t)
+			syncer := newPartitionSyncSyncer(t, api, classTunnel)
+			syncer.GRPCCatchAll = tt.mode
+
+			service := "pkg.Greeter"
+			port := gatewayv1.PortNumber(80)
+			route := grpcRouteFor("default", "greeter", "shared-gw", "svc")
+			route.Spec.Hostnames = []gatewayv1.Hostname{"grpc.example.com"}
+			route.Spec.Rules[0].Matches = []gatewayv1.GRPCRouteMatch{
+				{Method: &gatewayv1.GRPCMethodMatch{Service: &service}},
+			}
+			route.Spec.Rules[0].BackendRefs[0].Port = &port
+
+			require.NoError(t, syncer.Client.Create(ctx, route))
+
+			_, _, err := syncer.SyncAllRoutes(ctx)
+			require.NoError(t, err)
+
+			var grpcRules []recordedRule
+
+			for _, rule := range api.rulesFor(classTunnel) {
+				if rule.Hostname == "grpc.example.com" {
+					grpcRules = append(grpcRules, rule)
+				}
+			}
+
+			require.NotEmpty(t, grpcRules)
+			assert.Equal(t, `/pkg\.Greeter/*`, grpcRules[0].Path)
+
+			if !tt.wantRule {
+				assert.Len(t, grpcRules, 1)
+
+				return
+			}
+
+			require.Len(t, grpcRules, 2)
+			assert.Empty(t, grpcRules[1].Path)
+			assert.Equal(t, ingress.GRPCUnavailableService, grpcRules[1].Service)
+		})
+	}
+}
+
+// TestProxyGRPCUnavailableHostnames pins the proxy half of the flag: only in
+// unavailable mode, and only for hostnames no HTTPRoute rule shares.
+func TestProxyGRPCUnavailableHostnames(t *testing.T) {
+	t.Parallel()
+
+	cfg := &proxy.Config{
+		Rules: []proxy.RouteRule{
+			{Hostnames: []string{"web.example.com", "shared.example.com"}},
+			{Hostnames: []string{"Shared.example.com", "grpc.example.com"}},
+			{Hostnames: []string{"*.grpc.example.com"}},
+		},
+		Provenance: []proxy.RuleProvenance{
+			{Kind: "HTTPRoute"},
+			{Kind: "GRPCRoute"},
+			{Kind: "GRPCRoute"},
+		},
+	}
+
+	assert.Nil(t, proxyGRPCUnavailableHostnames(cfg, ingress.GRPCCatchAllNone))
+	assert.Equal(t, []string{"*.grpc.example.com", "grpc.example.com"},
+		proxyGRPCUnavailableHostnames(cfg, ingress.GRPCCatchAllUnavailable))
+}
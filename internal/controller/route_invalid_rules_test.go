@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// TestSyncAllRoutes_InvalidRouteExcluded pins partial-sync resilience: a
+// route whose RegularExpression path Cloudflare would refuse is left out of the tunnel document
+// while every other route is still written, and the offending route reports
+// Accepted=False/UnsupportedValue naming the refused path.
+func TestSyncAllRoutes_InvalidRouteExcluded(t *testing.T) {
+	t.Parallel()
+
+	const classTunnel = "99999999-9999-4999-8999-999999999999"
+
+	ctx := context.Background()
+	api := newRecordingTunnelAPI(t)
+	syncer := newPartitionSyncSyncer(t, api, classTunnel)
+
+	invalid := partitionSyncRoute("regex-route", "shared-gw", "regex.example.com")
+	invalid.Spec.Rules[0].Matches[0].Path.Type = new(gatewayv1.PathMatchRegularExpression)
+	invalid.Spec.Rules[0].Matches[0].Path.Value = new("/(?!admin)")
+
+	require.NoError(t, syncer.Client.Create(ctx, invalid))
+	require.NoError(t, syncer.Client.Create(ctx,
+		partitionSyncRoute("live-route", "shared-gw", "live.example.com")))
+
+	_, result, err := syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	hostnames := api.hostnamesFor(classTunnel)
+	assert.Contains(t, hostnames, "live.example.com", "valid routes are still pushed")
+	assert.NotContains(t, hostnames, "regex.example.com")
+
+	conditions := make(map[string]*acceptedConditionOverride)
+
+	for _, entry := range result.httpStatusEntries(result.CollisionDiagnostics, nil) {
+		override, partial := diagnosticConditions(entry.diagnostics, 1, 1, metav1.Now())
+		assert.Nil(t, partial, entry.name)
+
+		conditions[entry.name] = override
+	}
+
+	require.NotNil(t, conditions["regex-route"], "the refused route must report a failure")
+	assert.Equal(t, string(gatewayv1.RouteReasonUnsupportedValue), conditions["regex-route"].reason)
+	assert.Contains(t, conditions["regex-route"].message, `"/(?!admin)"`)
+	assert.Nil(t, conditions["live-route"])
+	assert.Nil(t, conditions["shared-route"])
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// withListenerScopedHTTPCatchAlls returns the routes with every hostname-less
+// route narrowed to the hostnames of the listeners it binds to (typically a
+// single listener picked with sectionName). In the tunnel ingress document
+// such a route then becomes that listener's catch-all: a terminal rule scoped
+// to the listener hostname, sorted after the hostname's more specific rules,
+// instead of a hostname-less wildcard answering every hostname ahead of the
+// global catch-all.
+//
+// Routes declaring hostnames are left as they are, and so are hostname-less
+// routes accepted by a hostname-less listener, which stay wildcards (see
+// withEffectiveHostnames). The input slice is not modified.
+func withListenerScopedHTTPCatchAlls(
+	ctx context.Context,
+	cli client.Client,
+	routes []gatewayv1.HTTPRoute,
+	views *listenerViewCache,
+) []gatewayv1.HTTPRoute {
+	var (
+		indexes  []int
+		scopable []*gatewayv1.HTTPRoute
+	)
+
+	for i := range routes {
+		if len(routes[i].Spec.Hostnames) == 0 {
+			indexes = append(indexes, i)
+			scopable = append(scopable, &routes[i])
+		}
+	}
+
+	if len(scopable) == 0 {
+		return routes
+	}
+
+	out := append([]gatewayv1.HTTPRoute(nil), routes...)
+
+	for j, scoped := range withEffectiveHostnames(ctx, cli, scopable, views) {
+		out[indexes[j]].Spec.Hostnames = scoped.Spec.Hostnames
+	}
+
+	return out
+}
+
+// withListenerScopedGRPCCatchAlls is the GRPCRoute counterpart of
+// withListenerScopedHTTPCatchAlls.
+func withListenerScopedGRPCCatchAlls(
+	ctx context.Context,
+	cli client.Client,
+	routes []gatewayv1.GRPCRoute,
+	views *listenerViewCache,
+) []gatewayv1.GRPCRoute {
+	var (
+		indexes  []int
+		scopable []*gatewayv1.GRPCRoute
+	)
+
+	for i := range routes {
+		if len(routes[i].Spec.Hostnames) == 0 {
+			indexes = append(indexes, i)
+			scopable = append(scopable, &routes[i])
+		}
+	}
+
+	if len(scopable) == 0 {
+		return routes
+	}
+
+	out := append([]gatewayv1.GRPCRoute(nil), routes...)
+
+	for j, scoped := range withEffectiveHostnamesGRPC(ctx, cli, scopable, views) {
+		out[indexes[j]].Spec.Hostnames = scoped.Spec.Hostnames
+	}
+
+	return out
+}
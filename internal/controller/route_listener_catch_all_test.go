@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// TestSyncAllRoutes_ListenerScopedCatchAll pins that a hostname-less route
+// attached to one listener with sectionName becomes that listener's
+// catch-all: a terminal rule for the listener hostname, after the hostname's
+// path rules, while the global catch-all stays last.
+func TestSyncAllRoutes_ListenerScopedCatchAll(t *testing.T) {
+	t.Parallel()
+
+	const classTunnel = "99999999-9999-4999-8999-999999999999"
+
+	ctx := context.Background()
+	api := newRecordingTunnelAPI(t)
+	syncer := newPartitionSyncSyncer(t, api, classTunnel)
+
+	allNamespaces := gatewayv1.NamespacesFromAll
+	listener := func(name, hostname string) gatewayv1.Listener {
+		return gatewayv1.Listener{
+			Name:     gatewayv1.SectionName(name),
+			Hostname: new(gatewayv1.Hostname(hostname)),
+			Port:     80,
+			Protocol: gatewayv1.HTTPProtocolType,
+			AllowedRoutes: &gatewayv1.AllowedRoutes{
+				Namespaces: &gatewayv1.RouteNamespaces{From: &allNamespaces},
+			},
+		}
+	}
+
+	require.NoError(t, syncer.Client.Create(ctx, &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "listeners-gw", Namespace: "default"},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "cf-test",
+			Listeners:        []gatewayv1.Listener{listener("api", "api.example.com"), listener("web", "web.example.com")},
+		},
+	}))
+
+	apiDefault := partitionSyncRoute("api-default", "listeners-gw", "")
+	apiDefault.Spec.Hostnames = nil
+	apiDefault.Spec.ParentRefs[0].SectionName = new(gatewayv1.SectionName("api"))
+	apiDefault.Spec.Rules[0].Matches = nil
+	require.NoError(t, syncer.Client.Create(ctx, apiDefault))
+
+	apiV1 := partitionSyncRoute("api-v1", "listeners-gw", "api.example.com")
+	apiV1.Spec.ParentRefs[0].SectionName = new(gatewayv1.SectionName("api"))
+	apiV1.Spec.Rules[0].Matches[0].Path.Value = new("/v1")
+	require.NoError(t, syncer.Client.Create(ctx, apiV1))
+
+	_, _, err := syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	rules := api.rulesFor(classTunnel)
+	require.NotEmpty(t, rules)
+
+	pathRule, listenerCatchAll := -1, -1
+
+	for i, rule := range rules[:len(rules)-1] {
+		assert.NotEmpty(t, rule.Hostname, "rule %d: only the global catch-all may omit the hostname: %+v", i, rules)
+		assert.NotEqual(t, "web.example.com", rule.Hostname, "the route is attached to the api listener only")
+
+		if rule.Hostname != "api.example.com" {
+			continue
+		}
+
+		if rule.Path == "" {
+			listenerCatchAll = i
+		} else {
+			pathRule = i
+		}
+	}
+
+	require.NotEqual(t, -1, pathRule, "api.example.com /v1 rule missing: %+v", rules)
+	require.NotEqual(t, -1, listenerCatchAll, "api.example.com catch-all rule missing: %+v", rules)
+	assert.Less(t, pathRule, listenerCatchAll, "the listener catch-all follows its hostname's path rules")
+
+	last := rules[len(rules)-1]
+	assert.Empty(t, last.Hostname, "the global catch-all stays last")
+	assert.Empty(t, last.Path)
+	assert.Equal(t, "http_status:404", last.Service)
+}
@@ -0,0 +1,23 @@
+package controller
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultRouteOptInAnnotation is the route annotation that opts a route into
+// management when route opt-in is enabled (--route-opt-in). Only the value
+// "true" opts in.
+const DefaultRouteOptInAnnotation = "cf.k8s.lex.la/managed"
+
+// routeOptedIn reports whether the syncer manages the route. With
+// RouteOptInAnnotation unset every route is managed; otherwise a route
+// without the annotation set to "true" is skipped before binding, exactly as
+// if it referenced another controller's Gateway: it is neither programmed
+// nor given a status entry.
+func (s *RouteSyncer) routeOptedIn(route metav1.Object) bool {
+	if s.RouteOptInAnnotation == "" {
+		return true
+	}
+
+	return route.GetAnnotations()[s.RouteOptInAnnotation] == "true"
+}
@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+// newOptInSyncer assembles a RouteSyncer over our Gateway and three routes
+// bound to it: one opted in, one with the annotation set to something other
+// than "true", and one without the annotation.
+func newOptInSyncer(t *testing.T, optInAnnotation string) *RouteSyncer {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-gateway", Namespace: "default"},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "cloudflare-tunnel",
+			Listeners:        httpListener(),
+		},
+	}
+	gatewayClass := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "cloudflare-tunnel"},
+		Spec:       gatewayv1.GatewayClassSpec{ControllerName: "cloudflare-tunnel"},
+	}
+
+	optedIn := ownershipHTTPRoute("default", "opted-in", "a.example.com")
+	optedIn.Annotations = map[string]string{DefaultRouteOptInAnnotation: "true"}
+
+	optedOut := ownershipHTTPRoute("default", "opted-out", "b.example.com")
+	optedOut.Annotations = map[string]string{DefaultRouteOptInAnnotation: "false"}
+
+	unannotated := ownershipHTTPRoute("default", "unannotated", "c.example.com")
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(gateway, gatewayClass, optedIn, optedOut, unannotated).
+		Build()
+
+	syncer := NewRouteSyncer(
+		fakeClient,
+		scheme,
+		"cluster.local",
+		"cloudflare-tunnel",
+		config.NewResolver(fakeClient, "default", cfmetrics.NewNoopCollector()),
+		cfmetrics.NewNoopCollector(),
+		nil,
+	)
+	syncer.RouteOptInAnnotation = optInAnnotation
+
+	return syncer
+}
+
+// TestRouteSyncer_RouteOptIn_ManagesOnlyAnnotatedRoutes pins opt-in mode:
+// only the route annotated "true" is accepted, and the others are skipped
+// outright — not rejected, so no status entry is written for them.
+func TestRouteSyncer_RouteOptIn_ManagesOnlyAnnotatedRoutes(t *testing.T) {
+	t.Parallel()
+
+	syncer := newOptInSyncer(t, DefaultRouteOptInAnnotation)
+
+	result, err := syncer.getRelevantHTTPRoutes(context.Background(), nil)
+	require.NoError(t, err)
+
+	require.Len(t, result.accepted, 1)
+	assert.Equal(t, "opted-in", result.accepted[0].Name)
+	assert.Empty(t, result.rejected, "routes that did not opt in must not get a status entry")
+	assert.NotContains(t, result.bindings, "default/opted-out")
+	assert.NotContains(t, result.bindings, "default/unannotated")
+}
+
+// TestRouteSyncer_RouteOptIn_DisabledManagesAllRoutes pins the default: with
+// opt-in off the annotation is irrelevant and every bound route is managed.
+func TestRouteSyncer_RouteOptIn_DisabledManagesAllRoutes(t *testing.T) {
+	t.Parallel()
+
+	syncer := newOptInSyncer(t, "")
+
+	result, err := syncer.getRelevantHTTPRoutes(context.Background(), nil)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(result.accepted))
+	for i := range result.accepted {
+		names = append(names, result.accepted[i].Name)
+	}
+
+	assert.ElementsMatch(t, []string{"opted-in", "opted-out", "unannotated"}, names)
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"log/slog"
 	"slices"
 	"strings"
 
@@ -29,6 +30,12 @@ type routePartition struct {
 	// push auth for the dedicated plane; nil for the shared partition.
 	PerGateway *config.PerGatewayConfig
 
+	// TunnelOverride is set only on the ingress-sync views produced by
+	// splitSharedTunnelOverrides: the shared-plane routes of Gateways carrying
+	// config.TunnelIDAnnotation, written to that tunnel's document instead of
+	// the class tunnel's. Proxy pushes never see these views.
+	TunnelOverride string
+
 	HTTPRoutes []gatewayv1.HTTPRoute
 	GRPCRoutes []gatewayv1.GRPCRoute
 }
@@ -55,6 +62,18 @@ type infraGateways struct {
 	// reconcile requeued so a newly-joined pod is not stranded configless until
 	// an unrelated event.
 	transient map[string]bool
+	// tunnelOverrides maps a shared-mode Gateway key to the canonical tunnel
+	// ID from its config.TunnelIDAnnotation. The Gateway stays on the shared
+	// data plane; only its tunnel ingress document changes.
+	tunnelOverrides map[string]string
+	// invalidOverrides holds shared-mode Gateways whose TunnelIDAnnotation is
+	// not a UUID. Their routes fail closed: writing them to the class tunnel
+	// would publish hostnames on the tunnel the operator is migrating away
+	// from.
+	invalidOverrides map[string]bool
+	// healthChecks maps a partition key to the hostnames its Gateways opted
+	// into via config.HealthCheckHostnameAnnotation, sorted and deduplicated.
+	healthChecks map[string][]string
 }
 
 // isBroken reports whether the Gateway key opted in but failed to resolve.
@@ -92,6 +111,98 @@ func (g *infraGateways) isResolved(key string) bool {
 	return ok
 }
 
+// tunnelOverride returns the tunnel a shared-mode Gateway pinned via
+// config.TunnelIDAnnotation. Nil-safe.
+func (g *infraGateways) tunnelOverride(key string) (string, bool) {
+	if g == nil {
+		return "", false
+	}
+
+	tunnelID, ok := g.tunnelOverrides[key]
+
+	return tunnelID, ok
+}
+
+// partitionKey returns the key of the partition whose tunnel document serves
+// the Gateway: its own for a resolved infra Gateway, the override view for a
+// pinned shared-mode Gateway, the shared partition otherwise. Nil-safe.
+func (g *infraGateways) partitionKey(gatewayKey string) string {
+	if g.isResolved(gatewayKey) {
+		return gatewayKey
+	}
+
+	if tunnelID, ok := g.tunnelOverride(gatewayKey); ok {
+		return tunnelOverridePartitionKey(tunnelID)
+	}
+
+	return sharedPartitionKey
+}
+
+// healthCheckHostnames returns the sorted, deduplicated synthetic health
+// hostnames of the given partitions. Nil-safe.
+func (g *infraGateways) healthCheckHostnames(partitionKeys []string) []string {
+	if g == nil {
+		return nil
+	}
+
+	var hostnames []string
+
+	for _, key := range partitionKeys {
+		hostnames = append(hostnames, g.healthChecks[key]...)
+	}
+
+	slices.Sort(hostnames)
+
+	return slices.Compact(hostnames)
+}
+
+// recordHealthCheck records the Gateway's synthetic health hostname against
+// the partition serving it, if it carries one. A malformed value is logged and
+// skipped; the Gateway's routes are unaffected.
+func (g *infraGateways) recordHealthCheck(logger *slog.Logger, key string, gateway *gatewayv1.Gateway) {
+	hostname, ok, err := config.HealthCheckHostname(gateway)
+	if err != nil {
+		logger.Warn("gateway health-check hostname is invalid; not publishing the health endpoint",
+			"gateway", key, "error", err)
+
+		return
+	}
+
+	if !ok {
+		return
+	}
+
+	partitionKey := g.partitionKey(key)
+	if !slices.Contains(g.healthChecks[partitionKey], hostname) {
+		g.healthChecks[partitionKey] = append(g.healthChecks[partitionKey], hostname)
+	}
+}
+
+// hasInvalidOverride reports whether the Gateway carries a malformed
+// config.TunnelIDAnnotation. Nil-safe.
+func (g *infraGateways) hasInvalidOverride(key string) bool {
+	return g != nil && g.invalidOverrides[key]
+}
+
+// recordTunnelOverride records a shared-mode Gateway's tunnel override, if it
+// carries one. A malformed value is logged and failed closed; the Gateway
+// reconciler surfaces it as InvalidParameters on the Gateway.
+func (g *infraGateways) recordTunnelOverride(logger *slog.Logger, key string, gateway *gatewayv1.Gateway) {
+	tunnelID, ok, err := config.TunnelIDOverride(gateway)
+	if err != nil {
+		logger.Warn("gateway tunnel override is invalid; failing the gateway's routes closed",
+			"gateway", key, "error", err)
+
+		g.invalidOverrides[key] = true
+
+		return
+	}
+
+	if ok {
+		g.tunnelOverrides[key] = tunnelID
+	}
+}
+
 // resolveInfraGateways returns the per-sync view of every managed Gateway
 // opted into a dedicated data plane, keyed "namespace/name". A Gateway whose
 // parametersRef does not resolve lands in the broken set (not an error): its
@@ -111,20 +222,33 @@ func (s *RouteSyncer) resolveInfraGateways(ctx context.Context) (*infraGateways,
 
 	logger := logging.FromContext(ctx)
 	out := &infraGateways{
-		resolved:  make(map[string]*infraGateway),
-		broken:    make(map[string]bool),
-		transient: make(map[string]bool),
+		resolved:         make(map[string]*infraGateway),
+		broken:           make(map[string]bool),
+		transient:        make(map[string]bool),
+		tunnelOverrides:  make(map[string]string),
+		invalidOverrides: make(map[string]bool),
+		healthChecks:     make(map[string][]string),
 	}
 
 	for i := range gateways.Items {
 		gateway := &gateways.Items[i]
 
-		if !classNames[string(gateway.Spec.GatewayClassName)] || !config.HasInfrastructureParametersRef(gateway) {
+		if !classNames[string(gateway.Spec.GatewayClassName)] {
 			continue
 		}
 
 		key := gateway.Namespace + "/" + gateway.Name
 
+		if !config.HasInfrastructureParametersRef(gateway) {
+			out.recordTunnelOverride(logger, key, gateway)
+
+			if !out.hasInvalidOverride(key) {
+				out.recordHealthCheck(logger, key, gateway)
+			}
+
+			continue
+		}
+
 		perGateway, resolveErr := s.ConfigResolver.ResolveForGateway(ctx, gateway)
 		if resolveErr != nil || perGateway == nil {
 			// DELIBERATE: transient resolve failures land in broken alongside
@@ -154,6 +278,8 @@ func (s *RouteSyncer) resolveInfraGateways(ctx context.Context) (*infraGateways,
 			gateway:    gateways.Items[i],
 			perGateway: perGateway,
 		}
+
+		out.recordHealthCheck(logger, key, gateway)
 	}
 
 	return out, nil
@@ -239,8 +365,9 @@ func partitionKeysFor(binding routeBindingInfo, infra *infraGateways) []string {
 			continue
 		}
 
-		if infra.isBroken(gatewayKey) {
-			// Opted in but unresolvable: serve nowhere.
+		if infra.isBroken(gatewayKey) || infra.hasInvalidOverride(gatewayKey) {
+			// Opted in but unresolvable, or pinned to a malformed tunnel:
+			// serve nowhere.
 			continue
 		}
 
@@ -255,6 +382,99 @@ func partitionKeysFor(binding routeBindingInfo, infra *infraGateways) []string {
 	return keys
 }
 
+// tunnelOverridePartitionKey keys the ingress-sync view of the shared-plane
+// routes pinned to tunnelID via config.TunnelIDAnnotation.
+func tunnelOverridePartitionKey(tunnelID string) string {
+	return sharedPartitionKey + "@" + tunnelID
+}
+
+// splitSharedTunnelOverrides returns the partitions as the TUNNEL DOCUMENTS
+// see them: the shared partition is split so that routes accepted on a
+// Gateway with config.TunnelIDAnnotation land in a view for that tunnel, and
+// only routes accepted on an un-pinned shared Gateway stay on the class
+// tunnel. A route accepted on both kinds of Gateway appears in both. The
+// shared partition itself is always kept (its document must converge to
+// empty); override views follow in tunnel order, then the infra partitions.
+// The input is not modified — the proxy push keeps using the unsplit shared
+// partition, since every shared-plane proxy serves every shared route.
+func splitSharedTunnelOverrides(
+	partitions []routePartition,
+	httpResult *httpRouteResult,
+	grpcResult *grpcRouteResult,
+	infra *infraGateways,
+) []routePartition {
+	if infra == nil || len(infra.tunnelOverrides) == 0 || len(partitions) == 0 {
+		return partitions
+	}
+
+	// tunnelsFor lists the tunnels a shared-plane route is written to: "" for
+	// the class tunnel, plus each pinned tunnel.
+	tunnelsFor := func(binding routeBindingInfo) []string {
+		var tunnels []string
+
+		for gatewayKey := range binding.acceptedGateways {
+			if infra.isResolved(gatewayKey) || infra.isBroken(gatewayKey) || infra.hasInvalidOverride(gatewayKey) {
+				continue
+			}
+
+			tunnelID, _ := infra.tunnelOverride(gatewayKey)
+			if !slices.Contains(tunnels, tunnelID) {
+				tunnels = append(tunnels, tunnelID)
+			}
+		}
+
+		return tunnels
+	}
+
+	shared := partitions[0]
+	byTunnel := map[string]*routePartition{"": {Key: sharedPartitionKey}}
+
+	viewFor := func(tunnelID string) *routePartition {
+		view, ok := byTunnel[tunnelID]
+		if !ok {
+			view = &routePartition{Key: tunnelOverridePartitionKey(tunnelID), TunnelOverride: tunnelID}
+			byTunnel[tunnelID] = view
+		}
+
+		return view
+	}
+
+	for i := range shared.HTTPRoutes {
+		route := &shared.HTTPRoutes[i]
+		for _, tunnelID := range tunnelsFor(httpResult.bindings[route.Namespace+"/"+route.Name]) {
+			view := viewFor(tunnelID)
+			view.HTTPRoutes = append(view.HTTPRoutes, *route)
+		}
+	}
+
+	for i := range shared.GRPCRoutes {
+		route := &shared.GRPCRoutes[i]
+		for _, tunnelID := range tunnelsFor(grpcResult.bindings[route.Namespace+"/"+route.Name]) {
+			view := viewFor(tunnelID)
+			view.GRPCRoutes = append(view.GRPCRoutes, *route)
+		}
+	}
+
+	tunnels := make([]string, 0, len(byTunnel))
+
+	for tunnelID := range byTunnel {
+		if tunnelID != "" {
+			tunnels = append(tunnels, tunnelID)
+		}
+	}
+
+	slices.Sort(tunnels)
+
+	out := make([]routePartition, 0, len(partitions)+len(tunnels))
+	out = append(out, *byTunnel[""])
+
+	for _, tunnelID := range tunnels {
+		out = append(out, *byTunnel[tunnelID])
+	}
+
+	return append(out, partitions[1:]...)
+}
+
 // unionPartitionRoutes rewrites each partition's route set to the UNION of
 // all partitions sharing its tunnel. Cloudflare load-balances a tunnel's
 // requests across ALL its connectors, so every data plane on one tunnel must
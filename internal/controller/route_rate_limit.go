@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cockroachdb/errors"
+)
+
+// cloudflareRetryAfter reports whether err is a Cloudflare 429 and, if so,
+// how long the response asks callers to back off: Retry-After-Ms, then
+// Retry-After in seconds or as an HTTP date. A 429 without a usable header
+// backs off for fallback.
+func cloudflareRetryAfter(err error, now time.Time, fallback time.Duration) (time.Duration, bool) {
+	var apiErr *cloudflare.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	if apiErr.Response == nil {
+		return fallback, true
+	}
+
+	header := apiErr.Response.Header
+
+	if ms, parseErr := strconv.ParseFloat(header.Get("Retry-After-Ms"), 64); parseErr == nil && ms > 0 {
+		return time.Duration(ms * float64(time.Millisecond)), true
+	}
+
+	retryAfter := header.Get("Retry-After")
+
+	if seconds, parseErr := strconv.Atoi(retryAfter); parseErr == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, parseErr := http.ParseTime(retryAfter); parseErr == nil && at.After(now) {
+		return at.Sub(now), true
+	}
+
+	return fallback, true
+}
+
+// holdForRateLimit suspends Cloudflare calls for every tunnel until the
+// Retry-After window of a 429 elapses. Other errors are ignored, and a
+// shorter window never cuts an existing hold short. Caller holds syncMu.
+func (s *RouteSyncer) holdForRateLimit(logger *slog.Logger, err error) {
+	now := s.clock()
+
+	wait, limited := cloudflareRetryAfter(err, now, s.apiErrorDelay())
+	if !limited {
+		return
+	}
+
+	if until := now.Add(wait); until.After(s.rateLimitedUntil) {
+		s.rateLimitedUntil = until
+
+		logger.Warn("Cloudflare API rate limit hit; holding tunnel syncs back",
+			"until", until.Format(time.RFC3339))
+	}
+}
+
+// rateLimitHold returns when the current rate-limit hold ends, or the zero
+// time when Cloudflare calls may proceed. Caller holds syncMu.
+func (s *RouteSyncer) rateLimitHold() time.Time {
+	if s.rateLimitedUntil.After(s.clock()) {
+		return s.rateLimitedUntil
+	}
+
+	return time.Time{}
+}
+
+// apiErrorRequeue is the requeue delay after a failed tunnel sync: the usual
+// apiErrorDelay, stretched to the end of a rate-limit hold so the retry does
+// not arrive while Cloudflare still refuses calls. Caller holds syncMu.
+func (s *RouteSyncer) apiErrorRequeue() time.Duration {
+	if until := s.rateLimitHold(); !until.IsZero() {
+		return max(s.apiErrorDelay(), until.Sub(s.clock()))
+	}
+
+	return s.apiErrorDelay()
+}
+
+// apiErrorDelay is APIErrorRequeueDelay, or apiErrorRequeueDelay when unset.
+func (s *RouteSyncer) apiErrorDelay() time.Duration {
+	if s == nil || s.APIErrorRequeueDelay <= 0 {
+		return apiErrorRequeueDelay
+	}
+
+	return s.APIErrorRequeueDelay
+}
+
+// startupPendingDelay is StartupPendingRequeueDelay, or
+// startupPendingRequeueDelay when unset.
+func (s *RouteSyncer) startupPendingDelay() time.Duration {
+	if s == nil || s.StartupPendingRequeueDelay <= 0 {
+		return startupPendingRequeueDelay
+	}
+
+	return s.StartupPendingRequeueDelay
+}
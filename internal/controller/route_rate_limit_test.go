@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/option"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+// TestSyncAllRoutes_RateLimitHoldsConcurrentSync pins the shared rate-limit
+// gate: a 429 from one sync holds back a concurrent sync's Cloudflare calls
+// until the Retry-After window elapses, both requeue for the window's end,
+// and the first sync after it writes the pending change.
+func TestSyncAllRoutes_RateLimitHoldsConcurrentSync(t *testing.T) {
+	t.Parallel()
+
+	const classTunnel = "99999999-9999-4999-8999-999999999999"
+
+	ctx := context.Background()
+	api := newRecordingTunnelAPI(t)
+	syncer := newPartitionSyncSyncer(t, api, classTunnel)
+	// No client-side retries: the 429 reaches the syncer at once.
+	syncer.cloudflareClientFactory = func(_ *config.ResolvedConfig) *cloudflare.Client {
+		return cloudflare.NewClient(
+			option.WithAPIToken("test-token"),
+			option.WithBaseURL(api.server.URL),
+			option.WithMaxRetries(0),
+		)
+	}
+
+	var clockMu sync.Mutex
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	syncer.now = func() time.Time {
+		clockMu.Lock()
+		defer clockMu.Unlock()
+
+		return now
+	}
+
+	_, _, err := syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	baseline := api.attemptedPuts()
+	writes := api.writesTo(classTunnel)
+
+	require.NoError(t, syncer.Client.Create(ctx, partitionSyncRoute("late-route", "shared-gw", "late.example.com")))
+	api.rateLimitNextPut("60")
+
+	var wg sync.WaitGroup
+
+	results := make([]ctrl.Result, 2)
+
+	for i := range results {
+		wg.Go(func() {
+			results[i], _, _ = syncer.SyncAllRoutes(ctx)
+		})
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, baseline+1, api.attemptedPuts(), "the second sync must not call Cloudflare while the hold lasts")
+	assert.Equal(t, writes, api.writesTo(classTunnel))
+
+	for i, result := range results {
+		assert.Equal(t, time.Minute, result.RequeueAfter, "sync %d requeues for the end of the Retry-After window", i)
+	}
+
+	clockMu.Lock()
+	now = now.Add(time.Minute)
+	clockMu.Unlock()
+
+	result, _, err := syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+	assert.Equal(t, writes+1, api.writesTo(classTunnel))
+	assert.Contains(t, api.hostnamesFor(classTunnel), "late.example.com")
+}
+
+func TestCloudflareRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	rateLimited := func(header http.Header) error {
+		return errors.Wrap(&cloudflare.Error{
+			StatusCode: http.StatusTooManyRequests,
+			Response:   &http.Response{Header: header},
+		}, "updating tunnel configuration")
+	}
+
+	tests := []struct {
+		name        string
+		err         error
+		wantWait    time.Duration
+		wantLimited bool
+	}{
+		{name: "seconds", err: rateLimited(http.Header{"Retry-After": {"30"}}), wantWait: 30 * time.Second, wantLimited: true},
+		{
+			name:        "http date",
+			err:         rateLimited(http.Header{"Retry-After": {now.Add(2 * time.Minute).Format(http.TimeFormat)}}),
+			wantWait:    2 * time.Minute,
+			wantLimited: true,
+		},
+		{
+			name:        "milliseconds win",
+			err:         rateLimited(http.Header{"Retry-After-Ms": {"1500"}, "Retry-After": {"30"}}),
+			wantWait:    1500 * time.Millisecond,
+			wantLimited: true,
+		},
+		{name: "no header", err: rateLimited(http.Header{}), wantWait: apiErrorRequeueDelay, wantLimited: true},
+		{name: "unparsable header", err: rateLimited(http.Header{"Retry-After": {"soon"}}), wantWait: apiErrorRequeueDelay, wantLimited: true},
+		{name: "other status", err: &cloudflare.Error{StatusCode: http.StatusInternalServerError}},
+		{name: "not an API error", err: errors.New("connection reset")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			wait, limited := cloudflareRetryAfter(tt.err, now, apiErrorRequeueDelay)
+			assert.Equal(t, tt.wantLimited, limited)
+			assert.Equal(t, tt.wantWait, wait)
+		})
+	}
+}
@@ -2,6 +2,8 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"maps"
 	"slices"
 	"strconv"
 	"strings"
@@ -297,6 +299,12 @@ func buildParentStatus(
 		conditions = append(conditions, *shared)
 	}
 
+	if dryRun := buildDiagnosticCondition(diagnostics, proxy.DiagnosticDryRun,
+		routeConditionDryRun, metav1.ConditionTrue, routeReasonWouldApply,
+		generation, now); dryRun != nil && accepted.Status == metav1.ConditionTrue {
+		conditions = append(conditions, *dryRun)
+	}
+
 	return gatewayv1.RouteParentStatus{
 		ParentRef: gatewayv1.ParentReference{
 			Group:       ref.Group,
@@ -556,6 +564,11 @@ func buildAcceptedCondition(
 		status = metav1.ConditionFalse
 		reason = override.reason
 		message = override.message
+	} else if hasBinding && bindingResult.Reason == routeReasonForceAttached {
+		// Forced onto the parent by annotation (see forceAttachIfAnnotated):
+		// served, but the reason keeps the override visible.
+		reason = routeReasonForceAttached
+		message = bindingResult.Message
 	}
 
 	return metav1.Condition{
@@ -670,6 +683,12 @@ const (
 	// boundary instead of leaving it only in a log line.
 	routeConditionTunnelShared = "cf.k8s.lex.la/TunnelShared"
 	routeReasonTunnelShared    = "TunnelSharedAcrossNamespaces"
+	// routeConditionDryRun is set True in dry-run mode when this route's tunnel
+	// document or proxy config has changes the controller computed but did not
+	// apply. Accepted still reflects the spec; this condition says the change
+	// is not live.
+	routeConditionDryRun  = "cf.k8s.lex.la/DryRun"
+	routeReasonWouldApply = "WouldApply"
 )
 
 const (
@@ -686,6 +705,11 @@ const (
 	// also surface in `kubectl events` and event-driven alerting.
 	eventReasonProxyConfigPushFailed = "ProxyConfigPushFailed"
 	eventReasonTunnelShared          = "TunnelShared"
+	// eventReasonNoMatchingListener flags a route that named a managed Gateway
+	// but was rejected by every one of its listeners (hostname, allowedRoutes,
+	// sectionName or port). The Accepted=False condition carries the spec
+	// reason; the Event makes the mismatch visible in `kubectl events`.
+	eventReasonNoMatchingListener = "NoMatchingListener"
 
 	// Event reason / action tokens for the GRPCRoute edge-toggle breadcrumb (see
 	// emitGRPCEdgeHint). The Cloudflare zone gRPC toggle is dashboard-only with no
@@ -742,12 +766,51 @@ func emitDiagnosticEvents(recorder events.EventRecorder, route runtime.Object, d
 		case proxy.DiagnosticTunnelShared:
 			// Mirror the TunnelShared=True condition (#488).
 			recorder.Eventf(route, nil, corev1.EventTypeWarning, eventReasonTunnelShared, eventActionRouteSync, "%s", diag.Message)
-		case proxy.DiagnosticAccepted, proxy.DiagnosticResolvedRefs:
+		case proxy.DiagnosticAccepted, proxy.DiagnosticResolvedRefs, proxy.DiagnosticDryRun:
 			// Condition-driving targets; no Event surface.
 		}
 	}
 }
 
+// emitListenerMismatchEvents emits a Warning Event for each parentRef that
+// resolved to a Gateway this controller manages but matched none of its
+// listeners. Parents are visited in parentRef order so repeated syncs emit
+// identical (and thus aggregated) Events. A nil recorder is a no-op.
+func emitListenerMismatchEvents(recorder events.EventRecorder, route runtime.Object, bindingInfo routeBindingInfo) {
+	if recorder == nil {
+		return
+	}
+
+	for _, refIdx := range slices.Sorted(maps.Keys(bindingInfo.bindingResults)) {
+		result := bindingInfo.bindingResults[refIdx]
+		if result.Accepted || !isListenerMismatchReason(result.Reason) {
+			continue
+		}
+
+		gateway := bindingInfo.parentGateways[refIdx]
+		if gateway == "" {
+			gateway = fmt.Sprintf("parentRefs[%d]", refIdx)
+		}
+
+		recorder.Eventf(route, nil, corev1.EventTypeWarning, eventReasonNoMatchingListener, eventActionRouteSync,
+			"route references Gateway %s but no listener accepted it (%s): %s", gateway, result.Reason, result.Message)
+	}
+}
+
+// isListenerMismatchReason reports whether a binding rejection means the
+// route reached the Gateway but no listener took it, as opposed to a later
+// policy rejection (Conflicted, HostnameNotPermitted).
+func isListenerMismatchReason(reason gatewayv1.RouteConditionReason) bool {
+	switch reason {
+	case gatewayv1.RouteReasonNoMatchingListenerHostname,
+		gatewayv1.RouteReasonNotAllowedByListeners,
+		gatewayv1.RouteReasonNoMatchingParent:
+		return true
+	default:
+		return false
+	}
+}
+
 func buildFailedRefsMessage(failedRefs []ingress.BackendRefError) string {
 	var msgBuilder strings.Builder
 
@@ -854,3 +917,15 @@ func newGRPCRouteAccessor() routeAccessor {
 		ruleCount:   func() int { return len(route.Spec.Rules) },
 	}
 }
+
+func newTCPRouteAccessor() routeAccessor {
+	route := &gatewayv1.TCPRoute{}
+
+	return routeAccessor{
+		obj:         route,
+		parentRefs:  func() []gatewayv1.ParentReference { return route.Spec.ParentRefs },
+		routeStatus: func() *gatewayv1.RouteStatus { return &route.Status.RouteStatus },
+		generation:  func() int64 { return route.Generation },
+		ruleCount:   func() int { return len(route.Spec.Rules) },
+	}
+}
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// TestSyncAllRoutes_FailedRefsDriveResolvedRefs pins the path from a sync's
+// BuildResult.FailedRefs to each route's ResolvedRefs condition: a missing
+// backend reads BackendNotFound, a cross-namespace backend without a
+// ReferenceGrant reads RefNotPermitted, and a route whose refs all resolve
+// reads ResolvedRefs=True even when its neighbours failed.
+func TestSyncAllRoutes_FailedRefsDriveResolvedRefs(t *testing.T) {
+	t.Parallel()
+
+	const classTunnel = "99999999-9999-4999-8999-999999999999"
+
+	ctx := context.Background()
+	api := newRecordingTunnelAPI(t)
+	syncer := newPartitionSyncSyncer(t, api, classTunnel)
+
+	missing := partitionSyncRoute("missing-backend", "shared-gw", "missing.example.com")
+	missing.Spec.Rules[0].BackendRefs[0].Name = "no-such-svc"
+
+	denied := partitionSyncRoute("denied-backend", "shared-gw", "denied.example.com")
+	deniedNamespace := gatewayv1.Namespace("other")
+	denied.Spec.Rules[0].BackendRefs[0].Namespace = &deniedNamespace
+
+	require.NoError(t, syncer.Client.Create(ctx, missing))
+	require.NoError(t, syncer.Client.Create(ctx, denied))
+
+	_, result, err := syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	resolvedRefs := make(map[string]metav1.Condition)
+
+	for _, entry := range result.httpStatusEntries(nil, nil) {
+		resolvedRefs[entry.name] = buildResolvedRefsCondition(1, metav1.Now(), entry.failedRefs, entry.diagnostics)
+	}
+
+	tests := []struct {
+		route      string
+		wantStatus metav1.ConditionStatus
+		wantReason gatewayv1.RouteConditionReason
+	}{
+		{route: "missing-backend", wantStatus: metav1.ConditionFalse, wantReason: gatewayv1.RouteReasonBackendNotFound},
+		{route: "denied-backend", wantStatus: metav1.ConditionFalse, wantReason: gatewayv1.RouteReasonRefNotPermitted},
+		{route: "shared-route", wantStatus: metav1.ConditionTrue, wantReason: gatewayv1.RouteReasonResolvedRefs},
+	}
+
+	for _, tt := range tests {
+		condition, ok := resolvedRefs[tt.route]
+		require.True(t, ok, "route %s must get a status entry", tt.route)
+		assert.Equal(t, tt.wantStatus, condition.Status, tt.route)
+		assert.Equal(t, string(tt.wantReason), condition.Reason, tt.route)
+	}
+}
@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// syncDebouncer collapses the full-sync requests that arrive within one
+// window into a single sync whose outcome every waiting caller receives.
+//
+// The first request opens a batch and the window starts. Requests arriving
+// before it ends join the batch. When the window ends the batch closes and
+// only then does the sync run, so it lists the cluster after every joined
+// request arrived: no change that triggered a request is lost. A request
+// arriving after the batch closed, even while its sync is still running,
+// opens the next batch, which serializes behind the running sync on
+// RouteSyncer.syncMu.
+//
+// The debouncer runs as a leader-election runnable: a batch still pending or
+// syncing when the manager stops or leadership is lost is dropped, so a
+// non-leader never writes a tunnel.
+type syncDebouncer struct {
+	window time.Duration
+	sync   func(ctx context.Context) (ctrl.Result, *SyncResult, error)
+
+	mu       sync.Mutex
+	pending  *debouncedSync
+	lifetime context.Context //nolint:containedctx // the runnable's lifetime, set by Start
+}
+
+// debouncedSync is one batch of requests and, once done is closed, the
+// outcome of its sync.
+type debouncedSync struct {
+	done chan struct{}
+
+	result     ctrl.Result
+	syncResult *SyncResult
+	err        error
+}
+
+// NeedLeaderElection ties the debouncer's lifetime to leadership.
+func (d *syncDebouncer) NeedLeaderElection() bool {
+	return true
+}
+
+// Start records ctx as the lifetime of every batch and blocks until it ends.
+func (d *syncDebouncer) Start(ctx context.Context) error {
+	d.mu.Lock()
+	d.lifetime = ctx
+	d.mu.Unlock()
+
+	<-ctx.Done()
+
+	return nil
+}
+
+// request joins the open batch, or opens one, and waits for its sync. A
+// caller whose context ends first gets the context error; the batch still
+// syncs for the others. The sync runs with the values of the request that
+// opened the batch, detached from its cancellation but bound to the
+// debouncer's lifetime.
+func (d *syncDebouncer) request(ctx context.Context) (ctrl.Result, *SyncResult, error) {
+	d.mu.Lock()
+
+	batch := d.pending
+	if batch == nil {
+		batch = &debouncedSync{done: make(chan struct{})}
+		d.pending = batch
+
+		lifetime := d.lifetime
+		if lifetime == nil {
+			lifetime = context.Background()
+		}
+
+		batchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		stop := context.AfterFunc(lifetime, cancel)
+
+		go func() {
+			defer cancel()
+			defer stop()
+
+			d.run(batchCtx, batch)
+		}()
+	}
+
+	d.mu.Unlock()
+
+	select {
+	case <-batch.done:
+		return batch.result, batch.syncResult, batch.err
+	case <-ctx.Done():
+		return ctrl.Result{}, nil, errors.Wrap(ctx.Err(), "waiting for debounced sync")
+	}
+}
+
+// run waits out the window, closes the batch and syncs once for it. When ctx
+// ends during the window the batch is closed and dropped without a sync.
+func (d *syncDebouncer) run(ctx context.Context, batch *debouncedSync) {
+	timer := time.NewTimer(d.window)
+	defer timer.Stop()
+
+	var canceled bool
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		canceled = true
+	}
+
+	d.mu.Lock()
+	d.pending = nil
+	d.mu.Unlock()
+
+	if canceled {
+		batch.err = errors.Wrap(ctx.Err(), "debounced sync dropped")
+	} else {
+		batch.result, batch.syncResult, batch.err = d.sync(ctx)
+	}
+
+	close(batch.done)
+}
+
+// debounce returns the syncer's debouncer, built from SyncDebounce on first
+// use. The manager adds it as a runnable.
+func (s *RouteSyncer) debounce() *syncDebouncer {
+	s.debouncerOnce.Do(func() {
+		s.debouncer = &syncDebouncer{window: s.SyncDebounce, sync: s.SyncAllRoutes}
+	})
+
+	return s.debouncer
+}
+
+// requestSync runs the full sync a route reconcile asks for. With
+// SyncDebounce set, requests arriving within that window share one sync.
+func (s *RouteSyncer) requestSync(ctx context.Context) (ctrl.Result, *SyncResult, error) {
+	if s.SyncDebounce <= 0 {
+		return s.SyncAllRoutes(ctx)
+	}
+
+	return s.debounce().request(ctx)
+}
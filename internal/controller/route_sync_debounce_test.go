@@ -0,0 +1,202 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// TestRequestSync_BurstCollapsesIntoOneSync pins the debounce contract on a
+// bulk apply: ten route changes requesting a sync within the window share a
+// single sync and a single tunnel write, and that write carries every route,
+// including the one applied last.
+func TestRequestSync_BurstCollapsesIntoOneSync(t *testing.T) {
+	t.Parallel()
+
+	const classTunnel = "99999999-9999-4999-8999-999999999999"
+
+	ctx := context.Background()
+	api := newRecordingTunnelAPI(t)
+	syncer := newPartitionSyncSyncer(t, api, classTunnel)
+	syncer.SyncDebounce = 500 * time.Millisecond
+
+	_, _, err := syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, api.writesTo(classTunnel))
+
+	const burst = 10
+
+	var wg sync.WaitGroup
+
+	results := make([]*SyncResult, burst)
+	errs := make([]error, burst)
+
+	for i := range burst {
+		hostname := fmt.Sprintf("app-%d.example.com", i)
+		require.NoError(t, syncer.Client.Create(ctx, partitionSyncRoute(fmt.Sprintf("app-%d", i), "shared-gw", hostname)))
+
+		wg.Go(func() {
+			_, results[i], errs[i] = syncer.requestSync(ctx)
+		})
+	}
+
+	wg.Wait()
+
+	for i := range burst {
+		require.NoError(t, errs[i])
+		require.NotNil(t, results[i])
+		assert.Same(t, results[0], results[i], "request %d must share the burst's single sync", i)
+	}
+
+	assert.Equal(t, 2, api.writesTo(classTunnel), "the burst makes exactly one write")
+
+	hostnames := api.hostnamesFor(classTunnel)
+	for i := range burst {
+		assert.Contains(t, hostnames, fmt.Sprintf("app-%d.example.com", i))
+	}
+}
+
+// TestSyncDebouncer_RequestDuringSyncGetsNextSync pins that no change is
+// lost: a request arriving while the batch's sync is already running does
+// not reuse that sync, which may have listed the cluster before the change,
+// but waits for the next one.
+func TestSyncDebouncer_RequestDuringSyncGetsNextSync(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	debouncer := &syncDebouncer{
+		window: 10 * time.Millisecond,
+		sync: func(context.Context) (ctrl.Result, *SyncResult, error) {
+			if calls.Add(1) == 1 {
+				close(started)
+				<-release
+			}
+
+			return ctrl.Result{}, &SyncResult{}, nil
+		},
+	}
+
+	ctx := context.Background()
+	first := make(chan *SyncResult)
+
+	go func() {
+		_, result, _ := debouncer.request(ctx)
+		first <- result
+	}()
+
+	<-started
+
+	second := make(chan *SyncResult)
+
+	go func() {
+		_, result, _ := debouncer.request(ctx)
+		second <- result
+	}()
+
+	// Let the second request open its own batch before the first sync ends.
+	time.Sleep(5 * time.Millisecond)
+	close(release)
+
+	firstResult, secondResult := <-first, <-second
+
+	assert.Equal(t, int32(2), calls.Load(), "the late request triggers a second sync")
+	assert.NotSame(t, firstResult, secondResult)
+}
+
+// TestSyncDebouncer_CanceledWaiterDoesNotCancelSync pins that a caller whose
+// context ends stops waiting without aborting the shared sync.
+func TestSyncDebouncer_CanceledWaiterDoesNotCancelSync(t *testing.T) {
+	t.Parallel()
+
+	synced := make(chan error, 1)
+
+	debouncer := &syncDebouncer{
+		window: 20 * time.Millisecond,
+		sync: func(ctx context.Context) (ctrl.Result, *SyncResult, error) {
+			synced <- ctx.Err()
+
+			return ctrl.Result{}, &SyncResult{}, nil
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, result, err := debouncer.request(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, result)
+
+	select {
+	case syncErr := <-synced:
+		assert.NoError(t, syncErr, "the sync runs detached from the opener's cancellation")
+	case <-time.After(5 * time.Second):
+		t.Fatal("the batch never synced")
+	}
+}
+
+// TestSyncDebouncer_LifetimeEndDropsPendingBatch pins that a batch still in
+// its window when the debouncer's lifetime ends (manager shutdown, lost
+// leadership) is dropped without syncing, so a non-leader never writes.
+func TestSyncDebouncer_LifetimeEndDropsPendingBatch(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	debouncer := &syncDebouncer{
+		window: time.Hour,
+		sync: func(context.Context) (ctrl.Result, *SyncResult, error) {
+			calls.Add(1)
+
+			return ctrl.Result{}, &SyncResult{}, nil
+		},
+	}
+
+	lifetime, stop := context.WithCancel(context.Background())
+	started := make(chan error, 1)
+
+	go func() { started <- debouncer.Start(lifetime) }()
+
+	require.Eventually(t, func() bool {
+		debouncer.mu.Lock()
+		defer debouncer.mu.Unlock()
+
+		return debouncer.lifetime != nil
+	}, 5*time.Second, time.Millisecond)
+
+	result := make(chan error, 1)
+
+	go func() {
+		_, _, err := debouncer.request(context.Background())
+		result <- err
+	}()
+
+	require.Eventually(t, func() bool {
+		debouncer.mu.Lock()
+		defer debouncer.mu.Unlock()
+
+		return debouncer.pending != nil
+	}, 5*time.Second, time.Millisecond)
+
+	stop()
+
+	select {
+	case err := <-result:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the pending batch outlived the debouncer's lifetime")
+	}
+
+	require.NoError(t, <-started)
+	assert.Equal(t, int32(0), calls.Load(), "a dropped batch never syncs")
+}
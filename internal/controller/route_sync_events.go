@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/ingress"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/proxy"
+)
+
+// Event reason tokens for the outcome of a route sync. The Accepted and
+// ResolvedRefs conditions carry the same facts; the Events put them in
+// `kubectl events` next to the rest of the namespace's history.
+const (
+	eventReasonTunnelConfigApplied = "TunnelConfigApplied"
+	eventReasonTunnelConfigFailed  = "TunnelConfigFailed"
+	eventReasonBackendNotFound     = "BackendNotFound"
+)
+
+// emitSyncOutcomeEvents emits the outcome of a sync for one route: a Warning
+// per backendRef that does not exist, a Warning per distinct tunnel sync error
+// on an accepted parent, and a Normal TunnelConfigApplied when at least one
+// accepted parent synced. A dry-run route whose document was not written gets
+// no TunnelConfigApplied. The recorder throttles repeats across syncs (see
+// throttledRecorder); a nil recorder is a no-op.
+func emitSyncOutcomeEvents(
+	recorder events.EventRecorder,
+	route runtime.Object,
+	bindingInfo routeBindingInfo,
+	failedRefs []ingress.BackendRefError,
+	diagnostics []proxy.RouteDiagnostic,
+	syncErr error,
+) {
+	if recorder == nil {
+		return
+	}
+
+	for _, ref := range failedRefs {
+		if ref.Reason != string(gatewayv1.RouteReasonBackendNotFound) {
+			continue
+		}
+
+		recorder.Eventf(route, nil, corev1.EventTypeWarning, eventReasonBackendNotFound, eventActionRouteSync,
+			"backend %s/%s not found: %s", ref.BackendNS, ref.BackendName, ref.Message)
+	}
+
+	applied := false
+	reported := make(map[string]struct{})
+
+	for _, refIdx := range slices.Sorted(maps.Keys(bindingInfo.bindingResults)) {
+		if !bindingInfo.bindingResults[refIdx].Accepted {
+			continue
+		}
+
+		err := perParentSyncErr(bindingInfo, refIdx, syncErr)
+		if err == nil {
+			applied = true
+
+			continue
+		}
+
+		if _, duplicate := reported[err.Error()]; duplicate {
+			continue
+		}
+
+		reported[err.Error()] = struct{}{}
+
+		recorder.Eventf(route, nil, corev1.EventTypeWarning, eventReasonTunnelConfigFailed, eventActionRouteSync,
+			"tunnel configuration for Gateway %s was not applied: %v", bindingInfo.parentGateways[refIdx], err)
+	}
+
+	if !applied || slices.ContainsFunc(diagnostics, func(diag proxy.RouteDiagnostic) bool {
+		return diag.Target == proxy.DiagnosticDryRun
+	}) {
+		return
+	}
+
+	recorder.Eventf(route, nil, corev1.EventTypeNormal, eventReasonTunnelConfigApplied, eventActionRouteSync,
+		"route applied to the Cloudflare tunnel configuration")
+}
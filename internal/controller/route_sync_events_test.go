@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/events"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/ingress"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/proxy"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/routebinding"
+)
+
+// TestSyncAllRoutes_OutcomeEvents pins the Events a sync leaves on its
+// routes: TunnelConfigApplied for a route that reached the tunnel, and
+// BackendNotFound for one whose Service does not exist.
+func TestSyncAllRoutes_OutcomeEvents(t *testing.T) {
+	t.Parallel()
+
+	const classTunnel = "99999999-9999-4999-8999-999999999999"
+
+	ctx := context.Background()
+	api := newRecordingTunnelAPI(t)
+	syncer := newPartitionSyncSyncer(t, api, classTunnel)
+
+	broken := partitionSyncRoute("broken", "shared-gw", "broken.example.com")
+	broken.Spec.Rules[0].BackendRefs[0].Name = "missing"
+
+	require.NoError(t, syncer.Client.Create(ctx, partitionSyncRoute("web", "shared-gw", "web.example.com")))
+	require.NoError(t, syncer.Client.Create(ctx, broken))
+
+	_, result, err := syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	recorders := map[string]*events.FakeRecorder{}
+	entries := result.httpStatusEntries(nil, func(
+		_ context.Context,
+		route *gatewayv1.HTTPRoute,
+		bindingInfo routeBindingInfo,
+		failedRefs []ingress.BackendRefError,
+		diagnostics []proxy.RouteDiagnostic,
+		syncErr error,
+	) error {
+		rec := events.NewFakeRecorder(10)
+		recorders[route.Name] = rec
+		emitSyncOutcomeEvents(rec, route, bindingInfo, failedRefs, diagnostics, syncErr)
+
+		return nil
+	})
+	require.NoError(t, updateRoutesStatus(ctx, slog.Default(), entries, nil))
+
+	require.Contains(t, recorders, "web")
+	assert.Equal(t, []string{"Normal TunnelConfigApplied route applied to the Cloudflare tunnel configuration"},
+		drainEvents(recorders["web"]))
+
+	require.Contains(t, recorders, "broken")
+
+	var notFound []string
+
+	for _, event := range drainEvents(recorders["broken"]) {
+		if strings.HasPrefix(event, "Warning BackendNotFound ") {
+			notFound = append(notFound, event)
+		}
+	}
+
+	require.Len(t, notFound, 1)
+	assert.Contains(t, notFound[0], "backend default/missing not found")
+}
+
+// TestEmitSyncOutcomeEvents_Failures pins that a tunnel sync error on an
+// accepted parent is a TunnelConfigFailed Warning instead of
+// TunnelConfigApplied, that a dry-run route is not reported as applied, and
+// that a rejected parent reports nothing.
+func TestEmitSyncOutcomeEvents_Failures(t *testing.T) {
+	t.Parallel()
+
+	route := &gatewayv1.HTTPRoute{}
+	accepted := routeBindingInfo{
+		bindingResults: map[int]routebinding.BindingResult{0: {Accepted: true}},
+		parentGateways: map[int]string{0: "default/gw"},
+	}
+
+	rec := events.NewFakeRecorder(10)
+	emitSyncOutcomeEvents(rec, route, accepted, nil, nil, errors.New("tunnel API unavailable"))
+	assert.Equal(t, []string{
+		"Warning TunnelConfigFailed tunnel configuration for Gateway default/gw was not applied: tunnel API unavailable",
+	}, drainEvents(rec))
+
+	rec = events.NewFakeRecorder(10)
+	emitSyncOutcomeEvents(rec, route, accepted, nil, []proxy.RouteDiagnostic{{Target: proxy.DiagnosticDryRun}}, nil)
+	assert.Empty(t, drainEvents(rec))
+
+	rec = events.NewFakeRecorder(10)
+	emitSyncOutcomeEvents(rec, route, routeBindingInfo{
+		bindingResults: map[int]routebinding.BindingResult{0: {Accepted: false}},
+	}, nil, nil, nil)
+	assert.Empty(t, drainEvents(rec))
+
+	assert.NotPanics(t, func() { emitSyncOutcomeEvents(nil, route, accepted, nil, nil, nil) })
+}
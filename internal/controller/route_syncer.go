@@ -48,9 +48,10 @@ type RouteSyncer struct {
 	Metrics        cfmetrics.Collector
 	Logger         *slog.Logger
 
-	httpBuilder      *ingress.Builder
-	grpcBuilder      *ingress.GRPCBuilder
-	bindingValidator *routebinding.Validator
+	httpBuilder       *ingress.Builder
+	grpcBuilder       *ingress.GRPCBuilder
+	bindingValidator  *routebinding.Validator
+	refGrantValidator *referencegrant.Validator
 
 	// HostnameOwnership, when non-nil, enables the controller-side layer of
 	// the per-namespace hostname-ownership policy (#475): a route whose
@@ -61,21 +62,111 @@ type RouteSyncer struct {
 	// plane stays clean even when the admission layer is absent or bypassed.
 	HostnameOwnership *hostnameownership.Policy
 
+	// RouteOptInAnnotation, when non-empty, limits management to routes
+	// carrying this annotation with the value "true" (see routeOptedIn), so
+	// teams can move routes onto the controller one at a time. Empty manages
+	// every route bound to our Gateways.
+	RouteOptInAnnotation string
+
+	// AllowForceAttach lets a route carrying ForceAttachAnnotation bind to
+	// Gateways whose listeners rejected it (see forceAttachIfAnnotated). A
+	// staging debugging aid, off by default.
+	AllowForceAttach bool
+
 	// ViewStore caches the per-Gateway ListenerSet merge view across reconciles
 	// (issue #332). Set by the manager after construction and shared with the
 	// other reconcilers. nil disables cross-reconcile reuse (per-pass dedup
 	// still applies).
 	ViewStore *mergeViewStore
 
+	// SharedTunnelPolicy decides whether GatewayClasses with different
+	// GatewayClassConfigs naming the same tunnel are merged into one ingress
+	// document (SharedTunnelPolicyMerge) or rejected as conflicting (any other
+	// value, SharedTunnelPolicyWarn by default). Set by the manager.
+	SharedTunnelPolicy string
+
+	// DryRun computes and logs the tunnel ingress document and the proxy
+	// config but never writes the one to Cloudflare nor pushes the other to
+	// the proxy. Routes with pending changes carry a DryRun=True condition
+	// instead of looking applied. Set by the manager.
+	DryRun bool
+
+	// GRPCCatchAll decides whether gRPC-only hostnames get a closing rule of
+	// their own. The zero value adds none. Set by the manager.
+	GRPCCatchAll ingress.GRPCCatchAll
+
+	// SyncDebounce, when positive, collapses the full syncs route reconciles
+	// request within this window into one (see syncDebouncer). Zero syncs on
+	// every request. Set by the manager.
+	SyncDebounce time.Duration
+
+	// APIErrorRequeueDelay is how long a route reconcile waits before retrying
+	// a failed tunnel sync, and the startup sync between attempts. Zero uses
+	// apiErrorRequeueDelay. Set by the manager.
+	APIErrorRequeueDelay time.Duration
+
+	// StartupPendingRequeueDelay is how long a route reconcile that arrives
+	// before the startup sync finished waits to be retried. Zero uses
+	// startupPendingRequeueDelay. Set by the manager.
+	StartupPendingRequeueDelay time.Duration
+
+	// debouncer is built from SyncDebounce on first use.
+	debouncer     *syncDebouncer
+	debouncerOnce sync.Once
+
 	// syncMu protects concurrent calls to SyncAllRoutes.
 	// Both HTTPRouteReconciler and GRPCRouteReconciler may call SyncAllRoutes
 	// concurrently, and this mutex ensures serialized access to Cloudflare API.
 	syncMu sync.Mutex
 
+	// appliedVersions records, per tunnel ID, the Cloudflare configuration
+	// version of the last document this syncer wrote. A GET returning an
+	// older version is a stale read (eventual consistency on the API side);
+	// a document diffed against it would roll back the newer write, so the
+	// sync skips the write and requeues instead. Guarded by syncMu.
+	appliedVersions map[string]int64
+
+	// appliedConfigs caches the last desired document confirmed deployed per
+	// tunnel, so a sync that rebuilds the same document makes no API calls.
+	appliedConfigs appliedConfigCache
+
+	// appliedDNS caches, per tunnel, the hostnames whose CNAME records the
+	// last DNS sync confirmed (manageDNS).
+	appliedDNS appliedDNSCache
+
+	// appliedAccess caches, per tunnel, the Access applications the last
+	// Access sync confirmed (AccessPoliciesAnnotation).
+	appliedAccess appliedAccessCache
+
+	// rateLimitedUntil holds back every tunnel's Cloudflare calls after a
+	// 429 until the response's Retry-After window elapses (see
+	// holdForRateLimit). Shared by every route reconciler. Guarded by syncMu.
+	rateLimitedUntil time.Time
+
 	// cloudflareClientFactory overrides how the Cloudflare API client is built
 	// from the resolved credentials. nil uses the ConfigResolver's default;
 	// tests inject a factory pointing at an httptest server.
 	cloudflareClientFactory func(resolved *config.ResolvedConfig) *cloudflare.Client
+
+	// dnsRecordsFactory overrides the DNS API the manageDNS record sync
+	// uses. nil uses the Cloudflare API; tests inject a fake.
+	dnsRecordsFactory func(resolved *config.ResolvedConfig) dnsRecordAPI
+
+	// accessApplicationsFactory overrides the Access API the application
+	// sync uses. nil uses the Cloudflare API; tests inject a fake.
+	accessApplicationsFactory func(resolved *config.ResolvedConfig) accessApplicationAPI
+
+	// now overrides the clock maintenance windows are evaluated against;
+	// nil uses time.Now. Tests inject a fixed time.
+	now func() time.Time
+}
+
+func (s *RouteSyncer) clock() time.Time {
+	if s.now != nil {
+		return s.now()
+	}
+
+	return time.Now()
 }
 
 // cloudflareClient builds the API client via the injected factory when set,
@@ -107,19 +198,98 @@ func NewRouteSyncer(
 	componentLogger := logger.With("component", "route-syncer")
 
 	return &RouteSyncer{
-		Client:           c,
-		Scheme:           scheme,
-		ClusterDomain:    clusterDomain,
-		ControllerName:   controllerName,
-		ConfigResolver:   configResolver,
-		Metrics:          metricsCollector,
-		Logger:           componentLogger,
-		httpBuilder:      ingress.NewBuilder(clusterDomain, refGrantValidator, c, metricsCollector, componentLogger),
-		grpcBuilder:      ingress.NewGRPCBuilder(clusterDomain, refGrantValidator, c, metricsCollector, componentLogger),
-		bindingValidator: routebinding.NewValidator(c),
+		Client:            c,
+		Scheme:            scheme,
+		ClusterDomain:     clusterDomain,
+		ControllerName:    controllerName,
+		ConfigResolver:    configResolver,
+		Metrics:           metricsCollector,
+		Logger:            componentLogger,
+		httpBuilder:       ingress.NewBuilder(clusterDomain, refGrantValidator, c, metricsCollector, componentLogger),
+		grpcBuilder:       ingress.NewGRPCBuilder(clusterDomain, refGrantValidator, c, metricsCollector, componentLogger),
+		bindingValidator:  routebinding.NewValidator(c),
+		refGrantValidator: refGrantValidator,
 	}
 }
 
+// effectiveClusterDomain returns the cluster domain backend origins are built
+// with: the GatewayClassConfig's clusterDomain when set, the controller's
+// --cluster-domain otherwise.
+func (s *RouteSyncer) effectiveClusterDomain(resolved *config.ResolvedConfig) string {
+	if resolved != nil && resolved.ClusterDomain != "" {
+		return resolved.ClusterDomain
+	}
+
+	return s.ClusterDomain
+}
+
+// proxyOriginDefaultsFor maps the GatewayClassConfig's originRequestDefaults
+// onto the settings the proxy applies to every backend.
+func proxyOriginDefaultsFor(resolved *config.ResolvedConfig) proxy.OriginConfig {
+	if resolved == nil {
+		return proxy.OriginConfig{}
+	}
+
+	defaults := resolved.OriginRequestDefaults
+
+	origin := proxy.OriginConfig{
+		ConnectTimeout:      originDefaultDuration(defaults.ConnectTimeout),
+		TLSHandshakeTimeout: originDefaultDuration(defaults.TLSTimeout),
+		TCPKeepAlive:        originDefaultDuration(defaults.TCPKeepAlive),
+		MaxIdleConnsPerHost: int(defaults.KeepAliveConnections),
+	}
+
+	if defaults.DisableHappyEyeballs {
+		disable := true
+		origin.DisableHappyEyeballs = &disable
+	}
+
+	return origin
+}
+
+// originDefaultDuration parses an originRequestDefaults duration. The CRD
+// pattern admits only well-formed values, so anything unparsable (including
+// unset) leaves the proxy default.
+func originDefaultDuration(raw string) time.Duration {
+	duration, err := time.ParseDuration(raw)
+	if err != nil || duration < 0 {
+		return 0
+	}
+
+	return duration
+}
+
+// catchAllFor maps the GatewayClassConfig's defaultBackend onto the ingress
+// catch-all target. Unset keeps the HTTP 404.
+func catchAllFor(resolved *config.ResolvedConfig) ingress.CatchAllTarget {
+	if resolved == nil || resolved.DefaultBackend == nil {
+		return ingress.CatchAllTarget{}
+	}
+
+	backend := resolved.DefaultBackend
+	if backend.ServiceRef == nil {
+		return ingress.CatchAllTarget{URL: backend.URL}
+	}
+
+	return ingress.CatchAllTarget{
+		ServiceName:      backend.ServiceRef.Name,
+		ServiceNamespace: backend.ServiceRef.Namespace,
+		ServicePort:      backend.ServiceRef.Port,
+	}
+}
+
+// buildersFor returns the ingress builders for clusterDomain. The controller
+// default reuses the builders constructed once in NewRouteSyncer; a per-class
+// override gets a fresh pair (construction is cheap and holds no state).
+func (s *RouteSyncer) buildersFor(clusterDomain string) (*ingress.Builder, *ingress.GRPCBuilder) {
+	if clusterDomain == s.ClusterDomain {
+		return s.httpBuilder, s.grpcBuilder
+	}
+
+	return ingress.NewBuilder(clusterDomain, s.refGrantValidator, s.Client, s.Metrics, s.Logger),
+		ingress.NewGRPCBuilder(clusterDomain, s.refGrantValidator, s.Client, s.Metrics, s.Logger)
+}
+
 // routeBindingInfo contains a route and its binding validation results per parent.
 type routeBindingInfo struct {
 	// bindingResults maps ParentRef index to binding result for that parent.
@@ -168,12 +338,18 @@ type SyncResult struct {
 	HTTPFailedRefs    []ingress.BackendRefError   // Failed backend refs from HTTP routes
 	GRPCFailedRefs    []ingress.BackendRefError   // Failed backend refs from GRPC routes
 
+	// TCPRoutes are bound for status only: no listener serves them, so they
+	// reach neither the tunnel ingress documents nor the proxy.
+	TCPRoutes        []gatewayv1.TCPRoute
+	TCPRouteBindings map[string]routeBindingInfo // key: namespace/name
+
 	// RejectedHTTPRoutes and RejectedGRPCRoutes are routes that reference
 	// our Gateways but were not accepted by binding validation (e.g.,
 	// sectionName or port mismatch). Their status must be updated with
 	// Accepted=False so conformance tests can observe the rejection.
 	RejectedHTTPRoutes []gatewayv1.HTTPRoute
 	RejectedGRPCRoutes []gatewayv1.GRPCRoute
+	RejectedTCPRoutes  []gatewayv1.TCPRoute
 
 	// Partitions is the per-data-plane route split (#479): the shared
 	// partition plus one per opted-in Gateway. The proxy push delivers each
@@ -181,6 +357,21 @@ type SyncResult struct {
 	// where the push falls back to treating everything as shared.
 	Partitions []routePartition
 
+	// ClusterDomain is the cluster domain the ingress rules were built with
+	// (the GatewayClassConfig's clusterDomain, or the controller default); the
+	// proxy push builds its backend URLs with the same domain. Empty on
+	// early-error paths, where the push uses the controller default.
+	ClusterDomain string
+
+	// CatchAll is the class-level defaultBackend the ingress catch-all routes
+	// to; the proxy push serves unmatched requests from the same origin. The
+	// zero value keeps the 404.
+	CatchAll ingress.CatchAllTarget
+
+	// OriginDefaults are the class-level originRequestDefaults; the proxy
+	// push fills them into every backend whose route leaves them unset.
+	OriginDefaults proxy.OriginConfig
+
 	// SharedTunnelID is the class-resolved tunnel of the shared partition;
 	// the proxy push uses it to detect per-Gateway partitions sharing the
 	// shared tunnel (their configs must be unioned — see
@@ -195,10 +386,23 @@ type SyncResult struct {
 	TransientBrokenKeys []string
 
 	// CollisionDiagnostics are route diagnostics synthesized during sync that do
-	// not come from the converter or the proxy push — currently the
-	// cross-namespace tunnel-sharing collision (#488). The status path
+	// not come from the converter or the proxy push — the cross-namespace
+	// tunnel-sharing collision (#488), the rules left out of a tunnel document
+	// because Cloudflare would reject them and, in dry-run mode, the marker for
+	// routes whose tunnel document was not written (routes whose proxy config
+	// was not pushed get theirs from the push path). The status path
 	// concatenates them with the push diagnostics so they reach route status.
 	CollisionDiagnostics []proxy.RouteDiagnostic
+
+	// HealthCheckHostnames maps a proxy partition key to the synthetic health
+	// hostnames that partition's proxy answers (see proxyHealthCheckHostnames).
+	// Nil on early-error paths, where no health endpoint is pushed.
+	HealthCheckHostnames map[string][]string
+
+	// PausedPartitions are the proxy partition keys inside a maintenance
+	// window (see proxyPausedPartitions). Their proxy push is held back like
+	// their tunnel document; nil when no window is open.
+	PausedPartitions map[string]bool
 }
 
 // httpStatusEntries builds routeStatusEntry slice for HTTP routes,
@@ -226,9 +430,21 @@ func (sr *SyncResult) grpcStatusEntries(
 	return entries
 }
 
+// tcpStatusEntries builds routeStatusEntry slice for TCP routes, including
+// rejected routes that need Accepted=False status. TCP routes reach neither
+// the tunnel nor the proxy, so no failed refs or diagnostics apply to them.
+func (sr *SyncResult) tcpStatusEntries(
+	updateFn func(ctx context.Context, route *gatewayv1.TCPRoute, bi routeBindingInfo, fr []ingress.BackendRefError, diags []proxy.RouteDiagnostic, se error) error,
+) []routeStatusEntry {
+	entries := buildStatusEntries(sr.TCPRoutes, sr.TCPRouteBindings, nil, nil, updateFn)
+	entries = append(entries, buildStatusEntries(sr.RejectedTCPRoutes, sr.TCPRouteBindings, nil, nil, updateFn)...)
+
+	return entries
+}
+
 // routeObject is the constraint for Gateway API route types with Name and Namespace.
 type routeObject interface {
-	gatewayv1.HTTPRoute | gatewayv1.GRPCRoute
+	gatewayv1.HTTPRoute | gatewayv1.GRPCRoute | gatewayv1.TCPRoute
 }
 
 // buildStatusEntries creates routeStatusEntry slice from any route type.
@@ -305,7 +521,7 @@ type syncUpdateParams struct {
 func syncAndUpdateStatusCommon(ctx context.Context, params *syncUpdateParams) (ctrl.Result, error) {
 	logger := logging.FromContext(ctx)
 
-	result, syncResult, syncErr := params.routeSyncer.SyncAllRoutes(ctx)
+	result, syncResult, syncErr := params.routeSyncer.requestSync(ctx)
 
 	// Push config to the L7 proxy replicas (best-effort, non-blocking).
 	// Both HTTPRoutes and GRPCRoutes are pushed: the converter maps gRPC
@@ -453,6 +669,39 @@ func proxyPushFailureDiagnostics(partition *routePartition) []proxy.RouteDiagnos
 	return partitionRouteDiagnostics(partition, proxy.DiagnosticProxyConfigPush, routeReasonProxyConfigPushFailed, message)
 }
 
+// dryRunDiagnostics synthesizes a DiagnosticDryRun for each route in the
+// partition, so a route whose tunnel document was only computed does not
+// read as applied.
+func dryRunDiagnostics(partition *routePartition) []proxy.RouteDiagnostic {
+	message := "the controller runs in dry-run mode: this route's configuration was computed and logged " +
+		"but neither written to Cloudflare nor pushed to the proxy. This route remains Accepted."
+
+	return partitionRouteDiagnostics(partition, proxy.DiagnosticDryRun, routeReasonWouldApply, message)
+}
+
+// invalidRuleDiagnostics synthesizes an Accepted-target diagnostic for each
+// rule the builders left out of a tunnel document because Cloudflare would
+// reject it. The rest of the tunnel still syncs; the route reports the rule as
+// dropped (PartiallyInvalid, or Accepted=False once every rule is).
+func invalidRuleDiagnostics(rules []ingress.InvalidRule) []proxy.RouteDiagnostic {
+	diags := make([]proxy.RouteDiagnostic, 0, len(rules))
+
+	for _, rule := range rules {
+		diags = append(diags, proxy.RouteDiagnostic{
+			Namespace: rule.RouteNamespace,
+			Name:      rule.RouteName,
+			RuleIndex: rule.RuleIndex,
+			Target:    proxy.DiagnosticAccepted,
+			Reason:    string(gatewayv1.RouteReasonUnsupportedValue),
+			Message: fmt.Sprintf("%v; the rule is left out of the tunnel configuration so the "+
+				"rest of the tunnel keeps syncing.", rule.Err),
+			WholeRule: rule.WholeRule,
+		})
+	}
+
+	return diags
+}
+
 // tunnelSharedDiagnostics synthesizes a DiagnosticTunnelShared for the routes of
 // every infra Gateway that shares one Cloudflare Tunnel with another namespace's
 // Gateway (#488). Sharing a tunnel is supported but collapses per-Gateway
@@ -559,6 +808,16 @@ func pushPartitionConfigs(
 				diagnostics = append(diagnostics, proxyPushFailureDiagnostics(&syncResult.Partitions[i])...)
 			}
 		}
+
+		// A held-back proxy change is pending exactly like an unwritten
+		// tunnel document, so it marks the partition's own routes the same way.
+		if results[i].heldChange && params.routeSyncer.DryRun {
+			diagnostics = append(diagnostics, dryRunDiagnostics(&syncResult.Partitions[i])...)
+		}
+
+		if results[i].heldChange && syncResult.PausedPartitions[partition.Key] {
+			logger.Info("maintenance window: proxy config push paused", "partition", partition.Key)
+		}
 	}
 
 	if len(pushErrs) > 0 && params.onPushError != nil {
@@ -600,6 +859,9 @@ func withLostRacePushRequeue(result ctrl.Result, lostRace bool) ctrl.Result {
 type partitionPushResult struct {
 	diags []proxy.RouteDiagnostic
 	err   error
+	// heldChange marks a partition whose push was held back although its
+	// config differs from the one its proxies serve.
+	heldChange bool
 }
 
 // pushPartitionsConcurrently pushes every partition's config in parallel and
@@ -607,7 +869,9 @@ type partitionPushResult struct {
 // partition must not delay the others (#489): each SyncRoutes/SyncPartition
 // takes syncMu only to build/record (the network push runs lock-free), so
 // distinct partitions push in parallel. A push error is carried in the result,
-// never returned to the group, so one failure does not cancel the others.
+// never returned to the group, so one failure does not cancel the others. In
+// dry-run mode nothing is pushed, and inside a maintenance window the paused
+// partitions are not: their config is only built, for its diagnostics.
 func pushPartitionsConcurrently(
 	ctx context.Context,
 	params *syncUpdateParams,
@@ -624,18 +888,36 @@ func pushPartitionsConcurrently(
 		partition := &partitions[i]
 
 		group.Go(func() error {
+			key := sharedPartitionKey
+			if partition.PerGateway != nil {
+				key = partition.Key
+			}
+
+			if params.routeSyncer.DryRun || syncResult.PausedPartitions[key] {
+				results[i].diags, results[i].heldChange = params.proxySyncer.previewPartition(ctx, syncResult.ClusterDomain,
+					key, httpRoutePtrs(partition.HTTPRoutes), grpcRoutePtrs(partition.GRPCRoutes),
+					syncResult.HTTPFailedRefs, syncResult.GRPCFailedRefs, syncResult.HealthCheckHostnames[key], syncResult.CatchAll,
+					syncResult.OriginDefaults)
+
+				return nil
+			}
+
 			if partition.PerGateway == nil {
-				results[i].diags, results[i].err = params.proxySyncer.SyncRoutes(ctx, params.proxyEndpoints,
+				results[i].diags, results[i].err = params.proxySyncer.syncPartitionInDomain(ctx, syncResult.ClusterDomain,
+					sharedPartitionKey, params.proxySyncer.defaultAuthToken, params.proxyEndpoints,
 					httpRoutePtrs(partition.HTTPRoutes), grpcRoutePtrs(partition.GRPCRoutes),
-					syncResult.HTTPFailedRefs, syncResult.GRPCFailedRefs)
+					syncResult.HTTPFailedRefs, syncResult.GRPCFailedRefs, syncResult.HealthCheckHostnames[sharedPartitionKey], syncResult.CatchAll,
+					syncResult.OriginDefaults)
 
 				return nil
 			}
 
 			endpoints := []string{render.ConfigEndpointURL(partition.Gateway, params.routeSyncer.ClusterDomain)}
-			results[i].diags, results[i].err = params.proxySyncer.SyncPartition(ctx, partition.Key, partition.PerGateway.AuthToken,
-				endpoints, httpRoutePtrs(partition.HTTPRoutes), grpcRoutePtrs(partition.GRPCRoutes),
-				syncResult.HTTPFailedRefs, syncResult.GRPCFailedRefs)
+			results[i].diags, results[i].err = params.proxySyncer.syncPartitionInDomain(ctx, syncResult.ClusterDomain,
+				partition.Key, partition.PerGateway.AuthToken, endpoints,
+				httpRoutePtrs(partition.HTTPRoutes), grpcRoutePtrs(partition.GRPCRoutes),
+				syncResult.HTTPFailedRefs, syncResult.GRPCFailedRefs, syncResult.HealthCheckHostnames[partition.Key], syncResult.CatchAll,
+				syncResult.OriginDefaults)
 
 			return nil
 		})
@@ -653,7 +935,8 @@ func pushPartitionsConcurrently(
 // with the lexicographically smallest name is used (deterministic ordering).
 // A warning is logged because all GatewayClasses under one controller share
 // the same tunnel credentials — multiple classes with different parametersRef
-// may lead to unexpected behavior.
+// are rejected unless they name the same tunnel and SharedTunnelPolicy is
+// SharedTunnelPolicyMerge.
 func (s *RouteSyncer) resolveConfigForController(ctx context.Context) (*config.ResolvedConfig, error) {
 	classes, err := listGatewayClassesForController(ctx, s.Client, s.ControllerName)
 	if err != nil {
@@ -683,14 +966,26 @@ func (s *RouteSyncer) resolveConfigForController(ctx context.Context) (*config.R
 
 		// Different parametersRef means different tunnel credentials — using one
 		// class's credentials for another class's routes would silently send
-		// traffic to the wrong tunnel. Return an error to prevent data integrity issues.
+		// traffic to the wrong tunnel. Return an error to prevent data integrity
+		// issues, unless every class names the same tunnel and the operator opted
+		// into merging: routes of all classes already land in this one document.
 		if hasConflictingParametersRef(classes) {
-			return nil, errors.Wrap(
-				errors.New("conflicting parametersRef across GatewayClasses"),
-				fmt.Sprintf("classes %v for controller %s — "+
-					"one controller instance supports only one tunnel configuration",
-					names, s.ControllerName),
-			)
+			sameTunnel := classesShareOneTunnel(classes, classTunnelIDs(ctx, s.Client, classes))
+
+			if !sameTunnel || s.SharedTunnelPolicy != SharedTunnelPolicyMerge {
+				hint := "one controller instance supports only one tunnel configuration"
+				if sameTunnel {
+					hint = "they name the same tunnel; use one GatewayClassConfig or --shared-tunnel-policy=merge"
+				}
+
+				return nil, errors.Wrap(
+					errors.New("conflicting parametersRef across GatewayClasses"),
+					fmt.Sprintf("classes %v for controller %s — %s", names, s.ControllerName, hint),
+				)
+			}
+
+			s.Logger.Info("GatewayClasses name the same tunnel; merging their routes into one ingress document",
+				"classes", names, "credentialsFrom", classes[0].Name)
 		}
 	}
 
@@ -738,6 +1033,7 @@ func (s *RouteSyncer) buildResultForError(ctx context.Context) *SyncResult {
 	views := newListenerViewCache(s.Client, s.ViewStore)
 	httpResult, _ := s.getRelevantHTTPRoutes(ctx, views)
 	grpcResult, _ := s.getRelevantGRPCRoutes(ctx, views)
+	tcpResult, _ := s.getRelevantTCPRoutes(ctx, views)
 
 	// Cross-route-type conflict resolution is intentionally NOT applied here: on
 	// the config-error path a sync error drives every route to
@@ -759,10 +1055,17 @@ func (s *RouteSyncer) buildResultForError(ctx context.Context) *SyncResult {
 		result.RejectedGRPCRoutes = grpcResult.rejected
 	}
 
+	if tcpResult != nil {
+		result.TCPRoutes = tcpResult.accepted
+		result.TCPRouteBindings = tcpResult.bindings
+		result.RejectedTCPRoutes = tcpResult.rejected
+	}
+
 	return result
 }
 
-// SyncAllRoutes synchronizes all HTTPRoute and GRPCRoute resources to Cloudflare Tunnel.
+// SyncAllRoutes synchronizes all HTTPRoute and GRPCRoute resources to
+// Cloudflare Tunnel and binds TCPRoutes for status.
 //
 //nolint:funlen // complex sync logic requires length
 func (s *RouteSyncer) SyncAllRoutes(ctx context.Context) (ctrl.Result, *SyncResult, error) {
@@ -785,7 +1088,7 @@ func (s *RouteSyncer) SyncAllRoutes(ctx context.Context) (ctrl.Result, *SyncResu
 	if err != nil {
 		logger.Error("failed to resolve config from GatewayClassConfig", "error", err)
 
-		return ctrl.Result{RequeueAfter: apiErrorRequeueDelay, Priority: new(priorityRoute)}, s.buildResultForError(ctx), err
+		return ctrl.Result{RequeueAfter: s.apiErrorDelay(), Priority: new(priorityRoute)}, s.buildResultForError(ctx), err
 	}
 
 	// Canonicalize the class tunnel ID so same-tunnel grouping keys on the same
@@ -804,7 +1107,7 @@ func (s *RouteSyncer) SyncAllRoutes(ctx context.Context) (ctrl.Result, *SyncResu
 	if err != nil {
 		logger.Error("failed to resolve account ID", "error", err)
 
-		return ctrl.Result{RequeueAfter: apiErrorRequeueDelay, Priority: new(priorityRoute)}, s.buildResultForError(ctx), err
+		return ctrl.Result{RequeueAfter: s.apiErrorDelay(), Priority: new(priorityRoute)}, s.buildResultForError(ctx), err
 	}
 
 	resolvedConfig.AccountID = accountID
@@ -826,6 +1129,13 @@ func (s *RouteSyncer) SyncAllRoutes(ctx context.Context) (ctrl.Result, *SyncResu
 		return ctrl.Result{}, nil, errors.Wrap(err, "failed to list grpcroutes")
 	}
 
+	// Collect all relevant TCPRoutes with binding validation, for status only:
+	// no listener serves TCPRoute, so none is written to a tunnel.
+	tcpResult, err := s.getRelevantTCPRoutes(ctx, views)
+	if err != nil {
+		return ctrl.Result{}, nil, errors.Wrap(err, "failed to list tcproutes")
+	}
+
 	// Resolve cross-route-type (HTTPRoute vs GRPCRoute) attachment conflicts
 	// before building any config or status: the loser is moved from accepted to
 	// rejected with Accepted=False/Conflicted so it is neither served nor
@@ -838,11 +1148,11 @@ func (s *RouteSyncer) SyncAllRoutes(ctx context.Context) (ctrl.Result, *SyncResu
 	// partition's routes.
 	infra, err := s.resolveInfraGateways(ctx)
 	if err != nil {
-		return ctrl.Result{RequeueAfter: apiErrorRequeueDelay, Priority: new(priorityRoute)}, s.buildResultForError(ctx), err
+		return ctrl.Result{RequeueAfter: s.apiErrorDelay(), Priority: new(priorityRoute)}, s.buildResultForError(ctx), err
 	}
 
 	partitions := partitionRoutes(httpResult, grpcResult, infra)
-	groups := buildTunnelGroups(resolvedConfig, partitions)
+	groups := buildTunnelGroups(resolvedConfig, splitSharedTunnelOverrides(partitions, httpResult, grpcResult, infra))
 
 	// Two DISTINCT opted-in Gateways whose connector tokens parse to the SAME
 	// tunnel collapse their isolation: the edge load-balances the tunnel's
@@ -876,7 +1186,11 @@ func (s *RouteSyncer) SyncAllRoutes(ctx context.Context) (ctrl.Result, *SyncResu
 		"tunnels", len(groups),
 	)
 
-	outcome := s.syncTunnelGroups(ctx, logger, groups)
+	markMaintenancePauses(groups, s.pausedGateways(ctx, logger, s.clock()), infra,
+		routeBindings{http: httpResult.bindings, grpc: grpcResult.bindings})
+
+	clusterDomain := s.effectiveClusterDomain(resolvedConfig)
+	outcome := s.syncTunnelGroups(ctx, logger, groups, infra, views, clusterDomain)
 
 	// Attribute each failed tunnel group to exactly the parents on it: a
 	// route's parent binds to a Gateway, which maps to a partition (its own,
@@ -887,17 +1201,26 @@ func (s *RouteSyncer) SyncAllRoutes(ctx context.Context) (ctrl.Result, *SyncResu
 	injectPartitionSyncErrors(grpcResult.bindings, outcome.failedPartitions, infra)
 
 	syncResult := buildSyncResult(httpResult, grpcResult, outcome.httpFailedRefs, outcome.grpcFailedRefs)
+	syncResult.TCPRoutes = tcpResult.accepted
+	syncResult.TCPRouteBindings = tcpResult.bindings
+	syncResult.RejectedTCPRoutes = tcpResult.rejected
 	syncResult.Partitions = partitions
 	syncResult.SharedTunnelID = resolvedConfig.TunnelID
+	syncResult.ClusterDomain = clusterDomain
+	syncResult.CatchAll = catchAllFor(resolvedConfig)
+	syncResult.OriginDefaults = proxyOriginDefaultsFor(resolvedConfig)
 	syncResult.TransientBrokenKeys = infra.transientKeys()
-	syncResult.CollisionDiagnostics = collisionDiagnostics
+	syncResult.CollisionDiagnostics = append(collisionDiagnostics, outcome.dryRunDiagnostics...)
+	syncResult.CollisionDiagnostics = append(syncResult.CollisionDiagnostics, outcome.invalidRuleDiagnostics...)
+	syncResult.HealthCheckHostnames = proxyHealthCheckHostnames(groups, infra)
+	syncResult.PausedPartitions = proxyPausedPartitions(groups)
 
 	// All groups failed: total sync outage — global error, every route goes
 	// Pending (matches the historic single-tunnel failure shape).
 	if len(outcome.groupErrs) == len(groups) && len(groups) > 0 {
 		s.Metrics.RecordSyncDuration(ctx, "error", time.Since(startTime))
 
-		return ctrl.Result{RequeueAfter: apiErrorRequeueDelay, Priority: new(priorityRoute)},
+		return ctrl.Result{RequeueAfter: s.apiErrorRequeue(), Priority: new(priorityRoute)},
 			syncResult, errors.Join(outcome.groupErrs...)
 	}
 
@@ -908,7 +1231,7 @@ func (s *RouteSyncer) SyncAllRoutes(ctx context.Context) (ctrl.Result, *SyncResu
 		s.recordSyncSuccessMetrics(ctx, "partial", startTime, httpResult, grpcResult,
 			len(outcome.httpFailedRefs), len(outcome.grpcFailedRefs), outcome.totalRules)
 
-		return ctrl.Result{RequeueAfter: apiErrorRequeueDelay, Priority: new(priorityRoute)}, syncResult, nil
+		return ctrl.Result{RequeueAfter: s.apiErrorRequeue(), Priority: new(priorityRoute)}, syncResult, nil
 	}
 
 	status := "skipped"
@@ -921,9 +1244,18 @@ func (s *RouteSyncer) SyncAllRoutes(ctx context.Context) (ctrl.Result, *SyncResu
 
 	// A transient infra-resolve failure left a Gateway's routes unprogrammed
 	// (fail closed) but is retryable — requeue so the next sync re-resolves and
-	// programs them, rather than waiting for an unrelated event.
-	if len(syncResult.TransientBrokenKeys) > 0 {
-		return ctrl.Result{RequeueAfter: apiErrorRequeueDelay, Priority: new(priorityRoute)}, syncResult, nil
+	// programs them, rather than waiting for an unrelated event. A stale
+	// configuration read skipped a write the same way: retry once the API
+	// catches up.
+	if len(syncResult.TransientBrokenKeys) > 0 || outcome.staleRead {
+		return ctrl.Result{RequeueAfter: s.apiErrorDelay(), Priority: new(priorityRoute)}, syncResult, nil
+	}
+
+	// Resume paused tunnels as soon as their maintenance window closes or the
+	// rate-limit hold ends, even when no route changes in the meantime.
+	if !outcome.resumeAt.IsZero() {
+		return ctrl.Result{RequeueAfter: max(outcome.resumeAt.Sub(s.clock()), time.Second), Priority: new(priorityRoute)},
+			syncResult, nil
 	}
 
 	return ctrl.Result{}, syncResult, nil
@@ -955,6 +1287,50 @@ func buildSyncResult(
 type tunnelGroup struct {
 	resolved   *config.ResolvedConfig
 	partitions []*routePartition
+
+	// pausedUntil, when set, holds back the document write until a
+	// maintenance window of the Gateways in pausedBy closes.
+	pausedUntil time.Time
+	pausedBy    []string
+}
+
+// partitionKeys returns the keys of the partitions merged into the group.
+func (g *tunnelGroup) partitionKeys() []string {
+	keys := make([]string, 0, len(g.partitions))
+	for _, partition := range g.partitions {
+		keys = append(keys, partition.Key)
+	}
+
+	return keys
+}
+
+// proxyHealthCheckHostnames maps each proxy partition key to the synthetic
+// health hostnames its connector must answer. The edge load-balances a tunnel
+// across every connector on it, so each proxy partition on a tunnel answers
+// the whole tunnel's hostnames; tunnel-override views have no data plane of
+// their own and are served by the shared proxy.
+func proxyHealthCheckHostnames(groups []tunnelGroup, infra *infraGateways) map[string][]string {
+	byPartition := make(map[string][]string)
+
+	for i := range groups {
+		hostnames := infra.healthCheckHostnames(groups[i].partitionKeys())
+		if len(hostnames) == 0 {
+			continue
+		}
+
+		for _, partition := range groups[i].partitions {
+			key := sharedPartitionKey
+			if partition.PerGateway != nil {
+				key = partition.Key
+			}
+
+			merged := append(byPartition[key], hostnames...)
+			slices.Sort(merged)
+			byPartition[key] = slices.Compact(merged)
+		}
+	}
+
+	return byPartition
 }
 
 // canonicalTunnelID normalizes a tunnel UUID to its canonical lowercase form so
@@ -972,7 +1348,8 @@ func canonicalTunnelID(id string) string {
 }
 
 // buildTunnelGroups groups partitions by resolved tunnel ID. The shared
-// partition uses the class-resolved config; per-Gateway partitions use the
+// partition uses the class-resolved config; a tunnel-override view uses the
+// class credentials with its pinned tunnel; per-Gateway partitions use the
 // identity parsed from their connector tokens. Group order is deterministic:
 // the shared tunnel first, the rest sorted by tunnel ID.
 func buildTunnelGroups(shared *config.ResolvedConfig, partitions []routePartition) []tunnelGroup {
@@ -983,8 +1360,14 @@ func buildTunnelGroups(shared *config.ResolvedConfig, partitions []routePartitio
 		partition := &partitions[i]
 
 		resolved := shared
-		if partition.PerGateway != nil {
+
+		switch {
+		case partition.PerGateway != nil:
 			resolved = &partition.PerGateway.ResolvedConfig
+		case partition.TunnelOverride != "":
+			override := *shared
+			override.TunnelID = partition.TunnelOverride
+			resolved = &override
 		}
 
 		// Same tunnel ⇒ one document ⇒ one credential: the FIRST partition's
@@ -1116,7 +1499,18 @@ type tunnelGroupsOutcome struct {
 	grpcFailedRefs []ingress.BackendRefError
 	totalRules     int
 	anyWritten     bool
+	staleRead      bool
 	groupErrs      []error
+	// dryRunDiagnostics mark the routes of every group whose document was
+	// computed but not written because of dry-run mode.
+	dryRunDiagnostics []proxy.RouteDiagnostic
+	// invalidRuleDiagnostics mark the route rules left out of a document
+	// because Cloudflare would reject them.
+	invalidRuleDiagnostics []proxy.RouteDiagnostic
+	// resumeAt is the earliest close of the maintenance windows open on a
+	// group or of the rate-limit hold that paused one this sync; zero when
+	// neither applies.
+	resumeAt time.Time
 	// failedPartitions maps a partition key (sharedPartitionKey or an infra
 	// Gateway's "namespace/name") to the sync error of the tunnel serving it.
 	// The per-route, per-Gateway attribution is derived from this against the
@@ -1126,17 +1520,23 @@ type tunnelGroupsOutcome struct {
 }
 
 // syncTunnelGroups runs the ingress-document sync for every tunnel group,
-// mapping each group's failure onto exactly its partitions' routes.
+// mapping each group's failure onto exactly its partitions' routes. Backend
+// origins are built with clusterDomain.
 func (s *RouteSyncer) syncTunnelGroups(
 	ctx context.Context,
 	logger *slog.Logger,
 	groups []tunnelGroup,
+	infra *infraGateways,
+	views *listenerViewCache,
+	clusterDomain string,
 ) tunnelGroupsOutcome {
 	outcome := tunnelGroupsOutcome{failedPartitions: make(map[string]error)}
+	tunnelIDs := make(map[string]struct{}, len(groups))
 
 	for i := range groups {
 		group := &groups[i]
-		result := s.syncTunnelGroup(ctx, logger, group)
+		tunnelIDs[group.resolved.TunnelID] = struct{}{}
+		result := s.syncTunnelGroup(ctx, logger, group, infra.healthCheckHostnames(group.partitionKeys()), views, clusterDomain)
 
 		outcome.httpFailedRefs = append(outcome.httpFailedRefs, result.httpFailedRefs...)
 		outcome.grpcFailedRefs = append(outcome.grpcFailedRefs, result.grpcFailedRefs...)
@@ -1146,7 +1546,35 @@ func (s *RouteSyncer) syncTunnelGroups(
 			outcome.anyWritten = true
 		}
 
+		if result.staleRead {
+			outcome.staleRead = true
+		}
+
+		// A paused group requeues for its window's close even when its
+		// document is unchanged: the proxy push is held back as well.
+		if paused := group.pausedUntil; !paused.IsZero() && (outcome.resumeAt.IsZero() || paused.Before(outcome.resumeAt)) {
+			outcome.resumeAt = paused
+		}
+
+		if until := result.rateLimitedUntil; !until.IsZero() && (outcome.resumeAt.IsZero() || until.Before(outcome.resumeAt)) {
+			outcome.resumeAt = until
+		}
+
+		outcome.invalidRuleDiagnostics = append(outcome.invalidRuleDiagnostics,
+			invalidRuleDiagnostics(result.invalidRules)...)
+
+		if result.dryRun {
+			for _, partition := range group.partitions {
+				outcome.dryRunDiagnostics = append(outcome.dryRunDiagnostics, dryRunDiagnostics(partition)...)
+			}
+		}
+
 		if result.err == nil {
+			if !result.staleRead {
+				s.syncGroupDNS(ctx, logger, group, result.hostnames)
+				s.syncGroupAccess(ctx, logger, group, result.hostnames)
+			}
+
 			continue
 		}
 
@@ -1157,6 +1585,10 @@ func (s *RouteSyncer) syncTunnelGroups(
 		}
 	}
 
+	s.appliedConfigs.retain(tunnelIDs)
+	s.syncDepartedDNS(ctx, logger, tunnelIDs)
+	s.syncDepartedAccess(ctx, logger, tunnelIDs)
+
 	return outcome
 }
 
@@ -1173,6 +1605,13 @@ var errBrokenDataPlane = errors.New(
 	"the Gateway's dedicated data plane is unavailable; the route is not programmed " +
 		"(see the Gateway's Accepted condition, which reports InvalidParameters)")
 
+// errInvalidTunnelOverride marks a route parent bound to a shared-mode
+// Gateway whose config.TunnelIDAnnotation is not a UUID. The route is served
+// nowhere (fail closed); the Gateway reports the cause as InvalidParameters.
+var errInvalidTunnelOverride = errors.New(
+	"the Gateway's tunnel-id annotation is invalid; the route is not programmed " +
+		"(see the Gateway's Accepted condition, which reports InvalidParameters)")
+
 // injectPartitionSyncErrors records, on each route binding, the sync error
 // affecting each Gateway the route is accepted on, attributed per Gateway so
 // the status writer flips only the affected parents. A parent is affected
@@ -1218,12 +1657,11 @@ func gatewaySyncError(gatewayKey string, failedPartitions map[string]error, infr
 		return errBrokenDataPlane
 	}
 
-	partitionKey := sharedPartitionKey
-	if infra.isResolved(gatewayKey) {
-		partitionKey = gatewayKey
+	if infra.hasInvalidOverride(gatewayKey) {
+		return errInvalidTunnelOverride
 	}
 
-	return failedPartitions[partitionKey]
+	return failedPartitions[infra.partitionKey(gatewayKey)]
 }
 
 // tunnelGroupResult is one group's sync outcome.
@@ -1232,7 +1670,19 @@ type tunnelGroupResult struct {
 	grpcFailedRefs []ingress.BackendRefError
 	ruleCount      int
 	written        bool
-	err            error
+	staleRead      bool
+	// dryRun marks a group whose document differed from the deployed one but
+	// was not written because the syncer runs in dry-run mode.
+	dryRun bool
+	// invalidRules are the route rules left out of the group's document
+	// because Cloudflare would reject them.
+	invalidRules []ingress.InvalidRule
+	// rateLimitedUntil is when the rate-limit hold that skipped this group's
+	// Cloudflare calls ends; zero when none did.
+	rateLimitedUntil time.Time
+	// hostnames are the distinct hostnames of the group's desired rules.
+	hostnames []string
+	err       error
 }
 
 // groupRoutes unions the group's partition routes, deduplicated by
@@ -1275,26 +1725,72 @@ func groupRoutes(group *tunnelGroup) ([]gatewayv1.HTTPRoute, []gatewayv1.GRPCRou
 }
 
 // syncTunnelGroup builds the desired rules from EXACTLY the group's routes
-// and reconciles the group's tunnel ingress document: get → diff → sort →
-// catch-all → limit check → unchanged skip → whole-document update.
+// (plus the reserved health rules of its Gateways) and reconciles the group's
+// tunnel ingress document: get → diff → sort → catch-all → limit check →
+// unchanged skip → whole-document update.
 //
 //nolint:funlen // sequential build → diff → write pipeline, mirrors the historic single-tunnel body
 func (s *RouteSyncer) syncTunnelGroup(
 	ctx context.Context,
 	logger *slog.Logger,
 	group *tunnelGroup,
+	healthCheckHostnames []string,
+	views *listenerViewCache,
+	clusterDomain string,
 ) tunnelGroupResult {
 	httpRoutes, grpcRoutes := groupRoutes(group)
+	httpRoutes = withListenerScopedHTTPCatchAlls(ctx, s.Client, httpRoutes, views)
+	grpcRoutes = withListenerScopedGRPCCatchAlls(ctx, s.Client, grpcRoutes, views)
 
-	httpBuild := s.httpBuilder.Build(ctx, httpRoutes)
-	grpcBuild := s.grpcBuilder.Build(ctx, grpcRoutes)
+	httpBuilder, grpcBuilder := s.buildersFor(clusterDomain)
+	catchAll := catchAllFor(group.resolved)
+	httpBuild := httpBuilder.WithCatchAll(catchAll).Build(ctx, httpRoutes)
+	grpcBuild := grpcBuilder.Build(ctx, grpcRoutes)
 
 	result := tunnelGroupResult{
 		httpFailedRefs: httpBuild.FailedRefs,
 		grpcFailedRefs: grpcBuild.FailedRefs,
+		invalidRules:   append(httpBuild.InvalidRules, grpcBuild.InvalidRules...),
+	}
+
+	grpcRules := slices.Concat(grpcBuild.Rules, s.GRPCCatchAll.Rules(grpcBuild.Rules, httpBuild.Rules))
+	desiredRules := mergeAndSortRules(httpBuild.Rules, grpcRules)
+	desiredRules = append(ingress.HealthCheckRules(healthCheckHostnames), desiredRules...)
+	result.hostnames = ruleHostnames(desiredRules)
+
+	// Churny but semantically stable routes rebuild the same document over
+	// and over; when it matches the one last confirmed deployed, skip the GET
+	// and the PUT altogether.
+	desiredDoc := desiredDocument(desiredRules, catchAll.Rule(clusterDomain))
+	if ruleCount, ok := s.appliedConfigs.lookup(group.resolved.TunnelID, desiredDoc); ok {
+		logger.Debug("desired tunnel configuration matches the last applied one; skipping API calls",
+			"tunnel", group.resolved.TunnelID, "rules", ruleCount)
+
+		result.ruleCount = ruleCount
+
+		return result
+	}
+
+	// A maintenance window holds back every Cloudflare call for the tunnel;
+	// the sync requeues itself for the moment the window closes.
+	if !group.pausedUntil.IsZero() {
+		logger.Info("maintenance window: tunnel ingress sync paused",
+			"tunnel", group.resolved.TunnelID, "gateways", strings.Join(group.pausedBy, ","),
+			"until", group.pausedUntil.Format(time.RFC3339))
+
+		return result
 	}
 
-	desiredRules := mergeAndSortRules(httpBuild.Rules, grpcBuild.Rules)
+	// A 429 from an earlier call holds back every tunnel until its
+	// Retry-After window elapses; the sync requeues itself for that moment.
+	if until := s.rateLimitHold(); !until.IsZero() {
+		logger.Info("Cloudflare rate limit: tunnel ingress sync held back",
+			"tunnel", group.resolved.TunnelID, "until", until.Format(time.RFC3339))
+
+		result.rateLimitedUntil = until
+
+		return result
+	}
 
 	cfClient := s.cloudflareClient(group.resolved)
 
@@ -1324,6 +1820,9 @@ func (s *RouteSyncer) syncTunnelGroup(
 		logger.Error("failed to get current tunnel configuration",
 			"tunnel", group.resolved.TunnelID, "error", err)
 
+		s.appliedConfigs.forget(group.resolved.TunnelID)
+		s.holdForRateLimit(logger, err)
+
 		result.err = err
 
 		return result
@@ -1331,15 +1830,27 @@ func (s *RouteSyncer) syncTunnelGroup(
 
 	s.Metrics.RecordAPICall(ctx, "get", "tunnel_config", "success", time.Since(getStart))
 
+	if lastApplied := s.appliedVersions[group.resolved.TunnelID]; currentConfig.Version < lastApplied {
+		logger.Warn("tunnel configuration read is older than the last applied version; skipping update",
+			"tunnel", group.resolved.TunnelID, "readVersion", currentConfig.Version, "appliedVersion", lastApplied)
+
+		result.staleRead = true
+
+		return result
+	}
+
 	toAdd, toRemove := ingress.DiffRules(currentConfig.Config.Ingress, desiredRules)
 	logger.Info("computed diff",
 		"tunnel", group.resolved.TunnelID, "toAdd", len(toAdd), "toRemove", len(toRemove))
 
 	// ApplyDiff returns rules in arbitrary order (kept-from-current first,
 	// then toAdd); wildcard rules must sort after specific hostnames to avoid
-	// Cloudflare error 1056, and the catch-all must close the document.
-	finalRules := ingress.EnsureCatchAll(sortIngressRules(
-		ingress.ApplyDiff(currentConfig.Config.Ingress, toAdd, toRemove)))
+	// Cloudflare error 1056, and the catch-all must close the document. A
+	// configured catch-all is not filtered as one by the diff, so it diffs
+	// like any rule (a changed defaultBackend removes the stale one) and is
+	// moved back to the end here.
+	finalRules := ingress.EnsureCatchAllRule(sortIngressRules(
+		ingress.ApplyDiff(currentConfig.Config.Ingress, toAdd, toRemove)), catchAll.Rule(clusterDomain))
 
 	result.ruleCount = len(finalRules)
 
@@ -1356,16 +1867,31 @@ func (s *RouteSyncer) syncTunnelGroup(
 
 	// Whole-document API: skip the write when the desired document equals the
 	// deployed one — steady-state reconciles hit this constantly.
-	if ingress.RulesUnchanged(currentConfig.Config.Ingress, finalRules) {
+	changes := ingress.ComputeChanges(currentConfig.Config.Ingress, finalRules)
+	if changes.IsEmpty() {
 		logger.Debug("tunnel configuration unchanged; skipping update",
 			"tunnel", group.resolved.TunnelID, "rules", len(finalRules))
 
+		s.appliedConfigs.store(group.resolved.TunnelID, desiredDoc, len(finalRules))
+
+		return result
+	}
+
+	if s.DryRun {
+		logger.Info("dry run: tunnel ingress changes not applied",
+			"tunnel", group.resolved.TunnelID, "rules", len(finalRules), "changes", changes)
+
+		result.dryRun = true
+
 		return result
 	}
 
+	logger.Info("tunnel ingress changes",
+		"tunnel", group.resolved.TunnelID, "changes", changes)
+
 	updateStart := time.Now()
 
-	_, err = cfClient.ZeroTrust.Tunnels.Cloudflared.Configurations.Update(ctx, group.resolved.TunnelID,
+	updated, err := cfClient.ZeroTrust.Tunnels.Cloudflared.Configurations.Update(ctx, group.resolved.TunnelID,
 		zero_trust.TunnelCloudflaredConfigurationUpdateParams{
 			AccountID: cloudflare.String(accountID),
 			Config: cloudflare.F(zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfig{
@@ -1379,6 +1905,9 @@ func (s *RouteSyncer) syncTunnelGroup(
 		logger.Error("failed to update tunnel configuration",
 			"tunnel", group.resolved.TunnelID, "error", err)
 
+		s.appliedConfigs.forget(group.resolved.TunnelID)
+		s.holdForRateLimit(logger, err)
+
 		result.err = err
 
 		return result
@@ -1386,13 +1915,27 @@ func (s *RouteSyncer) syncTunnelGroup(
 
 	s.Metrics.RecordAPICall(ctx, "update", "tunnel_config", "success", time.Since(updateStart))
 	logger.Info("successfully updated tunnel configuration",
-		"tunnel", group.resolved.TunnelID, "rules", len(finalRules))
+		"tunnel", group.resolved.TunnelID, "rules", len(finalRules), "version", updated.Version)
+
+	s.recordAppliedVersion(group.resolved.TunnelID, updated.Version)
+	s.appliedConfigs.store(group.resolved.TunnelID, desiredDoc, len(finalRules))
 
 	result.written = true
 
 	return result
 }
 
+// recordAppliedVersion remembers the configuration version a successful write
+// produced, keeping the highest seen so an out-of-order response never lowers
+// the watermark. Caller holds syncMu.
+func (s *RouteSyncer) recordAppliedVersion(tunnelID string, version int64) {
+	if s.appliedVersions == nil {
+		s.appliedVersions = make(map[string]int64)
+	}
+
+	s.appliedVersions[tunnelID] = max(s.appliedVersions[tunnelID], version)
+}
+
 // recordSyncSuccessMetrics records the per-sync metric set shared by the
 // skip path and the write path. status distinguishes a write ("success")
 // from a steady-state no-op ("skipped") so the skip rate is observable.
@@ -1448,10 +1991,23 @@ func (s *RouteSyncer) getRelevantHTTPRoutes(
 
 	for i := range routeList.Items {
 		route := &routeList.Items[i]
+		if !s.routeOptedIn(route) {
+			continue
+		}
+
 		bindingInfo, accepted, referencesUs := s.bindRouteParents(
 			ctx, logger, route.Namespace, route.Name, route.Spec.Hostnames,
 			routebinding.KindHTTPRoute, route.Spec.ParentRefs, views,
 		)
+
+		if !accepted && referencesUs {
+			accepted = s.forceAttachIfAnnotated(logger, route, &bindingInfo)
+		}
+
+		if accepted && disableIfAnnotated(logger, route, &bindingInfo) {
+			accepted = false
+		}
+
 		result.bindings[route.Namespace+"/"+route.Name] = bindingInfo
 
 		switch {
@@ -1486,10 +2042,82 @@ func (s *RouteSyncer) getRelevantGRPCRoutes(
 
 	for i := range routeList.Items {
 		route := &routeList.Items[i]
+		if !s.routeOptedIn(route) {
+			continue
+		}
+
 		bindingInfo, accepted, referencesUs := s.bindRouteParents(
 			ctx, logger, route.Namespace, route.Name, route.Spec.Hostnames,
 			routebinding.KindGRPCRoute, route.Spec.ParentRefs, views,
 		)
+
+		if !accepted && referencesUs {
+			accepted = s.forceAttachIfAnnotated(logger, route, &bindingInfo)
+		}
+
+		if accepted && disableIfAnnotated(logger, route, &bindingInfo) {
+			accepted = false
+		}
+
+		result.bindings[route.Namespace+"/"+route.Name] = bindingInfo
+
+		switch {
+		case accepted:
+			result.accepted = append(result.accepted, routeList.Items[i])
+		case referencesUs:
+			result.rejected = append(result.rejected, routeList.Items[i])
+		}
+	}
+
+	return result, nil
+}
+
+// tcpRouteResult holds accepted and rejected TCPRoutes from binding validation.
+type tcpRouteResult struct {
+	accepted []gatewayv1.TCPRoute
+	rejected []gatewayv1.TCPRoute
+	bindings map[string]routeBindingInfo
+}
+
+// getRelevantTCPRoutes binds every TCPRoute like getRelevantHTTPRoutes does.
+// TCPRoute has no spec hostnames, so listener hostnames never reject it.
+func (s *RouteSyncer) getRelevantTCPRoutes(
+	ctx context.Context,
+	views *listenerViewCache,
+) (*tcpRouteResult, error) {
+	logger := logging.FromContext(ctx)
+	if logger == slog.Default() {
+		logger = s.Logger
+	}
+
+	var routeList gatewayv1.TCPRouteList
+	if err := s.List(ctx, &routeList); err != nil {
+		return nil, errors.Wrap(err, "failed to list tcproutes")
+	}
+
+	result := &tcpRouteResult{
+		bindings: make(map[string]routeBindingInfo),
+	}
+
+	for i := range routeList.Items {
+		route := &routeList.Items[i]
+		if !s.routeOptedIn(route) {
+			continue
+		}
+
+		bindingInfo, accepted, referencesUs := s.bindRouteParents(
+			ctx, logger, route.Namespace, route.Name, nil,
+			routebinding.KindTCPRoute, route.Spec.ParentRefs, views,
+		)
+
+		if !accepted && referencesUs {
+			accepted = s.forceAttachIfAnnotated(logger, route, &bindingInfo)
+		}
+
+		if accepted && disableIfAnnotated(logger, route, &bindingInfo) {
+			accepted = false
+		}
+
 		result.bindings[route.Namespace+"/"+route.Name] = bindingInfo
 
 		switch {
@@ -1650,14 +2278,29 @@ func (s *RouteSyncer) evaluateHostnameOwnership(
 	return s.HostnameOwnership.Evaluate(namespace.Labels, hostnames)
 }
 
-// sortIngressRules sorts ingress rules: specific hostnames alphabetically first,
-// wildcard (no hostname) last, same hostname by path length (longer first).
+// sortIngressRules sorts ingress rules: synthetic health rules first, then
+// specific hostnames alphabetically, wildcard (no hostname) last, same
+// hostname by path length (longer first), then alphabetically by path so the order never depends on which builder
+// produced a rule.
 // This ordering is required by Cloudflare API — rules without hostname before
 // rules with hostname trigger error 1056.
 func sortIngressRules(
 	rules []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress,
 ) []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress {
 	slices.SortStableFunc(rules, func(left, right zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress) int {
+		// Synthetic health rules come first: cloudflared matches top-down,
+		// and no route (wildcard hostname or catch-all path) may shadow them.
+		leftHealth := ingress.IsHealthCheck(ingress.RuleFromUpdate(&left))
+		rightHealth := ingress.IsHealthCheck(ingress.RuleFromUpdate(&right))
+
+		if leftHealth != rightHealth {
+			if leftHealth {
+				return -1
+			}
+
+			return 1
+		}
+
 		leftPresent := left.Hostname.Present
 		rightPresent := right.Hostname.Present
 
@@ -1670,12 +2313,33 @@ func sortIngressRules(
 			return 1
 		}
 
-		// Both have hostname or both don't — sort alphabetically, then by path length (longer first).
-		if c := cmp.Compare(left.Hostname.Value, right.Hostname.Value); c != 0 {
+		// Both have hostname or both don't — exact hostnames before the
+		// wildcards covering them, then by path length (longer first).
+		if c := ingress.CompareHostnames(left.Hostname.Value, right.Hostname.Value); c != 0 {
+			return c
+		}
+
+		if c := cmp.Compare(len(right.Path.Value), len(left.Path.Value)); c != 0 {
 			return c
 		}
 
-		return cmp.Compare(len(right.Path.Value), len(left.Path.Value))
+		// Equally specific paths of one hostname may come from different
+		// builders (an HTTPRoute and a GRPCRoute sharing the host). As in the
+		// builders, an exact path precedes a prefix ("*"-suffixed) one and
+		// the path itself breaks the remaining tie. Rules for the same path
+		// keep their input order: the builders already ranked them by route.
+		leftPrefix := strings.HasSuffix(left.Path.Value, "*")
+		rightPrefix := strings.HasSuffix(right.Path.Value, "*")
+
+		if leftPrefix != rightPrefix {
+			if rightPrefix {
+				return -1
+			}
+
+			return 1
+		}
+
+		return cmp.Compare(left.Path.Value, right.Path.Value)
 	})
 
 	return rules
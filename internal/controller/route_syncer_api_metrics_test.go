@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+// apiCallRecord is one RecordAPICall observation.
+type apiCallRecord struct {
+	method, resource, status string
+}
+
+// apiCallCollector records Cloudflare API call metrics; every other metric
+// goes to the embedded noop collector.
+type apiCallCollector struct {
+	cfmetrics.Collector
+
+	mu     sync.Mutex
+	calls  []apiCallRecord
+	errors []string // method/errorType
+}
+
+func (c *apiCallCollector) RecordAPICall(_ context.Context, method, resource, status string, _ time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls = append(c.calls, apiCallRecord{method: method, resource: resource, status: status})
+}
+
+func (c *apiCallCollector) RecordAPIError(_ context.Context, method, errorType string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.errors = append(c.errors, method+"/"+errorType)
+}
+
+func (c *apiCallCollector) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.calls, c.errors = nil, nil
+}
+
+func (c *apiCallCollector) snapshot() ([]apiCallRecord, []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]apiCallRecord(nil), c.calls...), append([]string(nil), c.errors...)
+}
+
+// TestSyncAllRoutes_RecordsTunnelConfigAPICalls pins that the tunnel config
+// GET and PUT are timed and counted with their outcome, and that a failed
+// PUT also counts as an API error. A per-tunnel write failure is not a
+// global sync error, so only the metrics tell it apart here.
+func TestSyncAllRoutes_RecordsTunnelConfigAPICalls(t *testing.T) {
+	t.Parallel()
+
+	const classTunnel = "99999999-9999-4999-8999-999999999999"
+
+	ctx := context.Background()
+	api := newRecordingTunnelAPI(t)
+	syncer := newPartitionSyncSyncer(t, api, classTunnel)
+	collector := &apiCallCollector{Collector: cfmetrics.NewNoopCollector()}
+	syncer.Metrics = collector
+	// No client-side retries: the failed PUT below is recorded once, at once.
+	syncer.cloudflareClientFactory = func(_ *config.ResolvedConfig) *cloudflare.Client {
+		return cloudflare.NewClient(
+			option.WithAPIToken("test-token"),
+			option.WithBaseURL(api.server.URL),
+			option.WithMaxRetries(0),
+		)
+	}
+
+	_, _, err := syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	calls, apiErrors := collector.snapshot()
+	assert.Contains(t, calls, apiCallRecord{method: "get", resource: "tunnel_config", status: "success"})
+	assert.Contains(t, calls, apiCallRecord{method: "update", resource: "tunnel_config", status: "success"})
+	assert.Empty(t, apiErrors)
+
+	// A route change forces another PUT, which now fails.
+	collector.reset()
+	api.failTunnel(classTunnel)
+	require.NoError(t, syncer.Client.Create(ctx, partitionSyncRoute("late-route", "shared-gw", "late.example.com")))
+
+	_, _, err = syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	calls, apiErrors = collector.snapshot()
+	assert.Contains(t, calls, apiCallRecord{method: "update", resource: "tunnel_config", status: "error"})
+	assert.NotContains(t, calls, apiCallRecord{method: "update", resource: "tunnel_config", status: "success"})
+	require.Len(t, apiErrors, 1)
+	assert.Contains(t, apiErrors[0], "update/")
+}
@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/ingress"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/proxy"
+)
+
+func TestSyncAllRoutes_DryRunDoesNotWrite(t *testing.T) {
+	t.Parallel()
+
+	// The deployed document carries a stale rule, so a normal sync would
+	// write; in dry-run mode the change is only logged.
+	api := newFakeTunnelAPI(t, []map[string]any{
+		{"hostname": "stale.example.com", "service": "http://stale.default.svc.cluster.local:80"},
+		{"service": ingress.CatchAllService},
+	})
+
+	var logs bytes.Buffer
+
+	syncer := newSkipTestSyncer(t, api)
+	syncer.Logger = slog.New(slog.NewTextHandler(&logs, nil))
+	syncer.DryRun = true
+
+	for range 2 {
+		_, result, err := syncer.SyncAllRoutes(context.Background())
+		require.NoError(t, err)
+		require.NotNil(t, result)
+	}
+
+	assert.Equal(t, int32(0), api.putCount.Load(), "dry-run must never write the tunnel config")
+	assert.Equal(t, int32(2), api.getCount.Load(),
+		"an unwritten document must not be cached as applied")
+	assert.Contains(t, logs.String(), "dry run: tunnel ingress changes not applied")
+	assert.Contains(t, logs.String(), "stale.example.com")
+}
+
+func TestDryRunDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	partition := &routePartition{
+		Key: sharedPartitionKey,
+		HTTPRoutes: []gatewayv1.HTTPRoute{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "web"}},
+		},
+		GRPCRoutes: []gatewayv1.GRPCRoute{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "rpc"}},
+		},
+	}
+
+	diags := dryRunDiagnostics(partition)
+
+	require.Len(t, diags, 2)
+
+	for _, diag := range diags {
+		assert.Equal(t, proxy.DiagnosticDryRun, diag.Target)
+		assert.Equal(t, routeReasonWouldApply, diag.Reason)
+	}
+}
+
+// TestBuildParentStatus_DryRunCondition pins that a dry-run route carries
+// DryRun=True/WouldApply next to Accepted=True, so "would apply" is never
+// read as "applied".
+func TestBuildParentStatus_DryRunCondition(t *testing.T) {
+	t.Parallel()
+
+	status := buildParentStatusForDiag(dryRunDiagnostics(&routePartition{
+		HTTPRoutes: []gatewayv1.HTTPRoute{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "web"}},
+		},
+	}), 1)
+
+	accepted := findCondition(status.Conditions, string(gatewayv1.RouteConditionAccepted))
+	require.NotNil(t, accepted)
+	assert.Equal(t, metav1.ConditionTrue, accepted.Status)
+
+	dryRun := findCondition(status.Conditions, routeConditionDryRun)
+	require.NotNil(t, dryRun, "the DryRun condition must be present")
+	assert.Equal(t, metav1.ConditionTrue, dryRun.Status)
+	assert.Equal(t, routeReasonWouldApply, dryRun.Reason)
+
+	assert.Nil(t, findCondition(buildParentStatusForDiag(nil, 1).Conditions, routeConditionDryRun),
+		"no dry-run diagnostic means no condition")
+}
+
+// TestPushPartitionConfigs_DryRunHoldsProxyPush pins that dry-run covers the
+// data plane too: the proxy receives nothing, so traffic does not change, and
+// the routes of a partition whose config would change carry the DryRun
+// marker.
+func TestPushPartitionConfigs_DryRunHoldsProxyPush(t *testing.T) {
+	t.Parallel()
+
+	var pushes atomic.Int32
+
+	replica := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		pushes.Add(1)
+		writer.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(replica.Close)
+
+	testClient := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	params := syncUpdateParams{
+		routeSyncer: &RouteSyncer{
+			ClusterDomain: "cluster.local",
+			Metrics:       cfmetrics.NewNoopCollector(),
+			DryRun:        true,
+		},
+		proxySyncer:    NewProxySyncer("cluster.local", "shared-token", "", testClient, slog.Default()),
+		proxyEndpoints: []string{replica.URL + "/config"},
+		pushProxy:      true,
+	}
+
+	syncResult := &SyncResult{
+		Partitions: []routePartition{
+			{Key: sharedPartitionKey, HTTPRoutes: []gatewayv1.HTTPRoute{*pushFallbackRoute("web", "web.example.com")}},
+		},
+	}
+
+	diags, _ := pushPartitionConfigs(context.Background(), slog.Default(), &params, syncResult)
+
+	assert.Zero(t, pushes.Load(), "dry-run must never push the proxy config")
+
+	var marked bool
+
+	for _, diag := range diags {
+		if diag.Target == proxy.DiagnosticDryRun && diag.Name == "web" {
+			marked = true
+		}
+	}
+
+	assert.True(t, marked, "a route whose proxy config would change must carry the DryRun marker")
+}
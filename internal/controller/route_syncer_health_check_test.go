@@ -0,0 +1,203 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/zero_trust"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/ingress"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/logging"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/proxy"
+)
+
+// TestInfraGateways_HealthCheckHostnames pins that a Gateway's synthetic
+// health hostname lands in the tunnel document serving that Gateway: the
+// class tunnel for a shared Gateway, the pinned tunnel for an overridden one,
+// the dedicated tunnel for an infra Gateway — and never a neighbour's.
+func TestInfraGateways_HealthCheckHostnames(t *testing.T) {
+	t.Parallel()
+
+	infra := &infraGateways{
+		resolved:        map[string]*infraGateway{"tenant/dedicated": {}},
+		tunnelOverrides: map[string]string{"default/green": overrideGreenTunnel},
+		healthChecks:    make(map[string][]string),
+	}
+
+	annotated := func(hostname string) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{config.HealthCheckHostnameAnnotation: hostname},
+		}}
+	}
+
+	logger, logs := logging.TestLogger(t)
+	infra.recordHealthCheck(logger, "default/blue", annotated("blue.example.com"))
+	infra.recordHealthCheck(logger, "default/other", annotated("Blue.Example.com"))
+	infra.recordHealthCheck(logger, "default/green", annotated("green.example.com"))
+	infra.recordHealthCheck(logger, "tenant/dedicated", annotated("tenant.example.com"))
+	infra.recordHealthCheck(logger, "default/bad", annotated("*.example.com"))
+	infra.recordHealthCheck(logger, "default/plain", &gatewayv1.Gateway{})
+	assert.Contains(t, logs.String(), "gateway health-check hostname is invalid")
+
+	tests := []struct {
+		name          string
+		partitionKeys []string
+		want          []string
+	}{
+		{
+			name:          "shared partition deduplicates",
+			partitionKeys: []string{sharedPartitionKey},
+			want:          []string{"blue.example.com"},
+		},
+		{
+			name:          "override view",
+			partitionKeys: []string{tunnelOverridePartitionKey(overrideGreenTunnel)},
+			want:          []string{"green.example.com"},
+		},
+		{
+			name:          "dedicated partition",
+			partitionKeys: []string{"tenant/dedicated"},
+			want:          []string{"tenant.example.com"},
+		},
+		{
+			name:          "merged group unions sorted",
+			partitionKeys: []string{"tenant/dedicated", sharedPartitionKey},
+			want:          []string{"blue.example.com", "tenant.example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, infra.healthCheckHostnames(tt.partitionKeys))
+		})
+	}
+}
+
+// TestSortIngressRules_HealthCheckFirst pins the reserved health rules at the
+// top of the document: cloudflared matches top-down, so a route on the same
+// hostname (even with a longer path), an alphabetically earlier hostname, or a
+// wildcard must never shadow the health endpoint.
+func TestSortIngressRules_HealthCheckFirst(t *testing.T) {
+	t.Parallel()
+
+	rules := []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
+		{Service: cloudflare.String("http://wildcard:80")},
+		{Hostname: cloudflare.String("a.example.com"), Service: cloudflare.String("http://a:80")},
+		{
+			Hostname: cloudflare.String("z.example.com"),
+			Path:     cloudflare.String("^/__tunnel_health/and/more/path"),
+			Service:  cloudflare.String("http://long:80"),
+		},
+		{Hostname: cloudflare.String("z.example.com"), Service: cloudflare.String("http://z:80")},
+	}
+	rules = append(rules, ingress.HealthCheckRules([]string{"z.example.com", "b.example.com"})...)
+
+	result := ingress.EnsureCatchAll(sortIngressRules(rules))
+	require.Len(t, result, 7)
+
+	for i, hostname := range []string{"b.example.com", "z.example.com"} {
+		assert.Equal(t, hostname, result[i].Hostname.Value)
+		assert.Equal(t, ingress.HealthCheckService, result[i].Service.Value)
+		assert.True(t, ingress.IsHealthCheck(ingress.RuleFromUpdate(&result[i])))
+	}
+
+	assert.Equal(t, "a.example.com", result[2].Hostname.Value)
+	assert.Equal(t, "http://long:80", result[3].Service.Value)
+	assert.Equal(t, "http://z:80", result[4].Service.Value)
+	assert.False(t, result[5].Hostname.Present, "wildcard follows every specific hostname")
+	assert.Equal(t, ingress.CatchAllService, result[6].Service.Value)
+}
+
+// TestProxyHealthCheckHostnames pins which proxy answers each health hostname:
+// every proxy on a tunnel answers the whole tunnel's hostnames (the edge
+// load-balances across them), and an override view's hostnames land on the
+// shared proxy, which serves its routes.
+func TestProxyHealthCheckHostnames(t *testing.T) {
+	t.Parallel()
+
+	infra := &infraGateways{
+		healthChecks: map[string][]string{
+			sharedPartitionKey: {"blue.example.com"},
+			tunnelOverridePartitionKey(overrideGreenTunnel): {"green.example.com"},
+			"tenant/merged":    {"merged.example.com"},
+			"tenant/dedicated": {"tenant.example.com"},
+		},
+	}
+
+	groups := []tunnelGroup{
+		{partitions: []*routePartition{
+			{Key: sharedPartitionKey},
+			{Key: "tenant/merged", PerGateway: &config.PerGatewayConfig{}},
+		}},
+		{partitions: []*routePartition{{Key: tunnelOverridePartitionKey(overrideGreenTunnel), TunnelOverride: overrideGreenTunnel}}},
+		{partitions: []*routePartition{{Key: "tenant/dedicated", PerGateway: &config.PerGatewayConfig{}}}},
+		{partitions: []*routePartition{{Key: "tenant/plain", PerGateway: &config.PerGatewayConfig{}}}},
+	}
+
+	assert.Equal(t, map[string][]string{
+		sharedPartitionKey: {"blue.example.com", "green.example.com", "merged.example.com"},
+		"tenant/merged":    {"blue.example.com", "merged.example.com"},
+		"tenant/dedicated": {"tenant.example.com"},
+	}, proxyHealthCheckHostnames(groups, infra))
+}
+
+// TestPrependHealthCheckRules pins that the proxy itself answers the health
+// endpoint — the connector never consults the tunnel document — and that the
+// reserved rule wins over a route rule claiming the same exact path.
+func TestPrependHealthCheckRules(t *testing.T) {
+	t.Parallel()
+
+	cfg := &proxy.Config{
+		Rules: []proxy.RouteRule{{
+			Hostnames: []string{"health.example.com"},
+			Matches: []proxy.RouteMatch{{
+				Path: &proxy.PathMatch{Type: proxy.PathMatchExact, Value: ingress.HealthCheckPath},
+			}},
+			Backends:          []proxy.BackendRef{},
+			UnavailableStatus: http.StatusInternalServerError,
+		}},
+		Provenance: []proxy.RuleProvenance{{Kind: "HTTPRoute", Namespace: "default", Name: "app"}},
+	}
+
+	prependHealthCheckRules(cfg, []string{"health.example.com"})
+	require.Len(t, cfg.Rules, 2)
+	require.Len(t, cfg.Provenance, 2, "provenance must stay parallel to rules")
+	assert.Equal(t, "app", cfg.Provenance[1].Name)
+
+	router := proxy.NewRouter()
+	require.NoError(t, router.UpdateConfig(cfg))
+
+	handler := proxy.NewHandler(router)
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+	}{
+		{name: "health endpoint", url: "http://health.example.com" + ingress.HealthCheckPath, wantStatus: http.StatusOK},
+		{name: "sub-path is not the endpoint", url: "http://health.example.com" + ingress.HealthCheckPath + "/x", wantStatus: http.StatusNotFound},
+		{name: "other hostname", url: "http://other.example.com" + ingress.HealthCheckPath, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, tt.url, nil)
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.wantStatus, recorder.Code)
+		})
+	}
+}
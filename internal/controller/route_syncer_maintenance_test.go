@@ -0,0 +1,208 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+// maintenanceTestNow is a Saturday, 02:30 UTC: inside a window opening at
+// 02:00 every Saturday for an hour.
+var maintenanceTestNow = time.Date(2026, time.March, 7, 2, 30, 0, 0, time.UTC)
+
+func setMaintenanceWindow(ctx context.Context, t *testing.T, syncer *RouteSyncer, gatewayName string) {
+	t.Helper()
+
+	var gateway gatewayv1.Gateway
+	require.NoError(t, syncer.Client.Get(ctx, client.ObjectKey{Namespace: "default", Name: gatewayName}, &gateway))
+
+	gateway.Annotations = map[string]string{
+		config.MaintenanceScheduleAnnotation: "0 2 * * 6",
+		config.MaintenanceDurationAnnotation: "1h",
+	}
+	require.NoError(t, syncer.Client.Update(ctx, &gateway))
+}
+
+// TestSyncAllRoutes_MaintenanceWindowPausesTunnel pins that a Gateway inside
+// its maintenance window holds back its tunnel's document, leaves the other
+// tunnel alone, requeues for the window's close, and is written once the
+// window has passed.
+func TestSyncAllRoutes_MaintenanceWindowPausesTunnel(t *testing.T) {
+	t.Parallel()
+
+	const sharedTunnel = "99999999-9999-4999-8999-999999999999"
+
+	ctx := context.Background()
+	api := newRecordingTunnelAPI(t)
+	syncer := newPartitionSyncSyncer(t, api, sharedTunnel)
+	setMaintenanceWindow(ctx, t, syncer, "shared-gw")
+
+	now := maintenanceTestNow
+	syncer.now = func() time.Time { return now }
+
+	res, syncResult, err := syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	assert.Nil(t, api.hostnamesFor(sharedTunnel), "the paused tunnel must not be written")
+	assert.Equal(t, map[string]bool{sharedPartitionKey: true}, syncResult.PausedPartitions,
+		"the paused tunnel's proxy push is held back too")
+	assert.Contains(t, api.hostnamesFor(tenantTunnelUUID), "tenant.example.com",
+		"a Gateway on another tunnel is not paused")
+	assert.Equal(t, 30*time.Minute, res.RequeueAfter, "resume when the window closes")
+
+	now = maintenanceTestNow.Add(30 * time.Minute)
+
+	res, syncResult, err = syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	assert.Contains(t, api.hostnamesFor(sharedTunnel), "shared.example.com", "resumed after the window")
+	assert.Empty(t, syncResult.PausedPartitions)
+	assert.Zero(t, res.RequeueAfter)
+}
+
+// TestPushPartitionConfigs_MaintenanceWindowHoldsProxyPush pins that a paused
+// partition's proxy receives nothing, so its traffic does not change during
+// the window, while other partitions are still pushed.
+func TestPushPartitionConfigs_MaintenanceWindowHoldsProxyPush(t *testing.T) {
+	t.Parallel()
+
+	var pushes atomic.Int32
+
+	replica := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		pushes.Add(1)
+		writer.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(replica.Close)
+
+	testClient := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	params := syncUpdateParams{
+		routeSyncer: &RouteSyncer{
+			ClusterDomain: "cluster.local",
+			Metrics:       cfmetrics.NewNoopCollector(),
+		},
+		proxySyncer:    NewProxySyncer("cluster.local", "shared-token", "", testClient, slog.Default()),
+		proxyEndpoints: []string{replica.URL + "/config"},
+		pushProxy:      true,
+	}
+
+	syncResult := &SyncResult{
+		Partitions: []routePartition{
+			{Key: sharedPartitionKey, HTTPRoutes: []gatewayv1.HTTPRoute{*pushFallbackRoute("web", "web.example.com")}},
+		},
+		PausedPartitions: map[string]bool{sharedPartitionKey: true},
+	}
+
+	_, _ = pushPartitionConfigs(context.Background(), slog.Default(), &params, syncResult)
+	assert.Zero(t, pushes.Load(), "a paused partition must not be pushed")
+
+	syncResult.PausedPartitions = nil
+
+	_, _ = pushPartitionConfigs(context.Background(), slog.Default(), &params, syncResult)
+	assert.Positive(t, pushes.Load(), "the held change is pushed once the window closes")
+}
+
+// TestSyncAllRoutes_InvalidMaintenanceWindowNeverPauses pins that a malformed
+// schedule is ignored rather than freezing the tunnel.
+func TestSyncAllRoutes_InvalidMaintenanceWindowNeverPauses(t *testing.T) {
+	t.Parallel()
+
+	const sharedTunnel = "99999999-9999-4999-8999-999999999999"
+
+	ctx := context.Background()
+	api := newRecordingTunnelAPI(t)
+	syncer := newPartitionSyncSyncer(t, api, sharedTunnel)
+	syncer.now = func() time.Time { return maintenanceTestNow }
+
+	var gateway gatewayv1.Gateway
+	require.NoError(t, syncer.Client.Get(ctx, client.ObjectKey{Namespace: "default", Name: "shared-gw"}, &gateway))
+
+	gateway.Annotations = map[string]string{config.MaintenanceScheduleAnnotation: "0 2 * * 6"}
+	require.NoError(t, syncer.Client.Update(ctx, &gateway))
+
+	_, _, err := syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	assert.Contains(t, api.hostnamesFor(sharedTunnel), "shared.example.com")
+}
+
+func TestMaintenanceWindowStatus(t *testing.T) {
+	t.Parallel()
+
+	annotated := func(annotations map[string]string) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default", Generation: 2, Annotations: annotations},
+		}
+	}
+
+	weekly := map[string]string{
+		config.MaintenanceScheduleAnnotation: "0 2 * * 6",
+		config.MaintenanceDurationAnnotation: "1h",
+	}
+
+	tests := []struct {
+		name       string
+		gateway    *gatewayv1.Gateway
+		now        time.Time
+		wantStatus metav1.ConditionStatus
+		wantReason string
+		wantFlip   time.Duration
+	}{
+		{
+			name:       "inside the window",
+			gateway:    annotated(weekly),
+			now:        maintenanceTestNow,
+			wantStatus: metav1.ConditionTrue,
+			wantReason: gatewayReasonMaintenanceWindow,
+			wantFlip:   30 * time.Minute,
+		},
+		{
+			name:       "outside the window",
+			gateway:    annotated(weekly),
+			now:        maintenanceTestNow.Add(time.Hour),
+			wantStatus: metav1.ConditionFalse,
+			wantReason: gatewayReasonOutsideMaintenanceWindow,
+			wantFlip:   7*24*time.Hour - 90*time.Minute,
+		},
+		{
+			name:       "invalid schedule",
+			gateway:    annotated(map[string]string{config.MaintenanceScheduleAnnotation: "not cron"}),
+			now:        maintenanceTestNow,
+			wantStatus: metav1.ConditionFalse,
+			wantReason: gatewayReasonInvalidMaintenanceWindow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			condition, flip := maintenanceWindowStatus(tt.gateway, tt.now)
+
+			require.NotNil(t, condition)
+			assert.Equal(t, gatewayConditionPaused, condition.Type)
+			assert.Equal(t, tt.wantStatus, condition.Status)
+			assert.Equal(t, tt.wantReason, condition.Reason)
+			assert.Equal(t, int64(2), condition.ObservedGeneration)
+			assert.Equal(t, tt.wantFlip, flip)
+		})
+	}
+
+	condition, flip := maintenanceWindowStatus(annotated(nil), maintenanceTestNow)
+	assert.Nil(t, condition, "no schedule means no condition")
+	assert.Zero(t, flip)
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// TestSyncAllRoutes_ConcurrentHTTPAndGRPCChangesApplyOnce pins that the
+// HTTPRoute and GRPCRoute reconcilers share one sync: each pass lists every
+// route kind into one document under syncMu, so when both fire for changes
+// made together the first pass writes the merged document and the second
+// finds it already applied and makes no write.
+func TestSyncAllRoutes_ConcurrentHTTPAndGRPCChangesApplyOnce(t *testing.T) {
+	t.Parallel()
+
+	const classTunnel = "99999999-9999-4999-8999-999999999999"
+
+	ctx := context.Background()
+	api := newRecordingTunnelAPI(t)
+	syncer := newPartitionSyncSyncer(t, api, classTunnel)
+
+	_, _, err := syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, api.writesTo(classTunnel))
+
+	port := gatewayv1.PortNumber(80)
+
+	require.NoError(t, syncer.Client.Create(ctx, partitionSyncRoute("web", "shared-gw", "web.example.com")))
+	require.NoError(t, syncer.Client.Create(ctx, &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "rpc", Namespace: "default"},
+		Spec: gatewayv1.GRPCRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "shared-gw"}},
+			},
+			Hostnames: []gatewayv1.Hostname{"rpc.example.com"},
+			Rules: []gatewayv1.GRPCRouteRule{{
+				BackendRefs: []gatewayv1.GRPCBackendRef{{
+					BackendRef: gatewayv1.BackendRef{
+						BackendObjectReference: gatewayv1.BackendObjectReference{Name: "svc", Port: &port},
+					},
+				}},
+			}},
+		},
+	}))
+
+	// One sync per reconciler, as the HTTPRoute and GRPCRoute watches fire.
+	var wg sync.WaitGroup
+
+	errs := make([]error, 2)
+
+	for i := range errs {
+		wg.Go(func() {
+			_, _, errs[i] = syncer.SyncAllRoutes(ctx)
+		})
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, api.writesTo(classTunnel), "the two passes make exactly one merged write")
+
+	hostnames := api.hostnamesFor(classTunnel)
+	assert.Contains(t, hostnames, "web.example.com")
+	assert.Contains(t, hostnames, "rpc.example.com")
+	assert.Contains(t, hostnames, "shared.example.com")
+}
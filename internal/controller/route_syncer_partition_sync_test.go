@@ -40,9 +40,22 @@ const tenantTunnelUUID = "550e8400-e29b-41d4-a716-446655440000"
 type recordingTunnelAPI struct {
 	server *httptest.Server
 
-	mu           sync.Mutex
-	puts         map[string][]string // tunnelID -> hostnames in the written document
-	failTunnelID string              // PUTs to this tunnel ID return 500
+	mu            sync.Mutex
+	puts          map[string][]string        // tunnelID -> hostnames in the written document
+	rules         map[string][]recordedRule  // tunnelID -> every rule of the written document, in order
+	writes        map[string]int             // tunnelID -> successful PUTs
+	failTunnelID  string                     // PUTs to this tunnel ID return 500
+	rateLimitNext string                     // when set, the next PUT returns 429 with this Retry-After
+	putAttempts   int                        // every PUT, failed ones included
+	echoWrites    bool                       // GETs serve the last document written to the tunnel
+	written       map[string]json.RawMessage // tunnelID -> raw ingress of the last written document
+}
+
+// recordedRule is the routing part of one written ingress rule.
+type recordedRule struct {
+	Hostname string `json:"hostname"`
+	Path     string `json:"path"`
+	Service  string `json:"service"`
 }
 
 // failTunnel makes every PUT to tunnelID return a 5xx, simulating one tunnel's
@@ -61,26 +74,75 @@ func (a *recordingTunnelAPI) shouldFail(tunnelID string) bool {
 	return a.failTunnelID == tunnelID
 }
 
+// rateLimitNextPut makes the next PUT return a 429 carrying retryAfter as
+// its Retry-After header.
+func (a *recordingTunnelAPI) rateLimitNextPut(retryAfter string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.rateLimitNext = retryAfter
+}
+
+// takeRateLimit counts a PUT attempt and returns the Retry-After to answer it
+// with, or "" when the PUT proceeds.
+func (a *recordingTunnelAPI) takeRateLimit() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.putAttempts++
+	retryAfter := a.rateLimitNext
+	a.rateLimitNext = ""
+
+	return retryAfter
+}
+
+func (a *recordingTunnelAPI) attemptedPuts() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.putAttempts
+}
+
 func newRecordingTunnelAPI(t *testing.T) *recordingTunnelAPI {
 	t.Helper()
 
-	api := &recordingTunnelAPI{puts: make(map[string][]string)}
+	api := &recordingTunnelAPI{
+		puts:    make(map[string][]string),
+		rules:   make(map[string][]recordedRule),
+		writes:  make(map[string]int),
+		written: make(map[string]json.RawMessage),
+	}
 
 	api.server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
 		writer.Header().Set("Content-Type", "application/json")
 
+		// Path: /accounts/<acct>/cfd_tunnel/<tunnelID>/configurations
+		segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+		tunnelID := segments[len(segments)-2]
+
 		switch req.Method {
 		case http.MethodGet:
+			var current any = []map[string]any{{"service": "http_status:404"}}
+			if written := api.lastWritten(tunnelID); written != nil {
+				current = written
+			}
+
 			_ = json.NewEncoder(writer).Encode(map[string]any{
 				"success": true, "errors": []any{},
-				"result": map[string]any{"config": map[string]any{"ingress": []map[string]any{
-					{"service": "http_status:404"},
-				}}},
+				"result": map[string]any{"config": map[string]any{"ingress": current}},
 			})
 		case http.MethodPut:
-			// Path: /accounts/<acct>/cfd_tunnel/<tunnelID>/configurations
-			segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
-			tunnelID := segments[len(segments)-2]
+
+			if retryAfter := api.takeRateLimit(); retryAfter != "" {
+				writer.Header().Set("Retry-After", retryAfter)
+				writer.WriteHeader(http.StatusTooManyRequests)
+				_ = json.NewEncoder(writer).Encode(map[string]any{
+					"success": false,
+					"errors":  []any{map[string]any{"code": 10000, "message": "simulated rate limit"}},
+				})
+
+				return
+			}
 
 			if api.shouldFail(tunnelID) {
 				writer.WriteHeader(http.StatusInternalServerError)
@@ -94,16 +156,17 @@ func newRecordingTunnelAPI(t *testing.T) *recordingTunnelAPI {
 
 			var body struct {
 				Config struct {
-					Ingress []struct {
-						Hostname string `json:"hostname"`
-					} `json:"ingress"`
+					Ingress json.RawMessage `json:"ingress"`
 				} `json:"config"`
 			}
 			_ = json.NewDecoder(req.Body).Decode(&body)
 
-			hostnames := make([]string, 0, len(body.Config.Ingress))
+			var ingressRules []recordedRule
+			_ = json.Unmarshal(body.Config.Ingress, &ingressRules)
 
-			for _, rule := range body.Config.Ingress {
+			hostnames := make([]string, 0, len(ingressRules))
+
+			for _, rule := range ingressRules {
 				if rule.Hostname != "" {
 					hostnames = append(hostnames, rule.Hostname)
 				}
@@ -111,6 +174,9 @@ func newRecordingTunnelAPI(t *testing.T) *recordingTunnelAPI {
 
 			api.mu.Lock()
 			api.puts[tunnelID] = hostnames
+			api.rules[tunnelID] = ingressRules
+			api.written[tunnelID] = body.Config.Ingress
+			api.writes[tunnelID]++
 			api.mu.Unlock()
 
 			_ = json.NewEncoder(writer).Encode(map[string]any{
@@ -126,6 +192,29 @@ func newRecordingTunnelAPI(t *testing.T) *recordingTunnelAPI {
 	return api
 }
 
+// serveWrittenConfigs makes GETs return the last document written to each
+// tunnel, as Cloudflare does, instead of a catch-all-only config; needed to
+// observe rules being removed from a deployed document.
+func (a *recordingTunnelAPI) serveWrittenConfigs() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.echoWrites = true
+}
+
+// lastWritten returns the ingress a GET serves for tunnelID, or nil for the
+// catch-all-only default.
+func (a *recordingTunnelAPI) lastWritten(tunnelID string) json.RawMessage {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.echoWrites {
+		return nil
+	}
+
+	return a.written[tunnelID]
+}
+
 func (a *recordingTunnelAPI) hostnamesFor(tunnelID string) []string {
 	a.mu.Lock()
 	defer a.mu.Unlock()
@@ -133,6 +222,20 @@ func (a *recordingTunnelAPI) hostnamesFor(tunnelID string) []string {
 	return a.puts[tunnelID]
 }
 
+func (a *recordingTunnelAPI) rulesFor(tunnelID string) []recordedRule {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.rules[tunnelID]
+}
+
+func (a *recordingTunnelAPI) writesTo(tunnelID string) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.writes[tunnelID]
+}
+
 func (a *recordingTunnelAPI) tunnelsWritten() int {
 	a.mu.Lock()
 	defer a.mu.Unlock()
@@ -482,3 +585,42 @@ func TestSyncAllRoutes_BrokenGatewayConfigFailsClosed(t *testing.T) {
 	assert.Error(t, binding.syncErrByGateway["default/infra-gw"],
 		"a route on a broken data plane must carry a per-parent sync error so it is not reported Accepted=True")
 }
+
+// TestSyncAllRoutes_LastRouteDeletedLeavesCatchAllOnly pins the cleanup of a
+// tunnel whose last route goes away: no route finalizer is needed because the
+// next full sync still builds every tunnel's document, and with nothing left
+// to serve that document is the catch-all alone. Stale ingress for a deleted
+// route must not outlive it in Cloudflare.
+func TestSyncAllRoutes_LastRouteDeletedLeavesCatchAllOnly(t *testing.T) {
+	t.Parallel()
+
+	const sharedTunnel = "99999999-9999-4999-8999-999999999999"
+
+	api := newRecordingTunnelAPI(t)
+	api.serveWrittenConfigs()
+	syncer := newPartitionSyncSyncer(t, api, sharedTunnel)
+
+	_, _, err := syncer.SyncAllRoutes(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, api.hostnamesFor(sharedTunnel), "shared.example.com")
+	require.Contains(t, api.hostnamesFor(tenantTunnelUUID), "tenant.example.com")
+
+	for _, name := range []string{"shared-route", "tenant-route"} {
+		require.NoError(t, syncer.Delete(context.Background(), &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		}))
+	}
+
+	_, _, err = syncer.SyncAllRoutes(context.Background())
+	require.NoError(t, err)
+
+	catchAllOnly := []recordedRule{{Service: "http_status:404"}}
+
+	assert.Equal(t, 2, api.writesTo(sharedTunnel), "deleting the last route must rewrite the tunnel")
+	assert.Equal(t, catchAllOnly, api.rulesFor(sharedTunnel),
+		"the shared tunnel must keep only the catch-all once its last route is gone")
+
+	assert.Equal(t, 2, api.writesTo(tenantTunnelUUID), "deleting the last route must rewrite the tunnel")
+	assert.Equal(t, catchAllOnly, api.rulesFor(tenantTunnelUUID),
+		"a dedicated tunnel must keep only the catch-all once its last route is gone")
+}
@@ -12,8 +12,10 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/cloudflare/cloudflare-go/v7"
 	"github.com/cloudflare/cloudflare-go/v7/option"
@@ -22,6 +24,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
@@ -34,11 +37,18 @@ import (
 const skipTestControllerName = "cf.k8s.lex.la/test-controller"
 
 // fakeTunnelAPI emulates the two Cloudflare configuration endpoints the
-// syncer talks to, serving a fixed current ingress and counting writes.
+// syncer talks to, serving a fixed current ingress and counting reads and
+// writes. Any other read (the Access application listing every tunnel gets
+// once) is answered with an empty list and not counted.
+// getVersion and putVersion are the configuration versions the GET and PUT
+// responses report.
 type fakeTunnelAPI struct {
-	server   *httptest.Server
-	putCount atomic.Int32
-	ingress  []map[string]any
+	server     *httptest.Server
+	getCount   atomic.Int32
+	putCount   atomic.Int32
+	getVersion atomic.Int64
+	putVersion atomic.Int64
+	ingress    []map[string]any
 }
 
 func newFakeTunnelAPI(t *testing.T, currentIngress []map[string]any) *fakeTunnelAPI {
@@ -47,25 +57,34 @@ func newFakeTunnelAPI(t *testing.T, currentIngress []map[string]any) *fakeTunnel
 	api := &fakeTunnelAPI{ingress: currentIngress}
 
 	api.server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
-		switch req.Method {
-		case http.MethodGet:
+		switch {
+		case req.Method == http.MethodGet && !strings.HasSuffix(req.URL.Path, "/configurations"):
+			writer.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(writer).Encode(map[string]any{"success": true, "errors": []any{}, "result": []any{}})
+		case req.Method == http.MethodGet:
+			api.getCount.Add(1)
+
 			payload := map[string]any{
 				"success": true,
 				"errors":  []any{},
 				"result": map[string]any{
-					"config": map[string]any{"ingress": api.ingress},
+					"config":  map[string]any{"ingress": api.ingress},
+					"version": api.getVersion.Load(),
 				},
 			}
 			writer.Header().Set("Content-Type", "application/json")
 			_ = json.NewEncoder(writer).Encode(payload)
-		case http.MethodPut:
+		case req.Method == http.MethodPut:
 			api.putCount.Add(1)
 
 			writer.Header().Set("Content-Type", "application/json")
 			_ = json.NewEncoder(writer).Encode(map[string]any{
 				"success": true,
 				"errors":  []any{},
-				"result":  map[string]any{"config": map[string]any{}},
+				"result": map[string]any{
+					"config":  map[string]any{},
+					"version": api.putVersion.Load(),
+				},
 			})
 		default:
 			writer.WriteHeader(http.StatusMethodNotAllowed)
@@ -167,3 +186,150 @@ func TestSyncAllRoutes_WritesWhenDocumentDiffers(t *testing.T) {
 	assert.Equal(t, int32(1), api.putCount.Load(),
 		"a differing ingress document must be written")
 }
+
+func TestSyncAllRoutes_SkipsWriteOnStaleRead(t *testing.T) {
+	t.Parallel()
+
+	// The fake never applies a PUT, so the deployed document keeps differing
+	// from the desired one and every non-stale sync writes.
+	api := newFakeTunnelAPI(t, []map[string]any{
+		{"hostname": "stale.example.com", "service": "http://stale.default.svc.cluster.local:80"},
+		{"service": ingress.CatchAllService},
+	})
+	api.putVersion.Store(5)
+
+	syncer := newSkipTestSyncer(t, api)
+
+	_, _, err := syncer.SyncAllRoutes(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int32(1), api.putCount.Load(), "the first sync must write")
+
+	// The GET still reports version 0, older than the version-5 write: the
+	// diff would be computed from a document that predates it. Drop the
+	// applied-config cache first so the unchanged desired document is not
+	// short-circuited before the read.
+	syncer.appliedConfigs.forget("test-tunnel")
+
+	res, _, err := syncer.SyncAllRoutes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), api.putCount.Load(),
+		"a document diffed against a stale read must not be written")
+	assert.Positive(t, res.RequeueAfter, "a skipped stale write must be retried")
+
+	// Once the read catches up with the applied version, writes resume.
+	api.getVersion.Store(5)
+	syncer.appliedConfigs.forget("test-tunnel")
+
+	_, _, err = syncer.SyncAllRoutes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), api.putCount.Load(),
+		"a read at the applied version must be diffed and written")
+}
+
+func TestSyncAllRoutes_SecondIdenticalSyncMakesNoAPICalls(t *testing.T) {
+	t.Parallel()
+
+	api := newFakeTunnelAPI(t, []map[string]any{
+		{"hostname": "stale.example.com", "service": "http://stale.default.svc.cluster.local:80"},
+		{"service": ingress.CatchAllService},
+	})
+
+	syncer := newSkipTestSyncer(t, api)
+
+	_, _, err := syncer.SyncAllRoutes(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int32(1), api.getCount.Load())
+	require.Equal(t, int32(1), api.putCount.Load())
+
+	_, result, err := syncer.SyncAllRoutes(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	assert.Equal(t, int32(1), api.getCount.Load(), "an identical sync must not read the tunnel config")
+	assert.Equal(t, int32(1), api.putCount.Load(), "an identical sync must not write the tunnel config")
+}
+
+func TestSyncAllRoutes_UnchangedReadIsCached(t *testing.T) {
+	t.Parallel()
+
+	// The deployed document already matches: the first sync reads and skips
+	// the write, and that confirmation is cached like a write would be.
+	api := newFakeTunnelAPI(t, []map[string]any{
+		{"service": ingress.CatchAllService},
+	})
+
+	syncer := newSkipTestSyncer(t, api)
+
+	for range 3 {
+		_, _, err := syncer.SyncAllRoutes(context.Background())
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(1), api.getCount.Load())
+	assert.Equal(t, int32(0), api.putCount.Load())
+}
+
+func TestSyncAllRoutes_AppliedConfigCacheExpires(t *testing.T) {
+	t.Parallel()
+
+	api := newFakeTunnelAPI(t, []map[string]any{
+		{"service": ingress.CatchAllService},
+	})
+
+	syncer := newSkipTestSyncer(t, api)
+
+	now := time.Now()
+	syncer.appliedConfigs.now = func() time.Time { return now }
+
+	_, _, err := syncer.SyncAllRoutes(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int32(1), api.getCount.Load())
+
+	// Past the max age the deployed document is read again, so an
+	// out-of-band edit is still repaired.
+	now = now.Add(appliedConfigMaxAge + time.Second)
+
+	_, _, err = syncer.SyncAllRoutes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), api.getCount.Load())
+}
+
+func TestSyncAllRoutes_TunnelIDChangeInvalidatesCache(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	api := newFakeTunnelAPI(t, []map[string]any{
+		{"service": ingress.CatchAllService},
+	})
+
+	syncer := newSkipTestSyncer(t, api)
+
+	_, _, err := syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), api.getCount.Load())
+
+	setSkipTestTunnelID(ctx, t, syncer, "other-tunnel")
+
+	_, _, err = syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), api.getCount.Load(), "a new tunnel must be read before it is trusted")
+
+	// Switching back reads the original tunnel again: it may have been
+	// written by someone else while the controller pointed elsewhere.
+	setSkipTestTunnelID(ctx, t, syncer, "test-tunnel")
+
+	_, _, err = syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), api.getCount.Load())
+}
+
+// setSkipTestTunnelID points the test GatewayClassConfig at tunnelID.
+func setSkipTestTunnelID(ctx context.Context, t *testing.T, syncer *RouteSyncer, tunnelID string) {
+	t.Helper()
+
+	var cfg v1alpha1.GatewayClassConfig
+	require.NoError(t, syncer.Client.Get(ctx, client.ObjectKey{Name: "cfg"}, &cfg))
+
+	cfg.Spec.TunnelID = tunnelID
+	require.NoError(t, syncer.Client.Update(ctx, &cfg))
+}
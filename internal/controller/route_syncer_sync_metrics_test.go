@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/ingress"
+)
+
+// syncMetricsCollector records the per-sync gauges and the backend ref
+// validation counter; every other metric goes to the embedded noop.
+type syncMetricsCollector struct {
+	cfmetrics.Collector
+
+	mu           sync.Mutex
+	ingressRules int
+	failedRefs   map[string]int // route type -> last gauge value
+	validations  map[string]int // result/reason -> count
+}
+
+func (c *syncMetricsCollector) RecordIngressRules(_ context.Context, count int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ingressRules = count
+}
+
+func (c *syncMetricsCollector) RecordFailedBackendRefs(_ context.Context, routeType string, count int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.failedRefs[routeType] = count
+}
+
+func (c *syncMetricsCollector) RecordBackendRefValidation(_ context.Context, _, result, reason string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.validations[result+"/"+reason]++
+}
+
+// TestSyncAllRoutes_RecordsRuleAndFailedRefGauges pins the alerting metrics
+// after a sync with one good and one failed backend ref: the ingress rule
+// gauge equals the rules written, the failed-refs gauge counts the broken
+// HTTPRoute backend, and the validation counter carries its reason.
+func TestSyncAllRoutes_RecordsRuleAndFailedRefGauges(t *testing.T) {
+	t.Parallel()
+
+	const classTunnel = "99999999-9999-4999-8999-999999999999"
+
+	ctx := context.Background()
+	api := newRecordingTunnelAPI(t)
+	syncer := newPartitionSyncSyncer(t, api, classTunnel)
+	collector := &syncMetricsCollector{
+		Collector:   cfmetrics.NewNoopCollector(),
+		failedRefs:  make(map[string]int),
+		validations: make(map[string]int),
+	}
+	syncer.Metrics = collector
+	// The default builders captured the collector at construction.
+	syncer.httpBuilder, syncer.grpcBuilder = ingress.NewBuilder(syncer.ClusterDomain, syncer.refGrantValidator, syncer.Client, collector, syncer.Logger),
+		ingress.NewGRPCBuilder(syncer.ClusterDomain, syncer.refGrantValidator, syncer.Client, collector, syncer.Logger)
+
+	// shared-route resolves; broken-route points at a Service that does not exist.
+	broken := partitionSyncRoute("broken-route", "shared-gw", "broken.example.com")
+	broken.Spec.Rules[0].BackendRefs[0].Name = "missing"
+	require.NoError(t, syncer.Client.Create(ctx, broken))
+
+	_, _, err := syncer.SyncAllRoutes(ctx)
+	require.NoError(t, err)
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+
+	written := len(api.rulesFor(classTunnel)) + len(api.rulesFor(tenantTunnelUUID))
+	assert.Equal(t, written, collector.ingressRules)
+	assert.Equal(t, 1, collector.failedRefs["http"])
+	assert.Equal(t, 0, collector.failedRefs["grpc"])
+	assert.Positive(t, collector.validations["success/"])
+	assert.Equal(t, 1, collector.validations["failed/"+string(gatewayv1.RouteReasonBackendNotFound)])
+}
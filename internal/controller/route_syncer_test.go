@@ -2,7 +2,9 @@ package controller
 
 import (
 	"context"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/cloudflare/cloudflare-go/v7"
 	"github.com/cloudflare/cloudflare-go/v7/zero_trust"
@@ -517,6 +519,48 @@ func TestMergeAndSortRules_Ordering(t *testing.T) {
 	assert.Equal(t, "z.example.com", result[1].Hostname.Value)
 }
 
+// TestMergeAndSortRules_SharedHostname pins that HTTP and gRPC rules on one
+// hostname interleave by path specificity, equally specific paths in path
+// order with exact before prefix, whichever builder produced them.
+func TestMergeAndSortRules_SharedHostname(t *testing.T) {
+	t.Parallel()
+
+	rule := func(path, service string) zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress {
+		return zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
+			Hostname: cloudflare.String("app.example.com"),
+			Path:     cloudflare.String(path),
+			Service:  cloudflare.String(service),
+		}
+	}
+
+	httpRules := []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
+		rule("/zzzzzzzz*", "http://web:80"),
+		rule("/pkg.Svc/x", "http://web:80"),
+		rule("/api*", "http://web:80"),
+	}
+	grpcRules := []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
+		rule("/pkg.Svc/*", "http://grpc:50051"),
+	}
+
+	want := []string{"/pkg.Svc/x", "/pkg.Svc/*", "/zzzzzzzz*", "/api*"}
+
+	for _, swapped := range []bool{false, true} {
+		var result []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress
+		if swapped {
+			result = mergeAndSortRules(slices.Clone(grpcRules), slices.Clone(httpRules))
+		} else {
+			result = mergeAndSortRules(slices.Clone(httpRules), slices.Clone(grpcRules))
+		}
+
+		paths := make([]string, 0, len(result))
+		for _, r := range result {
+			paths = append(paths, r.Path.Value)
+		}
+
+		assert.Equal(t, want, paths, "swapped=%v", swapped)
+	}
+}
+
 func TestMergeAndSortRules_WildcardLast(t *testing.T) {
 	t.Parallel()
 
@@ -596,6 +640,15 @@ func TestSortIngressRules(t *testing.T) {
 			},
 			expected: []string{"a.example.com", "b.example.com", ""},
 		},
+		{
+			name: "exact hostname before the wildcard covering it",
+			rules: []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
+				{Hostname: cloudflare.String("*.example.com"), Service: cloudflare.String("http://wild:80")},
+				{Hostname: cloudflare.String("*.api.example.com"), Service: cloudflare.String("http://api-wild:80")},
+				{Hostname: cloudflare.String("app.example.com"), Service: cloudflare.String("http://app:80")},
+			},
+			expected: []string{"app.example.com", "*.api.example.com", "*.example.com"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -632,6 +685,29 @@ func TestSortIngressRules_LongerPathFirst(t *testing.T) {
 	assert.Equal(t, "/", result[1].Path.Value, "shorter path should come second")
 }
 
+func TestProxyOriginDefaultsFor(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, proxy.OriginConfig{}, proxyOriginDefaultsFor(nil))
+	assert.Equal(t, proxy.OriginConfig{}, proxyOriginDefaultsFor(&config.ResolvedConfig{}))
+
+	disable := true
+	resolved := &config.ResolvedConfig{OriginRequestDefaults: v1alpha1.OriginRequestDefaults{
+		ConnectTimeout:       "1m30s",
+		TLSTimeout:           "10s",
+		TCPKeepAlive:         "",
+		KeepAliveConnections: 50,
+		DisableHappyEyeballs: true,
+	}}
+
+	assert.Equal(t, proxy.OriginConfig{
+		ConnectTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:  10 * time.Second,
+		MaxIdleConnsPerHost:  50,
+		DisableHappyEyeballs: &disable,
+	}, proxyOriginDefaultsFor(resolved), "an unset duration leaves the proxy default")
+}
+
 func TestFilterOutCatchAll(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,278 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+// Shared-tunnel policies decide what happens when two GatewayClasses of this
+// controller reference DIFFERENT GatewayClassConfigs that name the SAME
+// tunnel. The controller writes one ingress document per tunnel, so the
+// classes can never clobber each other — the choice is only whether that
+// document is written at all.
+const (
+	// SharedTunnelPolicyWarn refuses to sync (the historic conflicting
+	// parametersRef error) and flags each class with the TunnelShared
+	// condition, leaving the tunnel untouched until the operator decides.
+	SharedTunnelPolicyWarn = "warn"
+	// SharedTunnelPolicyMerge writes the routes of every class into the one
+	// ingress document, using the credentials of the alphabetically first
+	// class. The classes still carry the TunnelShared condition.
+	SharedTunnelPolicyMerge = "merge"
+)
+
+// gatewayClassConditionTunnelShared flags a GatewayClass whose tunnel is also
+// named by another class of this controller.
+const gatewayClassConditionTunnelShared = "cf.k8s.lex.la/TunnelShared"
+
+// gatewayClassReasonSharedTunnel is the reason carried by
+// gatewayClassConditionTunnelShared while the tunnel is shared.
+const gatewayClassReasonSharedTunnel = "SharedTunnel"
+
+// ValidateSharedTunnelPolicy rejects an unknown --shared-tunnel-policy value.
+// Empty means the default (warn).
+func ValidateSharedTunnelPolicy(policy string) error {
+	switch policy {
+	case "", SharedTunnelPolicyWarn, SharedTunnelPolicyMerge:
+		return nil
+	default:
+		return errors.Newf("unknown shared-tunnel policy %q (expected %s or %s)",
+			policy, SharedTunnelPolicyWarn, SharedTunnelPolicyMerge)
+	}
+}
+
+// classTunnelIDs maps each class name to the canonical tunnelID of the
+// GatewayClassConfig it references. A class whose parametersRef is not a
+// GatewayClassConfig, whose config cannot be read, or whose config names its
+// tunnel by tunnelName, is omitted: it cannot share a tunnel it does not
+// provably resolve to without an API call.
+func classTunnelIDs(ctx context.Context, reader client.Reader, classes []gatewayv1.GatewayClass) map[string]string {
+	tunnels := make(map[string]string, len(classes))
+
+	for i := range classes {
+		ref := classes[i].Spec.ParametersRef
+		if ref == nil || string(ref.Group) != config.ParametersRefGroup || string(ref.Kind) != config.ParametersRefKind {
+			continue
+		}
+
+		var classConfig v1alpha1.GatewayClassConfig
+		if err := reader.Get(ctx, types.NamespacedName{Name: ref.Name}, &classConfig); err != nil {
+			continue
+		}
+
+		if classConfig.Spec.TunnelID != "" {
+			tunnels[classes[i].Name] = canonicalTunnelID(classConfig.Spec.TunnelID)
+		}
+	}
+
+	return tunnels
+}
+
+// classesShareOneTunnel reports whether every class resolved to the same
+// tunnel. A class missing from tunnels fails the check: merging is only safe
+// when the whole set provably targets one document.
+func classesShareOneTunnel(classes []gatewayv1.GatewayClass, tunnels map[string]string) bool {
+	first, ok := tunnels[classes[0].Name]
+	if !ok {
+		return false
+	}
+
+	for i := 1; i < len(classes); i++ {
+		if tunnels[classes[i].Name] != first {
+			return false
+		}
+	}
+
+	return true
+}
+
+// tunnelSharingClasses returns, sorted, the other classes of this controller
+// that reference a different GatewayClassConfig but the same tunnel as
+// className. Classes on the same parametersRef are one configuration, not a
+// shared tunnel, and are not reported.
+func tunnelSharingClasses(
+	ctx context.Context,
+	reader client.Reader,
+	classes []gatewayv1.GatewayClass,
+	className string,
+) []string {
+	tunnels := classTunnelIDs(ctx, reader, classes)
+
+	own, ok := tunnels[className]
+	if !ok {
+		return nil
+	}
+
+	idx := slices.IndexFunc(classes, func(class gatewayv1.GatewayClass) bool { return class.Name == className })
+	if idx < 0 {
+		return nil
+	}
+
+	var sharing []string
+
+	for i := range classes {
+		if i == idx || tunnels[classes[i].Name] != own ||
+			parametersRefEqual(classes[i].Spec.ParametersRef, classes[idx].Spec.ParametersRef) {
+			continue
+		}
+
+		sharing = append(sharing, classes[i].Name)
+	}
+
+	slices.Sort(sharing)
+
+	return sharing
+}
+
+// setTunnelSharedCondition sets or clears the TunnelShared condition on a
+// GatewayClass. It is advisory: Accepted is unaffected either way.
+func setTunnelSharedCondition(gatewayClass *gatewayv1.GatewayClass, sharing []string, policy string, now metav1.Time) {
+	if len(sharing) == 0 {
+		meta.RemoveStatusCondition(&gatewayClass.Status.Conditions, gatewayClassConditionTunnelShared)
+
+		return
+	}
+
+	message := "Tunnel is also referenced by GatewayClass(es) " + strings.Join(sharing, ", ") +
+		" through a different GatewayClassConfig; routes are not synced until they share one " +
+		"GatewayClassConfig or the controller runs with --shared-tunnel-policy=merge"
+	if policy == SharedTunnelPolicyMerge {
+		message = "Tunnel is also referenced by GatewayClass(es) " + strings.Join(sharing, ", ") +
+			" through a different GatewayClassConfig; their routes are merged into one ingress document " +
+			"written with the credentials of the alphabetically first class"
+	}
+
+	meta.SetStatusCondition(&gatewayClass.Status.Conditions, metav1.Condition{
+		Type:               gatewayClassConditionTunnelShared,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: gatewayClass.Generation,
+		LastTransitionTime: now,
+		Reason:             gatewayClassReasonSharedTunnel,
+		Message:            truncateMessage(message),
+	})
+}
+
+// ConditionTypeSettingsIgnored flags a GatewayClassConfig whose settings are
+// ignored because SharedTunnelPolicyMerge resolves the shared tunnel from
+// another class's config.
+const ConditionTypeSettingsIgnored = "cf.k8s.lex.la/SettingsIgnored"
+
+// settingsIgnoredReasonMergedTunnel is the reason carried by
+// ConditionTypeSettingsIgnored while the config loses the merge.
+const settingsIgnoredReasonMergedTunnel = "MergedIntoSharedTunnel"
+
+// mergedTunnelSource returns the class whose GatewayClassConfig resolves the
+// merged tunnel: the alphabetically first class of controllerName, when the
+// classes reference different configs that all name one tunnel and policy is
+// SharedTunnelPolicyMerge. ok is false when no merge is in effect.
+func mergedTunnelSource(
+	ctx context.Context,
+	cli client.Client,
+	controllerName, policy string,
+) (gatewayv1.GatewayClass, []gatewayv1.GatewayClass, bool) {
+	if policy != SharedTunnelPolicyMerge || controllerName == "" {
+		return gatewayv1.GatewayClass{}, nil, false
+	}
+
+	classes, err := listGatewayClassesForController(ctx, cli, controllerName)
+	if err != nil || len(classes) < 2 {
+		return gatewayv1.GatewayClass{}, nil, false
+	}
+
+	slices.SortFunc(classes, func(a, b gatewayv1.GatewayClass) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	if !hasConflictingParametersRef(classes) || !classesShareOneTunnel(classes, classTunnelIDs(ctx, cli, classes)) {
+		return gatewayv1.GatewayClass{}, nil, false
+	}
+
+	return classes[0], classes, true
+}
+
+// ignoredSharedSettings lists, by spec field name, the settings of loser that
+// differ from winner and are therefore not applied to the merged tunnel.
+func ignoredSharedSettings(winner, loser *v1alpha1.GatewayClassConfigSpec) []string {
+	var ignored []string
+
+	if winner.CloudflareCredentialsSecretRef != loser.CloudflareCredentialsSecretRef {
+		ignored = append(ignored, "cloudflareCredentialsSecretRef")
+	}
+
+	if winner.AccountID != loser.AccountID {
+		ignored = append(ignored, "accountId")
+	}
+
+	if winner.ClusterDomain != loser.ClusterDomain {
+		ignored = append(ignored, "clusterDomain")
+	}
+
+	if !equality.Semantic.DeepEqual(winner.OriginRequestDefaults, loser.OriginRequestDefaults) {
+		ignored = append(ignored, "originRequestDefaults")
+	}
+
+	if !equality.Semantic.DeepEqual(winner.DefaultBackend, loser.DefaultBackend) {
+		ignored = append(ignored, "defaultBackend")
+	}
+
+	if winner.ManageDNS != loser.ManageDNS {
+		ignored = append(ignored, "manageDNS")
+	}
+
+	return ignored
+}
+
+// settingsIgnoredCondition reports SettingsIgnored=True on a config that a
+// class of this controller references, but whose tunnel is merged under
+// another class's config with different settings. Nil means the config is
+// applied as written and the condition is left out.
+func (r *GatewayClassConfigReconciler) settingsIgnoredCondition(
+	ctx context.Context,
+	cfg *v1alpha1.GatewayClassConfig,
+	now metav1.Time,
+) *metav1.Condition {
+	source, classes, ok := mergedTunnelSource(ctx, r.Client, r.ControllerName, r.SharedTunnelPolicy)
+	if !ok || source.Spec.ParametersRef.Name == cfg.Name {
+		return nil
+	}
+
+	referenced := slices.ContainsFunc(classes, func(class gatewayv1.GatewayClass) bool {
+		return class.Spec.ParametersRef != nil && class.Spec.ParametersRef.Name == cfg.Name
+	})
+	if !referenced {
+		return nil
+	}
+
+	var winner v1alpha1.GatewayClassConfig
+	if err := r.Get(ctx, types.NamespacedName{Name: source.Spec.ParametersRef.Name}, &winner); err != nil {
+		return nil
+	}
+
+	ignored := ignoredSharedSettings(&winner.Spec, &cfg.Spec)
+	if len(ignored) == 0 {
+		return nil
+	}
+
+	return &metav1.Condition{
+		Type:               ConditionTypeSettingsIgnored,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cfg.Generation,
+		LastTransitionTime: now,
+		Reason:             settingsIgnoredReasonMergedTunnel,
+		Message: truncateMessage("Tunnel is merged with GatewayClass " + source.Name + " under --shared-tunnel-policy=merge " +
+			"and resolved from GatewayClassConfig " + winner.Name + "; these settings differ and are ignored: " +
+			strings.Join(ignored, ", ")),
+	}
+}
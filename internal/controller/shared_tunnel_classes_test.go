@@ -0,0 +1,309 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+const (
+	sharedTunnelA = "11111111-1111-1111-1111-111111111111"
+	sharedTunnelB = "22222222-2222-2222-2222-222222222222"
+)
+
+// sharedTunnelObjects builds two classes of one controller, each on its own
+// GatewayClassConfig, naming the given tunnels.
+func sharedTunnelObjects(tunnelA, tunnelB string) []client.Object {
+	classFor := func(name, configName string) *gatewayv1.GatewayClass {
+		return &gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: gatewayv1.GatewayClassSpec{
+				ControllerName: "test-controller",
+				ParametersRef: &gatewayv1.ParametersReference{
+					Group: config.ParametersRefGroup,
+					Kind:  config.ParametersRefKind,
+					Name:  configName,
+				},
+			},
+		}
+	}
+	configFor := func(name, tunnelID string) *v1alpha1.GatewayClassConfig {
+		return &v1alpha1.GatewayClassConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1alpha1.GatewayClassConfigSpec{
+				CloudflareCredentialsSecretRef: v1alpha1.SecretReference{Name: "cf-credentials", Namespace: "default"},
+				AccountID:                      "0123456789abcdef0123456789abcdef",
+				TunnelID:                       tunnelID,
+			},
+		}
+	}
+
+	return []client.Object{
+		classFor("a-class", "config-a"),
+		classFor("b-class", "config-b"),
+		configFor("config-a", tunnelA),
+		configFor("config-b", tunnelB),
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "cf-credentials", Namespace: "default"},
+			Data:       map[string][]byte{"api-token": []byte("token")},
+		},
+	}
+}
+
+func sharedTunnelScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	return scheme
+}
+
+// TestResolveConfigForController_SharedTunnelPolicy pins the policy matrix for
+// classes on different GatewayClassConfigs: merge proceeds only when they name
+// the same tunnel (one document, so no class can clobber another's rules);
+// warn keeps refusing, with a hint naming the shared tunnel as the cause.
+func TestResolveConfigForController_SharedTunnelPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		tunnelB    string
+		policy     string
+		wantErr    string
+		wantTunnel string
+	}{
+		{
+			name:    "warn refuses same-tunnel classes",
+			tunnelB: sharedTunnelA,
+			policy:  SharedTunnelPolicyWarn,
+			wantErr: "--shared-tunnel-policy=merge",
+		},
+		{
+			name:    "default policy is warn",
+			tunnelB: sharedTunnelA,
+			wantErr: "conflicting parametersRef",
+		},
+		{
+			name:       "merge proceeds for same-tunnel classes",
+			tunnelB:    sharedTunnelA,
+			policy:     SharedTunnelPolicyMerge,
+			wantTunnel: sharedTunnelA,
+		},
+		{
+			name:    "merge still refuses different tunnels",
+			tunnelB: sharedTunnelB,
+			policy:  SharedTunnelPolicyMerge,
+			wantErr: "only one tunnel configuration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			scheme := sharedTunnelScheme(t)
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(sharedTunnelObjects(sharedTunnelA, tt.tunnelB)...).
+				Build()
+
+			syncer := NewRouteSyncer(
+				fakeClient, scheme, "cluster.local", "test-controller",
+				config.NewResolver(fakeClient, "default", cfmetrics.NewNoopCollector()),
+				cfmetrics.NewNoopCollector(), nil,
+			)
+			syncer.SharedTunnelPolicy = tt.policy
+
+			resolved, err := syncer.resolveConfigForController(context.Background())
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTunnel, resolved.TunnelID)
+			assert.Equal(t, "config-a", resolved.ConfigName, "credentials come from the alphabetically first class")
+		})
+	}
+}
+
+// TestGatewayClassReconciler_TunnelSharedCondition pins the detection side:
+// every class naming a tunnel shared with another class's config carries the
+// advisory TunnelShared condition (Accepted unaffected), and classes on
+// distinct tunnels carry none.
+func TestGatewayClassReconciler_TunnelSharedCondition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		tunnelB     string
+		policy      string
+		wantShared  bool
+		wantMessage string
+	}{
+		{name: "same tunnel warns", tunnelB: sharedTunnelA, policy: SharedTunnelPolicyWarn, wantShared: true, wantMessage: "not synced"},
+		{name: "same tunnel merged", tunnelB: sharedTunnelA, policy: SharedTunnelPolicyMerge, wantShared: true, wantMessage: "merged"},
+		{name: "distinct tunnels", tunnelB: sharedTunnelB, policy: SharedTunnelPolicyWarn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(sharedTunnelScheme(t)).
+				WithObjects(sharedTunnelObjects(sharedTunnelA, tt.tunnelB)...).
+				WithStatusSubresource(&gatewayv1.GatewayClass{}).
+				Build()
+
+			reconciler := &GatewayClassReconciler{
+				Client:             fakeClient,
+				Scheme:             fakeClient.Scheme(),
+				ControllerName:     "test-controller",
+				SharedTunnelPolicy: tt.policy,
+			}
+
+			for _, name := range []string{"a-class", "b-class"} {
+				_, _ = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: name}})
+
+				var class gatewayv1.GatewayClass
+				require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: name}, &class))
+
+				accepted := findGatewayClassCondition(class.Status.Conditions, string(gatewayv1.GatewayClassConditionStatusAccepted))
+				require.NotNil(t, accepted)
+				assert.Equal(t, metav1.ConditionTrue, accepted.Status, "TunnelShared is advisory")
+
+				shared := findGatewayClassCondition(class.Status.Conditions, gatewayClassConditionTunnelShared)
+				if !tt.wantShared {
+					assert.Nil(t, shared)
+
+					continue
+				}
+
+				require.NotNil(t, shared)
+				assert.Equal(t, metav1.ConditionTrue, shared.Status)
+				assert.Equal(t, gatewayClassReasonSharedTunnel, shared.Reason)
+				assert.Contains(t, shared.Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+// TestGatewayClassConfigReconciler_SettingsIgnoredCondition pins the merge
+// loser side: the config of the alphabetically later class carries
+// SettingsIgnored listing the fields that differ from the config the tunnel
+// is resolved from, while the winning config and configs outside a merge
+// carry none.
+func TestGatewayClassConfigReconciler_SettingsIgnoredCondition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		tunnelB     string
+		policy      string
+		mutate      func(spec *v1alpha1.GatewayClassConfigSpec)
+		wantIgnored []string
+	}{
+		{
+			name:    "merged with differing settings",
+			tunnelB: sharedTunnelA,
+			policy:  SharedTunnelPolicyMerge,
+			mutate: func(spec *v1alpha1.GatewayClassConfigSpec) {
+				spec.ClusterDomain = "other.local"
+				spec.DefaultBackend = &v1alpha1.DefaultBackend{URL: "https://status.example.com"}
+			},
+			wantIgnored: []string{"clusterDomain", "defaultBackend"},
+		},
+		{name: "merged with identical settings", tunnelB: sharedTunnelA, policy: SharedTunnelPolicyMerge},
+		{
+			name:    "warn policy does not merge",
+			tunnelB: sharedTunnelA,
+			policy:  SharedTunnelPolicyWarn,
+			mutate:  func(spec *v1alpha1.GatewayClassConfigSpec) { spec.ManageDNS = true },
+		},
+		{
+			name:    "distinct tunnels",
+			tunnelB: sharedTunnelB,
+			policy:  SharedTunnelPolicyMerge,
+			mutate:  func(spec *v1alpha1.GatewayClassConfigSpec) { spec.ManageDNS = true },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			objects := sharedTunnelObjects(sharedTunnelA, tt.tunnelB)
+			if tt.mutate != nil {
+				tt.mutate(&objects[3].(*v1alpha1.GatewayClassConfig).Spec)
+			}
+
+			ctx := context.Background()
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(sharedTunnelScheme(t)).
+				WithObjects(objects...).
+				WithStatusSubresource(&v1alpha1.GatewayClassConfig{}).
+				Build()
+
+			reconciler := &GatewayClassConfigReconciler{
+				Client:             fakeClient,
+				Scheme:             fakeClient.Scheme(),
+				DefaultNamespace:   "default",
+				ControllerName:     "test-controller",
+				SharedTunnelPolicy: tt.policy,
+			}
+
+			for _, name := range []string{"config-a", "config-b"} {
+				_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: name}})
+				require.NoError(t, err)
+
+				var cfg v1alpha1.GatewayClassConfig
+				require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: name}, &cfg))
+
+				ignored := findGatewayClassCondition(cfg.Status.Conditions, ConditionTypeSettingsIgnored)
+				if name == "config-a" || len(tt.wantIgnored) == 0 {
+					assert.Nil(t, ignored, name)
+
+					continue
+				}
+
+				require.NotNil(t, ignored)
+				assert.Equal(t, metav1.ConditionTrue, ignored.Status)
+				assert.Equal(t, settingsIgnoredReasonMergedTunnel, ignored.Reason)
+				assert.Contains(t, ignored.Message, "config-a")
+				assert.Contains(t, ignored.Message, strings.Join(tt.wantIgnored, ", "))
+			}
+		})
+	}
+}
+
+func TestValidateSharedTunnelPolicy(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidateSharedTunnelPolicy(""))
+	require.NoError(t, ValidateSharedTunnelPolicy(SharedTunnelPolicyWarn))
+	require.NoError(t, ValidateSharedTunnelPolicy(SharedTunnelPolicyMerge))
+	require.Error(t, ValidateSharedTunnelPolicy("clobber"))
+}
@@ -9,11 +9,6 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
-// startupSyncRetryInterval spaces retries of a failed startup sync. It matches
-// apiErrorRequeueDelay so the startup path retries transient API failures at
-// the same rate as the reconcile path does.
-const startupSyncRetryInterval = apiErrorRequeueDelay
-
 // errRequeueRequested marks a degraded-success sync outcome: a nil error
 // carrying a requeue interval. The startup retry loop treats it as a failed
 // attempt (see startupAttempt).
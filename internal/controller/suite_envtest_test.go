@@ -11,6 +11,7 @@ import (
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -70,6 +71,10 @@ func TestMain(m *testing.M) {
 		panic(err)
 	}
 
+	if err := policyv1.AddToScheme(envScheme); err != nil {
+		panic(err)
+	}
+
 	envK8sClient, err = client.New(envCfg, client.Options{Scheme: envScheme})
 	if err != nil {
 		panic(err)
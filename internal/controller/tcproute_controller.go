@@ -0,0 +1,240 @@
+package controller
+
+import (
+	"context"
+	"sync/atomic"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/ingress"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/logging"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/proxy"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/routebinding"
+)
+
+// TCPRouteReconciler reconciles TCPRoute status. The embedded connector has
+// no TCP data plane, so no listener serves TCPRoute and a route is never
+// written to the tunnel ingress document or the proxy.
+//
+// Key behaviors:
+//   - Watches all TCPRoute resources in the cluster
+//   - Filters routes by parent Gateway's GatewayClass
+//   - Uses shared RouteSyncer for binding, alongside HTTPRoutes and GRPCRoutes
+//   - Updates TCPRoute status with acceptance conditions
+type TCPRouteReconciler struct {
+	client.Client
+
+	// Scheme is the runtime scheme for API type registration.
+	Scheme *runtime.Scheme
+
+	// ControllerName identifies this controller and is used to filter
+	// routes by their parent Gateway's GatewayClass controllerName.
+	ControllerName string
+
+	// RouteSyncer provides unified sync for HTTP, GRPC, and TCP routes.
+	RouteSyncer *RouteSyncer
+
+	// Recorder emits Kubernetes Events for listener mismatches. May be nil
+	// in unit tests, in which case event emission is a no-op.
+	Recorder events.EventRecorder
+
+	// bindingValidator validates route binding to Gateway listeners.
+	bindingValidator *routebinding.Validator
+
+	// ViewStore caches the per-Gateway ListenerSet merge view across reconciles
+	// (issue #332). Shared with the other reconcilers. May be nil.
+	ViewStore *mergeViewStore
+
+	// startupComplete indicates whether the initial startup sync has been
+	// ATTEMPTED. Reconciles are parked until the first attempt, same as the
+	// HTTPRoute and GRPCRoute reconcilers.
+	startupComplete atomic.Bool
+}
+
+func (r *TCPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return reconcileRoute(ctx, req, &gatewayv1.TCPRoute{}, reconcileRouteParams[*gatewayv1.TCPRoute]{
+		startupComplete:     &r.startupComplete,
+		startupPendingDelay: r.RouteSyncer.startupPendingDelay(),
+		k8sClient:           r.Client,
+		controllerName:      r.ControllerName,
+		componentName:       "tcproute",
+		wrapRoute:           func(route *gatewayv1.TCPRoute) Route { return TCPRouteWrapper{route} },
+		syncAndUpdate:       r.syncAndUpdateStatus,
+	})
+}
+
+func (r *TCPRouteReconciler) syncParams() *syncUpdateParams {
+	return &syncUpdateParams{
+		routeSyncer: r.RouteSyncer,
+		// A TCPRoute change alters neither the proxy config nor the tunnel
+		// ingress document; only its status is written.
+		pushProxy: false,
+		statusEntries: func(sr *SyncResult, _ []proxy.RouteDiagnostic) []routeStatusEntry {
+			return sr.tcpStatusEntries(r.updateRouteStatus)
+		},
+	}
+}
+
+func (r *TCPRouteReconciler) syncAndUpdateStatus(ctx context.Context) (ctrl.Result, error) {
+	return syncAndUpdateStatusCommon(ctx, r.syncParams())
+}
+
+func (r *TCPRouteReconciler) isRouteForOurGateway(ctx context.Context, route *gatewayv1.TCPRoute) bool {
+	return IsRouteAcceptedByGateway(ctx, r.Client, r.bindingValidator, r.ControllerName, TCPRouteWrapper{route},
+		newListenerViewCache(r.Client, r.ViewStore))
+}
+
+func (r *TCPRouteReconciler) updateRouteStatus(
+	ctx context.Context,
+	route *gatewayv1.TCPRoute,
+	bindingInfo routeBindingInfo,
+	failedRefs []ingress.BackendRefError,
+	diagnostics []proxy.RouteDiagnostic,
+	syncErr error,
+) error {
+	emitListenerMismatchEvents(r.Recorder, route, bindingInfo)
+	emitForceAttachEvents(r.Recorder, route, bindingInfo)
+	emitSyncOutcomeEvents(r.Recorder, route, bindingInfo, failedRefs, diagnostics, syncErr)
+
+	params := &routeStatusUpdateParams{
+		k8sClient:            r.Client,
+		controllerName:       r.ControllerName,
+		reconciledGeneration: route.Generation,
+	}
+
+	return updateRouteStatusGeneric(
+		ctx,
+		params,
+		types.NamespacedName{Name: route.Name, Namespace: route.Namespace},
+		newTCPRouteAccessor,
+		bindingInfo,
+		failedRefs,
+		syncErr,
+	)
+}
+
+func (r *TCPRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.bindingValidator = routebinding.NewValidator(r.Client)
+
+	return setupRouteController(mgr, &routeControllerSetupParams{
+		routeObject:              &gatewayv1.TCPRoute{},
+		reconciler:               r,
+		runnable:                 r,
+		k8sClient:                r.Client,
+		controllerName:           r.ControllerName,
+		configResolver:           r.RouteSyncer.ConfigResolver,
+		findRoutesForGateway:     r.findRoutesForGateway,
+		findRoutesForListenerSet: r.findRoutesForListenerSet,
+		findRoutesForRefGrant:    r.findRoutesForReferenceGrant,
+		// No Service, EndpointSlice, ExternalBackend or BackendTLSPolicy
+		// watch: a TCPRoute's backends are never resolved.
+		getAllRelevantRoutes:  r.getAllRelevantRoutes,
+		watchNamespaceLabels:  r.RouteSyncer.HostnameOwnership != nil,
+		watchRouteAnnotations: r.RouteSyncer.RouteOptInAnnotation != "" || r.RouteSyncer.AllowForceAttach,
+	})
+}
+
+// Start implements manager.Runnable for startup sync. It blocks until the
+// initial sync succeeds (retrying failures) or the manager shuts down.
+func (r *TCPRouteReconciler) Start(ctx context.Context) error {
+	logger := logging.Component(ctx, "tcproute-startup-sync")
+	logger.Info("performing startup sync of tcproute configuration")
+
+	ctx = logging.WithLogger(ctx, logger)
+
+	// Retries use the reconcile path's API-error delay, so transient API
+	// failures are retried at the same rate on both paths.
+	runStartupSync(ctx, logger, r.RouteSyncer.apiErrorDelay(),
+		func() { r.startupComplete.Store(true) },
+		startupSyncAttempt(r.syncParams()),
+	)
+
+	return nil
+}
+
+// listRoutes returns every TCPRoute in the cluster, or nil on a list
+// error (the mappers drop the event rather than fail).
+func (r *TCPRouteReconciler) listRoutes(ctx context.Context) []gatewayv1.TCPRoute {
+	var routeList gatewayv1.TCPRouteList
+	if err := r.List(ctx, &routeList); err != nil {
+		return nil
+	}
+
+	return routeList.Items
+}
+
+func (r *TCPRouteReconciler) findRoutesForGateway(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	items := r.listRoutes(ctx)
+
+	routes := make([]Route, len(items))
+	for i := range items {
+		routes[i] = TCPRouteWrapper{&items[i]}
+	}
+
+	return FindRoutesForGateway(ctx, r.Client, obj, r.ControllerName, routes)
+}
+
+// findRoutesForListenerSet enqueues every TCPRoute managed by our controller
+// whose parentRef targets the given ListenerSet.
+func (r *TCPRouteReconciler) findRoutesForListenerSet(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	listenerSet, ok := obj.(*gatewayv1.ListenerSet)
+	if !ok {
+		return nil
+	}
+
+	items := r.listRoutes(ctx)
+
+	routes := make([]Route, len(items))
+	for i := range items {
+		routes[i] = TCPRouteWrapper{&items[i]}
+	}
+
+	return findRoutesAttachedToListenerSet(ctx, r.Client, listenerSet, r.ControllerName, routes)
+}
+
+// managedRoutes returns the TCPRoutes accepted by one of our Gateways.
+func (r *TCPRouteReconciler) managedRoutes(ctx context.Context) []Route {
+	items := r.listRoutes(ctx)
+
+	routes := make([]Route, 0, len(items))
+
+	for i := range items {
+		route := &items[i]
+		if r.isRouteForOurGateway(ctx, route) {
+			routes = append(routes, TCPRouteWrapper{route})
+		}
+	}
+
+	return routes
+}
+
+func (r *TCPRouteReconciler) findRoutesForReferenceGrant(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	return FindRoutesForReferenceGrant(obj, r.managedRoutes(ctx))
+}
+
+func (r *TCPRouteReconciler) getAllRelevantRoutes(ctx context.Context) []reconcile.Request {
+	items := r.listRoutes(ctx)
+
+	routes := make([]Route, len(items))
+	for i := range items {
+		routes[i] = TCPRouteWrapper{&items[i]}
+	}
+
+	return FilterAcceptedRoutes(ctx, r.Client, r.bindingValidator, r.ControllerName, routes,
+		newListenerViewCache(r.Client, r.ViewStore))
+}
@@ -0,0 +1,192 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/zero_trust"
+	"github.com/cockroachdb/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+// tunnelConnectionCounter reports how many edge connections a tunnel holds.
+// It is an interface so the reporter can be tested without the Cloudflare API.
+type tunnelConnectionCounter interface {
+	CountConnections(ctx context.Context, resolved *config.ResolvedConfig) (int, error)
+}
+
+// cloudflareConnectionCounter counts connections through the Cloudflare API.
+type cloudflareConnectionCounter struct {
+	resolver *config.Resolver
+	metrics  cfmetrics.Collector
+}
+
+// CountConnections lists the tunnel's connected clients and counts their
+// active edge connections.
+func (c *cloudflareConnectionCounter) CountConnections(
+	ctx context.Context,
+	resolved *config.ResolvedConfig,
+) (int, error) {
+	cfClient := c.resolver.CreateCloudflareClient(resolved)
+
+	accountID, err := c.resolver.ResolveAccountID(ctx, cfClient, resolved)
+	if err != nil {
+		return 0, errors.Wrapf(err, "resolving account for tunnel %s", resolved.TunnelID)
+	}
+
+	start := time.Now()
+
+	page, err := cfClient.ZeroTrust.Tunnels.Cloudflared.Connections.Get(
+		ctx,
+		resolved.TunnelID,
+		zero_trust.TunnelCloudflaredConnectionGetParams{
+			AccountID: cloudflare.String(accountID),
+		},
+	)
+	if err != nil {
+		c.metrics.RecordAPICall(ctx, "get", "tunnel_connections", "error", time.Since(start))
+		c.metrics.RecordAPIError(ctx, "get", cfmetrics.ClassifyCloudflareError(err))
+
+		return 0, errors.Wrapf(err, "listing connections of tunnel %s", resolved.TunnelID)
+	}
+
+	c.metrics.RecordAPICall(ctx, "get", "tunnel_connections", "success", time.Since(start))
+
+	return countActiveConnections(page.Result), nil
+}
+
+// countActiveConnections sums the edge connections of every client, leaving
+// out connections that are pending a reconnect.
+func countActiveConnections(clients []zero_trust.Client) int {
+	count := 0
+
+	for i := range clients {
+		for _, conn := range clients[i].Conns {
+			if !conn.IsPendingReconnect {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+// TunnelConnectionReporter periodically polls the connection count of the
+// tunnel behind each managed GatewayClass and exposes it as the
+// cftunnel_tunnel_connections gauge.
+type TunnelConnectionReporter struct {
+	Client         client.Reader
+	ControllerName string
+	ConfigResolver *config.Resolver
+	Metrics        cfmetrics.Collector
+	Interval       time.Duration
+	Logger         *slog.Logger
+
+	// counter is set by NewTunnelConnectionReporter; tests inject a fake.
+	counter tunnelConnectionCounter
+	// reported holds the classes that currently have a gauge series, so a
+	// deleted class has its series removed instead of reporting a stale count.
+	reported map[string]struct{}
+}
+
+// NewTunnelConnectionReporter creates a reporter that counts connections
+// through the Cloudflare API.
+func NewTunnelConnectionReporter(
+	c client.Reader,
+	controllerName string,
+	resolver *config.Resolver,
+	metrics cfmetrics.Collector,
+	interval time.Duration,
+	logger *slog.Logger,
+) *TunnelConnectionReporter {
+	return &TunnelConnectionReporter{
+		Client:         c,
+		ControllerName: controllerName,
+		ConfigResolver: resolver,
+		Metrics:        metrics,
+		Interval:       interval,
+		Logger:         logger.With("component", "tunnel-connections"),
+		counter:        &cloudflareConnectionCounter{resolver: resolver, metrics: metrics},
+	}
+}
+
+// NeedLeaderElection keeps the Cloudflare API polling to the leader.
+func (r *TunnelConnectionReporter) NeedLeaderElection() bool {
+	return true
+}
+
+// Start polls once immediately and then every Interval until ctx is done.
+func (r *TunnelConnectionReporter) Start(ctx context.Context) error {
+	ticker := time.NewTicker(r.Interval)
+	defer ticker.Stop()
+
+	for {
+		r.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll records the connection count of every managed GatewayClass. A class
+// whose count cannot be read keeps its previous value; the error is logged
+// and the next poll retries.
+func (r *TunnelConnectionReporter) poll(ctx context.Context) {
+	var classList gatewayv1.GatewayClassList
+
+	if err := r.Client.List(ctx, &classList); err != nil {
+		r.Logger.Error("failed to list GatewayClasses", "error", err)
+
+		return
+	}
+
+	if r.reported == nil {
+		r.reported = make(map[string]struct{})
+	}
+
+	seen := make(map[string]struct{}, len(classList.Items))
+
+	for i := range classList.Items {
+		class := &classList.Items[i]
+		if string(class.Spec.ControllerName) != r.ControllerName {
+			continue
+		}
+
+		seen[class.Name] = struct{}{}
+
+		resolved, err := r.ConfigResolver.ResolveFromGatewayClass(ctx, class)
+		if err != nil {
+			r.Logger.Warn("failed to resolve config for tunnel connection count",
+				"gatewayClass", class.Name, "error", err)
+
+			continue
+		}
+
+		count, err := r.counter.CountConnections(ctx, resolved)
+		if err != nil {
+			r.Logger.Warn("failed to count tunnel connections",
+				"gatewayClass", class.Name, "tunnel", resolved.TunnelID, "error", err)
+
+			continue
+		}
+
+		r.Metrics.RecordTunnelConnections(ctx, class.Name, count)
+		r.reported[class.Name] = struct{}{}
+	}
+
+	for name := range r.reported {
+		if _, ok := seen[name]; !ok {
+			r.Metrics.ForgetTunnelConnections(ctx, name)
+			delete(r.reported, name)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v7/zero_trust"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/cfmetrics"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/config"
+)
+
+// fakeConnectionCounter returns a fixed count per tunnel ID.
+type fakeConnectionCounter struct {
+	counts map[string]int
+	err    error
+}
+
+func (f *fakeConnectionCounter) CountConnections(_ context.Context, resolved *config.ResolvedConfig) (int, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+
+	return f.counts[resolved.TunnelID], nil
+}
+
+// connectionsRecorder captures the tunnel connection gauge per class.
+type connectionsRecorder struct {
+	*cfmetrics.NoopCollector
+
+	counts map[string]int
+}
+
+func (r *connectionsRecorder) RecordTunnelConnections(_ context.Context, gatewayClass string, count int) {
+	r.counts[gatewayClass] = count
+}
+
+func (r *connectionsRecorder) ForgetTunnelConnections(_ context.Context, gatewayClass string) {
+	delete(r.counts, gatewayClass)
+}
+
+func newTunnelConnectionsTestClient(t *testing.T) client.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cf-creds", Namespace: "default"},
+		Data:       map[string][]byte{"api-token": []byte("token")},
+	}
+
+	gatewayClassConfig := &v1alpha1.GatewayClassConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-config"},
+		Spec: v1alpha1.GatewayClassConfigSpec{
+			CloudflareCredentialsSecretRef: v1alpha1.SecretReference{Name: "cf-creds", Namespace: "default"},
+			TunnelID:                       "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+			AccountID:                      "test-account-id",
+		},
+	}
+
+	ours := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "cloudflare-tunnel"},
+		Spec: gatewayv1.GatewayClassSpec{
+			ControllerName: "cloudflare-tunnel",
+			ParametersRef: &gatewayv1.ParametersReference{
+				Group: config.ParametersRefGroup,
+				Kind:  config.ParametersRefKind,
+				Name:  "test-config",
+			},
+		},
+	}
+
+	foreign := &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "other"},
+		Spec:       gatewayv1.GatewayClassSpec{ControllerName: "example.com/other"},
+	}
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(secret, gatewayClassConfig, ours, foreign).
+		Build()
+}
+
+func newTestTunnelConnectionReporter(
+	fakeClient client.Client,
+	counter tunnelConnectionCounter,
+	recorder *connectionsRecorder,
+) *TunnelConnectionReporter {
+	return &TunnelConnectionReporter{
+		Client:         fakeClient,
+		ControllerName: "cloudflare-tunnel",
+		ConfigResolver: config.NewResolver(fakeClient, "default", cfmetrics.NewNoopCollector()),
+		Metrics:        recorder,
+		Logger:         slog.Default(),
+		counter:        counter,
+	}
+}
+
+func TestTunnelConnectionReporter_Poll(t *testing.T) {
+	t.Parallel()
+
+	fakeClient := newTunnelConnectionsTestClient(t)
+	recorder := &connectionsRecorder{counts: map[string]int{}}
+	reporter := newTestTunnelConnectionReporter(fakeClient, &fakeConnectionCounter{
+		counts: map[string]int{"aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee": 4},
+	}, recorder)
+
+	reporter.poll(context.Background())
+
+	assert.Equal(t, map[string]int{"cloudflare-tunnel": 4}, recorder.counts,
+		"only classes of this controller are reported")
+}
+
+func TestTunnelConnectionReporter_PollForgetsDeletedClass(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	fakeClient := newTunnelConnectionsTestClient(t)
+	recorder := &connectionsRecorder{counts: map[string]int{}}
+	reporter := newTestTunnelConnectionReporter(fakeClient, &fakeConnectionCounter{
+		counts: map[string]int{"aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee": 2},
+	}, recorder)
+
+	reporter.poll(ctx)
+	require.Contains(t, recorder.counts, "cloudflare-tunnel")
+
+	require.NoError(t, fakeClient.Delete(ctx, &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "cloudflare-tunnel"},
+	}))
+
+	reporter.poll(ctx)
+	assert.Empty(t, recorder.counts, "a deleted class must not keep reporting a stale count")
+}
+
+func TestTunnelConnectionReporter_PollKeepsValueOnError(t *testing.T) {
+	t.Parallel()
+
+	fakeClient := newTunnelConnectionsTestClient(t)
+	recorder := &connectionsRecorder{counts: map[string]int{"cloudflare-tunnel": 3}}
+	reporter := newTestTunnelConnectionReporter(fakeClient, &fakeConnectionCounter{
+		err: errors.New("api unavailable"),
+	}, recorder)
+
+	reporter.poll(context.Background())
+
+	assert.Equal(t, 3, recorder.counts["cloudflare-tunnel"])
+}
+
+func TestCountActiveConnections(t *testing.T) {
+	t.Parallel()
+
+	clients := []zero_trust.Client{
+		{Conns: []zero_trust.ClientConn{{}, {}, {IsPendingReconnect: true}}},
+		{Conns: []zero_trust.ClientConn{{}, {}}},
+		{},
+	}
+
+	assert.Equal(t, 4, countActiveConnections(clients))
+	assert.Zero(t, countActiveConnections(nil))
+}
@@ -16,7 +16,7 @@ import (
 
 const (
 	// CatchAllService is the Cloudflare Tunnel service that returns HTTP 404.
-	// It is always added as the last rule in the ingress configuration.
+	// It closes the ingress configuration unless a CatchAllTarget overrides it.
 	CatchAllService = "http_status:404"
 
 	// DefaultHTTPPort is the default port for HTTP backend services.
@@ -38,6 +38,15 @@ const (
 	clustersetDomain = "clusterset.local"
 	schemeHTTP       = "http"
 	schemeHTTPS      = "https"
+	// Service port appProtocol values that pick the backend URL scheme.
+	appProtocolHTTP       = "http"
+	appProtocolHTTPUpper  = "HTTP"
+	appProtocolHTTPS      = "https"
+	appProtocolHTTPSUpper = "HTTPS"
+	appProtocolH2C        = "h2c"
+	appProtocolK8sH2C     = "kubernetes.io/h2c"
+	appProtocolWS         = "kubernetes.io/ws"
+	appProtocolWSS        = "kubernetes.io/wss"
 )
 
 // Builder converts Gateway API HTTPRoute resources to Cloudflare Tunnel
@@ -73,26 +82,36 @@ type routeEntry struct {
 	path     string
 	service  string
 	priority int
+	// regex marks a path taken from a RegularExpression match; any other path
+	// is a literal and is quoted before it reaches the document.
+	regex  bool
+	origin originSettings
+	// redirect marks an entry generated from a RequestRedirect rule. It sorts
+	// ahead of a backend entry for the same hostname and path.
+	redirect bool
+	// routeNamespace, routeName and ruleIndex identify the route rule the
+	// entry came from. They break ties between entries that are otherwise
+	// equal (the same prefix on the same hostname from two routes), so the
+	// order never depends on the order routes were listed in.
+	routeNamespace string
+	routeName      string
+	ruleIndex      int
 }
 
 // sortRouteEntries sorts entries for Cloudflare Tunnel ingress configuration.
-// Wildcard hostname "*" must always come last (Cloudflare requirement).
-// Specific hostnames are sorted alphabetically, then by priority (exact > prefix),
-// then by path length (longer paths first for specificity), then alphabetically
-// by path for deterministic ordering.
+// Hostnames are ordered by CompareHostnames: exact hostnames before the "*."
+// wildcards covering them, and "*" always last (Cloudflare requirement).
+// Entries of one hostname are sorted by priority (exact > prefix),
+// then by path length (longer paths first for specificity), then
+// alphabetically by path for deterministic ordering. Among entries for the same hostname and
+// path, a redirect entry comes first; remaining ties are broken by route
+// namespace, route name and rule index. The sort is stable, so entries of one
+// rule that tie on all of these (duplicate matches) keep their spec order.
 func sortRouteEntries(entries []routeEntry) {
-	sort.Slice(entries, func(idx, jdx int) bool {
-		// Wildcard hostname "*" must always come last
-		if entries[idx].hostname == "*" && entries[jdx].hostname != "*" {
-			return false
-		}
-
-		if entries[idx].hostname != "*" && entries[jdx].hostname == "*" {
-			return true
-		}
-
-		if entries[idx].hostname != entries[jdx].hostname {
-			return entries[idx].hostname < entries[jdx].hostname
+	sort.SliceStable(entries, func(idx, jdx int) bool {
+		// Exact hostnames before the wildcards covering them, "*" last.
+		if c := CompareHostnames(entries[idx].hostname, entries[jdx].hostname); c != 0 {
+			return c < 0
 		}
 
 		if entries[idx].priority != entries[jdx].priority {
@@ -103,8 +122,24 @@ func sortRouteEntries(entries []routeEntry) {
 			return len(entries[idx].path) > len(entries[jdx].path)
 		}
 
-		// Alphabetical path order for deterministic sorting
-		return entries[idx].path < entries[jdx].path
+		if entries[idx].path != entries[jdx].path {
+			// Alphabetical path order for deterministic sorting
+			return entries[idx].path < entries[jdx].path
+		}
+
+		if entries[idx].redirect != entries[jdx].redirect {
+			return entries[idx].redirect
+		}
+
+		if entries[idx].routeNamespace != entries[jdx].routeNamespace {
+			return entries[idx].routeNamespace < entries[jdx].routeNamespace
+		}
+
+		if entries[idx].routeName != entries[jdx].routeName {
+			return entries[idx].routeName < entries[jdx].routeName
+		}
+
+		return entries[idx].ruleIndex < entries[jdx].ruleIndex
 	})
 }
 
@@ -128,16 +163,38 @@ type BackendRefError struct {
 type BuildResult struct {
 	Rules      []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress
 	FailedRefs []BackendRefError
+	// CollapsedSplits lists the rules whose weighted backends the document
+	// reduced to the highest-weight one, for status reporting.
+	CollapsedSplits []CollapsedSplit
+	// InvalidRules lists the route rules left out of the document because
+	// Cloudflare would reject them, for status reporting.
+	InvalidRules []InvalidRule
+}
+
+// WithCatchAll returns a copy of the builder whose closing catch-all rule
+// routes to target instead of returning HTTP 404.
+func (b *Builder) WithCatchAll(target CatchAllTarget) *Builder {
+	return &Builder{generic: b.generic.WithCatchAll(target)}
+}
+
+// WithServiceLookupRetry returns a copy of the builder that retries
+// transient backend Service lookup errors as bounded by retry.
+func (b *Builder) WithServiceLookupRetry(retry ServiceLookupRetry) *Builder {
+	return &Builder{generic: b.generic.WithServiceLookupRetry(retry)}
 }
 
 // Build converts a list of HTTPRoute resources to Cloudflare Tunnel ingress rules.
 //
 // Rules are sorted by:
-//  1. Hostname (specific hostnames before wildcard "*")
+//  1. Hostname (exact hostnames before the "*." wildcards covering them,
+//     wildcard "*" last; see CompareHostnames)
 //  2. Priority (exact matches before prefix matches)
 //  3. Path length (longer paths first for specificity)
+//  4. Route namespace, route name and rule index, so identical input always
+//     yields an identical rule slice
 //
-// A catch-all rule returning HTTP 404 is always appended as the last rule.
+// A catch-all rule is always appended as the last rule: HTTP 404 unless
+// WithCatchAll configured another target.
 //
 // Returns BuildResult containing the generated rules and any backend references
 // that failed validation (e.g., due to missing ReferenceGrant).
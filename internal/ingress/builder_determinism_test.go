@@ -0,0 +1,93 @@
+package ingress_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/ingress"
+)
+
+// prefixRoute builds an HTTPRoute on hostname with one PathPrefix rule per
+// path, each routing to service.
+func prefixRoute(namespace, name, hostname, service string, paths ...string) gatewayv1.HTTPRoute {
+	prefixType := gatewayv1.PathMatchPathPrefix
+
+	rules := make([]gatewayv1.HTTPRouteRule, 0, len(paths))
+	for _, path := range paths {
+		rules = append(rules, gatewayv1.HTTPRouteRule{
+			Matches: []gatewayv1.HTTPRouteMatch{{
+				Path: &gatewayv1.HTTPPathMatch{Type: &prefixType, Value: new(path)},
+			}},
+			BackendRefs: []gatewayv1.HTTPBackendRef{newHTTPBackendRef(service, nil, int32Ptr(8080))},
+		})
+	}
+
+	return gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Hostnames: []gatewayv1.Hostname{gatewayv1.Hostname(hostname)},
+			Rules:     rules,
+		},
+	}
+}
+
+// TestBuild_RepeatedBuildsAreIdentical pins that Build is a pure function of
+// its input: building the same multi-route input twice yields equal rule
+// slices, so an unchanged cluster never produces a Cloudflare write.
+func TestBuild_RepeatedBuildsAreIdentical(t *testing.T) {
+	t.Parallel()
+
+	builder := ingress.NewBuilder("cluster.local", nil, nil, nil, nil)
+	routes := []gatewayv1.HTTPRoute{
+		prefixRoute("team-b", "api", "app.example.com", "api-b", "/api", "/v1"),
+		prefixRoute("team-a", "api", "app.example.com", "api-a", "/api", "/v2"),
+		prefixRoute("team-a", "web", "app.example.com", "web", "/", "/api"),
+		prefixRoute("team-a", "other", "other.example.com", "other", "/"),
+	}
+
+	first := builder.Build(context.Background(), routes)
+	second := builder.Build(context.Background(), routes)
+
+	assert.Equal(t, first.Rules, second.Rules)
+}
+
+// TestBuild_CollidingPrefixesAcrossRoutes pins the tie-breaker for the same
+// prefix on the same hostname from two routes: the entries sort by route
+// namespace, then name, whatever order the routes were listed in.
+func TestBuild_CollidingPrefixesAcrossRoutes(t *testing.T) {
+	t.Parallel()
+
+	builder := ingress.NewBuilder("cluster.local", nil, nil, nil, nil)
+	routes := []gatewayv1.HTTPRoute{
+		prefixRoute("team-b", "api", "app.example.com", "api-b", "/api"),
+		prefixRoute("team-a", "zeta", "app.example.com", "api-zeta", "/api"),
+		prefixRoute("team-a", "alpha", "app.example.com", "api-alpha", "/api"),
+	}
+
+	reversed := slices.Clone(routes)
+	slices.Reverse(reversed)
+
+	forward := builder.Build(context.Background(), routes)
+	backward := builder.Build(context.Background(), reversed)
+
+	require.Len(t, forward.Rules, 4)
+	assert.Equal(t, forward.Rules, backward.Rules)
+
+	services := make([]string, 0, 3)
+	for _, rule := range forward.Rules[:3] {
+		assert.Equal(t, "/api*", rule.Path.Value)
+		services = append(services, rule.Service.Value)
+	}
+
+	assert.Equal(t, []string{
+		"http://api-alpha.team-a.svc.cluster.local:8080",
+		"http://api-zeta.team-a.svc.cluster.local:8080",
+		"http://api-b.team-b.svc.cluster.local:8080",
+	}, services)
+}
@@ -0,0 +1,354 @@
+package ingress_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/ingress"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/logging"
+)
+
+func headerModifierRoute(name, hostname string, filter *gatewayv1.HTTPHeaderFilter) gatewayv1.HTTPRoute {
+	return gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Hostnames: []gatewayv1.Hostname{gatewayv1.Hostname(hostname)},
+			Rules: []gatewayv1.HTTPRouteRule{{
+				Filters: []gatewayv1.HTTPRouteFilter{{
+					Type:                  gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+					RequestHeaderModifier: filter,
+				}},
+				BackendRefs: []gatewayv1.HTTPBackendRef{newHTTPBackendRef("svc", nil, int32Ptr(8080))},
+			}},
+		},
+	}
+}
+
+// TestBuild_RequestHeaderModifier pins the translation of rule-level
+// RequestHeaderModifier filters: a Host set becomes the rule's
+// originRequest.httpHostHeader, and every other operation is left to the
+// in-process proxy with a "document reduced" diagnostic.
+func TestBuild_RequestHeaderModifier(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		filter         gatewayv1.HTTPHeaderFilter
+		wantHostHeader string
+		wantProxyOps   int
+	}{
+		{
+			name:           "set Host only",
+			filter:         gatewayv1.HTTPHeaderFilter{Set: []gatewayv1.HTTPHeader{{Name: "Host", Value: "internal.example"}}},
+			wantHostHeader: "internal.example",
+		},
+		{
+			name:           "set Host is case-insensitive",
+			filter:         gatewayv1.HTTPHeaderFilter{Set: []gatewayv1.HTTPHeader{{Name: "host", Value: "internal.example"}}},
+			wantHostHeader: "internal.example",
+		},
+		{
+			name:         "set other header",
+			filter:       gatewayv1.HTTPHeaderFilter{Set: []gatewayv1.HTTPHeader{{Name: "X-Env", Value: "prod"}}},
+			wantProxyOps: 1,
+		},
+		{
+			name:         "add only",
+			filter:       gatewayv1.HTTPHeaderFilter{Add: []gatewayv1.HTTPHeader{{Name: "X-Trace", Value: "1"}}},
+			wantProxyOps: 1,
+		},
+		{
+			name:         "remove only",
+			filter:       gatewayv1.HTTPHeaderFilter{Remove: []string{"X-Debug", "Cookie"}},
+			wantProxyOps: 2,
+		},
+		{
+			name: "set Host combined with add and remove",
+			filter: gatewayv1.HTTPHeaderFilter{
+				Set:    []gatewayv1.HTTPHeader{{Name: "Host", Value: "internal.example"}, {Name: "X-Env", Value: "prod"}},
+				Add:    []gatewayv1.HTTPHeader{{Name: "X-Trace", Value: "1"}},
+				Remove: []string{"X-Debug"},
+			},
+			wantHostHeader: "internal.example",
+			wantProxyOps:   3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger, buf := logging.TestLogger(t)
+			builder := ingress.NewBuilder("cluster.local", nil, nil, nil, logger)
+
+			result := builder.Build(context.Background(),
+				[]gatewayv1.HTTPRoute{headerModifierRoute("app", "app.example.com", &tt.filter)})
+
+			require.Len(t, result.Rules, 2, "route rule + catch-all")
+			assert.Empty(t, result.FailedRefs)
+			assert.Equal(t, tt.wantHostHeader, result.Rules[0].OriginRequest.Value.HTTPHostHeader.Value)
+			assert.Equal(t, tt.wantHostHeader != "", result.Rules[0].OriginRequest.Present,
+				"originRequest is written only when there is a Host to set")
+
+			logs := buf.String()
+			if tt.wantProxyOps > 0 {
+				assert.Contains(t, logs, "only a Host set is expressible in tunnel ingress rules")
+				assert.Contains(t, logs, `"header_operations":`+strconv.Itoa(tt.wantProxyOps))
+			} else {
+				assert.NotContains(t, logs, "only a Host set is expressible")
+			}
+		})
+	}
+}
+
+// TestBuild_RequestHeaderModifierSurvivesSort pins that the Host header stays
+// attached to its own rule after the hostname/priority sort reorders rules.
+func TestBuild_RequestHeaderModifierSurvivesSort(t *testing.T) {
+	t.Parallel()
+
+	logger, _ := logging.TestLogger(t)
+	builder := ingress.NewBuilder("cluster.local", nil, nil, nil, logger)
+
+	result := builder.Build(context.Background(), []gatewayv1.HTTPRoute{
+		headerModifierRoute("zeta", "zeta.example.com",
+			&gatewayv1.HTTPHeaderFilter{Set: []gatewayv1.HTTPHeader{{Name: "Host", Value: "zeta.internal"}}}),
+		headerModifierRoute("alpha", "alpha.example.com",
+			&gatewayv1.HTTPHeaderFilter{Remove: []string{"X-Debug"}}),
+	})
+
+	require.Len(t, result.Rules, 3)
+	assert.Equal(t, "alpha.example.com", result.Rules[0].Hostname.Value)
+	assert.False(t, result.Rules[0].OriginRequest.Present)
+	assert.Equal(t, "zeta.example.com", result.Rules[1].Hostname.Value)
+	assert.Equal(t, "zeta.internal", result.Rules[1].OriginRequest.Value.HTTPHostHeader.Value)
+}
+
+// TestBuild_BackendRefHeaderModifierNotApplied pins that a backendRef-level
+// Host set is not hoisted onto the rule: it applies to one backend only.
+func TestBuild_BackendRefHeaderModifierNotApplied(t *testing.T) {
+	t.Parallel()
+
+	logger, _ := logging.TestLogger(t)
+	builder := ingress.NewBuilder("cluster.local", nil, nil, nil, logger)
+
+	ref := newHTTPBackendRef("svc", nil, int32Ptr(8080))
+	ref.Filters = []gatewayv1.HTTPRouteFilter{{
+		Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+		RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+			Set: []gatewayv1.HTTPHeader{{Name: "Host", Value: "backend.internal"}},
+		},
+	}}
+
+	route := headerModifierRoute("app", "app.example.com", nil)
+	route.Spec.Rules[0].Filters = nil
+	route.Spec.Rules[0].BackendRefs = []gatewayv1.HTTPBackendRef{ref}
+
+	result := builder.Build(context.Background(), []gatewayv1.HTTPRoute{route})
+
+	require.Len(t, result.Rules, 2)
+	assert.False(t, result.Rules[0].OriginRequest.Present)
+}
+
+func urlRewriteRoute(path string, filter *gatewayv1.HTTPURLRewriteFilter) gatewayv1.HTTPRoute {
+	prefix := gatewayv1.PathMatchPathPrefix
+
+	route := headerModifierRoute("rewrite", "app.example.com", nil)
+	route.Spec.Rules[0].Matches = []gatewayv1.HTTPRouteMatch{{
+		Path: &gatewayv1.HTTPPathMatch{Type: &prefix, Value: &path},
+	}}
+	route.Spec.Rules[0].Filters = []gatewayv1.HTTPRouteFilter{{
+		Type:       gatewayv1.HTTPRouteFilterURLRewrite,
+		URLRewrite: filter,
+	}}
+
+	return route
+}
+
+// TestBuild_URLRewrite pins the translation of rule-level URLRewrite filters:
+// a hostname rewrite becomes originRequest.httpHostHeader, while a path
+// rewrite leaves the public match path (and its prefix glob) and the service
+// URL untouched, logging that the proxy performs the rewrite.
+func TestBuild_URLRewrite(t *testing.T) {
+	t.Parallel()
+
+	hostname := gatewayv1.PreciseHostname("backend.internal")
+
+	tests := []struct {
+		name           string
+		matchPath      string
+		filter         gatewayv1.HTTPURLRewriteFilter
+		wantPath       string
+		wantHostHeader string
+		wantPathLog    bool
+	}{
+		{
+			name:      "prefix /old rewritten to /new",
+			matchPath: "/old",
+			filter: gatewayv1.HTTPURLRewriteFilter{Path: &gatewayv1.HTTPPathModifier{
+				Type:               gatewayv1.PrefixMatchHTTPPathModifier,
+				ReplacePrefixMatch: new("/new"),
+			}},
+			wantPath:    "/old*",
+			wantPathLog: true,
+		},
+		{
+			name:      "prefix rewritten to root",
+			matchPath: "/api",
+			filter: gatewayv1.HTTPURLRewriteFilter{Path: &gatewayv1.HTTPPathModifier{
+				Type:               gatewayv1.PrefixMatchHTTPPathModifier,
+				ReplacePrefixMatch: new("/"),
+			}},
+			wantPath:    "/api*",
+			wantPathLog: true,
+		},
+		{
+			name:           "hostname rewrite",
+			matchPath:      "/api",
+			filter:         gatewayv1.HTTPURLRewriteFilter{Hostname: &hostname},
+			wantPath:       "/api*",
+			wantHostHeader: "backend.internal",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger, buf := logging.TestLogger(t)
+			builder := ingress.NewBuilder("cluster.local", nil, nil, nil, logger)
+
+			result := builder.Build(context.Background(),
+				[]gatewayv1.HTTPRoute{urlRewriteRoute(tt.matchPath, &tt.filter)})
+
+			require.Len(t, result.Rules, 2, "route rule + catch-all")
+			assert.Equal(t, "http://svc.default.svc.cluster.local:8080", result.Rules[0].Service.Value,
+				"the service URL never carries the rewritten path")
+			assert.Equal(t, tt.wantPath, result.Rules[0].Path.Value)
+			assert.Equal(t, tt.wantHostHeader, result.Rules[0].OriginRequest.Value.HTTPHostHeader.Value)
+
+			if tt.wantPathLog {
+				assert.Contains(t, buf.String(), "URLRewrite path modifiers are not expressible")
+			} else {
+				assert.NotContains(t, buf.String(), "URLRewrite path modifiers")
+			}
+		})
+	}
+}
+
+func redirectRoute(name, path string, filter *gatewayv1.HTTPRequestRedirectFilter) gatewayv1.HTTPRoute {
+	route := urlRewriteRoute(path, nil)
+	route.Name = name
+	route.Spec.Rules[0].BackendRefs = nil
+	route.Spec.Rules[0].Filters = []gatewayv1.HTTPRouteFilter{{
+		Type:            gatewayv1.HTTPRouteFilterRequestRedirect,
+		RequestRedirect: filter,
+	}}
+
+	return route
+}
+
+// TestBuild_RequestRedirect pins that a RequestRedirect rule is written as a
+// dedicated http_status entry carrying the redirect code (302 by default),
+// even though it has no backendRefs, and that an unsupported code is logged
+// and written as 302.
+func TestBuild_RequestRedirect(t *testing.T) {
+	t.Parallel()
+
+	hostname := gatewayv1.PreciseHostname("www.example.com")
+	fullPath := "/landing"
+
+	tests := []struct {
+		name        string
+		filter      gatewayv1.HTTPRequestRedirectFilter
+		wantService string
+		wantWarning bool
+	}{
+		{
+			name:        "301 permanent",
+			filter:      gatewayv1.HTTPRequestRedirectFilter{StatusCode: new(301)},
+			wantService: "http_status:301",
+		},
+		{
+			name:        "302 found",
+			filter:      gatewayv1.HTTPRequestRedirectFilter{StatusCode: new(302)},
+			wantService: "http_status:302",
+		},
+		{
+			name:        "scheme-only redirect defaults to 302",
+			filter:      gatewayv1.HTTPRequestRedirectFilter{Scheme: new("https")},
+			wantService: "http_status:302",
+		},
+		{
+			name: "full host and path redirect",
+			filter: gatewayv1.HTTPRequestRedirectFilter{
+				Scheme:     new("https"),
+				Hostname:   &hostname,
+				Port:       new(gatewayv1.PortNumber(8443)),
+				Path:       &gatewayv1.HTTPPathModifier{Type: gatewayv1.FullPathHTTPPathModifier, ReplaceFullPath: &fullPath},
+				StatusCode: new(308),
+			},
+			wantService: "http_status:308",
+		},
+		{
+			name:        "unsupported status code",
+			filter:      gatewayv1.HTTPRequestRedirectFilter{StatusCode: new(418)},
+			wantService: "http_status:302",
+			wantWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger, buf := logging.TestLogger(t)
+			builder := ingress.NewBuilder("cluster.local", nil, nil, nil, logger)
+
+			result := builder.Build(context.Background(),
+				[]gatewayv1.HTTPRoute{redirectRoute("redirect", "/old", &tt.filter)})
+
+			require.Len(t, result.Rules, 2, "redirect rule + catch-all")
+			assert.Equal(t, "app.example.com", result.Rules[0].Hostname.Value)
+			assert.Equal(t, "/old*", result.Rules[0].Path.Value)
+			assert.Equal(t, tt.wantService, result.Rules[0].Service.Value)
+			assert.False(t, result.Rules[0].OriginRequest.Present, "a redirect never reaches an origin")
+
+			if tt.wantWarning {
+				assert.Contains(t, buf.String(), "unsupported redirect status code")
+				assert.Contains(t, buf.String(), `"status_code":418`)
+			} else {
+				assert.NotContains(t, buf.String(), "unsupported redirect status code")
+			}
+		})
+	}
+}
+
+// TestBuild_RequestRedirectAheadOfBackend pins that a redirect entry sorts
+// ahead of a backend entry claiming the same hostname and path.
+func TestBuild_RequestRedirectAheadOfBackend(t *testing.T) {
+	t.Parallel()
+
+	logger, _ := logging.TestLogger(t)
+	builder := ingress.NewBuilder("cluster.local", nil, nil, nil, logger)
+
+	backend := urlRewriteRoute("/old", nil)
+	backend.Name = "backend"
+	backend.Spec.Rules[0].Filters = nil
+
+	for _, routes := range [][]gatewayv1.HTTPRoute{
+		{backend, redirectRoute("redirect", "/old", &gatewayv1.HTTPRequestRedirectFilter{StatusCode: new(301)})},
+		{redirectRoute("redirect", "/old", &gatewayv1.HTTPRequestRedirectFilter{StatusCode: new(301)}), backend},
+	} {
+		result := builder.Build(context.Background(), routes)
+
+		require.Len(t, result.Rules, 3)
+		assert.Equal(t, "http_status:301", result.Rules[0].Service.Value)
+		assert.Equal(t, "http://svc.default.svc.cluster.local:8080", result.Rules[1].Service.Value)
+	}
+}
@@ -122,3 +122,50 @@ func TestBuild_MultiBackend_GRPC_InvalidLowWeightReported(t *testing.T) {
 	assert.Equal(t, "missing-grpc", result.FailedRefs[0].BackendName)
 	assert.Equal(t, int32(9001), result.FailedRefs[0].Port)
 }
+
+// TestBuild_MultiBackend_CollapsedSplitReported pins the discarded-backend
+// report: a 70/30 split is written as one entry for the 70 backend, and the
+// build result names that backend as selected and the 30 one as discarded.
+// A disabled weight-0 backend and a single-backend rule are not reported.
+func TestBuild_MultiBackend_CollapsedSplitReported(t *testing.T) {
+	t.Parallel()
+
+	route := gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "canary", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Hostnames: []gatewayv1.Hostname{"app.example.com"},
+			Rules: []gatewayv1.HTTPRouteRule{
+				{
+					BackendRefs: []gatewayv1.HTTPBackendRef{
+						weightedHTTPBackendRef("stable", 80, 70),
+						weightedHTTPBackendRef("canary", 8080, 30),
+						weightedHTTPBackendRef("drained", 80, 0),
+					},
+				},
+				{
+					BackendRefs: []gatewayv1.HTTPBackendRef{
+						weightedHTTPBackendRef("single", 80, 1),
+					},
+				},
+			},
+		},
+	}
+
+	builder := ingress.NewBuilder("cluster.local", nil, nil, nil, nil)
+	result := builder.Build(context.Background(), []gatewayv1.HTTPRoute{route})
+
+	require.NotEmpty(t, result.Rules)
+	assert.Equal(t, "http://stable.default.svc.cluster.local:80", result.Rules[0].Service.Value,
+		"the entry must point at the highest-weight backend")
+
+	require.Len(t, result.CollapsedSplits, 1, "only the weighted rule collapses a split")
+
+	split := result.CollapsedSplits[0]
+	assert.Equal(t, "default", split.RouteNamespace)
+	assert.Equal(t, "canary", split.RouteName)
+	assert.Equal(t, 0, split.RuleIndex)
+	assert.Equal(t, ingress.WeightedBackend{Name: "stable", Namespace: "default", Port: 80, Weight: 70}, split.Selected)
+	assert.Equal(t, []ingress.WeightedBackend{
+		{Name: "canary", Namespace: "default", Port: 8080, Weight: 30},
+	}, split.Discarded)
+}
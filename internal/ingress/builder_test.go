@@ -2,16 +2,22 @@ package ingress_test
 
 import (
 	"context"
+	"errors"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
@@ -668,7 +674,9 @@ func TestBuild_RegularExpressionPath(t *testing.T) {
 	buildResult := builder.Build(context.Background(), routes)
 
 	require.Len(t, buildResult.Rules, 2)
-	assert.Equal(t, "/api/[0-9]+*", buildResult.Rules[0].Path.Value)
+	assert.Equal(t, "/api/[0-9]+", buildResult.Rules[0].Path.Value,
+		"a trailing wildcard after a quantifier is not a valid regex, so it is not appended")
+	assert.Empty(t, buildResult.InvalidRules)
 }
 
 func TestBuild_CustomClusterDomain(t *testing.T) {
@@ -1355,6 +1363,365 @@ func TestBuild_ServiceNotFound(t *testing.T) {
 	assert.Equal(t, "nonexistent-service", buildResult.FailedRefs[0].BackendName)
 }
 
+// TestBuild_ServicePortProtocol pins that a backendRef to a UDP Service port
+// fails with UnsupportedProtocol: the tunnel only dials origins over TCP.
+func TestBuild_ServicePortProtocol(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		ports      []corev1.ServicePort
+		wantFailed bool
+	}{
+		{
+			name:  "tcp port",
+			ports: []corev1.ServicePort{{Port: 8080, Protocol: corev1.ProtocolTCP}},
+		},
+		{
+			name:  "protocol defaults to tcp",
+			ports: []corev1.ServicePort{{Port: 8080}},
+		},
+		{
+			name:       "udp port",
+			ports:      []corev1.ServicePort{{Port: 8080, Protocol: corev1.ProtocolUDP}},
+			wantFailed: true,
+		},
+		{
+			name: "same port over tcp and udp",
+			ports: []corev1.ServicePort{
+				{Name: "udp", Port: 8080, Protocol: corev1.ProtocolUDP},
+				{Name: "tcp", Port: 8080, Protocol: corev1.ProtocolTCP},
+			},
+		},
+		{
+			name: "udp on another port",
+			ports: []corev1.ServicePort{
+				{Name: "dns", Port: 53, Protocol: corev1.ProtocolUDP},
+				{Name: "web", Port: 8080},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-service", Namespace: "default"},
+				Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, Ports: tt.ports},
+			}
+
+			scheme := runtime.NewScheme()
+			utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc).Build()
+
+			builder := ingress.NewBuilder("cluster.local", nil, fakeClient, nil, nil)
+			routes := []gatewayv1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-route", Namespace: "default"},
+					Spec: gatewayv1.HTTPRouteSpec{
+						Hostnames: []gatewayv1.Hostname{"app.example.com"},
+						Rules: []gatewayv1.HTTPRouteRule{
+							{
+								BackendRefs: []gatewayv1.HTTPBackendRef{
+									newHTTPBackendRef("my-service", nil, int32Ptr(8080)),
+								},
+							},
+						},
+					},
+				},
+			}
+
+			buildResult := builder.Build(context.Background(), routes)
+
+			if !tt.wantFailed {
+				require.Len(t, buildResult.Rules, 2)
+				assert.Empty(t, buildResult.FailedRefs)
+
+				return
+			}
+
+			require.Len(t, buildResult.Rules, 1, "only the catch-all remains")
+			require.Len(t, buildResult.FailedRefs, 1)
+			assert.Equal(t, string(gatewayv1.RouteReasonUnsupportedProtocol), buildResult.FailedRefs[0].Reason)
+			assert.Contains(t, buildResult.FailedRefs[0].Message, "UDP")
+			assert.Equal(t, int32(8080), buildResult.FailedRefs[0].Port)
+		})
+	}
+}
+
+// TestBuild_ServicePortExists pins that a backendRef port the Service does
+// not declare fails the backend with BackendNotFound, while a headless
+// Service, whose Pods are dialed directly, accepts any port.
+func TestBuild_ServicePortExists(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		clusterIP  string
+		ports      []corev1.ServicePort
+		wantFailed bool
+	}{
+		{
+			name:  "declared port",
+			ports: []corev1.ServicePort{{Name: "metrics", Port: 9090}, {Name: "web", Port: 8080}},
+		},
+		{
+			name:       "undeclared port",
+			ports:      []corev1.ServicePort{{Name: "web", Port: 80, TargetPort: intstr.FromInt32(8080)}},
+			wantFailed: true,
+		},
+		{
+			name:      "headless service",
+			clusterIP: corev1.ClusterIPNone,
+			ports:     []corev1.ServicePort{{Name: "web", Port: 80}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-service", Namespace: "default"},
+				Spec: corev1.ServiceSpec{
+					Type:      corev1.ServiceTypeClusterIP,
+					ClusterIP: tt.clusterIP,
+					Ports:     tt.ports,
+				},
+			}
+
+			scheme := runtime.NewScheme()
+			utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc).Build()
+
+			builder := ingress.NewBuilder("cluster.local", nil, fakeClient, nil, nil)
+			routes := []gatewayv1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-route", Namespace: "default"},
+					Spec: gatewayv1.HTTPRouteSpec{
+						Hostnames: []gatewayv1.Hostname{"app.example.com"},
+						Rules: []gatewayv1.HTTPRouteRule{
+							{
+								BackendRefs: []gatewayv1.HTTPBackendRef{
+									newHTTPBackendRef("my-service", nil, int32Ptr(8080)),
+								},
+							},
+						},
+					},
+				},
+			}
+
+			buildResult := builder.Build(context.Background(), routes)
+
+			if !tt.wantFailed {
+				require.Len(t, buildResult.Rules, 2)
+				assert.Equal(t, "http://my-service.default.svc.cluster.local:8080", buildResult.Rules[0].Service.Value)
+				assert.Empty(t, buildResult.FailedRefs)
+
+				return
+			}
+
+			require.Len(t, buildResult.Rules, 1, "only the catch-all remains")
+			require.Len(t, buildResult.FailedRefs, 1)
+			assert.Equal(t, string(gatewayv1.RouteReasonBackendNotFound), buildResult.FailedRefs[0].Reason)
+			assert.Contains(t, buildResult.FailedRefs[0].Message, "does not expose port 8080")
+			assert.Equal(t, int32(8080), buildResult.FailedRefs[0].Port)
+		})
+	}
+}
+
+// TestBuild_ServicePortAppProtocol pins that the Service port's appProtocol
+// picks the backend URL scheme, and that the port-number guess (443 is
+// https) only applies when the port declares none the builder recognizes.
+func TestBuild_ServicePortAppProtocol(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		port    int32
+		ports   []corev1.ServicePort
+		wantURL string
+	}{
+		{
+			name:    "https on a non-standard port",
+			port:    8443,
+			ports:   []corev1.ServicePort{{Port: 8443, AppProtocol: new("https")}},
+			wantURL: "https://my-service.default.svc.cluster.local:8443",
+		},
+		{
+			name:    "kubernetes.io/wss is TLS",
+			port:    8443,
+			ports:   []corev1.ServicePort{{Port: 8443, AppProtocol: new("kubernetes.io/wss")}},
+			wantURL: "https://my-service.default.svc.cluster.local:8443",
+		},
+		{
+			name:    "http on 443",
+			port:    443,
+			ports:   []corev1.ServicePort{{Port: 443, AppProtocol: new("http")}},
+			wantURL: "http://my-service.default.svc.cluster.local:443",
+		},
+		{
+			name:    "no appProtocol falls back to the port",
+			port:    443,
+			ports:   []corev1.ServicePort{{Port: 443}},
+			wantURL: "https://my-service.default.svc.cluster.local:443",
+		},
+		{
+			name:    "unrecognized appProtocol falls back to the port",
+			port:    8443,
+			ports:   []corev1.ServicePort{{Port: 8443, AppProtocol: new("example.com/custom")}},
+			wantURL: "http://my-service.default.svc.cluster.local:8443",
+		},
+		{
+			name: "appProtocol of another port is ignored",
+			port: 8443,
+			ports: []corev1.ServicePort{
+				{Name: "metrics", Port: 9090, AppProtocol: new("https")},
+				{Name: "web", Port: 8443},
+			},
+			wantURL: "http://my-service.default.svc.cluster.local:8443",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-service", Namespace: "default"},
+				Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP, Ports: tt.ports},
+			}
+
+			scheme := runtime.NewScheme()
+			utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc).Build()
+
+			builder := ingress.NewBuilder("cluster.local", nil, fakeClient, nil, nil)
+			routes := []gatewayv1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-route", Namespace: "default"},
+					Spec: gatewayv1.HTTPRouteSpec{
+						Hostnames: []gatewayv1.Hostname{"app.example.com"},
+						Rules: []gatewayv1.HTTPRouteRule{
+							{
+								BackendRefs: []gatewayv1.HTTPBackendRef{
+									newHTTPBackendRef("my-service", nil, int32Ptr(tt.port)),
+								},
+							},
+						},
+					},
+				},
+			}
+
+			buildResult := builder.Build(context.Background(), routes)
+
+			require.Len(t, buildResult.Rules, 2)
+			assert.Equal(t, tt.wantURL, buildResult.Rules[0].Service.Value)
+		})
+	}
+}
+
+// TestBuild_ServiceLookupRetry pins that a transient Service lookup error is
+// retried within the build, while NotFound fails the backend at once.
+func TestBuild_ServiceLookupRetry(t *testing.T) {
+	t.Parallel()
+
+	externalName := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-service", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: "api.external.com"},
+	}
+
+	tests := []struct {
+		name       string
+		service    *corev1.Service
+		failures   int
+		wantGets   int
+		wantURL    string
+		wantReason string
+	}{
+		{
+			name:     "transient error then success",
+			service:  externalName,
+			failures: 1,
+			wantGets: 2,
+			wantURL:  "http://api.external.com:8080",
+		},
+		{
+			name:       "not found is not retried",
+			wantGets:   1,
+			wantReason: string(gatewayv1.RouteReasonBackendNotFound),
+		},
+		{
+			name:     "persistent error falls back to cluster DNS",
+			service:  externalName,
+			failures: 5,
+			wantGets: 3,
+			wantURL:  "http://my-service.default.svc.cluster.local:8080",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			scheme := runtime.NewScheme()
+			utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+			var gets atomic.Int32
+
+			clientBuilder := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					if int(gets.Add(1)) <= tt.failures {
+						return errors.New("informer cache not synced")
+					}
+
+					return c.Get(ctx, key, obj, opts...)
+				},
+			})
+			if tt.service != nil {
+				clientBuilder = clientBuilder.WithObjects(tt.service)
+			}
+
+			builder := ingress.NewBuilder("cluster.local", nil, clientBuilder.Build(), nil, nil).
+				WithServiceLookupRetry(ingress.ServiceLookupRetry{Attempts: 3, Backoff: time.Millisecond})
+			routes := []gatewayv1.HTTPRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-route", Namespace: "default"},
+					Spec: gatewayv1.HTTPRouteSpec{
+						Hostnames: []gatewayv1.Hostname{"app.example.com"},
+						Rules: []gatewayv1.HTTPRouteRule{
+							{
+								BackendRefs: []gatewayv1.HTTPBackendRef{
+									newHTTPBackendRef("my-service", nil, int32Ptr(8080)),
+								},
+							},
+						},
+					},
+				},
+			}
+
+			buildResult := builder.Build(context.Background(), routes)
+
+			assert.Equal(t, int32(tt.wantGets), gets.Load())
+
+			if tt.wantReason != "" {
+				require.Len(t, buildResult.FailedRefs, 1)
+				assert.Equal(t, tt.wantReason, buildResult.FailedRefs[0].Reason)
+
+				return
+			}
+
+			assert.Empty(t, buildResult.FailedRefs)
+			require.Len(t, buildResult.Rules, 2)
+			assert.Equal(t, tt.wantURL, buildResult.Rules[0].Service.Value)
+		})
+	}
+}
+
 func TestBuild_NilClient_FallbackBehavior(t *testing.T) {
 	t.Parallel()
 
@@ -1570,3 +1937,50 @@ func TestBuild_ExternalNameService_CrossNamespace_Denied(t *testing.T) {
 	assert.Equal(t, "external-api", buildResult.FailedRefs[0].BackendName)
 	assert.Equal(t, "backend", buildResult.FailedRefs[0].BackendNS)
 }
+
+// TestBuild_WildcardHostnamePrecedence pins wildcard-aware hostname ordering:
+// cloudflared uses the first matching rule, so app.example.com must precede
+// *.example.com even though "*" sorts before "a" in ASCII, a deeper wildcard
+// must precede the shallower one covering it, and the catch-all stays last.
+func TestBuild_WildcardHostnamePrecedence(t *testing.T) {
+	t.Parallel()
+
+	route := func(name string, hostnames ...gatewayv1.Hostname) gatewayv1.HTTPRoute {
+		return gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: gatewayv1.HTTPRouteSpec{
+				Hostnames: hostnames,
+				Rules: []gatewayv1.HTTPRouteRule{
+					{
+						BackendRefs: []gatewayv1.HTTPBackendRef{
+							newHTTPBackendRef(name, nil, int32Ptr(8080)),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	builder := ingress.NewBuilder("cluster.local", nil, nil, nil, nil)
+	buildResult := builder.Build(context.Background(), []gatewayv1.HTTPRoute{
+		route("wildcard", "*.example.com"),
+		route("deep-wildcard", "*.api.example.com"),
+		route("exact", "app.example.com", "v1.api.example.com"),
+		route("other", "zz.example.org"),
+	})
+
+	hostnames := make([]string, 0, len(buildResult.Rules))
+	for _, rule := range buildResult.Rules {
+		hostnames = append(hostnames, rule.Hostname.Value)
+	}
+
+	assert.Equal(t, []string{
+		"app.example.com",
+		"v1.api.example.com",
+		"zz.example.org",
+		"*.api.example.com",
+		"*.example.com",
+		"",
+	}, hostnames, "exact hostnames, then deeper wildcards, then the catch-all")
+	assert.Equal(t, ingress.CatchAllService, buildResult.Rules[len(buildResult.Rules)-1].Service.Value)
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
@@ -368,6 +369,43 @@ func TestBuild_WarnFilters(t *testing.T) {
 	assert.Contains(t, logs, `"filters":2`)
 }
 
+// TestBuild_WarnBackendRefFilters pins that backendRef-level filters are
+// reported like rule-level ones: the ingress rule still points at the
+// highest-weight origin, and the filter is left to the proxy.
+func TestBuild_WarnBackendRefFilters(t *testing.T) {
+	t.Parallel()
+
+	logger, buf := logging.TestLogger(t)
+	builder := ingress.NewBuilder("cluster.local", nil, nil, nil, logger)
+
+	backendRef := newHTTPBackendRefWithWeight("service1", nil, int32Ptr(8080))
+	backendRef.Filters = []gatewayv1.HTTPRouteFilter{{
+		Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+		RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+			Set: []gatewayv1.HTTPHeader{{Name: "X-Backend", Value: "v1"}},
+		},
+	}}
+
+	routes := []gatewayv1.HTTPRoute{{
+		ObjectMeta: metav1.ObjectMeta{Name: "backend-filter-route", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Hostnames: []gatewayv1.Hostname{"app.example.com"},
+			Rules:     []gatewayv1.HTTPRouteRule{{BackendRefs: []gatewayv1.HTTPBackendRef{backendRef}}},
+		},
+	}}
+
+	result := builder.Build(context.Background(), routes)
+
+	require.NotEmpty(t, result.Rules)
+	assert.Equal(t, "http://service1.default.svc.cluster.local:8080", result.Rules[0].Service.Value)
+	assert.Empty(t, result.FailedRefs, "a supported backendRef filter is not a failed ref")
+
+	logs := buf.String()
+	assert.Contains(t, logs, `"route":"default/backend-filter-route"`)
+	assert.Contains(t, logs, "filters are not expressible")
+	assert.Contains(t, logs, `"filters":1`)
+}
+
 func TestBuild_MultipleWarnings(t *testing.T) {
 	t.Parallel()
 
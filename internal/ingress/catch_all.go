@@ -0,0 +1,78 @@
+package ingress
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/zero_trust"
+)
+
+// CatchAllTarget overrides the origin of the catch-all rule closing the
+// ingress document. The zero value keeps CatchAllService (HTTP 404).
+type CatchAllTarget struct {
+	// URL is an http(s) origin outside the cluster. The rule sends the URL's
+	// host as the Host header, since the origin will not serve the hostnames
+	// of unmatched requests.
+	URL string
+
+	// ServiceName, ServiceNamespace and ServicePort reference an in-cluster
+	// Service. Ignored when URL is set.
+	ServiceName      string
+	ServiceNamespace string
+	ServicePort      int32
+}
+
+// IsZero reports whether the target keeps the default HTTP 404.
+func (t CatchAllTarget) IsZero() bool {
+	return t == CatchAllTarget{}
+}
+
+// Service returns the origin the catch-all rule routes to, with Service
+// origins built in clusterDomain. Port 443 is dialed over https, matching
+// route backends.
+func (t CatchAllTarget) Service(clusterDomain string) string {
+	switch {
+	case t.URL != "":
+		return strings.TrimSuffix(t.URL, "/")
+	case t.ServiceName != "":
+		scheme := schemeHTTP
+		if t.ServicePort == DefaultHTTPSPort {
+			scheme = schemeHTTPS
+		}
+
+		return fmt.Sprintf("%s://%s.%s.svc.%s:%d",
+			scheme, t.ServiceName, t.ServiceNamespace, clusterDomain, t.ServicePort)
+	default:
+		return CatchAllService
+	}
+}
+
+// HostHeader returns the Host header a URL target must be sent with, or ""
+// when the client's Host is kept (Service targets and the default 404).
+func (t CatchAllTarget) HostHeader() string {
+	if t.URL == "" {
+		return ""
+	}
+
+	parsed, err := url.Parse(t.URL)
+	if err != nil {
+		return ""
+	}
+
+	return parsed.Host
+}
+
+// Rule returns the catch-all ingress rule for the target.
+func (t CatchAllTarget) Rule(clusterDomain string) zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress {
+	rule := zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
+		Service: cloudflare.F(t.Service(clusterDomain)),
+	}
+
+	if host := t.HostHeader(); host != "" {
+		rule.OriginRequest = cloudflare.F(originSettings{hostHeader: host}.ingressParams())
+	}
+
+	return rule
+}
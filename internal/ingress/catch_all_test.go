@@ -0,0 +1,107 @@
+package ingress_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/zero_trust"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/ingress"
+)
+
+// TestBuild_CatchAllTarget pins the closing rule for each catch-all target:
+// the default 404, a URL sent with its own Host, and an in-cluster Service
+// (https on port 443). The catch-all must stay last behind route rules.
+func TestBuild_CatchAllTarget(t *testing.T) {
+	t.Parallel()
+
+	pathPrefix := gatewayv1.PathMatchPathPrefix
+	routes := []gatewayv1.HTTPRoute{{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Hostnames: []gatewayv1.Hostname{"app.example.com"},
+			Rules: []gatewayv1.HTTPRouteRule{{
+				Matches: []gatewayv1.HTTPRouteMatch{{Path: &gatewayv1.HTTPPathMatch{Type: &pathPrefix, Value: new("/")}}},
+				BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{
+					BackendObjectReference: gatewayv1.BackendObjectReference{Name: "app", Port: new(gatewayv1.PortNumber(80))},
+				}}},
+			}},
+		},
+	}}
+
+	tests := []struct {
+		name        string
+		target      ingress.CatchAllTarget
+		wantService string
+		wantHost    string
+	}{
+		{
+			name:        "default returns 404",
+			wantService: ingress.CatchAllService,
+		},
+		{
+			name:        "url keeps its own host",
+			target:      ingress.CatchAllTarget{URL: "https://status.example.com/"},
+			wantService: "https://status.example.com",
+			wantHost:    "status.example.com",
+		},
+		{
+			name:        "service on http port",
+			target:      ingress.CatchAllTarget{ServiceName: "maintenance", ServiceNamespace: "web", ServicePort: 8080},
+			wantService: "http://maintenance.web.svc.cluster.local:8080",
+		},
+		{
+			name:        "service on 443 is dialed over https",
+			target:      ingress.CatchAllTarget{ServiceName: "maintenance", ServiceNamespace: "web", ServicePort: 443},
+			wantService: "https://maintenance.web.svc.cluster.local:443",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := ingress.NewBuilder("cluster.local", nil, nil, nil, nil).WithCatchAll(tt.target)
+			result := builder.Build(context.Background(), routes)
+
+			require.Len(t, result.Rules, 2)
+			assert.Equal(t, "app.example.com", result.Rules[0].Hostname.Value)
+
+			catchAll := result.Rules[1]
+			assert.False(t, catchAll.Hostname.Present, "the catch-all has no hostname")
+			assert.False(t, catchAll.Path.Present, "the catch-all has no path")
+			assert.Equal(t, tt.wantService, catchAll.Service.Value)
+
+			if tt.wantHost == "" {
+				assert.False(t, catchAll.OriginRequest.Present)
+			} else {
+				assert.Equal(t, tt.wantHost, catchAll.OriginRequest.Value.HTTPHostHeader.Value)
+			}
+		})
+	}
+}
+
+// TestEnsureCatchAllRule pins that a configured catch-all closes the document
+// exactly once: the default 404 and an earlier copy are dropped.
+func TestEnsureCatchAllRule(t *testing.T) {
+	t.Parallel()
+
+	catchAll := ingress.CatchAllTarget{URL: "https://status.example.com"}.Rule("cluster.local")
+
+	rules := []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
+		{Service: cloudflare.F(ingress.CatchAllService)},
+		catchAll,
+		{Hostname: cloudflare.F("app.example.com"), Service: cloudflare.F("http://app:80")},
+	}
+
+	result := ingress.EnsureCatchAllRule(rules, catchAll)
+
+	require.Len(t, result, 2)
+	assert.Equal(t, "app.example.com", result[0].Hostname.Value)
+	assert.Equal(t, "https://status.example.com", result[1].Service.Value)
+}
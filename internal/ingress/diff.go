@@ -1,15 +1,18 @@
 package ingress
 
 import (
+	"log/slog"
+
 	"github.com/cloudflare/cloudflare-go/v7"
 	"github.com/cloudflare/cloudflare-go/v7/zero_trust"
 )
 
 // Rule represents a simplified ingress rule for comparison.
 type Rule struct {
-	Hostname string
-	Path     string
-	Service  string
+	Hostname   string
+	Path       string
+	Service    string
+	HostHeader string
 }
 
 // RuleFromUpdate converts an update params ingress rule to a Rule for comparison.
@@ -20,9 +23,10 @@ func RuleFromUpdate(r *zero_trust.TunnelCloudflaredConfigurationUpdateParamsConf
 	}
 
 	return Rule{
-		Hostname: r.Hostname.Value,
-		Path:     r.Path.Value,
-		Service:  r.Service.Value,
+		Hostname:   r.Hostname.Value,
+		Path:       r.Path.Value,
+		Service:    r.Service.Value,
+		HostHeader: r.OriginRequest.Value.HTTPHostHeader.Value,
 	}
 }
 
@@ -34,9 +38,10 @@ func RuleFromGet(r *zero_trust.TunnelCloudflaredConfigurationGetResponseConfigIn
 	}
 
 	return Rule{
-		Hostname: r.Hostname,
-		Path:     r.Path,
-		Service:  r.Service,
+		Hostname:   r.Hostname,
+		Path:       r.Path,
+		Service:    r.Service,
+		HostHeader: r.OriginRequest.HTTPHostHeader,
 	}
 }
 
@@ -44,7 +49,8 @@ func RuleFromGet(r *zero_trust.TunnelCloudflaredConfigurationGetResponseConfigIn
 func RulesEqual(a, b Rule) bool {
 	return a.Hostname == b.Hostname &&
 		a.Path == b.Path &&
-		a.Service == b.Service
+		a.Service == b.Service &&
+		a.HostHeader == b.HostHeader
 }
 
 // IsCatchAll returns true if the rule is a catch-all rule (no hostname and catch-all service).
@@ -164,22 +170,35 @@ func ApplyDiff(
 func EnsureCatchAll(
 	rules []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress,
 ) []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress {
-	// Check if catch-all already exists and filter it out
-	filtered := make([]zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress, 0, len(rules))
+	return EnsureCatchAllRule(rules, CatchAllTarget{}.Rule(""))
+}
+
+// EnsureCatchAllRule is EnsureCatchAll with a configured catch-all (see
+// CatchAllTarget). Both the default 404 and an earlier copy of catchAll are
+// dropped before catchAll is appended, so the document closes with exactly
+// one catch-all.
+func EnsureCatchAllRule(
+	rules []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress,
+	catchAll zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress,
+) []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress {
+	filtered := make([]zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress, 0, len(rules)+1)
 
 	for idx := range rules {
 		rule := RuleFromUpdate(&rules[idx])
-		if !IsCatchAll(rule) {
-			filtered = append(filtered, rules[idx])
+		if IsCatchAll(rule) || isCatchAllCopy(rule, catchAll) {
+			continue
 		}
+
+		filtered = append(filtered, rules[idx])
 	}
 
-	// Add catch-all at the end
-	filtered = append(filtered, zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
-		Service: cloudflare.F(CatchAllService),
-	})
+	return append(filtered, catchAll)
+}
 
-	return filtered
+// isCatchAllCopy reports whether rule is a hostname- and path-less rule
+// routing to catchAll's service.
+func isCatchAllCopy(rule Rule, catchAll zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress) bool {
+	return rule.Hostname == "" && rule.Path == "" && rule.Service == catchAll.Service.Value
 }
 
 // convertGetToUpdate converts a get response ingress rule to update params format.
@@ -198,6 +217,11 @@ func convertGetToUpdate(
 		result.Path = cloudflare.F(r.Path)
 	}
 
+	origin := originSettings{hostHeader: r.OriginRequest.HTTPHostHeader}
+	if !origin.isZero() {
+		result.OriginRequest = cloudflare.F(origin.ingressParams())
+	}
+
 	return result
 }
 
@@ -223,3 +247,128 @@ func RulesUnchanged(
 
 	return true
 }
+
+// RuleChange is a deployed rule whose hostname and path stay but whose
+// origin (service or originRequest settings) changes.
+type RuleChange struct {
+	Before Rule
+	After  Rule
+}
+
+// Changes is the structured difference between the deployed ingress document
+// and the one about to be written, for operators to see what a sync changes.
+// Unlike DiffRules it covers the whole document, catch-all included.
+type Changes struct {
+	// Added are desired rules whose hostname and path are not deployed.
+	Added []Rule
+	// Removed are deployed rules whose hostname and path are not desired.
+	Removed []Rule
+	// Modified pairs deployed and desired rules sharing a hostname and path.
+	Modified []RuleChange
+	// Reordered is set when the documents hold the same rules in a different
+	// order. cloudflared ingress is first-match, so this still needs a write.
+	Reordered bool
+}
+
+// IsEmpty reports whether the documents are identical, i.e. the write can be
+// skipped. Equivalent to RulesUnchanged.
+func (c Changes) IsEmpty() bool {
+	return len(c.Added) == 0 && len(c.Removed) == 0 && len(c.Modified) == 0 && !c.Reordered
+}
+
+// LogValue renders the changes as a log group: counts always, rule lists only
+// when non-empty.
+func (c Changes) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.Int("added", len(c.Added)),
+		slog.Int("removed", len(c.Removed)),
+		slog.Int("modified", len(c.Modified)),
+		slog.Bool("reordered", c.Reordered),
+	}
+
+	if len(c.Added) > 0 {
+		attrs = append(attrs, slog.Any("addedRules", c.Added))
+	}
+
+	if len(c.Removed) > 0 {
+		attrs = append(attrs, slog.Any("removedRules", c.Removed))
+	}
+
+	if len(c.Modified) > 0 {
+		attrs = append(attrs, slog.Any("modifiedRules", c.Modified))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// ComputeChanges compares the deployed document with the desired one. Rules
+// equal on every compared field match first; of the rest, a deployed and a
+// desired rule with the same hostname and path pair up as Modified, and the
+// leftovers are Removed and Added. Rules appear in document order.
+func ComputeChanges(
+	current []zero_trust.TunnelCloudflaredConfigurationGetResponseConfigIngress,
+	desired []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress,
+) Changes {
+	currentRules := make([]Rule, 0, len(current))
+	for idx := range current {
+		currentRules = append(currentRules, RuleFromGet(&current[idx]))
+	}
+
+	desiredRules := make([]Rule, 0, len(desired))
+	for idx := range desired {
+		desiredRules = append(desiredRules, RuleFromUpdate(&desired[idx]))
+	}
+
+	currentMatched := make([]bool, len(currentRules))
+	desiredMatched := make([]bool, len(desiredRules))
+
+	pair := func(same func(a, b Rule) bool, onPair func(currentRule, desiredRule Rule)) {
+		for desiredIdx, desiredRule := range desiredRules {
+			if desiredMatched[desiredIdx] {
+				continue
+			}
+
+			for currentIdx, currentRule := range currentRules {
+				if currentMatched[currentIdx] || !same(currentRule, desiredRule) {
+					continue
+				}
+
+				currentMatched[currentIdx] = true
+				desiredMatched[desiredIdx] = true
+
+				if onPair != nil {
+					onPair(currentRule, desiredRule)
+				}
+
+				break
+			}
+		}
+	}
+
+	var changes Changes
+
+	pair(RulesEqual, nil)
+	pair(func(a, b Rule) bool {
+		return a.Hostname == b.Hostname && a.Path == b.Path
+	}, func(currentRule, desiredRule Rule) {
+		changes.Modified = append(changes.Modified, RuleChange{Before: currentRule, After: desiredRule})
+	})
+
+	for idx, rule := range currentRules {
+		if !currentMatched[idx] {
+			changes.Removed = append(changes.Removed, rule)
+		}
+	}
+
+	for idx, rule := range desiredRules {
+		if !desiredMatched[idx] {
+			changes.Added = append(changes.Added, rule)
+		}
+	}
+
+	if changes.IsEmpty() {
+		changes.Reordered = !RulesUnchanged(current, desired)
+	}
+
+	return changes
+}
@@ -43,6 +43,12 @@ func TestRulesEqual(t *testing.T) {
 			ruleB:    ingress.Rule{Hostname: "app.example.com", Path: "/api", Service: "http://svc2:8080"},
 			expected: false,
 		},
+		{
+			name:     "different host header",
+			ruleA:    ingress.Rule{Hostname: "app.example.com", Service: "http://svc:8080", HostHeader: "internal.example"},
+			ruleB:    ingress.Rule{Hostname: "app.example.com", Service: "http://svc:8080"},
+			expected: false,
+		},
 		{
 			name:     "empty rules",
 			ruleA:    ingress.Rule{},
@@ -355,6 +361,113 @@ func TestRulesUnchanged(t *testing.T) {
 	}
 	assert.False(t, ingress.RulesUnchanged(current, differentService))
 
+	hostHeader := []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
+		{
+			Hostname: cloudflare.F("a.example.com"),
+			Service:  cloudflare.F("http://svc-a.default.svc.cluster.local:80"),
+			OriginRequest: cloudflare.F(zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngressOriginRequest{
+				HTTPHostHeader: cloudflare.F("internal.example"),
+			}),
+		},
+		identical[1], identical[2],
+	}
+	assert.False(t, ingress.RulesUnchanged(current, hostHeader), "an added httpHostHeader must trigger a write")
+
 	shorter := identical[:2]
 	assert.False(t, ingress.RulesUnchanged(current, shorter), "length difference must compare unequal")
 }
+
+// TestComputeChanges pins the structured diff logged before a tunnel write:
+// an empty diff skips the write, and a reorder alone still needs one.
+func TestComputeChanges(t *testing.T) {
+	t.Parallel()
+
+	current := []zero_trust.TunnelCloudflaredConfigurationGetResponseConfigIngress{
+		{Hostname: "a.example.com", Service: "http://svc-a.default.svc.cluster.local:80"},
+		{Hostname: "b.example.com", Path: "/api*", Service: "http://svc-b.default.svc.cluster.local:80"},
+		{Service: ingress.CatchAllService},
+	}
+
+	ruleA := zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
+		Hostname: cloudflare.F("a.example.com"), Service: cloudflare.F("http://svc-a.default.svc.cluster.local:80"),
+	}
+	ruleB := zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
+		Hostname: cloudflare.F("b.example.com"), Path: cloudflare.F("/api*"), Service: cloudflare.F("http://svc-b.default.svc.cluster.local:80"),
+	}
+	ruleC := zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
+		Hostname: cloudflare.F("c.example.com"), Service: cloudflare.F("http://svc-c.default.svc.cluster.local:80"),
+	}
+	catchAll := zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
+		Service: cloudflare.F(ingress.CatchAllService),
+	}
+
+	t.Run("no change", func(t *testing.T) {
+		t.Parallel()
+
+		changes := ingress.ComputeChanges(current, []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
+			ruleA, ruleB, catchAll,
+		})
+
+		assert.True(t, changes.IsEmpty())
+		assert.Equal(t, ingress.Changes{}, changes)
+	})
+
+	t.Run("added rule", func(t *testing.T) {
+		t.Parallel()
+
+		changes := ingress.ComputeChanges(current, []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
+			ruleA, ruleB, ruleC, catchAll,
+		})
+
+		assert.False(t, changes.IsEmpty())
+		assert.Equal(t, []ingress.Rule{ingress.RuleFromUpdate(&ruleC)}, changes.Added)
+		assert.Empty(t, changes.Removed)
+		assert.Empty(t, changes.Modified)
+		assert.False(t, changes.Reordered)
+	})
+
+	t.Run("removed rule", func(t *testing.T) {
+		t.Parallel()
+
+		changes := ingress.ComputeChanges(current, []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
+			ruleA, catchAll,
+		})
+
+		assert.False(t, changes.IsEmpty())
+		assert.Empty(t, changes.Added)
+		assert.Equal(t, []ingress.Rule{ingress.RuleFromGet(&current[1])}, changes.Removed)
+		assert.Empty(t, changes.Modified)
+	})
+
+	t.Run("modified rule", func(t *testing.T) {
+		t.Parallel()
+
+		moved := ruleB
+		moved.Service = cloudflare.F("http://svc-b2.default.svc.cluster.local:80")
+
+		changes := ingress.ComputeChanges(current, []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
+			ruleA, moved, catchAll,
+		})
+
+		assert.Empty(t, changes.Added)
+		assert.Empty(t, changes.Removed)
+		assert.Equal(t, []ingress.RuleChange{{
+			Before: ingress.RuleFromGet(&current[1]),
+			After:  ingress.RuleFromUpdate(&moved),
+		}}, changes.Modified)
+	})
+
+	t.Run("reordered rules", func(t *testing.T) {
+		t.Parallel()
+
+		changes := ingress.ComputeChanges(current, []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
+			ruleB, ruleA, catchAll,
+		})
+
+		assert.Empty(t, changes.Added)
+		assert.Empty(t, changes.Removed)
+		assert.Empty(t, changes.Modified)
+		assert.True(t, changes.Reordered, "order matters: cloudflared ingress is first-match")
+		assert.False(t, changes.IsEmpty())
+	})
+}
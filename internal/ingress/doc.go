@@ -39,7 +39,8 @@
 //
 //	http://<service>.<namespace>.svc.<cluster-domain>:<port>
 //
-// Port 443 automatically uses HTTPS scheme.
+// The scheme follows the appProtocol of the matching Service port (https and
+// kubernetes.io/wss use HTTPS); without one, port 443 uses HTTPS.
 //
 // # Catch-All Rule
 //
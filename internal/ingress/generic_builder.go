@@ -3,6 +3,7 @@ package ingress
 import (
 	"context"
 	"log/slog"
+	"regexp"
 	"time"
 
 	"github.com/cloudflare/cloudflare-go/v7"
@@ -52,6 +53,8 @@ type backendResolver struct {
 	logger        *slog.Logger
 	clusterDomain string
 	metrics       cfmetrics.Collector
+	catchAll      CatchAllTarget
+	lookupRetry   ServiceLookupRetry
 }
 
 // GenericBuilder is a generic builder for converting Gateway API routes to
@@ -63,6 +66,8 @@ type GenericBuilder[R any] struct {
 	metrics       cfmetrics.Collector
 	logger        *slog.Logger
 	adapter       RouteAdapter[R]
+	catchAll      CatchAllTarget
+	lookupRetry   ServiceLookupRetry
 }
 
 // NewGenericBuilder creates a new GenericBuilder with the specified configuration.
@@ -85,15 +90,39 @@ func NewGenericBuilder[R any](
 		metrics:       m,
 		logger:        logger.With("builder", adapter.RouteKind()),
 		adapter:       adapter,
+		lookupRetry:   DefaultServiceLookupRetry,
 	}
 }
 
+// WithCatchAll returns a copy of the builder whose catch-all rule routes to
+// target instead of returning HTTP 404. Only adapters that add a catch-all
+// are affected.
+func (b *GenericBuilder[R]) WithCatchAll(target CatchAllTarget) *GenericBuilder[R] {
+	clone := *b
+	clone.catchAll = target
+
+	return &clone
+}
+
+// WithServiceLookupRetry returns a copy of the builder that retries
+// transient backend Service lookup errors as bounded by retry instead of
+// DefaultServiceLookupRetry.
+func (b *GenericBuilder[R]) WithServiceLookupRetry(retry ServiceLookupRetry) *GenericBuilder[R] {
+	clone := *b
+	clone.lookupRetry = retry
+
+	return &clone
+}
+
 // Build converts a list of routes to Cloudflare Tunnel ingress rules.
 //
 // Rules are sorted by:
-//  1. Hostname (specific hostnames before wildcard "*")
+//  1. Hostname (exact hostnames before the "*." wildcards covering them,
+//     wildcard "*" last; see CompareHostnames)
 //  2. Priority (exact matches before prefix matches)
 //  3. Path length (longer paths first for specificity)
+//  4. Route namespace, route name and rule index, so identical input always
+//     yields an identical rule slice
 func (b *GenericBuilder[R]) Build(ctx context.Context, routes []R) BuildResult {
 	startTime := time.Now()
 
@@ -103,26 +132,28 @@ func (b *GenericBuilder[R]) Build(ctx context.Context, routes []R) BuildResult {
 		logger:        b.logger,
 		clusterDomain: b.clusterDomain,
 		metrics:       b.metrics,
+		lookupRetry:   b.lookupRetry,
 	}
 
 	var entries []routeEntry
 
 	var failedRefs []BackendRefError
 
+	var splits []CollapsedSplit
+
 	for i := range routes {
-		routeEntries, routeFailedRefs := extractProjectedEntries(ctx, b.adapter, &routes[i], resolver)
+		routeEntries, routeFailedRefs, routeSplits := extractProjectedEntries(ctx, b.adapter, &routes[i], resolver)
 		entries = append(entries, routeEntries...)
 		failedRefs = append(failedRefs, routeFailedRefs...)
+		splits = append(splits, routeSplits...)
 	}
 
 	sortRouteEntries(entries)
 
-	rules := entriesToIngressRules(entries, b.logger)
+	rules, invalid := entriesToIngressRules(entries, b.logger)
 
 	if b.adapter.AddCatchAll() {
-		rules = append(rules, zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
-			Service: cloudflare.F(CatchAllService),
-		})
+		rules = append(rules, b.catchAll.Rule(b.clusterDomain))
 	}
 
 	if b.metrics != nil {
@@ -130,8 +161,10 @@ func (b *GenericBuilder[R]) Build(ctx context.Context, routes []R) BuildResult {
 	}
 
 	return BuildResult{
-		Rules:      rules,
-		FailedRefs: failedRefs,
+		Rules:           rules,
+		FailedRefs:      failedRefs,
+		CollapsedSplits: splits,
+		InvalidRules:    invalid,
 	}
 }
 
@@ -139,11 +172,16 @@ func (b *GenericBuilder[R]) Build(ctx context.Context, routes []R) BuildResult {
 // Wildcard entries (hostname == "*") are skipped — Cloudflare API rejects rules
 // with empty hostname (error 1056). These routes are handled by the in-process
 // L7 proxy via its OverrideProxy hook, not by Cloudflare's edge ingress.
+//
+// Entries whose RegularExpression path Cloudflare would refuse are left out
+// too and reported as InvalidRules: one refused rule fails the whole document
+// update, so keeping it would stall every other route on the tunnel.
 func entriesToIngressRules(
 	entries []routeEntry,
 	logger *slog.Logger,
-) []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress {
+) ([]zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress, []InvalidRule) {
 	rules := make([]zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress, 0, len(entries))
+	invalid := newInvalidRuleSet(entries)
 
 	for _, entry := range entries {
 		if entry.hostname == "*" {
@@ -160,16 +198,54 @@ func entriesToIngressRules(
 		}
 
 		if entry.path != "" && entry.path != "/" {
-			pathWithWildcard := entry.path
-			if entry.priority == 0 {
-				pathWithWildcard = entry.path + "*"
+			path, err := ingressPath(entry)
+			if err != nil {
+				logger.Warn("skipping route rule Cloudflare would reject",
+					"route", entry.routeNamespace+"/"+entry.routeName,
+					"rule", entry.ruleIndex,
+					"path", path,
+					"error", err,
+				)
+
+				invalid.add(entry, path, err)
+
+				continue
 			}
 
-			rule.Path = cloudflare.F(pathWithWildcard)
+			rule.Path = cloudflare.F(path)
+		}
+
+		if !entry.origin.isZero() {
+			rule.OriginRequest = cloudflare.F(entry.origin.ingressParams())
 		}
 
 		rules = append(rules, rule)
 	}
 
-	return rules
+	return rules, invalid.rules()
+}
+
+// ingressPath returns the document path of entry. cloudflared compiles the
+// path as a Go regular expression, so an Exact or PathPrefix literal is
+// quoted ("/c++" is written as "/c\+\+*") and always compiles; only a
+// RegularExpression value can be refused, in which case the error is
+// returned with the path that failed.
+func ingressPath(entry routeEntry) (string, error) {
+	if !entry.regex {
+		path := regexp.QuoteMeta(entry.path)
+		if entry.priority == 0 {
+			path += "*"
+		}
+
+		return path, nil
+	}
+
+	// A regex path already ending in a quantifier ("/v[0-9]+") cannot take
+	// the prefix wildcard; the bare path matches the same prefix.
+	path := entry.path
+	if entry.priority == 0 && ValidatePath(entry.path+"*") == nil {
+		path = entry.path + "*"
+	}
+
+	return path, ValidatePath(path)
 }
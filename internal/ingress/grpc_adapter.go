@@ -50,15 +50,20 @@ func (a GRPCRouteAdapter) ProjectRules(route *gatewayv1.GRPCRoute, resolver *bac
 
 	for _, rule := range route.Spec.Rules {
 		projected := projectedRule{
-			ignoredFilters: len(rule.Filters),
+			ignoredFilters: len(rule.Filters) + grpcBackendFilterCount(rule.BackendRefs),
 			backendRefs:    grpcBackendRefs(rule.BackendRefs),
 		}
 
+		for i := range rule.Filters {
+			if rule.Filters[i].Type == gatewayv1.GRPCRouteFilterRequestHeaderModifier {
+				projected.applyRequestHeaderModifier(rule.Filters[i].RequestHeaderModifier)
+			}
+		}
+
 		for _, match := range rule.Matches {
 			a.logProxyOnlyHeaderMatches(resolver, route.Namespace, route.Name, match.Headers)
 
-			path, priority := a.extractGRPCPath(match.Method)
-			projected.matches = append(projected.matches, projectedMatch{path: path, priority: priority})
+			projected.matches = append(projected.matches, a.extractGRPCPath(match.Method))
 		}
 
 		rules = append(rules, projected)
@@ -67,6 +72,17 @@ func (a GRPCRouteAdapter) ProjectRules(route *gatewayv1.GRPCRoute, resolver *bac
 	return rules
 }
 
+// grpcBackendFilterCount counts the backendRef-level filters of a rule (see
+// httpBackendFilterCount).
+func grpcBackendFilterCount(refs []gatewayv1.GRPCBackendRef) int {
+	count := 0
+	for i := range refs {
+		count += len(refs[i].Filters)
+	}
+
+	return count
+}
+
 // grpcBackendRefs projects GRPCBackendRefs to the embedded plain BackendRefs
 // (the per-backend filters are not expressible in tunnel ingress rules).
 func grpcBackendRefs(refs []gatewayv1.GRPCBackendRef) []gatewayv1.BackendRef {
@@ -88,11 +104,15 @@ func (GRPCRouteAdapter) logProxyOnlyHeaderMatches(resolver *backendResolver, nam
 	}
 }
 
+// grpcSegmentPattern matches one segment of a gRPC request path, standing in
+// for the service or method a RegularExpression match leaves empty.
+const grpcSegmentPattern = "[^/]+"
+
 // extractGRPCPath converts a GRPCMethodMatch to an HTTP path.
 // gRPC requests use HTTP/2 POST to paths like /package.Service/Method.
-func (GRPCRouteAdapter) extractGRPCPath(methodMatch *gatewayv1.GRPCMethodMatch) (string, int) {
+func (GRPCRouteAdapter) extractGRPCPath(methodMatch *gatewayv1.GRPCMethodMatch) projectedMatch {
 	if methodMatch == nil {
-		return "", 0
+		return projectedMatch{}
 	}
 
 	service := ""
@@ -107,19 +127,34 @@ func (GRPCRouteAdapter) extractGRPCPath(methodMatch *gatewayv1.GRPCMethodMatch)
 
 	// No service and no method - match all gRPC traffic
 	if service == "" && method == "" {
-		return "", 0
+		return projectedMatch{}
+	}
+
+	// RegularExpression - the same anchored /{service}/{method} pattern the
+	// in-process proxy matches, written without the prefix wildcard. Each user
+	// pattern is grouped so a top-level alternation stays in its segment.
+	if methodMatch.Type != nil && *methodMatch.Type == gatewayv1.GRPCMethodMatchRegularExpression {
+		if service == "" {
+			service = grpcSegmentPattern
+		}
+
+		if method == "" {
+			method = grpcSegmentPattern
+		}
+
+		return projectedMatch{path: "^/(?:" + service + ")/(?:" + method + ")$", priority: 1, regex: true}
 	}
 
 	// Service only - prefix match on /Service/
 	if service != "" && method == "" {
-		return "/" + service + "/", 0
+		return projectedMatch{path: "/" + service + "/"}
 	}
 
 	// Method only (implementation-specific) - not fully supported, treat as prefix
 	if service == "" && method != "" {
-		return "", 0
+		return projectedMatch{}
 	}
 
 	// Both service and method - exact match
-	return "/" + service + "/" + method, 1
+	return projectedMatch{path: "/" + service + "/" + method, priority: 1}
 }
@@ -37,10 +37,17 @@ func NewGRPCBuilder(
 	}
 }
 
+// WithServiceLookupRetry returns a copy of the builder that retries
+// transient backend Service lookup errors as bounded by retry.
+func (b *GRPCBuilder) WithServiceLookupRetry(retry ServiceLookupRetry) *GRPCBuilder {
+	return &GRPCBuilder{generic: b.generic.WithServiceLookupRetry(retry)}
+}
+
 // Build converts a list of GRPCRoute resources to Cloudflare Tunnel ingress rules.
 //
 // Rules are sorted by:
-//  1. Hostname (specific hostnames before wildcard "*")
+//  1. Hostname (exact hostnames before the "*." wildcards covering them,
+//     wildcard "*" last; see CompareHostnames)
 //  2. Priority (exact matches before prefix matches)
 //  3. Path length (longer paths first for specificity)
 //
@@ -126,7 +126,7 @@ func TestGRPCBuild_ServiceMethodMatch(t *testing.T) {
 	buildResult := builder.Build(context.Background(), routes)
 
 	require.Len(t, buildResult.Rules, 1)
-	assert.Equal(t, "/mypackage.MyService/GetUser", buildResult.Rules[0].Path.Value)
+	assert.Equal(t, `/mypackage\.MyService/GetUser`, buildResult.Rules[0].Path.Value)
 }
 
 func TestGRPCBuild_ServiceOnlyMatch(t *testing.T) {
@@ -163,7 +163,7 @@ func TestGRPCBuild_ServiceOnlyMatch(t *testing.T) {
 	buildResult := builder.Build(context.Background(), routes)
 
 	require.Len(t, buildResult.Rules, 1)
-	assert.Equal(t, "/mypackage.MyService/*", buildResult.Rules[0].Path.Value)
+	assert.Equal(t, `/mypackage\.MyService/*`, buildResult.Rules[0].Path.Value)
 }
 
 func TestGRPCBuild_NoMethodMatch(t *testing.T) {
@@ -536,6 +536,60 @@ func TestGRPCBuild_MethodOnlyMatch(t *testing.T) {
 	assert.False(t, buildResult.Rules[0].Path.Present)
 }
 
+// TestGRPCBuild_RegexMethodMatch pins that a RegularExpression method match
+// is written as the anchored /{service}/{method} pattern the proxy matches,
+// with an empty side standing for any one segment, and that Exact matches
+// keep their literal paths.
+func TestGRPCBuild_RegexMethodMatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		service  string
+		method   string
+		wantPath string
+	}{
+		{name: "service and method", service: `mypackage\.User.*`, method: "Get.*", wantPath: `^/(?:mypackage\.User.*)/(?:Get.*)$`},
+		{name: "service only", service: "mypackage.UserService", wantPath: "^/(?:mypackage.UserService)/(?:[^/]+)$"},
+		{name: "method only", method: "Get|List", wantPath: "^/(?:[^/]+)/(?:Get|List)$"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			regexType := gatewayv1.GRPCMethodMatchRegularExpression
+			methodMatch := &gatewayv1.GRPCMethodMatch{Type: &regexType}
+
+			if tt.service != "" {
+				methodMatch.Service = &tt.service
+			}
+
+			if tt.method != "" {
+				methodMatch.Method = &tt.method
+			}
+
+			builder := ingress.NewGRPCBuilder("cluster.local", nil, nil, nil, nil)
+			buildResult := builder.Build(context.Background(), []gatewayv1.GRPCRoute{{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-route", Namespace: "default"},
+				Spec: gatewayv1.GRPCRouteSpec{
+					Hostnames: []gatewayv1.Hostname{"grpc.example.com"},
+					Rules: []gatewayv1.GRPCRouteRule{{
+						Matches: []gatewayv1.GRPCRouteMatch{{Method: methodMatch}},
+						BackendRefs: []gatewayv1.GRPCBackendRef{
+							newGRPCBackendRef("grpc-service", nil, int32Ptr(50051)),
+						},
+					}},
+				},
+			}})
+
+			require.Len(t, buildResult.Rules, 1)
+			assert.Equal(t, tt.wantPath, buildResult.Rules[0].Path.Value)
+			assert.Empty(t, buildResult.InvalidRules)
+		})
+	}
+}
+
 func TestGRPCBuild_EmptyServiceAndMethod(t *testing.T) {
 	t.Parallel()
 
@@ -912,6 +966,71 @@ func TestGRPCBuild_ClusterIPService_WithClient(t *testing.T) {
 	assert.Empty(t, buildResult.FailedRefs)
 }
 
+// TestGRPCBuild_ServicePortAppProtocol pins the Service port's appProtocol
+// picking the scheme of a gRPC backend: h2c stays cleartext http, https on
+// the gRPC port switches the entry to TLS.
+func TestGRPCBuild_ServicePortAppProtocol(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		appProtocol string
+		wantURL     string
+	}{
+		{
+			name:        "h2c",
+			appProtocol: "kubernetes.io/h2c",
+			wantURL:     "http://grpc-service.default.svc.cluster.local:50051",
+		},
+		{
+			name:        "https",
+			appProtocol: "https",
+			wantURL:     "https://grpc-service.default.svc.cluster.local:50051",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "grpc-service", Namespace: "default"},
+				Spec: corev1.ServiceSpec{
+					Type:  corev1.ServiceTypeClusterIP,
+					Ports: []corev1.ServicePort{{Port: 50051, AppProtocol: new(tt.appProtocol)}},
+				},
+			}
+
+			scheme := runtime.NewScheme()
+			utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(svc).Build()
+
+			builder := ingress.NewGRPCBuilder("cluster.local", nil, fakeClient, nil, nil)
+			routes := []gatewayv1.GRPCRoute{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-route", Namespace: "default"},
+					Spec: gatewayv1.GRPCRouteSpec{
+						Hostnames: []gatewayv1.Hostname{"grpc.example.com"},
+						Rules: []gatewayv1.GRPCRouteRule{
+							{
+								BackendRefs: []gatewayv1.GRPCBackendRef{
+									newGRPCBackendRef("grpc-service", nil, int32Ptr(50051)),
+								},
+							},
+						},
+					},
+				},
+			}
+
+			buildResult := builder.Build(context.Background(), routes)
+
+			require.Len(t, buildResult.Rules, 1)
+			assert.Equal(t, tt.wantURL, buildResult.Rules[0].Service.Value)
+		})
+	}
+}
+
 func TestGRPCBuild_ServiceNotFound(t *testing.T) {
 	t.Parallel()
 
@@ -1047,10 +1166,10 @@ func TestGRPCBuild_NamedAndUnnamedRules_BothRoutable(t *testing.T) {
 
 	assert.Equal(t,
 		"http://grpc-infra-backend-v1.gateway-conformance-infra.svc.cluster.local:8080",
-		pathToService["/gateway_api_conformance.echo_basic.grpcecho.GrpcEcho/Echo"],
+		pathToService[`/gateway_api_conformance\.echo_basic\.grpcecho\.GrpcEcho/Echo`],
 	)
 	assert.Equal(t,
 		"http://grpc-infra-backend-v2.gateway-conformance-infra.svc.cluster.local:8080",
-		pathToService["/gateway_api_conformance.echo_basic.grpcecho.GrpcEcho/EchoTwo"],
+		pathToService[`/gateway_api_conformance\.echo_basic\.grpcecho\.GrpcEcho/EchoTwo`],
 	)
 }
@@ -2,6 +2,7 @@ package ingress_test
 
 import (
 	"context"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -194,6 +195,42 @@ func TestGRPCBuild_WarnHeaderMatching(t *testing.T) {
 	assert.Contains(t, logs, `"header_matches":2`)
 }
 
+// TestGRPCBuild_WarnRegexHeaderMatching pins that a RegularExpression header
+// match reports the same diagnostic as an Exact one and leaves the method
+// path intact.
+func TestGRPCBuild_WarnRegexHeaderMatching(t *testing.T) {
+	t.Parallel()
+
+	logger, buf := logging.TestLogger(t)
+	builder := ingress.NewGRPCBuilder("cluster.local", nil, nil, nil, logger)
+	headerType := gatewayv1.GRPCHeaderMatchRegularExpression
+	service := testGRPCService
+	method := testGRPCMethod
+
+	result := builder.Build(context.Background(), []gatewayv1.GRPCRoute{{
+		ObjectMeta: metav1.ObjectMeta{Name: "regex-header-route", Namespace: "default"},
+		Spec: gatewayv1.GRPCRouteSpec{
+			Hostnames: []gatewayv1.Hostname{"grpc.example.com"},
+			Rules: []gatewayv1.GRPCRouteRule{{
+				Matches: []gatewayv1.GRPCRouteMatch{{
+					Method:  &gatewayv1.GRPCMethodMatch{Service: &service, Method: &method},
+					Headers: []gatewayv1.GRPCHeaderMatch{{Type: &headerType, Name: "X-Tenant", Value: "team-[a-z]+"}},
+				}},
+				BackendRefs: []gatewayv1.GRPCBackendRef{
+					newGRPCBackendRefWithWeight("grpc-service1", nil, int32Ptr(9090)),
+				},
+			}},
+		},
+	}})
+
+	assert.Equal(t, "/"+regexp.QuoteMeta(testGRPCService)+"/"+testGRPCMethod, result.Rules[0].Path.Value)
+
+	logs := buf.String()
+	assert.Contains(t, logs, `"route":"default/regex-header-route"`)
+	assert.Contains(t, logs, "header matching is not expressible")
+	assert.Contains(t, logs, `"header_matches":1`)
+}
+
 func TestGRPCBuild_WarnFilters(t *testing.T) {
 	t.Parallel()
 
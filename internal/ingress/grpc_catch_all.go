@@ -0,0 +1,84 @@
+package ingress
+
+import (
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/zero_trust"
+	"github.com/cockroachdb/errors"
+)
+
+// GRPCCatchAll decides how a request to a gRPC hostname that matches none of
+// its rules is answered. Without a rule of its own the request falls through
+// to the document's closing catch-all, an HTTP 404 that gRPC clients read as
+// UNIMPLEMENTED.
+type GRPCCatchAll string
+
+const (
+	// GRPCCatchAllNone adds no rule. It is the default.
+	GRPCCatchAllNone GRPCCatchAll = "none"
+
+	// GRPCCatchAllUnavailable closes every gRPC-only hostname with
+	// GRPCUnavailableService, which gRPC clients read as UNAVAILABLE.
+	GRPCCatchAllUnavailable GRPCCatchAll = "unavailable"
+)
+
+// GRPCUnavailableService answers HTTP 503 directly from cloudflared.
+const GRPCUnavailableService = "http_status:503"
+
+// ParseGRPCCatchAll validates a gRPC catch-all mode. Empty means
+// GRPCCatchAllNone.
+func ParseGRPCCatchAll(name string) (GRPCCatchAll, error) {
+	switch GRPCCatchAll(name) {
+	case "", GRPCCatchAllNone:
+		return GRPCCatchAllNone, nil
+	case GRPCCatchAllUnavailable:
+		return GRPCCatchAllUnavailable, nil
+	default:
+		return "", errors.Newf("unknown gRPC catch-all %q (want %q or %q)", name, GRPCCatchAllNone, GRPCCatchAllUnavailable)
+	}
+}
+
+// Rules returns the path-less rule closing each hostname of grpcRules. A
+// hostname that also carries httpRules is skipped: its unmatched paths belong
+// to the HTTPRoutes. So is a hostname whose gRPC rules already match every
+// path. The rules are in hostname order, and none is returned for
+// GRPCCatchAllNone.
+func (m GRPCCatchAll) Rules(
+	grpcRules, httpRules []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress,
+) []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress {
+	if m != GRPCCatchAllUnavailable {
+		return nil
+	}
+
+	skip := make(map[string]bool)
+
+	for i := range httpRules {
+		if httpRules[i].Hostname.Present {
+			skip[httpRules[i].Hostname.Value] = true
+		}
+	}
+
+	for i := range grpcRules {
+		if grpcRules[i].Hostname.Present && !grpcRules[i].Path.Present {
+			skip[grpcRules[i].Hostname.Value] = true
+		}
+	}
+
+	var rules []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress
+
+	for i := range grpcRules {
+		hostname := grpcRules[i].Hostname
+
+		if !hostname.Present || skip[hostname.Value] {
+			continue
+		}
+
+		skip[hostname.Value] = true
+
+		rules = append(rules, zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
+			Hostname: cloudflare.F(hostname.Value),
+			Service:  cloudflare.F(GRPCUnavailableService),
+		})
+	}
+
+	return rules
+}
@@ -0,0 +1,73 @@
+package ingress_test
+
+import (
+	"testing"
+
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/zero_trust"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/ingress"
+)
+
+func catchAllTestRule(hostname, path string) zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress {
+	rule := zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
+		Service: cloudflare.F("http://grpc.default.svc.cluster.local:9000"),
+	}
+
+	if hostname != "" {
+		rule.Hostname = cloudflare.F(hostname)
+	}
+
+	if path != "" {
+		rule.Path = cloudflare.F(path)
+	}
+
+	return rule
+}
+
+func TestParseGRPCCatchAll(t *testing.T) {
+	t.Parallel()
+
+	for name, want := range map[string]ingress.GRPCCatchAll{
+		"":            ingress.GRPCCatchAllNone,
+		"none":        ingress.GRPCCatchAllNone,
+		"unavailable": ingress.GRPCCatchAllUnavailable,
+	} {
+		got, err := ingress.ParseGRPCCatchAll(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, want, got, name)
+	}
+
+	_, err := ingress.ParseGRPCCatchAll("404")
+	require.Error(t, err)
+}
+
+// TestGRPCCatchAll_Rules pins that the default adds no rule, and that the
+// unavailable mode closes each gRPC-only hostname once with HTTP 503 while
+// leaving hostnames shared with HTTPRoutes, or already matched on every path,
+// alone.
+func TestGRPCCatchAll_Rules(t *testing.T) {
+	t.Parallel()
+
+	grpcRules := []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
+		catchAllTestRule("api.example.com", "/pkg.Svc/*"),
+		catchAllTestRule("grpc.example.com", "/pkg.Greeter/Hello"),
+		catchAllTestRule("grpc.example.com", "/pkg.Svc/*"),
+		catchAllTestRule("open.example.com", "/pkg.Svc/*"),
+		catchAllTestRule("open.example.com", ""),
+	}
+	httpRules := []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
+		catchAllTestRule("api.example.com", "/api*"),
+		catchAllTestRule("", ""),
+	}
+
+	assert.Empty(t, ingress.GRPCCatchAllNone.Rules(grpcRules, httpRules))
+
+	rules := ingress.GRPCCatchAllUnavailable.Rules(grpcRules, httpRules)
+	require.Len(t, rules, 1)
+	assert.Equal(t, "grpc.example.com", rules[0].Hostname.Value)
+	assert.False(t, rules[0].Path.Present)
+	assert.Equal(t, ingress.GRPCUnavailableService, rules[0].Service.Value)
+}
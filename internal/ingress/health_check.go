@@ -0,0 +1,44 @@
+package ingress
+
+import (
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/zero_trust"
+)
+
+const (
+	// HealthCheckPath is the request path the synthetic health endpoint
+	// answers on, e.g. https://<host>/__tunnel_health.
+	HealthCheckPath = "/__tunnel_health"
+
+	// HealthCheckService is the cloudflared static response the tunnel
+	// document names for HealthCheckPath. The embedded connector hands every
+	// request to the proxy, which answers the same path with a 200 of its own,
+	// so the endpoint proves the tunnel path without depending on any backend.
+	HealthCheckService = "http_status:200"
+
+	// healthCheckPathPattern anchors HealthCheckPath: cloudflared treats the
+	// rule path as an unanchored regex, and the reserved rule must not claim
+	// route paths that merely contain it.
+	healthCheckPathPattern = "^" + HealthCheckPath + "$"
+)
+
+// HealthCheckRules returns the reserved synthetic health rule for each
+// hostname, in input order.
+func HealthCheckRules(hostnames []string) []zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress {
+	rules := make([]zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress, 0, len(hostnames))
+
+	for _, hostname := range hostnames {
+		rules = append(rules, zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngress{
+			Hostname: cloudflare.F(hostname),
+			Path:     cloudflare.F(healthCheckPathPattern),
+			Service:  cloudflare.F(HealthCheckService),
+		})
+	}
+
+	return rules
+}
+
+// IsHealthCheck reports whether the rule is a reserved synthetic health rule.
+func IsHealthCheck(r Rule) bool {
+	return r.Hostname != "" && r.Path == healthCheckPathPattern && r.Service == HealthCheckService
+}
@@ -0,0 +1,46 @@
+package ingress
+
+import (
+	"cmp"
+	"strings"
+)
+
+// CompareHostnames orders two ingress hostnames for top-down matching:
+// cloudflared uses the first rule whose hostname matches, so a hostname must
+// precede every wildcard that also covers it. Exact hostnames come first,
+// then wildcards with more labels before those with fewer ("*.a.example.com"
+// before "*.example.com"), then the bare "*". Hostnames of the same kind
+// compare alphabetically.
+func CompareHostnames(left, right string) int {
+	leftRank, rightRank := hostnameRank(left), hostnameRank(right)
+	if c := cmp.Compare(leftRank, rightRank); c != 0 {
+		return c
+	}
+
+	if leftRank == hostnameRankWildcard {
+		if c := cmp.Compare(strings.Count(right, "."), strings.Count(left, ".")); c != 0 {
+			return c
+		}
+	}
+
+	return cmp.Compare(left, right)
+}
+
+// Hostname groups in match order, from the narrowest to the broadest.
+const (
+	hostnameRankExact = iota
+	hostnameRankWildcard
+	hostnameRankAny
+)
+
+// hostnameRank groups a hostname by how broadly it matches.
+func hostnameRank(hostname string) int {
+	switch {
+	case hostname == "*":
+		return hostnameRankAny
+	case strings.HasPrefix(hostname, "*."):
+		return hostnameRankWildcard
+	default:
+		return hostnameRankExact
+	}
+}
@@ -49,15 +49,29 @@ func (a HTTPRouteAdapter) ProjectRules(route *gatewayv1.HTTPRoute, resolver *bac
 
 	for _, rule := range route.Spec.Rules {
 		projected := projectedRule{
-			ignoredFilters: len(rule.Filters),
+			ignoredFilters: len(rule.Filters) + httpBackendFilterCount(rule.BackendRefs),
 			backendRefs:    httpBackendRefs(rule.BackendRefs),
 		}
 
+		for i := range rule.Filters {
+			switch rule.Filters[i].Type {
+			case gatewayv1.HTTPRouteFilterRequestHeaderModifier:
+				projected.applyRequestHeaderModifier(rule.Filters[i].RequestHeaderModifier)
+			case gatewayv1.HTTPRouteFilterURLRewrite:
+				projected.applyURLRewrite(rule.Filters[i].URLRewrite)
+			case gatewayv1.HTTPRouteFilterRequestRedirect:
+				projected.applyRequestRedirect(resolver, route.Namespace, route.Name, rule.Filters[i].RequestRedirect)
+			case gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+				gatewayv1.HTTPRouteFilterRequestMirror, gatewayv1.HTTPRouteFilterCORS,
+				gatewayv1.HTTPRouteFilterExtensionRef, gatewayv1.HTTPRouteFilterExternalAuth:
+				// No tunnel ingress equivalent; counted in ignoredFilters.
+			}
+		}
+
 		for _, match := range rule.Matches {
 			a.logProxyOnlyMatches(resolver, route.Namespace, route.Name, match)
 
-			path, priority := a.extractPath(resolver, route.Namespace, route.Name, match.Path)
-			projected.matches = append(projected.matches, projectedMatch{path: path, priority: priority})
+			projected.matches = append(projected.matches, a.extractPath(resolver, route.Namespace, route.Name, match.Path))
 		}
 
 		rules = append(rules, projected)
@@ -66,6 +80,18 @@ func (a HTTPRouteAdapter) ProjectRules(route *gatewayv1.HTTPRoute, resolver *bac
 	return rules
 }
 
+// httpBackendFilterCount counts the backendRef-level filters of a rule. Like
+// rule-level filters they are served by the in-process proxy (applied only to
+// the backend that carries them) and are absent from the tunnel ingress rule.
+func httpBackendFilterCount(refs []gatewayv1.HTTPBackendRef) int {
+	count := 0
+	for i := range refs {
+		count += len(refs[i].Filters)
+	}
+
+	return count
+}
+
 // httpBackendRefs projects HTTPBackendRefs to the embedded plain BackendRefs
 // (the per-backend filters are not expressible in tunnel ingress rules).
 func httpBackendRefs(refs []gatewayv1.HTTPBackendRef) []gatewayv1.BackendRef {
@@ -105,9 +131,9 @@ func (HTTPRouteAdapter) logProxyOnlyMatches(resolver *backendResolver, namespace
 	}
 }
 
-func (HTTPRouteAdapter) extractPath(resolver *backendResolver, namespace, routeName string, pathMatch *gatewayv1.HTTPPathMatch) (string, int) {
+func (HTTPRouteAdapter) extractPath(resolver *backendResolver, namespace, routeName string, pathMatch *gatewayv1.HTTPPathMatch) projectedMatch {
 	if pathMatch == nil {
-		return "", 0
+		return projectedMatch{}
 	}
 
 	pathType := gatewayv1.PathMatchPathPrefix
@@ -122,7 +148,7 @@ func (HTTPRouteAdapter) extractPath(resolver *backendResolver, namespace, routeN
 
 	switch pathType {
 	case gatewayv1.PathMatchExact:
-		return path, 1
+		return projectedMatch{path: path, priority: 1}
 	case gatewayv1.PathMatchRegularExpression:
 		resolver.logger.Info("cloudflare tunnel ingress document reduced",
 			"route", fmt.Sprintf("%s/%s", namespace, routeName),
@@ -130,10 +156,10 @@ func (HTTPRouteAdapter) extractPath(resolver *backendResolver, namespace, routeN
 			"path", path,
 		)
 
-		return path, 0
+		return projectedMatch{path: path, regex: true}
 	case gatewayv1.PathMatchPathPrefix:
-		return path, 0
+		return projectedMatch{path: path}
 	}
 
-	return path, 0
+	return projectedMatch{path: path}
 }
@@ -0,0 +1,106 @@
+package ingress
+
+import (
+	"regexp"
+
+	"github.com/cockroachdb/errors"
+)
+
+// InvalidRule reports a route rule whose tunnel ingress entries were left out
+// of the document because Cloudflare would reject them. The in-process proxy
+// still carries the rule, but a hostname left with no entry in the document
+// also leaves the managed DNS and Access sets (with DNS management on, its
+// CNAME is deleted), so the edge stops reaching it.
+type InvalidRule struct {
+	RouteNamespace string
+	RouteName      string
+	RuleIndex      int
+	// Paths are the refused document paths, in document order.
+	Paths []string
+	// Err is the reason the first of Paths was refused.
+	Err error
+	// WholeRule is true when every document entry of the rule was refused, so
+	// the edge routes none of its traffic.
+	WholeRule bool
+}
+
+// ValidatePath reports whether Cloudflare accepts path as an ingress rule
+// path. cloudflared compiles the path as a Go regular expression, so a
+// RegularExpression value outside RE2 syntax (a lookahead such as
+// "/(?!admin)") is refused. Exact and PathPrefix literals are quoted before
+// they are written and never reach this check.
+func ValidatePath(path string) error {
+	if _, err := regexp.Compile(path); err != nil {
+		return errors.Wrapf(err, "path %q is not a valid tunnel ingress path", path)
+	}
+
+	return nil
+}
+
+// invalidRuleKey identifies the route rule a document entry came from.
+type invalidRuleKey struct {
+	namespace string
+	name      string
+	ruleIndex int
+}
+
+// invalidRuleSet accumulates refused entries per route rule, keeping the
+// order in which rules were first refused.
+type invalidRuleSet struct {
+	entryCount map[invalidRuleKey]int
+	byKey      map[invalidRuleKey]*InvalidRule
+	order      []invalidRuleKey
+}
+
+// newInvalidRuleSet counts the document entries of every rule in entries so a
+// rule can later be told apart as wholly or partly refused.
+func newInvalidRuleSet(entries []routeEntry) *invalidRuleSet {
+	set := &invalidRuleSet{
+		entryCount: make(map[invalidRuleKey]int),
+		byKey:      make(map[invalidRuleKey]*InvalidRule),
+	}
+
+	for _, entry := range entries {
+		if entry.hostname == "*" {
+			continue
+		}
+
+		set.entryCount[invalidRuleKey{entry.routeNamespace, entry.routeName, entry.ruleIndex}]++
+	}
+
+	return set
+}
+
+func (s *invalidRuleSet) add(entry routeEntry, path string, err error) {
+	key := invalidRuleKey{entry.routeNamespace, entry.routeName, entry.ruleIndex}
+
+	rule, ok := s.byKey[key]
+	if !ok {
+		rule = &InvalidRule{
+			RouteNamespace: entry.routeNamespace,
+			RouteName:      entry.routeName,
+			RuleIndex:      entry.ruleIndex,
+			Err:            err,
+		}
+		s.byKey[key] = rule
+		s.order = append(s.order, key)
+	}
+
+	rule.Paths = append(rule.Paths, path)
+}
+
+func (s *invalidRuleSet) rules() []InvalidRule {
+	if len(s.order) == 0 {
+		return nil
+	}
+
+	rules := make([]InvalidRule, 0, len(s.order))
+
+	for _, key := range s.order {
+		rule := *s.byKey[key]
+		rule.WholeRule = len(rule.Paths) >= s.entryCount[key]
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
@@ -0,0 +1,99 @@
+package ingress_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/ingress"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/logging"
+)
+
+func pathRoute(name, hostname string, rules ...[]gatewayv1.HTTPRouteMatch) gatewayv1.HTTPRoute {
+	route := gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       gatewayv1.HTTPRouteSpec{Hostnames: []gatewayv1.Hostname{gatewayv1.Hostname(hostname)}},
+	}
+
+	for _, matches := range rules {
+		route.Spec.Rules = append(route.Spec.Rules, gatewayv1.HTTPRouteRule{
+			Matches:     matches,
+			BackendRefs: []gatewayv1.HTTPBackendRef{newHTTPBackendRef("svc", nil, int32Ptr(8080))},
+		})
+	}
+
+	return route
+}
+
+func pathMatch(pathType gatewayv1.PathMatchType, value string) gatewayv1.HTTPRouteMatch {
+	return gatewayv1.HTTPRouteMatch{Path: &gatewayv1.HTTPPathMatch{Type: &pathType, Value: &value}}
+}
+
+func TestValidatePath(t *testing.T) {
+	t.Parallel()
+
+	for _, path := range []string{"/api*", "/helloworld.Greeter/*", "/v[0-9]+/users"} {
+		require.NoError(t, ingress.ValidatePath(path), path)
+	}
+
+	for _, path := range []string{"/c++", "/api/[0-9]+*", "/(?!admin)", "/unclosed("} {
+		require.Error(t, ingress.ValidatePath(path), path)
+	}
+}
+
+// TestBuild_InvalidPathsLeftOut pins that RegularExpression entries Cloudflare
+// would refuse are left out of the document and reported per rule, while the
+// rest of the routes, and the valid matches of a partly refused rule, are
+// still emitted. A literal path with regex metacharacters is quoted, not
+// refused.
+func TestBuild_InvalidPathsLeftOut(t *testing.T) {
+	t.Parallel()
+
+	logger, buf := logging.TestLogger(t)
+	builder := ingress.NewBuilder("cluster.local", nil, nil, nil, logger)
+
+	result := builder.Build(context.Background(), []gatewayv1.HTTPRoute{
+		pathRoute("bad", "bad.example.com",
+			[]gatewayv1.HTTPRouteMatch{pathMatch(gatewayv1.PathMatchRegularExpression, "/unclosed(")},
+			[]gatewayv1.HTTPRouteMatch{
+				pathMatch(gatewayv1.PathMatchExact, "/docs"),
+				pathMatch(gatewayv1.PathMatchRegularExpression, "/(?!admin)"),
+			}),
+		pathRoute("cpp", "cpp.example.com",
+			[]gatewayv1.HTTPRouteMatch{pathMatch(gatewayv1.PathMatchPathPrefix, "/c++")},
+			[]gatewayv1.HTTPRouteMatch{pathMatch(gatewayv1.PathMatchExact, "/v1.0")}),
+		pathRoute("web", "web.example.com",
+			[]gatewayv1.HTTPRouteMatch{pathMatch(gatewayv1.PathMatchPathPrefix, "/app")}),
+	})
+
+	paths := make([]string, 0, len(result.Rules))
+	for _, rule := range result.Rules {
+		paths = append(paths, rule.Hostname.Value+rule.Path.Value)
+	}
+
+	assert.Equal(t, []string{
+		"bad.example.com/docs",
+		`cpp.example.com/v1\.0`,
+		`cpp.example.com/c\+\+*`,
+		"web.example.com/app*",
+		"",
+	}, paths)
+
+	require.Len(t, result.InvalidRules, 2)
+
+	assert.Equal(t, "bad", result.InvalidRules[0].RouteName)
+	assert.Equal(t, 1, result.InvalidRules[0].RuleIndex)
+	assert.Equal(t, []string{"/(?!admin)"}, result.InvalidRules[0].Paths)
+	assert.False(t, result.InvalidRules[0].WholeRule, "the rule's Exact match is still written")
+
+	assert.Equal(t, 0, result.InvalidRules[1].RuleIndex)
+	assert.Equal(t, []string{"/unclosed("}, result.InvalidRules[1].Paths)
+	assert.True(t, result.InvalidRules[1].WholeRule)
+	require.Error(t, result.InvalidRules[1].Err)
+
+	assert.Contains(t, buf.String(), "skipping route rule Cloudflare would reject")
+}
@@ -0,0 +1,28 @@
+package ingress
+
+import (
+	"github.com/cloudflare/cloudflare-go/v7"
+	"github.com/cloudflare/cloudflare-go/v7/zero_trust"
+)
+
+// originSettings is the per-rule originRequest projection. The zero value
+// writes no originRequest at all, keeping rules without settings identical to
+// what older controller versions deployed. Every other originRequest setting
+// is applied by the L7 proxy, not written to the tunnel document.
+type originSettings struct {
+	hostHeader string
+}
+
+func (o originSettings) isZero() bool {
+	return o == originSettings{}
+}
+
+func (o originSettings) ingressParams() zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngressOriginRequest {
+	var params zero_trust.TunnelCloudflaredConfigurationUpdateParamsConfigIngressOriginRequest
+
+	if o.hostHeader != "" {
+		params.HTTPHostHeader = cloudflare.F(o.hostHeader)
+	}
+
+	return params
+}
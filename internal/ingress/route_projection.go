@@ -3,16 +3,20 @@ package ingress
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
 
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 // projectedMatch is the kind-neutral form of a single route match: the
 // tunnel-ingress path it contributes plus its sorting priority (1 = exact,
-// 0 = prefix).
+// 0 = prefix). regex marks a path that is already a regular expression; any
+// other path is a literal the document quotes.
 type projectedMatch struct {
 	path     string
 	priority int
+	regex    bool
 }
 
 // projectedRule is the kind-neutral form of a single route rule. Adapters
@@ -23,6 +27,22 @@ type projectedRule struct {
 	ignoredFilters int
 	backendRefs    []gatewayv1.BackendRef
 	matches        []projectedMatch
+	// hostHeader is the Host value a rule-level RequestHeaderModifier or
+	// URLRewrite hostname sets (the last in filter order wins, as in the
+	// proxy); it is written to the rule's originRequest.httpHostHeader.
+	hostHeader string
+	// proxyOnlyHeaderOps counts the RequestHeaderModifier operations with no
+	// tunnel ingress equivalent (every add/remove, and set of any header
+	// other than Host).
+	proxyOnlyHeaderOps int
+	// pathRewrites counts the URLRewrite path modifiers. The tunnel ingress
+	// service URL cannot carry a path, so the entry keeps the public match
+	// path and the in-process proxy rewrites the request.
+	pathRewrites int
+	// redirectStatus is the status code of a rule-level RequestRedirect, 0
+	// when the rule has none. A redirect rule is written as an http_status
+	// entry; the in-process proxy sends the actual redirect.
+	redirectStatus int
 }
 
 // extractProjectedEntries is the shared rule-walking skeleton behind every
@@ -38,38 +58,65 @@ type projectedRule struct {
 // regardless of backend resolvability, so gating the warnings on a resolved
 // backend (as the historical per-kind code did) hid them exactly when an
 // operator was debugging a broken rule.
+//
+// Every rule written with a weighted split collapsed to one backend is
+// returned as a CollapsedSplit.
 func extractProjectedEntries[R any](
 	ctx context.Context,
 	adapter RouteAdapter[R],
 	route *R,
 	resolver *backendResolver,
-) ([]routeEntry, []BackendRefError) {
+) ([]routeEntry, []BackendRefError, []CollapsedSplit) {
 	var entries []routeEntry
 
 	var failedRefs []BackendRefError
 
+	var splits []CollapsedSplit
+
 	namespace, name := adapter.GetMeta(route)
 	hostnames := adapter.GetHostnames(route)
 
-	for _, rule := range adapter.ProjectRules(route, resolver) {
+	for ruleIndex, rule := range adapter.ProjectRules(route, resolver) {
 		logProxyServedFilters(resolver, namespace, name, rule.ignoredFilters)
+		logProxyOnlyHeaderOps(resolver, namespace, name, rule.proxyOnlyHeaderOps)
+		logProxyOnlyPathRewrites(resolver, namespace, name, rule.pathRewrites)
 
 		service, ruleFailedRefs := resolveRuleBackendRefs(
 			ctx, resolver, namespace, name, adapter.GatewayKind(), rule.backendRefs,
 		)
 		failedRefs = append(failedRefs, ruleFailedRefs...)
 
+		origin := originSettings{hostHeader: rule.hostHeader}
+
+		if rule.redirectStatus != 0 {
+			// A redirect answers at the proxy and never reaches an origin, so
+			// the entry carries no originRequest.
+			service = fmt.Sprintf("http_status:%d", rule.redirectStatus)
+			origin = originSettings{}
+		}
+
 		if service == "" {
 			continue
 		}
 
+		if rule.redirectStatus == 0 {
+			if split := collapseSplit(namespace, name, ruleIndex, rule.backendRefs); split != nil {
+				splits = append(splits, *split)
+			}
+		}
+
 		for _, hostname := range hostnames {
 			if len(rule.matches) == 0 {
 				entries = append(entries, routeEntry{
-					hostname: string(hostname),
-					path:     "",
-					service:  service,
-					priority: 0,
+					hostname:       string(hostname),
+					path:           "",
+					service:        service,
+					priority:       0,
+					origin:         origin,
+					redirect:       rule.redirectStatus != 0,
+					routeNamespace: namespace,
+					routeName:      name,
+					ruleIndex:      ruleIndex,
 				})
 
 				continue
@@ -77,16 +124,22 @@ func extractProjectedEntries[R any](
 
 			for _, match := range rule.matches {
 				entries = append(entries, routeEntry{
-					hostname: string(hostname),
-					path:     match.path,
-					service:  service,
-					priority: match.priority,
+					hostname:       string(hostname),
+					path:           match.path,
+					service:        service,
+					priority:       match.priority,
+					regex:          match.regex,
+					origin:         origin,
+					redirect:       rule.redirectStatus != 0,
+					routeNamespace: namespace,
+					routeName:      name,
+					ruleIndex:      ruleIndex,
 				})
 			}
 		}
 	}
 
-	return entries, failedRefs
+	return entries, failedRefs, splits
 }
 
 // resolveRuleBackendRefs validates every traffic-receiving backend in the
@@ -124,13 +177,13 @@ func resolveRuleBackendRefs(
 			continue
 		}
 
-		url, failedRef := resolveValidatedBackend(ctx, resolver, refs[i], namespace, routeName, routeKind)
+		backendURL, failedRef := resolveValidatedBackend(ctx, resolver, refs[i], namespace, routeName, routeKind)
 		if failedRef != nil {
 			failedRefs = append(failedRefs, *failedRef)
 		}
 
 		if i == selectedIdx {
-			serviceURL = url
+			serviceURL = backendURL
 		}
 	}
 
@@ -147,8 +200,9 @@ func effectiveBackendWeight(ref *gatewayv1.BackendRef) int32 {
 	return DefaultBackendWeight
 }
 
-// logProxyServedFilters reports rule-level filters, which the Cloudflare tunnel
-// ingress path cannot express (the in-process proxy serves them instead).
+// logProxyServedFilters reports rule- and backendRef-level filters, which the
+// Cloudflare tunnel ingress path cannot express (the in-process proxy serves
+// them instead).
 func logProxyServedFilters(resolver *backendResolver, namespace, name string, count int) {
 	if count > 0 {
 		resolver.logger.Info("cloudflare tunnel ingress document reduced",
@@ -159,6 +213,98 @@ func logProxyServedFilters(resolver *backendResolver, namespace, name string, co
 	}
 }
 
+// applyRequestHeaderModifier folds a rule-level RequestHeaderModifier into
+// the projection. A set of Host maps onto originRequest.httpHostHeader (the
+// last such set wins, as in the proxy); every other operation is counted as
+// proxy-only.
+func (r *projectedRule) applyRequestHeaderModifier(filter *gatewayv1.HTTPHeaderFilter) {
+	if filter == nil {
+		return
+	}
+
+	for _, header := range filter.Set {
+		if strings.EqualFold(string(header.Name), "Host") {
+			r.hostHeader = header.Value
+
+			continue
+		}
+
+		r.proxyOnlyHeaderOps++
+	}
+
+	r.proxyOnlyHeaderOps += len(filter.Add) + len(filter.Remove)
+}
+
+// applyURLRewrite folds a rule-level URLRewrite into the projection. A
+// hostname rewrite maps onto originRequest.httpHostHeader; a path rewrite
+// (ReplacePrefixMatch or ReplaceFullPath) leaves the entry's path as the
+// public match — "/old*" stays "/old*" — and is counted as proxy-only.
+func (r *projectedRule) applyURLRewrite(filter *gatewayv1.HTTPURLRewriteFilter) {
+	if filter == nil {
+		return
+	}
+
+	if filter.Hostname != nil {
+		r.hostHeader = string(*filter.Hostname)
+	}
+
+	if filter.Path != nil {
+		r.pathRewrites++
+	}
+}
+
+// applyRequestRedirect folds a rule-level RequestRedirect into the
+// projection. The status defaults to 302 as in the proxy; a code outside the
+// set Cloudflare redirects support (301, 302, 303, 307, 308) is logged and
+// written as 302.
+func (r *projectedRule) applyRequestRedirect(
+	resolver *backendResolver,
+	namespace, name string,
+	filter *gatewayv1.HTTPRequestRedirectFilter,
+) {
+	if filter == nil {
+		return
+	}
+
+	r.redirectStatus = http.StatusFound
+
+	if filter.StatusCode == nil {
+		return
+	}
+
+	switch *filter.StatusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		r.redirectStatus = *filter.StatusCode
+	default:
+		resolver.logger.Warn("unsupported redirect status code",
+			"route", fmt.Sprintf("%s/%s", namespace, name),
+			"reason", "Cloudflare redirects support 301, 302, 303, 307 and 308; writing the tunnel ingress entry as 302",
+			"status_code", *filter.StatusCode,
+		)
+	}
+}
+
+func logProxyOnlyPathRewrites(resolver *backendResolver, namespace, name string, count int) {
+	if count > 0 {
+		resolver.logger.Info("cloudflare tunnel ingress document reduced",
+			"route", fmt.Sprintf("%s/%s", namespace, name),
+			"reason", "URLRewrite path modifiers are not expressible in tunnel ingress rules (the service URL cannot carry a path); the rule keeps its public path and the in-process proxy rewrites it",
+			"path_rewrites", count,
+		)
+	}
+}
+
+func logProxyOnlyHeaderOps(resolver *backendResolver, namespace, name string, count int) {
+	if count > 0 {
+		resolver.logger.Info("cloudflare tunnel ingress document reduced",
+			"route", fmt.Sprintf("%s/%s", namespace, name),
+			"reason", "only a Host set is expressible in tunnel ingress rules (originRequest.httpHostHeader); the in-process proxy applies the other request header modifications",
+			"header_operations", count,
+		)
+	}
+}
+
 func logMultipleBackends(resolver *backendResolver, namespace, routeName string, totalBackends int) {
 	if totalBackends > 1 {
 		resolver.logger.Info("cloudflare tunnel ingress document reduced",
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -27,9 +28,27 @@ const (
 	backendTargetExternal
 )
 
+// ServiceLookupRetry bounds how often a backend Service lookup that failed
+// with anything but NotFound is retried within one build. Such errors are
+// usually transient (informer cache not yet synced, API server hiccup); a
+// NotFound is final and is never retried.
+type ServiceLookupRetry struct {
+	// Attempts is the total number of lookups, the first included. Values
+	// below 1 mean a single lookup.
+	Attempts int
+	// Backoff is the wait before the second lookup; each further wait grows
+	// by the same amount.
+	Backoff time.Duration
+}
+
+// DefaultServiceLookupRetry is the Service lookup retry every builder starts
+// with.
+var DefaultServiceLookupRetry = ServiceLookupRetry{Attempts: 3, Backoff: 100 * time.Millisecond}
+
 // serviceResolveParams contains all parameters needed to resolve a backend service URL.
 type serviceResolveParams struct {
 	client        client.Reader
+	lookupRetry   ServiceLookupRetry
 	validator     *referencegrant.Validator
 	logger        *slog.Logger
 	clusterDomain string
@@ -114,7 +133,7 @@ func resolveValidatedBackend(
 	}
 
 	params := &serviceResolveParams{
-		client: resolver.client, validator: resolver.validator,
+		client: resolver.client, lookupRetry: resolver.lookupRetry, validator: resolver.validator,
 		logger: resolver.logger, clusterDomain: resolver.clusterDomain,
 		routeKind: routeKind, routeNS: namespace, routeName: routeName,
 		svcName: string(ref.Name), svcNS: svcNamespace, port: port,
@@ -176,10 +195,7 @@ func resolveServiceURL(ctx context.Context, params *serviceResolveParams) (strin
 	if params.client != nil {
 		svc := &corev1.Service{}
 
-		err := params.client.Get(ctx, types.NamespacedName{
-			Name:      params.svcName,
-			Namespace: params.svcNS,
-		}, svc)
+		err := getServiceWithRetry(ctx, params, svc)
 		if err != nil {
 			if apierrors.IsNotFound(err) {
 				return "", &BackendRefError{
@@ -196,8 +212,20 @@ func resolveServiceURL(ctx context.Context, params *serviceResolveParams) (strin
 				"service", fmt.Sprintf("%s/%s", params.svcNS, params.svcName),
 				"error", err.Error(),
 			)
-		} else if svc.Spec.Type == corev1.ServiceTypeExternalName {
-			return fmt.Sprintf("%s://%s:%d", scheme, svc.Spec.ExternalName, params.port), nil
+		} else {
+			scheme = appProtocolScheme(svc, params.port, scheme)
+
+			if svc.Spec.Type == corev1.ServiceTypeExternalName {
+				return fmt.Sprintf("%s://%s:%d", scheme, svc.Spec.ExternalName, params.port), nil
+			}
+
+			if portErr := servicePortMissingError(params, svc); portErr != nil {
+				return "", portErr
+			}
+
+			if protocolErr := servicePortProtocolError(params, svc); protocolErr != nil {
+				return "", protocolErr
+			}
 		}
 	}
 
@@ -210,6 +238,125 @@ func resolveServiceURL(ctx context.Context, params *serviceResolveParams) (strin
 	), nil
 }
 
+// getServiceWithRetry fetches the backend Service, retrying errors other than
+// NotFound as bounded by params.lookupRetry. It returns the last error once
+// the attempts run out or ctx is done.
+func getServiceWithRetry(ctx context.Context, params *serviceResolveParams, svc *corev1.Service) error {
+	key := types.NamespacedName{Name: params.svcName, Namespace: params.svcNS}
+	attempts := max(params.lookupRetry.Attempts, 1)
+
+	var err error
+
+	for attempt := 1; ; attempt++ {
+		err = params.client.Get(ctx, key, svc)
+		if err == nil || apierrors.IsNotFound(err) || attempt >= attempts {
+			return err
+		}
+
+		params.logger.Debug("retrying Service lookup",
+			"service", key.String(),
+			"attempt", attempt,
+			"error", err.Error(),
+		)
+
+		timer := time.NewTimer(time.Duration(attempt) * params.lookupRetry.Backoff)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// servicePortMissingError rejects a backendRef whose port the Service does
+// not declare: the Service routes no traffic on it, so every request would
+// fail at the origin. Like a ServiceImport that does not export the port, it
+// is reported as BackendNotFound. A headless Service is exempt, since its
+// DNS name resolves to the Pods and any port they listen on is reachable,
+// and so is a Service declaring no ports at all.
+func servicePortMissingError(params *serviceResolveParams, svc *corev1.Service) *BackendRefError {
+	if svc.Spec.ClusterIP == corev1.ClusterIPNone || len(svc.Spec.Ports) == 0 {
+		return nil
+	}
+
+	for i := range svc.Spec.Ports {
+		if int(svc.Spec.Ports[i].Port) == params.port {
+			return nil
+		}
+	}
+
+	return &BackendRefError{
+		RouteNamespace: params.routeNS,
+		RouteName:      params.routeName,
+		BackendName:    params.svcName,
+		BackendNS:      params.svcNS,
+		Reason:         string(gatewayv1.RouteReasonBackendNotFound),
+		Message:        fmt.Sprintf("Service %s/%s does not expose port %d", params.svcNS, params.svcName, params.port),
+	}
+}
+
+// servicePortProtocolError rejects a backendRef whose Service port is not
+// TCP: the tunnel and the proxy only ever open TCP connections to an origin,
+// so a UDP (or SCTP) port can never be served. A port number declared for
+// both TCP and UDP (DNS on 53) is fine.
+func servicePortProtocolError(params *serviceResolveParams, svc *corev1.Service) *BackendRefError {
+	var protocol corev1.Protocol
+
+	for i := range svc.Spec.Ports {
+		servicePort := &svc.Spec.Ports[i]
+		if int(servicePort.Port) != params.port {
+			continue
+		}
+
+		// An empty protocol defaults to TCP.
+		if servicePort.Protocol == "" || servicePort.Protocol == corev1.ProtocolTCP {
+			return nil
+		}
+
+		protocol = servicePort.Protocol
+	}
+
+	if protocol == "" {
+		return nil
+	}
+
+	return &BackendRefError{
+		RouteNamespace: params.routeNS,
+		RouteName:      params.routeName,
+		BackendName:    params.svcName,
+		BackendNS:      params.svcNS,
+		Reason:         string(gatewayv1.RouteReasonUnsupportedProtocol),
+		Message: fmt.Sprintf("Service %s/%s port %d uses protocol %s; only TCP ports can be routed",
+			params.svcNS, params.svcName, params.port, protocol),
+	}
+}
+
+// appProtocolScheme returns the URL scheme the appProtocol of the Service
+// port named by port asks for: https for a TLS protocol, http for a
+// cleartext one. h2c is written as http because a tunnel ingress service
+// URL has no cleartext HTTP/2 scheme. A port without a recognized
+// appProtocol keeps fallback, the port-number guess.
+func appProtocolScheme(svc *corev1.Service, port int, fallback string) string {
+	for i := range svc.Spec.Ports {
+		servicePort := &svc.Spec.Ports[i]
+		if int(servicePort.Port) != port || servicePort.AppProtocol == nil {
+			continue
+		}
+
+		switch *servicePort.AppProtocol {
+		case appProtocolHTTPS, appProtocolHTTPSUpper, appProtocolWSS:
+			return schemeHTTPS
+		case appProtocolHTTP, appProtocolHTTPUpper, appProtocolH2C, appProtocolK8sH2C, appProtocolWS:
+			return schemeHTTP
+		}
+	}
+
+	return fallback
+}
+
 // crossNamespaceDeniedError builds the RefNotPermitted error for a
 // cross-namespace backendRef that no ReferenceGrant authorizes.
 func crossNamespaceDeniedError(params *serviceResolveParams) *BackendRefError {
@@ -78,6 +78,21 @@ func TestSortRouteEntries(t *testing.T) {
 				{hostname: "example.com", path: "/multi-v3", service: "svc3"},
 			},
 		},
+		{
+			name: "equal entries sorted by route namespace, name and rule index",
+			entries: []routeEntry{
+				{hostname: "example.com", path: "/api", service: "b", routeNamespace: "team-b", routeName: "api"},
+				{hostname: "example.com", path: "/api", service: "a2", routeNamespace: "team-a", routeName: "api", ruleIndex: 2},
+				{hostname: "example.com", path: "/api", service: "z", routeNamespace: "team-a", routeName: "zeta"},
+				{hostname: "example.com", path: "/api", service: "a0", routeNamespace: "team-a", routeName: "api"},
+			},
+			expected: []routeEntry{
+				{hostname: "example.com", path: "/api", service: "a0", routeNamespace: "team-a", routeName: "api"},
+				{hostname: "example.com", path: "/api", service: "a2", routeNamespace: "team-a", routeName: "api", ruleIndex: 2},
+				{hostname: "example.com", path: "/api", service: "z", routeNamespace: "team-a", routeName: "zeta"},
+				{hostname: "example.com", path: "/api", service: "b", routeNamespace: "team-b", routeName: "api"},
+			},
+		},
 		{
 			name: "complex sorting - all criteria",
 			entries: []routeEntry{
@@ -47,3 +47,73 @@ func SelectHighestWeightIndex(refs []gatewayv1.BackendRef) int {
 
 	return selectedIdx
 }
+
+// WeightedBackend identifies one traffic-receiving backend of a rule.
+type WeightedBackend struct {
+	Name      string
+	Namespace string
+	Port      int32
+	Weight    int32
+}
+
+// CollapsedSplit reports a rule that splits traffic across several backends
+// by weight while its tunnel ingress entry points at Selected alone: a
+// tunnel ingress rule has exactly one service, so the Discarded backends get
+// no traffic that reaches the origin through the tunnel document.
+type CollapsedSplit struct {
+	RouteNamespace string
+	RouteName      string
+	RuleIndex      int
+	Selected       WeightedBackend
+	Discarded      []WeightedBackend
+}
+
+// collapseSplit returns the CollapsedSplit of a rule whose refs carry more
+// than one traffic-receiving backend, or nil. Weight-0 backends are disabled
+// rather than discarded and are left out.
+func collapseSplit(namespace, routeName string, ruleIndex int, refs []gatewayv1.BackendRef) *CollapsedSplit {
+	selectedIdx := SelectHighestWeightIndex(refs)
+	if selectedIdx == -1 {
+		return nil
+	}
+
+	split := &CollapsedSplit{
+		RouteNamespace: namespace,
+		RouteName:      routeName,
+		RuleIndex:      ruleIndex,
+		Selected:       weightedBackend(namespace, &refs[selectedIdx]),
+	}
+
+	for i := range refs {
+		if i == selectedIdx || effectiveBackendWeight(&refs[i]) == 0 {
+			continue
+		}
+
+		split.Discarded = append(split.Discarded, weightedBackend(namespace, &refs[i]))
+	}
+
+	if len(split.Discarded) == 0 {
+		return nil
+	}
+
+	return split
+}
+
+// weightedBackend describes ref, defaulting its namespace to the route's.
+func weightedBackend(routeNamespace string, ref *gatewayv1.BackendRef) WeightedBackend {
+	backend := WeightedBackend{
+		Name:      string(ref.Name),
+		Namespace: routeNamespace,
+		Weight:    effectiveBackendWeight(ref),
+	}
+
+	if ref.Namespace != nil {
+		backend.Namespace = string(*ref.Namespace)
+	}
+
+	if ref.Port != nil {
+		backend.Port = int32(*ref.Port)
+	}
+
+	return backend
+}
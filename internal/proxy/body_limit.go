@@ -0,0 +1,111 @@
+package proxy
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// MaxRequestBodySizeAnnotation is the HTTPRoute annotation capping the request
+// body size the proxy forwards for every rule of the route. The value is a
+// Kubernetes quantity ("10Mi", "500k", "1048576"). Cloudflare Tunnel
+// originRequest has no body-size setting, so the limit is enforced by the
+// in-cluster proxy, which answers an oversized upload with 413 before it
+// reaches the backend.
+const MaxRequestBodySizeAnnotation = "cf.k8s.lex.la/max-request-body-size"
+
+var errBodySizeNotPositive = errors.New("must be a positive number of bytes")
+
+// parseMaxRequestBodySize parses a MaxRequestBodySizeAnnotation value into a
+// byte count. Fractional quantities round up to the next whole byte.
+func parseMaxRequestBodySize(value string) (int64, error) {
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid size %q", value)
+	}
+
+	if quantity.Sign() <= 0 {
+		return 0, errors.Wrapf(errBodySizeNotPositive, "invalid size %q", value)
+	}
+
+	return quantity.Value(), nil
+}
+
+// invalidBodySizeMessage builds the actionable status message for a route
+// whose body-size annotation could not be parsed. The route still serves,
+// without a limit.
+func invalidBodySizeMessage(err error) string {
+	return fmt.Sprintf(
+		"The %s annotation could not be parsed (%v); the route is served without a body size limit. "+
+			"Use a positive Kubernetes quantity (e.g. \"10Mi\", \"500k\") or remove the annotation.",
+		MaxRequestBodySizeAnnotation, err,
+	)
+}
+
+// unsupportedBodySizeMessage is the Event message for a GRPCRoute carrying the
+// body-size annotation, which only HTTPRoute honours.
+const unsupportedBodySizeMessage = "The " + MaxRequestBodySizeAnnotation + " annotation is only supported on " +
+	"HTTPRoute and is ignored on this GRPCRoute; remove it or bound message sizes in the gRPC server."
+
+// applyMaxRequestBodySize sets the rule's body limit from the route
+// annotations. An unparseable value is reported once per route (on rule 0) so
+// a multi-rule route does not repeat the same message per rule; the rule
+// serves unlimited rather than failing closed, like an invalid timeout.
+func applyMaxRequestBodySize(proxyRule *RouteRule, annotations map[string]string, ruleIdx int, sink *diagSink) {
+	value, ok := annotations[MaxRequestBodySizeAnnotation]
+	if !ok {
+		return
+	}
+
+	limit, err := parseMaxRequestBodySize(value)
+	if err != nil {
+		if ruleIdx == 0 {
+			sink.add(
+				DiagnosticAccepted,
+				string(gatewayv1.RouteReasonUnsupportedValue),
+				invalidBodySizeMessage(err),
+				false,
+			)
+		}
+
+		return
+	}
+
+	proxyRule.MaxRequestBodyBytes = limit
+}
+
+// warnBodySizeUnsupported records a Warning Event, once per route, for a
+// GRPCRoute carrying the HTTPRoute-only body-size annotation.
+func warnBodySizeUnsupported(annotations map[string]string, ruleIdx int, sink *diagSink) {
+	if _, ok := annotations[MaxRequestBodySizeAnnotation]; !ok || ruleIdx != 0 {
+		return
+	}
+
+	sink.event(EventTypeWarning, unsupportedBodySizeMessage)
+}
+
+// writeBodyTooLarge enforces the matched rule's MaxRequestBodyBytes. A request
+// whose declared Content-Length exceeds the limit is answered 413 up front and
+// true is returned so the caller short-circuits. Otherwise the body is wrapped
+// in http.MaxBytesReader so a chunked upload that runs past the limit fails
+// the backend round-trip, which errorHandler maps to 413.
+func writeBodyTooLarge(writer http.ResponseWriter, req *http.Request, rule *RouteRule) bool {
+	if rule == nil || rule.MaxRequestBodyBytes <= 0 {
+		return false
+	}
+
+	if req.ContentLength > rule.MaxRequestBodyBytes {
+		http.Error(writer, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+
+		return true
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		req.Body = http.MaxBytesReader(writer, req.Body, rule.MaxRequestBodyBytes)
+	}
+
+	return false
+}
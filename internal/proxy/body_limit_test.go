@@ -0,0 +1,198 @@
+package proxy_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/proxy"
+)
+
+func bodyLimitRoute(annotation string, ruleCount int) *gatewayv1.HTTPRoute {
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "upload",
+			Namespace:   "default",
+			Annotations: map[string]string{proxy.MaxRequestBodySizeAnnotation: annotation},
+		},
+		Spec: gatewayv1.HTTPRouteSpec{Hostnames: []gatewayv1.Hostname{"upload.example.com"}},
+	}
+
+	for range ruleCount {
+		route.Spec.Rules = append(route.Spec.Rules, gatewayv1.HTTPRouteRule{
+			BackendRefs: []gatewayv1.HTTPBackendRef{backendRef("upload-svc", 80, 1)},
+		})
+	}
+
+	return route
+}
+
+// TestConvertHTTPRoutes_MaxRequestBodySize pins that the annotation renders
+// onto every rule of the route as a byte count, in each accepted quantity form.
+func TestConvertHTTPRoutes_MaxRequestBodySize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value string
+		want  int64
+	}{
+		{value: "1048576", want: 1 << 20},
+		{value: "10Mi", want: 10 << 20},
+		{value: "500k", want: 500_000},
+		{value: "1G", want: 1_000_000_000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := proxy.ConvertHTTPRoutes(context.Background(),
+				[]*gatewayv1.HTTPRoute{bodyLimitRoute(tt.value, 2)}, "cluster.local", nil, nil, nil, nil)
+
+			require.Len(t, cfg.Rules, 2)
+
+			for _, rule := range cfg.Rules {
+				assert.Equal(t, tt.want, rule.MaxRequestBodyBytes)
+			}
+
+			assert.Empty(t, cfg.Diagnostics)
+			require.NoError(t, cfg.Validate())
+		})
+	}
+}
+
+// TestConvertHTTPRoutes_InvalidMaxRequestBodySize pins that a malformed or
+// non-positive size is dropped (the route serves unlimited) and reported once
+// per route as a PartiallyInvalid-bound diagnostic naming the annotation.
+func TestConvertHTTPRoutes_InvalidMaxRequestBodySize(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"ten megabytes", "10MB", "0", "-1Mi", ""} {
+		t.Run(value, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := proxy.ConvertHTTPRoutes(context.Background(),
+				[]*gatewayv1.HTTPRoute{bodyLimitRoute(value, 2)}, "cluster.local", nil, nil, nil, nil)
+
+			require.Len(t, cfg.Rules, 2)
+
+			for _, rule := range cfg.Rules {
+				assert.Zero(t, rule.MaxRequestBodyBytes, "an invalid size must not be applied")
+				assert.Zero(t, rule.UnavailableStatus, "an invalid size is report-only, not fail-closed")
+			}
+
+			require.Len(t, cfg.Diagnostics, 1, "reported once per route, not once per rule")
+			diag := cfg.Diagnostics[0]
+			assert.Equal(t, proxy.DiagnosticAccepted, diag.Target)
+			assert.Equal(t, string(gatewayv1.RouteReasonUnsupportedValue), diag.Reason)
+			assert.False(t, diag.WholeRule)
+			assert.Contains(t, diag.Message, proxy.MaxRequestBodySizeAnnotation)
+		})
+	}
+}
+
+// TestConvertGRPCRoutes_MaxRequestBodySizeUnsupported pins that the
+// HTTPRoute-only annotation on a GRPCRoute is ignored with one Warning Event.
+func TestConvertGRPCRoutes_MaxRequestBodySizeUnsupported(t *testing.T) {
+	t.Parallel()
+
+	route := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "rpc",
+			Namespace:   "default",
+			Annotations: map[string]string{proxy.MaxRequestBodySizeAnnotation: "1Mi"},
+		},
+		Spec: gatewayv1.GRPCRouteSpec{
+			Rules: []gatewayv1.GRPCRouteRule{
+				{BackendRefs: []gatewayv1.GRPCBackendRef{grpcBackendRef("rpc-svc", 50051, 1)}},
+				{BackendRefs: []gatewayv1.GRPCBackendRef{grpcBackendRef("rpc-svc", 50051, 1)}},
+			},
+		},
+	}
+
+	cfg := proxy.ConvertGRPCRoutes(context.Background(), []*gatewayv1.GRPCRoute{route}, "cluster.local", nil, nil, nil, nil)
+
+	require.Len(t, cfg.Rules, 2)
+	assert.Zero(t, cfg.Rules[0].MaxRequestBodyBytes)
+
+	require.Len(t, cfg.Diagnostics, 1)
+	assert.Equal(t, proxy.DiagnosticEvent, cfg.Diagnostics[0].Target)
+	assert.Equal(t, proxy.EventTypeWarning, cfg.Diagnostics[0].EventType)
+	assert.Contains(t, cfg.Diagnostics[0].Message, proxy.MaxRequestBodySizeAnnotation)
+}
+
+// TestConfig_Validate_NegativeBodyLimit pins that a pushed config carrying a
+// negative limit is rejected by the proxy API.
+func TestConfig_Validate_NegativeBodyLimit(t *testing.T) {
+	t.Parallel()
+
+	cfg := &proxy.Config{Rules: []proxy.RouteRule{{MaxRequestBodyBytes: -1}}}
+	require.Error(t, cfg.Validate())
+}
+
+// TestHandler_MaxRequestBodyBytes pins the data-plane enforcement: a body at
+// the limit is forwarded, a declared oversize is rejected before the backend
+// is dialed, and a chunked upload that runs past the limit answers 413 too.
+func TestHandler_MaxRequestBodyBytes(t *testing.T) {
+	t.Parallel()
+
+	const limit = 16
+
+	var backendHits atomic.Int32
+
+	backend := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		backendHits.Add(1)
+
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return
+		}
+
+		_, _ = writer.Write(body)
+	}))
+	defer backend.Close()
+
+	router := proxy.NewRouter()
+	require.NoError(t, router.UpdateConfig(&proxy.Config{
+		Version: 1,
+		Rules: []proxy.RouteRule{{
+			Hostnames:           []string{"upload.example.com"},
+			Backends:            []proxy.BackendRef{{URL: backend.URL, Weight: 1}},
+			MaxRequestBodyBytes: limit,
+		}},
+	}))
+
+	handler := proxy.NewHandler(router)
+
+	serve := func(body io.Reader, contentLength int64) *httptest.ResponseRecorder {
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodPost, "http://upload.example.com/", body)
+		req.ContentLength = contentLength
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+
+		return recorder
+	}
+
+	atLimit := strings.Repeat("a", limit)
+	recorder := serve(strings.NewReader(atLimit), limit)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, atLimit, recorder.Body.String())
+	assert.EqualValues(t, 1, backendHits.Load())
+
+	recorder = serve(strings.NewReader(atLimit+"b"), limit+1)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	assert.EqualValues(t, 1, backendHits.Load(), "a declared oversize must not reach the backend")
+
+	// ContentLength -1 is an unknown-length (chunked) upload.
+	recorder = serve(io.MultiReader(strings.NewReader(atLimit), strings.NewReader("overflow")), -1)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+}
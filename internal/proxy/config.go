@@ -21,6 +21,7 @@ var (
 	errNameRequired      = errors.New("name is required")
 	errPathValueRequired = errors.New("path: value is required")
 	errUnknownFilterType = errors.New("unknown filter type")
+	errBodyLimitNegative = errors.New("maxRequestBodyBytes must be non-negative")
 	errStaleVersion      = errors.New("stale config version")
 	// ErrLostConfigPushRace is returned by the pusher when a 409 stale-version
 	// conflict is attributable to a concurrent same-process pusher having
@@ -54,6 +55,16 @@ type Config struct {
 	// indistinguishable from HTTP rules with h2c backends, so the controller
 	// marks the presence of gRPC explicitly rather than having the proxy guess.
 	HasGRPCRoute bool `json:"hasGrpcRoute,omitempty"`
+	// Fallback, when set, serves HTTP requests that match no rule instead of
+	// the default 404 (the GatewayClassConfig defaultBackend). Its hostnames
+	// and matches are ignored. Unmatched gRPC calls still answer
+	// Unimplemented, as the Gateway API requires.
+	Fallback *RouteRule `json:"fallback,omitempty"`
+	// GRPCUnavailableHostnames are the hostnames on which a gRPC call that
+	// matches no rule answers Unavailable instead of Unimplemented (the
+	// controller's --grpc-catch-all=unavailable). A "*.suffix" entry matches
+	// like a rule hostname. Other requests are unaffected.
+	GRPCUnavailableHostnames []string `json:"grpcUnavailableHostnames,omitempty"`
 	// Diagnostics carries the converter's per-route findings about config it
 	// will not serve exactly as written (dropped filters, unsupported app
 	// protocols, fail-closed rules, benign overrides). It is a controller-side
@@ -84,6 +95,10 @@ type RouteRule struct {
 	// without the dropped config, as the Gateway API spec requires. This is the
 	// rule-level analogue of BackendRef.UnavailableStatus.
 	UnavailableStatus int `json:"unavailableStatus,omitempty"`
+	// MaxRequestBodyBytes, when positive, caps the request body the proxy
+	// forwards for this rule; larger uploads are answered 413. Set from the
+	// route's MaxRequestBodySizeAnnotation. Zero means unlimited.
+	MaxRequestBodyBytes int64 `json:"maxRequestBodyBytes,omitempty"`
 }
 
 // RouteMatch defines conditions that must all be true for a request to match.
@@ -348,6 +363,9 @@ type BackendRef struct {
 	// endpoints, applied to the proportion of requests that would otherwise have
 	// been routed to this backend.
 	UnavailableStatus int `json:"unavailableStatus,omitempty"`
+	// Origin tunes how the proxy connects to this backend (dial timeout and
+	// friends). Nil keeps the proxy defaults.
+	Origin *OriginConfig `json:"origin,omitempty"`
 }
 
 // RouteTimeouts configures timeout durations for proxied requests.
@@ -427,10 +445,21 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.Fallback != nil {
+		err := c.Fallback.validate()
+		if err != nil {
+			return errors.Wrap(err, "fallback")
+		}
+	}
+
 	return nil
 }
 
 func (r *RouteRule) validate() error {
+	if r.MaxRequestBodyBytes < 0 {
+		return errBodyLimitNegative
+	}
+
 	// Rules with empty backends and no redirect filter are valid —
 	// per Gateway API spec, the proxy handler returns HTTP 500 for
 	// routes with unresolvable backend refs.
@@ -453,6 +482,11 @@ func (r *RouteRule) validate() error {
 				return errors.Wrapf(err, "backend[%d].filter[%d]", idx, filterIdx)
 			}
 		}
+
+		err := backend.Origin.validate()
+		if err != nil {
+			return errors.Wrapf(err, "backend[%d].origin", idx)
+		}
 	}
 
 	for idx, match := range r.Matches {
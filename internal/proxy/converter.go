@@ -154,10 +154,14 @@ func ConvertHTTPRoutes(
 		convertRule: func(ctx context.Context, route *gatewayv1.HTTPRoute, ruleIdx int,
 			hostnames []string, clientCert *ClientCertConfig, sink *diagSink,
 		) RouteRule {
-			return convertHTTPRouteRule(
+			rule := convertHTTPRouteRule(
 				ctx, &route.Spec.Rules[ruleIdx], hostnames,
 				route.Namespace, clusterDomain, validator, protocolResolver, tlsResolver, clientCert, sink,
 			)
+			applyMaxRequestBodySize(&rule, route.Annotations, ruleIdx, sink)
+			applyOriginAnnotations(&rule, route.Annotations, ruleIdx, sink)
+
+			return rule
 		},
 	})
 }
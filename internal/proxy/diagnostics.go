@@ -37,6 +37,11 @@ const (
 	// route stays Accepted; the controller surfaces a dedicated condition plus a
 	// Warning Event so the collapsed isolation is visible, not just logged.
 	DiagnosticTunnelShared DiagnosticTarget = "TunnelShared"
+	// DiagnosticDryRun means the controller runs in dry-run mode and computed a
+	// tunnel document or proxy config for this route that differs from the
+	// deployed one, but did not apply it. Status only: the route stays Accepted; the controller
+	// surfaces a dedicated condition so "would apply" is not read as "applied".
+	DiagnosticDryRun DiagnosticTarget = "DryRun"
 	// DiagnosticEvent means the config was applied successfully but a redundant
 	// or conflicting hint was overridden (a benign override, e.g. an appProtocol
 	// cleartext hint superseded by a BackendTLSPolicy, or a ResponseHeaderModifier
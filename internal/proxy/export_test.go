@@ -39,28 +39,46 @@ func (h *Handler) EffectiveWSHandshakeReadTimeoutForTest() time.Duration {
 // dimension pass 0 to mean "no header deadline" and behave exactly as
 // before.
 func TransportKey(host string, protocol BackendProtocol, backendTLS *BackendTLSConfig) string {
-	return transportKey(host, protocol, backendTLS, 0)
+	return transportKey(host, protocol, backendTLS, nil, 0)
 }
 
 // TransportKeyWithTimeout exposes transportKey with an explicit header
 // timeout so timeout-dimensional tests can assert that two routes with
 // different per-rule timeouts get distinct keys.
 func TransportKeyWithTimeout(host string, protocol BackendProtocol, backendTLS *BackendTLSConfig, headerTimeout time.Duration) string {
-	return transportKey(host, protocol, backendTLS, headerTimeout)
+	return transportKey(host, protocol, backendTLS, nil, headerTimeout)
+}
+
+// TransportKeyWithOrigin exposes transportKey with a backend OriginConfig so
+// tests can assert that origin settings split the pool.
+func TransportKeyWithOrigin(host string, protocol BackendProtocol, backendTLS *BackendTLSConfig, origin *OriginConfig) string {
+	return transportKey(host, protocol, backendTLS, origin, 0)
+}
+
+// NewOriginDialerForTest exposes newOriginDialer so tests can assert the
+// dialer fields an OriginConfig sets.
+func NewOriginDialerForTest(origin *OriginConfig) *net.Dialer {
+	return newOriginDialer(origin)
+}
+
+// NewTransportWithOriginForTest exposes newTransport with a backend
+// OriginConfig so tests can assert the transport fields it sets.
+func NewTransportWithOriginForTest(protocol BackendProtocol, backendTLS *BackendTLSConfig, origin *OriginConfig) http.RoundTripper {
+	return newTransport(protocol, backendTLS, origin, 0)
 }
 
 // NewTransportForTest exposes newTransport for testing purposes. Existing
 // callers receive a no-timeout transport (matching the pre-fix shape);
 // timeout-dimensional tests can use NewTransportForTestWithTimeout.
 func NewTransportForTest(protocol BackendProtocol, backendTLS *BackendTLSConfig) http.RoundTripper {
-	return newTransport(protocol, backendTLS, 0)
+	return newTransport(protocol, backendTLS, nil, 0)
 }
 
 // NewTransportForTestWithTimeout exposes newTransport with the
 // ResponseHeaderTimeout knob so per-rule-timeout tests can assert on
 // the resulting transport's response-header deadline.
 func NewTransportForTestWithTimeout(protocol BackendProtocol, backendTLS *BackendTLSConfig, headerTimeout time.Duration) http.RoundTripper {
-	return newTransport(protocol, backendTLS, headerTimeout)
+	return newTransport(protocol, backendTLS, nil, headerTimeout)
 }
 
 // ShouldMirrorForTest exposes the unexported requestMirror.shouldMirror
@@ -69,10 +69,15 @@ func ConvertGRPCRoutes(
 		convertRule: func(ctx context.Context, route *gatewayv1.GRPCRoute, ruleIdx int,
 			hostnames []string, clientCert *ClientCertConfig, sink *diagSink,
 		) RouteRule {
-			return convertGRPCRouteRule(
+			warnBodySizeUnsupported(route.Annotations, ruleIdx, sink)
+
+			rule := convertGRPCRouteRule(
 				ctx, &route.Spec.Rules[ruleIdx], hostnames, route.Namespace, clusterDomain,
 				validator, protocolResolver, tlsResolver, clientCert, sink,
 			)
+			applyOriginAnnotations(&rule, route.Annotations, ruleIdx, sink)
+
+			return rule
 		},
 	})
 }
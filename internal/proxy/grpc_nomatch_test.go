@@ -60,7 +60,52 @@ func (rawCodec) Name() string { return "raw" }
 func TestGRPCClientObservesUnimplementedOnNoMatch(t *testing.T) {
 	t.Parallel()
 
-	router := proxy.NewRouter()
+	err := invokeUnmatchedGRPC(t, proxy.NewRouter(), "")
+	require.Error(t, err)
+	require.Equalf(t, codes.Unimplemented, status.Code(err),
+		"a gRPC client must observe Unimplemented for a request matching no configured hostname, got: %v", err)
+}
+
+// TestGRPCClientObservesUnavailableOnClosedHost pins the proxy half of
+// --grpc-catch-all=unavailable: an unmatched call to a host listed in
+// GRPCUnavailableHostnames reaches the client as Unavailable, while any other
+// host keeps Unimplemented.
+func TestGRPCClientObservesUnavailableOnClosedHost(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		authority string
+		want      codes.Code
+	}{
+		{name: "exact host", authority: "grpc.example.com", want: codes.Unavailable},
+		{name: "wildcard host", authority: "api.wild.example.com", want: codes.Unavailable},
+		{name: "other host", authority: "other.example.com", want: codes.Unimplemented},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			router := proxy.NewRouter()
+			require.NoError(t, router.UpdateConfig(&proxy.Config{
+				Version:                  1,
+				GRPCUnavailableHostnames: []string{"GRPC.example.com", "*.wild.example.com"},
+			}))
+
+			err := invokeUnmatchedGRPC(t, router, tt.authority)
+			require.Error(t, err)
+			require.Equalf(t, tt.want, status.Code(err), "unexpected status: %v", err)
+		})
+	}
+}
+
+// invokeUnmatchedGRPC calls a method no rule serves through a TLS HTTP/2
+// server fronting router, with authority as the :authority when set, and
+// returns the client's error.
+func invokeUnmatchedGRPC(t *testing.T, router *proxy.Router, authority string) error {
+	t.Helper()
+
 	handler := proxy.NewHandler(router)
 
 	srv := httptest.NewUnstartedServer(handler)
@@ -72,15 +117,21 @@ func TestGRPCClientObservesUnimplementedOnNoMatch(t *testing.T) {
 	pool.AddCert(srv.Certificate())
 
 	creds := credentials.NewTLS(&tls.Config{
-		RootCAs:    pool,
-		MinVersion: tls.VersionTLS12,
+		RootCAs:            pool,
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: authority != "", //nolint:gosec // the test certificate does not cover the overridden authority
 	})
 
-	conn, err := grpc.NewClient(
-		"passthrough:///"+srv.Listener.Addr().String(),
+	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(creds),
 		grpc.WithDefaultCallOptions(grpc.ForceCodec(rawCodec{})),
-	)
+	}
+
+	if authority != "" {
+		opts = append(opts, grpc.WithAuthority(authority))
+	}
+
+	conn, err := grpc.NewClient("passthrough:///"+srv.Listener.Addr().String(), opts...)
 	require.NoError(t, err)
 	t.Cleanup(func() { _ = conn.Close() })
 
@@ -89,8 +140,5 @@ func TestGRPCClientObservesUnimplementedOnNoMatch(t *testing.T) {
 
 	var out []byte
 
-	err = conn.Invoke(ctx, "/unmatched.Service/Ping", []byte{}, &out)
-	require.Error(t, err)
-	require.Equalf(t, codes.Unimplemented, status.Code(err),
-		"a gRPC client must observe Unimplemented for a request matching no configured hostname, got: %v", err)
+	return conn.Invoke(ctx, "/unmatched.Service/Ping", []byte{}, &out)
 }
@@ -241,9 +241,17 @@ func ruleHeaderTimeout(timeouts *RouteTimeouts) time.Duration {
 // set on the *http.Transport itself (ResponseHeaderTimeout) and stdlib does
 // not let us override it per-call; without including it in the key, a route
 // reconfigured from `Request: 5s` to `Request: 30s` would silently keep
-// using the cached 5s transport.
-func transportKey(host string, protocol BackendProtocol, backendTLS *BackendTLSConfig, headerTimeout time.Duration) string {
-	return host + "|" + string(protocol) + "|" + tlsFingerprint(backendTLS) + "|" + headerTimeout.String()
+// using the cached 5s transport. The backend's OriginConfig participates
+// for the same reason: its dial settings live on the transport. A nil
+// origin appends nothing, so backends without one keep their historic keys.
+func transportKey(host string, protocol BackendProtocol, backendTLS *BackendTLSConfig, origin *OriginConfig, headerTimeout time.Duration) string {
+	key := host + "|" + string(protocol) + "|" + tlsFingerprint(backendTLS) + "|" + headerTimeout.String()
+
+	if origin != nil {
+		key += "|" + originFingerprint(origin)
+	}
+
+	return key
 }
 
 // tlsFingerprint returns a stable short hash of the TLS config, or "" when nil.
@@ -334,16 +342,29 @@ func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
 		// HTTP status; a bare HTTP 404 reaches it as "stream closed without
 		// trailers" (Internal) over the real tunnel. The Gateway API spec
 		// (v1.6.0, #4408) requires an unmatched gRPC request to surface as
-		// Unimplemented, so emit a trailers-only gRPC response for it.
+		// Unimplemented, so emit a trailers-only gRPC response for it. A host
+		// the controller closed with --grpc-catch-all=unavailable answers
+		// Unavailable instead.
 		if isGRPCRequest(req) {
-			writeGRPCStatus(writer, grpcStatusUnimplemented, "no matching route")
+			status := grpcStatusUnimplemented
+			if h.router.GRPCUnavailable(req) {
+				status = grpcStatusUnavailable
+			}
+
+			writeGRPCStatus(writer, status, "no matching route")
 
 			return
 		}
 
-		http.Error(writer, "no matching route", http.StatusNotFound)
+		// The class-level default backend, when configured, replaces the
+		// 404. It is not consulted for gRPC above: a gRPC client must still
+		// receive Unimplemented, not a maintenance page.
+		result = h.router.Fallback(req)
+		if result == nil {
+			http.Error(writer, "no matching route", http.StatusNotFound)
 
-		return
+			return
+		}
 	}
 
 	// Backfill the config-bounded hostname pattern for the metric labels
@@ -354,6 +375,10 @@ func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if writeBodyTooLarge(writer, req, result.Rule) {
+		return
+	}
+
 	// Per-rule timeouts (Request / BackendRequest) are enforced
 	// downstream by the cached transport's ResponseHeaderTimeout
 	// (set in newTransport from ruleHeaderTimeout's collapse). No
@@ -397,6 +422,10 @@ func (h *Handler) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
 // so the data plane does not depend on the grpc-go codes package for one value.
 const grpcStatusUnimplemented = "12"
 
+// grpcStatusUnavailable is the gRPC status code Unavailable, answered to
+// unmatched calls on the hosts listed in Config.GRPCUnavailableHostnames.
+const grpcStatusUnavailable = "14"
+
 // isGRPCRequest reports whether req is a gRPC call, identified by its
 // application/grpc content type (RFC-style prefix match covers the
 // application/grpc+proto and +json variants).
@@ -509,11 +538,14 @@ type TransportFactory func(host string, protocol BackendProtocol, backendTLS *Ba
 // returned function is a thin adapter — calling it has identical
 // semantics to a direct getTransport call, including pool sharing,
 // PruneTransports eviction, and the headerTimeout-as-part-of-key rule.
+// Filter dials carry no OriginConfig, so the proxy defaults apply.
 //
 // Pure method: the Handler's hot-path is unchanged; no internal state
 // is mutated, no new fields are introduced.
 func (h *Handler) TransportFactory() TransportFactory {
-	return h.getTransport
+	return func(host string, protocol BackendProtocol, backendTLS *BackendTLSConfig, headerTimeout time.Duration) http.RoundTripper {
+		return h.getTransport(host, protocol, backendTLS, nil, headerTimeout)
+	}
 }
 
 // instrumentRequest assembles the per-request observability pipeline shared
@@ -671,7 +703,7 @@ func (h *Handler) proxyToBackend(writer http.ResponseWriter, req *http.Request,
 	// dial/handshake/hijack/pipe sequence directly; see the comment on
 	// that method for the protocol-level details.
 	if shouldUseWebSocketUpgradePath(req, backend.WebSocket) {
-		h.proxyWebSocketUpgrade(writer, req, backendURL, backend.TLS, allFilters, result.MatchedHostname)
+		h.proxyWebSocketUpgrade(writer, req, backendURL, backend.TLS, backend.Origin, allFilters, result.MatchedHostname)
 
 		return
 	}
@@ -684,7 +716,7 @@ func (h *Handler) proxyToBackend(writer http.ResponseWriter, req *http.Request,
 	// timeout comment for the rationale.
 	headerTimeout := ruleHeaderTimeout(result.Rule.Timeouts)
 
-	proxy := h.createReverseProxy(backendURL, backend.Protocol, backend.TLS, allFilters, headerTimeout, result.MatchedHostname)
+	proxy := h.createReverseProxy(backendURL, &backend, allFilters, headerTimeout, result.MatchedHostname)
 	proxy.ServeHTTP(writer, req)
 }
 
@@ -693,8 +725,9 @@ func (h *Handler) proxyToBackend(writer http.ResponseWriter, req *http.Request,
 // ruleHeaderTimeout) becomes part of the transport cache key and the
 // resulting transport's ResponseHeaderTimeout. Zero means "no header
 // deadline". hostname is the matched config pattern used to label
-// backend-error metrics.
-func (h *Handler) createReverseProxy(backendURL *url.URL, protocol BackendProtocol, backendTLS *BackendTLSConfig, filters []Filter, headerTimeout time.Duration, hostname string) *httputil.ReverseProxy {
+// backend-error metrics. The backend's protocol, TLS policy, and
+// OriginConfig select the pooled transport.
+func (h *Handler) createReverseProxy(backendURL *url.URL, backend *BackendRef, filters []Filter, headerTimeout time.Duration, hostname string) *httputil.ReverseProxy {
 	return &httputil.ReverseProxy{
 		Director: func(req *http.Request) {
 			req.URL.Scheme = backendURL.Scheme
@@ -725,7 +758,7 @@ func (h *Handler) createReverseProxy(backendURL *url.URL, protocol BackendProtoc
 				req.Header.Set("User-Agent", "")
 			}
 		},
-		Transport:    h.backendTransport(backendURL.Host, protocol, backendTLS, headerTimeout),
+		Transport:    h.backendTransport(backendURL.Host, backend.Protocol, backend.TLS, backend.Origin, headerTimeout),
 		ErrorHandler: h.proxyErrorHandler(hostname),
 		ModifyResponse: func(resp *http.Response) error {
 			ApplyResponseFilters(filters, resp)
@@ -818,8 +851,8 @@ func joinBackendBasePath(base, reqPath string) string {
 // PruneTransports needs to reach for CloseIdleConnections. otelhttp.Transport
 // does not expose CloseIdleConnections, so caching the wrapper would leak idle
 // connections on eviction.
-func (h *Handler) backendTransport(host string, protocol BackendProtocol, backendTLS *BackendTLSConfig, headerTimeout time.Duration) http.RoundTripper {
-	rt := h.getTransport(host, protocol, backendTLS, headerTimeout)
+func (h *Handler) backendTransport(host string, protocol BackendProtocol, backendTLS *BackendTLSConfig, origin *OriginConfig, headerTimeout time.Duration) http.RoundTripper {
+	rt := h.getTransport(host, protocol, backendTLS, origin, headerTimeout)
 	if !h.tracingEnabled {
 		return rt
 	}
@@ -828,13 +861,13 @@ func (h *Handler) backendTransport(host string, protocol BackendProtocol, backen
 }
 
 // getTransport returns a shared transport for the given backend host /
-// protocol / TLS / header-timeout tuple. The cache key includes the
+// protocol / TLS / origin / header-timeout tuple. The cache key includes the
 // header timeout because ResponseHeaderTimeout is a *http.Transport
 // field, not per-call -- two routes with different per-rule timeouts
 // against the same backend must NOT share the cached transport, or one
 // route silently inherits the other's deadline.
-func (h *Handler) getTransport(host string, protocol BackendProtocol, backendTLS *BackendTLSConfig, headerTimeout time.Duration) http.RoundTripper {
-	key := transportKey(host, protocol, backendTLS, headerTimeout)
+func (h *Handler) getTransport(host string, protocol BackendProtocol, backendTLS *BackendTLSConfig, origin *OriginConfig, headerTimeout time.Duration) http.RoundTripper {
+	key := transportKey(host, protocol, backendTLS, origin, headerTimeout)
 
 	if transport, ok := h.transports.Load(key); ok {
 		if rt, castOK := transport.(http.RoundTripper); castOK {
@@ -842,7 +875,7 @@ func (h *Handler) getTransport(host string, protocol BackendProtocol, backendTLS
 		}
 	}
 
-	transport := newTransport(protocol, backendTLS, headerTimeout)
+	transport := newTransport(protocol, backendTLS, origin, headerTimeout)
 	actual, _ := h.transports.LoadOrStore(key, transport)
 
 	if rt, ok := actual.(http.RoundTripper); ok {
@@ -894,13 +927,18 @@ func newH2CDialer() *net.Dialer {
 // same "bound time-to-first-response-byte but stream the body freely"
 // contract holds. The pre-stream conn dial is still bounded by the
 // h2c dialer's Timeout, so a fully dead backend still fails fast.
-func newTransport(protocol BackendProtocol, backendTLS *BackendTLSConfig, headerTimeout time.Duration) http.RoundTripper {
+//
+// origin (nil = proxy defaults) overrides the dialer settings on every
+// path; see applyOriginTransport. Its TLS settings apply only to the
+// default path: a BackendTLSPolicy pins verification on its own.
+func newTransport(protocol BackendProtocol, backendTLS *BackendTLSConfig, origin *OriginConfig, headerTimeout time.Duration) http.RoundTripper {
 	if backendTLS != nil {
-		return newTLSTransport(backendTLS, headerTimeout)
+		return newTLSTransport(backendTLS, origin, headerTimeout)
 	}
 
 	if protocol == BackendProtocolH2C {
 		dialer := newH2CDialer()
+		applyOriginDialer(dialer, origin)
 
 		h2cTransport := &http2.Transport{
 			AllowHTTP: true,
@@ -921,6 +959,11 @@ func newTransport(protocol BackendProtocol, backendTLS *BackendTLSConfig, header
 	if defaultTransport, ok := http.DefaultTransport.(*http.Transport); ok {
 		cloned := defaultTransport.Clone()
 		cloned.ResponseHeaderTimeout = headerTimeout
+		applyOriginTransport(cloned, origin)
+
+		if tlsConfig := originTLSConfig("", origin); tlsConfig != nil {
+			cloned.TLSClientConfig = tlsConfig
+		}
 
 		return cloned
 	}
@@ -951,7 +994,7 @@ var (
 // In both modes a CA pool that fails to parse any PEM block is treated as a
 // hard failure so misconfigured operators see a TLS handshake error instead of
 // silently trusting nothing (gosec G402-safe path).
-func newTLSTransport(backendTLS *BackendTLSConfig, headerTimeout time.Duration) http.RoundTripper {
+func newTLSTransport(backendTLS *BackendTLSConfig, origin *OriginConfig, headerTimeout time.Duration) http.RoundTripper {
 	rootCAs := x509.NewCertPool()
 	if ok := rootCAs.AppendCertsFromPEM([]byte(backendTLS.CABundlePEM)); !ok {
 		slog.Error("BackendTLSPolicy CA bundle did not parse — all backend TLS handshakes will fail",
@@ -971,6 +1014,7 @@ func newTLSTransport(backendTLS *BackendTLSConfig, headerTimeout time.Duration)
 	transport := base.Clone()
 	transport.TLSClientConfig = tlsConfig
 	transport.ResponseHeaderTimeout = headerTimeout
+	applyOriginTransport(transport, origin)
 
 	return transport
 }
@@ -1143,6 +1187,15 @@ func errorHandler(writer http.ResponseWriter, _ *http.Request, err error) {
 		return
 	}
 
+	// A route's MaxRequestBodyBytes wraps the body in http.MaxBytesReader; a
+	// streamed upload that runs past it surfaces here as a transport error.
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		http.Error(writer, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+
+		return
+	}
+
 	// http.Transport.ResponseHeaderTimeout returns a sentinel that
 	// satisfies the Timeout() bool method but is NOT a wrapped
 	// context.DeadlineExceeded -- check the interface explicitly so a
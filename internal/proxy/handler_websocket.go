@@ -74,10 +74,11 @@ func (h *Handler) proxyWebSocketUpgrade(
 	req *http.Request,
 	backendURL *url.URL,
 	backendTLS *BackendTLSConfig,
+	origin *OriginConfig,
 	filters []Filter,
 	hostname string,
 ) {
-	backendConn, err := h.dialBackendForUpgrade(req.Context(), backendURL, backendTLS)
+	backendConn, err := h.dialBackendForUpgrade(req.Context(), backendURL, backendTLS, origin)
 	if err != nil {
 		slog.Warn("websocket upgrade: backend dial failed",
 			"error", err, "backend", backendURL.Host)
@@ -262,13 +263,16 @@ func flushBufferedToBackend(clientBuf *bufio.ReadWriter, backendConn net.Conn) {
 // Method on Handler (not a free function) so the per-Handler
 // effectiveWSDialTimeout flows in -- otherwise the function couldn't
 // see the WithWSDialTimeout override and would silently keep using
-// the 30s default.
+// the 30s default. The backend's OriginConfig overrides it and bounds the
+// TLS handshake like on the HTTP transport.
 func (h *Handler) dialBackendForUpgrade(
 	ctx context.Context,
 	backendURL *url.URL,
 	backendTLS *BackendTLSConfig,
+	origin *OriginConfig,
 ) (net.Conn, error) {
 	dialer := &net.Dialer{Timeout: h.effectiveWSDialTimeout()}
+	applyOriginDialer(dialer, origin)
 
 	if backendURL.Scheme != schemeHTTPS {
 		conn, err := dialer.DialContext(ctx, "tcp", backendURL.Host)
@@ -279,7 +283,7 @@ func (h *Handler) dialBackendForUpgrade(
 		return conn, nil
 	}
 
-	tlsConfig, err := backendUpgradeTLSConfig(backendURL, backendTLS)
+	tlsConfig, err := backendUpgradeTLSConfig(backendURL, backendTLS, origin)
 	if err != nil {
 		return nil, err
 	}
@@ -291,7 +295,16 @@ func (h *Handler) dialBackendForUpgrade(
 
 	tlsConn := tls.Client(rawConn, tlsConfig)
 
-	handshakeErr := tlsConn.HandshakeContext(ctx)
+	handshakeCtx := ctx
+
+	if origin != nil && origin.TLSHandshakeTimeout > 0 {
+		var cancel context.CancelFunc
+
+		handshakeCtx, cancel = context.WithTimeout(ctx, origin.TLSHandshakeTimeout)
+		defer cancel()
+	}
+
+	handshakeErr := tlsConn.HandshakeContext(handshakeCtx)
 	if handshakeErr != nil {
 		_ = rawConn.Close()
 
@@ -304,9 +317,14 @@ func (h *Handler) dialBackendForUpgrade(
 // backendUpgradeTLSConfig assembles the *tls.Config used for the WS
 // upgrade dial. Splits from dialBackendForUpgrade so the latter stays
 // within the funlen budget while keeping the TLS-vs-plaintext branch
-// readable.
-func backendUpgradeTLSConfig(backendURL *url.URL, backendTLS *BackendTLSConfig) (*tls.Config, error) {
+// readable. Without a BackendTLSPolicy the backend's OriginConfig may relax
+// verification, like on the HTTP transport.
+func backendUpgradeTLSConfig(backendURL *url.URL, backendTLS *BackendTLSConfig, origin *OriginConfig) (*tls.Config, error) {
 	if backendTLS == nil {
+		if tlsConfig := originTLSConfig(backendURL.Hostname(), origin); tlsConfig != nil {
+			return tlsConfig, nil
+		}
+
 		return &tls.Config{
 			MinVersion: tls.VersionTLS12,
 			ServerName: backendURL.Hostname(),
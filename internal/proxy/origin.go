@@ -0,0 +1,408 @@
+package proxy
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// ConnectTimeoutAnnotation is the route annotation bounding how long the
+// proxy waits to establish a connection to each of the route's backends. The
+// value is a positive Go duration ("5s", "1m30s").
+const ConnectTimeoutAnnotation = "cf.k8s.lex.la/connect-timeout"
+
+// NoTLSVerifyAnnotation is the route annotation that makes the proxy skip
+// certificate verification on the route's https backends, for backends that
+// terminate TLS with a self-signed certificate. The value must parse as a
+// boolean. A BackendTLSPolicy attached to the backend takes precedence.
+const NoTLSVerifyAnnotation = "cf.k8s.lex.la/no-tls-verify"
+
+// OriginServerNameAnnotation is the route annotation setting the name the
+// proxy sends as SNI to the route's https backends and verifies their
+// certificate against, for backends (typically ExternalName Services) whose
+// certificate does not cover the dialed hostname. A BackendTLSPolicy attached
+// to the backend takes precedence.
+const OriginServerNameAnnotation = "cf.k8s.lex.la/origin-server-name"
+
+// DisableHappyEyeballsAnnotation is the route annotation overriding the
+// class-level disableHappyEyeballs default for the route's backends. The value
+// must parse as a boolean.
+const DisableHappyEyeballsAnnotation = "cf.k8s.lex.la/disable-happy-eyeballs"
+
+// defaultOriginDialTimeout and defaultOriginKeepAlive match the dialer of
+// http.DefaultTransport, which backends without an OriginConfig use.
+const (
+	defaultOriginDialTimeout = 30 * time.Second
+	defaultOriginKeepAlive   = 30 * time.Second
+)
+
+var (
+	errOriginDurationNegative  = errors.New("origin durations must be non-negative")
+	errOriginIdleConnsNegative = errors.New("origin maxIdleConnsPerHost must be non-negative")
+)
+
+// OriginConfig tunes how the proxy connects to one backend. The controller
+// fills it from the route's annotations and the class-level
+// originRequestDefaults (see WithDefaults). Nil, like every zero field, keeps
+// the proxy default.
+type OriginConfig struct {
+	// ConnectTimeout bounds the TCP dial to the backend.
+	ConnectTimeout time.Duration
+	// InsecureSkipVerify accepts any certificate from an https backend that
+	// has no BackendTLSPolicy.
+	InsecureSkipVerify bool
+	// ServerName replaces the dialed hostname as SNI and certificate name for
+	// an https backend that has no BackendTLSPolicy.
+	ServerName string
+	// DisableHappyEyeballs dials the backend's addresses one after another
+	// instead of racing IPv4 and IPv6. A pointer so a route can turn off a
+	// class-level true.
+	DisableHappyEyeballs *bool
+	// TLSHandshakeTimeout bounds the TLS handshake with an https backend.
+	TLSHandshakeTimeout time.Duration
+	// TCPKeepAlive is the keepalive probe interval of the backend
+	// connections.
+	TCPKeepAlive time.Duration
+	// MaxIdleConnsPerHost caps the idle keepalive connections kept open to
+	// an HTTP/1.1 or https backend.
+	MaxIdleConnsPerHost int
+}
+
+// originConfigJSON is the JSON wire format for OriginConfig; durations travel
+// as human-readable strings, like RouteTimeouts.
+type originConfigJSON struct {
+	ConnectTimeout       string `json:"connectTimeout,omitempty"`
+	InsecureSkipVerify   bool   `json:"insecureSkipVerify,omitempty"`
+	ServerName           string `json:"serverName,omitempty"`
+	DisableHappyEyeballs *bool  `json:"disableHappyEyeballs,omitempty"`
+	TLSHandshakeTimeout  string `json:"tlsHandshakeTimeout,omitempty"`
+	TCPKeepAlive         string `json:"tcpKeepAlive,omitempty"`
+	MaxIdleConnsPerHost  int    `json:"maxIdleConnsPerHost,omitempty"`
+}
+
+// MarshalJSON serializes durations as human-readable strings.
+func (o OriginConfig) MarshalJSON() ([]byte, error) {
+	aux := originConfigJSON{
+		InsecureSkipVerify:   o.InsecureSkipVerify,
+		ServerName:           o.ServerName,
+		DisableHappyEyeballs: o.DisableHappyEyeballs,
+		MaxIdleConnsPerHost:  o.MaxIdleConnsPerHost,
+	}
+
+	if o.ConnectTimeout != 0 {
+		aux.ConnectTimeout = o.ConnectTimeout.String()
+	}
+
+	if o.TLSHandshakeTimeout != 0 {
+		aux.TLSHandshakeTimeout = o.TLSHandshakeTimeout.String()
+	}
+
+	if o.TCPKeepAlive != 0 {
+		aux.TCPKeepAlive = o.TCPKeepAlive.String()
+	}
+
+	result, err := json.Marshal(aux)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal origin config")
+	}
+
+	return result, nil
+}
+
+// UnmarshalJSON deserializes durations from human-readable strings.
+func (o *OriginConfig) UnmarshalJSON(data []byte) error {
+	var aux originConfigJSON
+
+	err := json.Unmarshal(data, &aux)
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshal origin config")
+	}
+
+	durations := []struct {
+		raw  string
+		name string
+		dest *time.Duration
+	}{
+		{aux.ConnectTimeout, "connect timeout", &o.ConnectTimeout},
+		{aux.TLSHandshakeTimeout, "TLS handshake timeout", &o.TLSHandshakeTimeout},
+		{aux.TCPKeepAlive, "TCP keepalive", &o.TCPKeepAlive},
+	}
+
+	for _, duration := range durations {
+		if duration.raw == "" {
+			continue
+		}
+
+		d, err := time.ParseDuration(duration.raw)
+		if err != nil {
+			return errors.Wrapf(err, "invalid %s %q", duration.name, duration.raw)
+		}
+
+		*duration.dest = d
+	}
+
+	o.InsecureSkipVerify = aux.InsecureSkipVerify
+	o.ServerName = aux.ServerName
+	o.DisableHappyEyeballs = aux.DisableHappyEyeballs
+	o.MaxIdleConnsPerHost = aux.MaxIdleConnsPerHost
+
+	return nil
+}
+
+func (o *OriginConfig) validate() error {
+	if o == nil {
+		return nil
+	}
+
+	if o.ConnectTimeout < 0 || o.TLSHandshakeTimeout < 0 || o.TCPKeepAlive < 0 {
+		return errOriginDurationNegative
+	}
+
+	if o.MaxIdleConnsPerHost < 0 {
+		return errOriginIdleConnsNegative
+	}
+
+	return nil
+}
+
+// originFingerprint renders the settings that shape a backend transport for
+// transportKey, or "" when o is nil so backends without settings keep their
+// historic keys.
+func originFingerprint(origin *OriginConfig) string {
+	if origin == nil {
+		return ""
+	}
+
+	return "connect=" + origin.ConnectTimeout.String() +
+		",insecure=" + strconv.FormatBool(origin.InsecureSkipVerify) +
+		",sni=" + origin.ServerName +
+		",serial=" + strconv.FormatBool(origin.happyEyeballsDisabled()) +
+		",tls=" + origin.TLSHandshakeTimeout.String() +
+		",keepalive=" + origin.TCPKeepAlive.String() +
+		",idle=" + strconv.Itoa(origin.MaxIdleConnsPerHost)
+}
+
+// happyEyeballsDisabled reports whether the backend is dialed without the
+// IPv4/IPv6 race.
+func (o *OriginConfig) happyEyeballsDisabled() bool {
+	return o.DisableHappyEyeballs != nil && *o.DisableHappyEyeballs
+}
+
+// WithDefaults returns the backend's settings with every field the route left
+// unset taken from defaults, or nil when neither sets anything.
+func (o *OriginConfig) WithDefaults(defaults OriginConfig) *OriginConfig {
+	merged := defaults
+
+	if o != nil {
+		if o.ConnectTimeout > 0 {
+			merged.ConnectTimeout = o.ConnectTimeout
+		}
+
+		if o.DisableHappyEyeballs != nil {
+			merged.DisableHappyEyeballs = o.DisableHappyEyeballs
+		}
+
+		if o.TLSHandshakeTimeout > 0 {
+			merged.TLSHandshakeTimeout = o.TLSHandshakeTimeout
+		}
+
+		if o.TCPKeepAlive > 0 {
+			merged.TCPKeepAlive = o.TCPKeepAlive
+		}
+
+		if o.MaxIdleConnsPerHost > 0 {
+			merged.MaxIdleConnsPerHost = o.MaxIdleConnsPerHost
+		}
+
+		merged.InsecureSkipVerify = o.InsecureSkipVerify
+		merged.ServerName = o.ServerName
+	}
+
+	if merged == (OriginConfig{}) {
+		return nil
+	}
+
+	return &merged
+}
+
+// ApplyOriginDefaults fills the OriginConfig of every backend in the config,
+// the fallback included, with the class-level defaults the routes leave
+// unset.
+func (c *Config) ApplyOriginDefaults(defaults OriginConfig) {
+	if defaults == (OriginConfig{}) {
+		return
+	}
+
+	for ruleIdx := range c.Rules {
+		applyRuleOriginDefaults(&c.Rules[ruleIdx], defaults)
+	}
+
+	if c.Fallback != nil {
+		applyRuleOriginDefaults(c.Fallback, defaults)
+	}
+}
+
+func applyRuleOriginDefaults(rule *RouteRule, defaults OriginConfig) {
+	for idx := range rule.Backends {
+		rule.Backends[idx].Origin = rule.Backends[idx].Origin.WithDefaults(defaults)
+	}
+}
+
+// newOriginDialer builds the dialer for a backend transport: the
+// http.DefaultTransport dialer, with the backend's OriginConfig applied.
+func newOriginDialer(origin *OriginConfig) *net.Dialer {
+	dialer := &net.Dialer{
+		Timeout:   defaultOriginDialTimeout,
+		KeepAlive: defaultOriginKeepAlive,
+	}
+
+	applyOriginDialer(dialer, origin)
+
+	return dialer
+}
+
+// applyOriginDialer overrides the dialer fields the backend's OriginConfig
+// sets. A nil origin leaves the dialer untouched.
+func applyOriginDialer(dialer *net.Dialer, origin *OriginConfig) {
+	if origin == nil {
+		return
+	}
+
+	if origin.ConnectTimeout > 0 {
+		dialer.Timeout = origin.ConnectTimeout
+	}
+
+	if origin.TCPKeepAlive > 0 {
+		dialer.KeepAlive = origin.TCPKeepAlive
+	}
+
+	if origin.happyEyeballsDisabled() {
+		// A negative FallbackDelay turns off RFC 6555 Fast Fallback.
+		dialer.FallbackDelay = -1
+	}
+}
+
+// applyOriginAnnotations sets every backend's OriginConfig of the rule from
+// the route annotations. An unparseable value is reported once per route (on
+// rule 0) and ignored, so the backend keeps the proxy default, like an
+// invalid body size.
+func applyOriginAnnotations(proxyRule *RouteRule, annotations map[string]string, ruleIdx int, sink *diagSink) {
+	var origin OriginConfig
+
+	if value, ok := annotations[ConnectTimeoutAnnotation]; ok {
+		timeout, err := time.ParseDuration(value)
+
+		switch {
+		case err != nil:
+			reportInvalidOriginAnnotation(ConnectTimeoutAnnotation, value, "a duration such as \"5s\"", ruleIdx, sink)
+		case timeout <= 0:
+			reportInvalidOriginAnnotation(ConnectTimeoutAnnotation, value, "a positive duration", ruleIdx, sink)
+		default:
+			origin.ConnectTimeout = timeout
+		}
+	}
+
+	if value, ok := annotations[NoTLSVerifyAnnotation]; ok {
+		skip, err := strconv.ParseBool(value)
+		if err != nil {
+			reportInvalidOriginAnnotation(NoTLSVerifyAnnotation, value, "\"true\" or \"false\"", ruleIdx, sink)
+		} else {
+			origin.InsecureSkipVerify = skip
+		}
+	}
+
+	if value, ok := annotations[OriginServerNameAnnotation]; ok {
+		serverName := strings.ToLower(value)
+		if problems := k8svalidation.IsDNS1123Subdomain(serverName); len(problems) > 0 {
+			reportInvalidOriginAnnotation(OriginServerNameAnnotation, value, "a hostname", ruleIdx, sink)
+		} else {
+			origin.ServerName = serverName
+		}
+	}
+
+	if value, ok := annotations[DisableHappyEyeballsAnnotation]; ok {
+		disable, err := strconv.ParseBool(value)
+		if err != nil {
+			reportInvalidOriginAnnotation(DisableHappyEyeballsAnnotation, value, "\"true\" or \"false\"", ruleIdx, sink)
+		} else {
+			origin.DisableHappyEyeballs = &disable
+		}
+	}
+
+	if origin == (OriginConfig{}) {
+		return
+	}
+
+	for idx := range proxyRule.Backends {
+		backendOrigin := origin
+		proxyRule.Backends[idx].Origin = &backendOrigin
+	}
+}
+
+// reportInvalidOriginAnnotation records the actionable status message for an
+// origin annotation that could not be parsed, once per route.
+func reportInvalidOriginAnnotation(annotation, value, want string, ruleIdx int, sink *diagSink) {
+	if ruleIdx != 0 {
+		return
+	}
+
+	sink.add(
+		DiagnosticAccepted,
+		string(gatewayv1.RouteReasonUnsupportedValue),
+		fmt.Sprintf("The %s annotation value %q is invalid and is ignored; use %s or remove the annotation.",
+			annotation, value, want),
+		false,
+	)
+}
+
+// applyOriginTransport overrides the dial, handshake and idle-pool settings
+// of a cloned http.Transport with the backend's OriginConfig. A nil origin
+// keeps the clone's http.DefaultTransport settings. The TLS config is left to
+// the caller: see originTLSConfig.
+func applyOriginTransport(transport *http.Transport, origin *OriginConfig) {
+	if origin == nil {
+		return
+	}
+
+	transport.DialContext = newOriginDialer(origin).DialContext
+
+	if origin.TLSHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = origin.TLSHandshakeTimeout
+	}
+
+	if origin.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = origin.MaxIdleConnsPerHost
+	}
+}
+
+// originTLSConfig returns the client TLS config for an https backend without
+// a BackendTLSPolicy, or nil when the OriginConfig leaves the stdlib default
+// (system roots, SNI from the dialed host) in place. serverName is the SNI to
+// send unless the origin overrides it; empty lets the transport derive it
+// from the dialed host.
+func originTLSConfig(serverName string, origin *OriginConfig) *tls.Config {
+	if origin == nil || (!origin.InsecureSkipVerify && origin.ServerName == "") {
+		return nil
+	}
+
+	if origin.ServerName != "" {
+		serverName = origin.ServerName
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+		// The operator opted out of verification for this route with the
+		// no-tls-verify annotation, for self-signed backends.
+		InsecureSkipVerify: origin.InsecureSkipVerify, //nolint:gosec // explicit per-route opt-in
+	}
+}
@@ -0,0 +1,39 @@
+package proxy
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBackendUpgradeTLSConfig_Origin pins the WebSocket dial's TLS identity:
+// the dialed host by default, the OriginConfig's server name and skip-verify
+// without a BackendTLSPolicy, and the policy's name whenever one is attached.
+func TestBackendUpgradeTLSConfig_Origin(t *testing.T) {
+	t.Parallel()
+
+	backendURL := &url.URL{Scheme: schemeHTTPS, Host: "api.external.com:443"}
+	origin := &OriginConfig{InsecureSkipVerify: true, ServerName: "cert.example.net"}
+
+	plain, err := backendUpgradeTLSConfig(backendURL, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "api.external.com", plain.ServerName)
+	assert.False(t, plain.InsecureSkipVerify)
+
+	relaxed, err := backendUpgradeTLSConfig(backendURL, nil, origin)
+	require.NoError(t, err)
+	assert.Equal(t, "cert.example.net", relaxed.ServerName)
+	assert.True(t, relaxed.InsecureSkipVerify)
+
+	renamed, err := backendUpgradeTLSConfig(backendURL, nil, &OriginConfig{ServerName: "cert.example.net"})
+	require.NoError(t, err)
+	assert.Equal(t, "cert.example.net", renamed.ServerName)
+	assert.False(t, renamed.InsecureSkipVerify)
+
+	policy, err := backendUpgradeTLSConfig(backendURL, &BackendTLSConfig{ServerName: "policy.example.com"}, origin)
+	require.NoError(t, err)
+	assert.Equal(t, "policy.example.com", policy.ServerName, "a BackendTLSPolicy wins over the annotations")
+	assert.False(t, policy.InsecureSkipVerify)
+}
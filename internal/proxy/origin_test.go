@@ -0,0 +1,512 @@
+package proxy_test
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/proxy"
+)
+
+func originRoute(annotations map[string]string) *gatewayv1.HTTPRoute {
+	return &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "slow", Namespace: "default", Annotations: annotations},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Hostnames: []gatewayv1.Hostname{"slow.example.com"},
+			Rules: []gatewayv1.HTTPRouteRule{
+				{BackendRefs: []gatewayv1.HTTPBackendRef{backendRef("a", 80, 1), backendRef("b", 80, 1)}},
+				{BackendRefs: []gatewayv1.HTTPBackendRef{backendRef("c", 80, 1)}},
+			},
+		},
+	}
+}
+
+// TestConvertHTTPRoutes_ConnectTimeout pins that the connect-timeout
+// annotation lands on every backend of every rule of the route, and that a
+// route without it carries no OriginConfig at all.
+func TestConvertHTTPRoutes_ConnectTimeout(t *testing.T) {
+	t.Parallel()
+
+	cfg := proxy.ConvertHTTPRoutes(context.Background(), []*gatewayv1.HTTPRoute{
+		originRoute(map[string]string{proxy.ConnectTimeoutAnnotation: "1500ms"}),
+	}, "cluster.local", nil, nil, nil, nil)
+
+	require.Len(t, cfg.Rules, 2)
+
+	for _, rule := range cfg.Rules {
+		for _, backend := range rule.Backends {
+			require.NotNil(t, backend.Origin)
+			assert.Equal(t, 1500*time.Millisecond, backend.Origin.ConnectTimeout)
+		}
+	}
+
+	assert.NotSame(t, cfg.Rules[0].Backends[0].Origin, cfg.Rules[0].Backends[1].Origin,
+		"each backend owns its OriginConfig")
+	assert.Empty(t, cfg.Diagnostics)
+	require.NoError(t, cfg.Validate())
+
+	plain := proxy.ConvertHTTPRoutes(context.Background(), []*gatewayv1.HTTPRoute{originRoute(nil)},
+		"cluster.local", nil, nil, nil, nil)
+
+	for _, rule := range plain.Rules {
+		for _, backend := range rule.Backends {
+			assert.Nil(t, backend.Origin)
+		}
+	}
+}
+
+// TestConvertHTTPRoutes_InvalidConnectTimeout pins that a malformed or
+// non-positive timeout is dropped and reported once per route.
+func TestConvertHTTPRoutes_InvalidConnectTimeout(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"30", "soon", "0s", "-5s"} {
+		t.Run(value, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := proxy.ConvertHTTPRoutes(context.Background(), []*gatewayv1.HTTPRoute{
+				originRoute(map[string]string{proxy.ConnectTimeoutAnnotation: value}),
+			}, "cluster.local", nil, nil, nil, nil)
+
+			for _, rule := range cfg.Rules {
+				for _, backend := range rule.Backends {
+					assert.Nil(t, backend.Origin, "an invalid timeout must not be applied")
+				}
+			}
+
+			require.Len(t, cfg.Diagnostics, 1, "reported once per route, not once per rule")
+			assert.Equal(t, proxy.DiagnosticAccepted, cfg.Diagnostics[0].Target)
+			assert.Equal(t, string(gatewayv1.RouteReasonUnsupportedValue), cfg.Diagnostics[0].Reason)
+			assert.Contains(t, cfg.Diagnostics[0].Message, proxy.ConnectTimeoutAnnotation)
+		})
+	}
+}
+
+// TestConvertGRPCRoutes_ConnectTimeout pins that GRPCRoutes honour the
+// annotation like HTTPRoutes.
+func TestConvertGRPCRoutes_ConnectTimeout(t *testing.T) {
+	t.Parallel()
+
+	route := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "rpc",
+			Namespace:   "default",
+			Annotations: map[string]string{proxy.ConnectTimeoutAnnotation: "2s"},
+		},
+		Spec: gatewayv1.GRPCRouteSpec{
+			Rules: []gatewayv1.GRPCRouteRule{
+				{BackendRefs: []gatewayv1.GRPCBackendRef{grpcBackendRef("rpc-svc", 50051, 1)}},
+			},
+		},
+	}
+
+	cfg := proxy.ConvertGRPCRoutes(context.Background(), []*gatewayv1.GRPCRoute{route}, "cluster.local", nil, nil, nil, nil)
+
+	require.Len(t, cfg.Rules, 1)
+	require.Len(t, cfg.Rules[0].Backends, 1)
+	require.NotNil(t, cfg.Rules[0].Backends[0].Origin)
+	assert.Equal(t, 2*time.Second, cfg.Rules[0].Backends[0].Origin.ConnectTimeout)
+}
+
+// TestOriginConfig_JSONRoundTrip pins the human-readable wire format.
+func TestOriginConfig_JSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	original := proxy.BackendRef{
+		URL:    "http://svc:80",
+		Weight: 1,
+		Origin: &proxy.OriginConfig{
+			ConnectTimeout:      5 * time.Second,
+			TLSHandshakeTimeout: 10 * time.Second,
+			TCPKeepAlive:        time.Minute,
+			MaxIdleConnsPerHost: 50,
+		},
+	}
+
+	data, err := json.Marshal(original)
+	require.NoError(t, err)
+	assert.Contains(t, string(data),
+		`"origin":{"connectTimeout":"5s","tlsHandshakeTimeout":"10s","tcpKeepAlive":"1m0s","maxIdleConnsPerHost":50}`)
+
+	var decoded proxy.BackendRef
+
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, original, decoded)
+
+	require.Error(t, json.Unmarshal([]byte(`{"connectTimeout":"soon"}`), &proxy.OriginConfig{}))
+	require.Error(t, json.Unmarshal([]byte(`{"tcpKeepAlive":"often"}`), &proxy.OriginConfig{}))
+}
+
+// TestConfig_Validate_NegativeOrigin pins that a pushed config carrying a
+// negative origin setting is rejected by the proxy API.
+func TestConfig_Validate_NegativeOrigin(t *testing.T) {
+	t.Parallel()
+
+	origins := map[string]*proxy.OriginConfig{
+		"connectTimeout":      {ConnectTimeout: -time.Second},
+		"tlsHandshakeTimeout": {TLSHandshakeTimeout: -time.Second},
+		"tcpKeepAlive":        {TCPKeepAlive: -time.Second},
+		"maxIdleConnsPerHost": {MaxIdleConnsPerHost: -1},
+	}
+
+	for name, origin := range origins {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := &proxy.Config{Rules: []proxy.RouteRule{{
+				Backends: []proxy.BackendRef{{URL: "http://svc:80", Weight: 1, Origin: origin}},
+			}}}
+			require.Error(t, cfg.Validate())
+		})
+	}
+}
+
+// TestOriginDialer pins the dialer an OriginConfig builds: the connect timeout
+// replaces the http.DefaultTransport dial timeout, and unset fields keep it.
+func TestOriginDialer(t *testing.T) {
+	t.Parallel()
+
+	defaults := proxy.NewOriginDialerForTest(&proxy.OriginConfig{})
+	assert.Equal(t, 30*time.Second, defaults.Timeout)
+	assert.Equal(t, 30*time.Second, defaults.KeepAlive)
+
+	dialer := proxy.NewOriginDialerForTest(&proxy.OriginConfig{ConnectTimeout: 3 * time.Second, TCPKeepAlive: time.Minute})
+	assert.Equal(t, 3*time.Second, dialer.Timeout)
+	assert.Equal(t, time.Minute, dialer.KeepAlive)
+	assert.Zero(t, dialer.FallbackDelay)
+
+	disable := true
+	serial := proxy.NewOriginDialerForTest(&proxy.OriginConfig{DisableHappyEyeballs: &disable})
+	assert.Negative(t, serial.FallbackDelay, "a negative delay turns off Fast Fallback")
+}
+
+// TestOriginTransport pins the transport fields an OriginConfig sets on the
+// default and BackendTLSPolicy paths, and that unset fields keep the
+// http.DefaultTransport values.
+func TestOriginTransport(t *testing.T) {
+	t.Parallel()
+
+	base, ok := http.DefaultTransport.(*http.Transport)
+	require.True(t, ok)
+
+	origin := &proxy.OriginConfig{TLSHandshakeTimeout: 3 * time.Second, MaxIdleConnsPerHost: 50}
+	backendTLS := &proxy.BackendTLSConfig{ServerName: "backend.example.com"}
+
+	for name, backendTLS := range map[string]*proxy.BackendTLSConfig{"default": nil, "backend TLS": backendTLS} {
+		transport, ok := proxy.NewTransportWithOriginForTest(proxy.BackendProtocolHTTP, backendTLS, origin).(*http.Transport)
+		require.True(t, ok, name)
+		assert.Equal(t, 3*time.Second, transport.TLSHandshakeTimeout, name)
+		assert.Equal(t, 50, transport.MaxIdleConnsPerHost, name)
+	}
+
+	unset, ok := proxy.NewTransportWithOriginForTest(proxy.BackendProtocolHTTP, nil,
+		&proxy.OriginConfig{ConnectTimeout: time.Second}).(*http.Transport)
+	require.True(t, ok)
+	assert.Equal(t, base.TLSHandshakeTimeout, unset.TLSHandshakeTimeout)
+	assert.Equal(t, base.MaxIdleConnsPerHost, unset.MaxIdleConnsPerHost)
+}
+
+// TestTransportKey_DistinguishesByOrigin pins that origin settings split the
+// transport pool, and that a nil origin keeps the historic key.
+func TestTransportKey_DistinguishesByOrigin(t *testing.T) {
+	t.Parallel()
+
+	const host = "backend.example.com:8080"
+
+	plain := proxy.TransportKey(host, proxy.BackendProtocolHTTP, nil)
+	fast := proxy.TransportKeyWithOrigin(host, proxy.BackendProtocolHTTP, nil,
+		&proxy.OriginConfig{ConnectTimeout: time.Second})
+	slow := proxy.TransportKeyWithOrigin(host, proxy.BackendProtocolHTTP, nil,
+		&proxy.OriginConfig{ConnectTimeout: time.Minute})
+
+	assert.Equal(t, plain, proxy.TransportKeyWithOrigin(host, proxy.BackendProtocolHTTP, nil, nil))
+	assert.NotEqual(t, plain, fast)
+	assert.NotEqual(t, fast, slow)
+	assert.NotEqual(t, fast, proxy.TransportKeyWithOrigin(host, proxy.BackendProtocolHTTP, nil,
+		&proxy.OriginConfig{ConnectTimeout: time.Second, MaxIdleConnsPerHost: 50}))
+}
+
+// TestHandler_OriginConnectTimeout pins that a backend with an OriginConfig is
+// proxied through its own transport.
+func TestHandler_OriginConnectTimeout(t *testing.T) {
+	t.Parallel()
+
+	backend := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		_, _ = writer.Write([]byte("ok"))
+	}))
+	defer backend.Close()
+
+	router := proxy.NewRouter()
+	require.NoError(t, router.UpdateConfig(&proxy.Config{
+		Version: 1,
+		Rules: []proxy.RouteRule{{
+			Hostnames: []string{"slow.example.com"},
+			Backends: []proxy.BackendRef{{
+				URL:    backend.URL,
+				Weight: 1,
+				Origin: &proxy.OriginConfig{ConnectTimeout: 5 * time.Second},
+			}},
+		}},
+	}))
+
+	handler := proxy.NewHandler(router)
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "http://slow.example.com/", nil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "ok", recorder.Body.String())
+}
+
+// TestConvertHTTPRoutes_NoTLSVerify pins that the no-tls-verify annotation
+// lands on every backend, and that a non-boolean value is reported and
+// dropped.
+func TestConvertHTTPRoutes_NoTLSVerify(t *testing.T) {
+	t.Parallel()
+
+	cfg := proxy.ConvertHTTPRoutes(context.Background(), []*gatewayv1.HTTPRoute{
+		originRoute(map[string]string{proxy.NoTLSVerifyAnnotation: "true"}),
+	}, "cluster.local", nil, nil, nil, nil)
+
+	for _, rule := range cfg.Rules {
+		for _, backend := range rule.Backends {
+			require.NotNil(t, backend.Origin)
+			assert.True(t, backend.Origin.InsecureSkipVerify)
+		}
+	}
+
+	invalid := proxy.ConvertHTTPRoutes(context.Background(), []*gatewayv1.HTTPRoute{
+		originRoute(map[string]string{proxy.NoTLSVerifyAnnotation: "sure"}),
+	}, "cluster.local", nil, nil, nil, nil)
+
+	assert.Nil(t, invalid.Rules[0].Backends[0].Origin)
+	require.Len(t, invalid.Diagnostics, 1)
+	assert.Contains(t, invalid.Diagnostics[0].Message, proxy.NoTLSVerifyAnnotation)
+}
+
+// TestHandler_OriginInsecureSkipVerify pins that a self-signed https backend
+// is rejected by default and served once the route opts out of verification.
+func TestHandler_OriginInsecureSkipVerify(t *testing.T) {
+	t.Parallel()
+
+	backend := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		_, _ = writer.Write([]byte("ok"))
+	}))
+	defer backend.Close()
+
+	serve := func(origin *proxy.OriginConfig) *httptest.ResponseRecorder {
+		router := proxy.NewRouter()
+		require.NoError(t, router.UpdateConfig(&proxy.Config{
+			Version: 1,
+			Rules: []proxy.RouteRule{{
+				Hostnames: []string{"selfsigned.example.com"},
+				Backends:  []proxy.BackendRef{{URL: backend.URL, Weight: 1, Origin: origin}},
+			}},
+		}))
+
+		req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "http://selfsigned.example.com/", nil)
+		recorder := httptest.NewRecorder()
+		proxy.NewHandler(router).ServeHTTP(recorder, req)
+
+		return recorder
+	}
+
+	assert.Equal(t, http.StatusBadGateway, serve(nil).Code, "an unknown CA must fail verification")
+
+	recorder := serve(&proxy.OriginConfig{InsecureSkipVerify: true})
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "ok", recorder.Body.String())
+}
+
+// TestHandler_HTTPSBackendNegotiatesHTTP2 pins why there is no http2Origin
+// setting: the proxy offers h2 over ALPN to every https backend, on the
+// default transport and with a relaxed OriginConfig TLS config alike.
+func TestHandler_HTTPSBackendNegotiatesHTTP2(t *testing.T) {
+	t.Parallel()
+
+	backend := httptest.NewUnstartedServer(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		_, _ = writer.Write([]byte(req.Proto))
+	}))
+	backend.EnableHTTP2 = true
+	backend.StartTLS()
+	t.Cleanup(backend.Close)
+
+	router := proxy.NewRouter()
+	require.NoError(t, router.UpdateConfig(&proxy.Config{
+		Version: 1,
+		Rules: []proxy.RouteRule{{
+			Hostnames: []string{"h2.example.com"},
+			Backends: []proxy.BackendRef{{
+				URL:    backend.URL,
+				Weight: 1,
+				Origin: &proxy.OriginConfig{InsecureSkipVerify: true},
+			}},
+		}},
+	}))
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "http://h2.example.com/", nil)
+	recorder := httptest.NewRecorder()
+	proxy.NewHandler(router).ServeHTTP(recorder, req)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "HTTP/2.0", recorder.Body.String())
+}
+
+// TestConvertHTTPRoutes_OriginServerName pins that the annotation is
+// lower-cased onto every backend and that a value that is not a hostname is
+// reported and dropped.
+func TestConvertHTTPRoutes_OriginServerName(t *testing.T) {
+	t.Parallel()
+
+	cfg := proxy.ConvertHTTPRoutes(context.Background(), []*gatewayv1.HTTPRoute{
+		originRoute(map[string]string{proxy.OriginServerNameAnnotation: "Cert.Example.net"}),
+	}, "cluster.local", nil, nil, nil, nil)
+
+	for _, rule := range cfg.Rules {
+		for _, backend := range rule.Backends {
+			require.NotNil(t, backend.Origin)
+			assert.Equal(t, "cert.example.net", backend.Origin.ServerName)
+		}
+	}
+
+	invalid := proxy.ConvertHTTPRoutes(context.Background(), []*gatewayv1.HTTPRoute{
+		originRoute(map[string]string{proxy.OriginServerNameAnnotation: "https://cert.example.net"}),
+	}, "cluster.local", nil, nil, nil, nil)
+
+	assert.Nil(t, invalid.Rules[0].Backends[0].Origin)
+	require.Len(t, invalid.Diagnostics, 1)
+	assert.Contains(t, invalid.Diagnostics[0].Message, proxy.OriginServerNameAnnotation)
+}
+
+// TestHandler_OriginServerName pins that the proxy sends the configured name
+// as SNI to an https backend instead of the dialed host.
+func TestHandler_OriginServerName(t *testing.T) {
+	t.Parallel()
+
+	sni := make(chan string, 1)
+
+	backend := httptest.NewUnstartedServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		_, _ = writer.Write([]byte("ok"))
+	}))
+	backend.TLS = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			select {
+			case sni <- hello.ServerName:
+			default:
+			}
+
+			return nil, nil
+		},
+	}
+	backend.StartTLS()
+	defer backend.Close()
+
+	router := proxy.NewRouter()
+	require.NoError(t, router.UpdateConfig(&proxy.Config{
+		Version: 1,
+		Rules: []proxy.RouteRule{{
+			Hostnames: []string{"sni.example.com"},
+			Backends: []proxy.BackendRef{{
+				URL:    backend.URL,
+				Weight: 1,
+				Origin: &proxy.OriginConfig{InsecureSkipVerify: true, ServerName: "cert.example.net"},
+			}},
+		}},
+	}))
+
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "http://sni.example.com/", nil)
+	recorder := httptest.NewRecorder()
+	proxy.NewHandler(router).ServeHTTP(recorder, req)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "cert.example.net", <-sni)
+}
+
+// TestConvertHTTPRoutes_DisableHappyEyeballs pins that both values of the
+// annotation reach the backends, so "false" can override a class default.
+func TestConvertHTTPRoutes_DisableHappyEyeballs(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []bool{true, false} {
+		cfg := proxy.ConvertHTTPRoutes(context.Background(), []*gatewayv1.HTTPRoute{
+			originRoute(map[string]string{proxy.DisableHappyEyeballsAnnotation: strconv.FormatBool(value)}),
+		}, "cluster.local", nil, nil, nil, nil)
+
+		origin := cfg.Rules[0].Backends[0].Origin
+		require.NotNil(t, origin)
+		require.NotNil(t, origin.DisableHappyEyeballs)
+		assert.Equal(t, value, *origin.DisableHappyEyeballs)
+	}
+
+	invalid := proxy.ConvertHTTPRoutes(context.Background(), []*gatewayv1.HTTPRoute{
+		originRoute(map[string]string{proxy.DisableHappyEyeballsAnnotation: "maybe"}),
+	}, "cluster.local", nil, nil, nil, nil)
+
+	assert.Nil(t, invalid.Rules[0].Backends[0].Origin)
+	require.Len(t, invalid.Diagnostics, 1)
+	assert.Contains(t, invalid.Diagnostics[0].Message, proxy.DisableHappyEyeballsAnnotation)
+}
+
+// TestConfig_ApplyOriginDefaults pins the class-level defaults: they fill
+// every backend, the fallback included, and a route annotation wins over
+// them in both directions.
+func TestConfig_ApplyOriginDefaults(t *testing.T) {
+	t.Parallel()
+
+	disable, keep := true, false
+
+	cfg := &proxy.Config{
+		Rules: []proxy.RouteRule{{
+			Backends: []proxy.BackendRef{
+				{URL: "http://plain:80", Weight: 1},
+				{URL: "http://dual:80", Weight: 1, Origin: &proxy.OriginConfig{DisableHappyEyeballs: &keep}},
+				{URL: "https://fast:443", Weight: 1, Origin: &proxy.OriginConfig{
+					ConnectTimeout: time.Second,
+					ServerName:     "cert.example.net",
+				}},
+			},
+		}},
+		Fallback: &proxy.RouteRule{Backends: []proxy.BackendRef{{URL: "http://fallback:80", Weight: 1}}},
+	}
+
+	cfg.ApplyOriginDefaults(proxy.OriginConfig{
+		ConnectTimeout:       10 * time.Second,
+		DisableHappyEyeballs: &disable,
+		MaxIdleConnsPerHost:  50,
+	})
+
+	backends := cfg.Rules[0].Backends
+	assert.Equal(t, &proxy.OriginConfig{
+		ConnectTimeout:       10 * time.Second,
+		DisableHappyEyeballs: &disable,
+		MaxIdleConnsPerHost:  50,
+	}, backends[0].Origin)
+	assert.Equal(t, &proxy.OriginConfig{
+		ConnectTimeout:       10 * time.Second,
+		DisableHappyEyeballs: &keep,
+		MaxIdleConnsPerHost:  50,
+	}, backends[1].Origin, "a route's \"false\" overrides the class default")
+	assert.Equal(t, &proxy.OriginConfig{
+		ConnectTimeout:       time.Second,
+		ServerName:           "cert.example.net",
+		DisableHappyEyeballs: &disable,
+		MaxIdleConnsPerHost:  50,
+	}, backends[2].Origin)
+	assert.Equal(t, backends[0].Origin, cfg.Fallback.Backends[0].Origin)
+
+	untouched := &proxy.Config{Rules: []proxy.RouteRule{{Backends: []proxy.BackendRef{{URL: "http://plain:80", Weight: 1}}}}}
+	untouched.ApplyOriginDefaults(proxy.OriginConfig{})
+	assert.Nil(t, untouched.Rules[0].Backends[0].Origin, "no defaults keep the historic transport key")
+}
@@ -5,6 +5,7 @@ import (
 	"math/rand/v2"
 	"net/http"
 	"net/url"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -44,7 +45,13 @@ type routingTable struct {
 	exactHosts    map[string][]*compiledRule
 	wildcardHosts []wildcardEntry
 	defaultRules  []*compiledRule
-	version       int64
+	// fallback serves requests no rule matched; nil means 404.
+	fallback *compiledRule
+	// grpcUnavailableHosts and grpcUnavailableSuffixes hold
+	// Config.GRPCUnavailableHostnames, split like the rule hostnames.
+	grpcUnavailableHosts    map[string]bool
+	grpcUnavailableSuffixes []string
+	version                 int64
 }
 
 // wildcardEntry maps a wildcard suffix to its compiled rules.
@@ -210,6 +217,41 @@ func (r *Router) Route(req *http.Request) *RouteResult {
 	return nil
 }
 
+// Fallback returns the routing result for the config's Fallback rule, or nil
+// when none is configured. The handler consults it only after Route found no
+// match, so every configured rule takes precedence.
+func (r *Router) Fallback(req *http.Request) *RouteResult {
+	table := r.table.Load()
+	if table == nil || table.fallback == nil {
+		return nil
+	}
+
+	return matchRules([]*compiledRule{table.fallback}, req)
+}
+
+// GRPCUnavailable reports whether a gRPC call that matched no rule must
+// answer Unavailable rather than Unimplemented, because its host is one of the
+// config's GRPCUnavailableHostnames.
+func (r *Router) GRPCUnavailable(req *http.Request) bool {
+	table := r.table.Load()
+	if table == nil {
+		return false
+	}
+
+	host := extractHost(req)
+	if table.grpcUnavailableHosts[host] {
+		return true
+	}
+
+	for _, suffix := range table.grpcUnavailableSuffixes {
+		if matchesWildcard(host, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // UpdateConfig compiles a new routing table from the config and atomically swaps it in.
 // Rejects configs with a version older than the current one to prevent out-of-order updates.
 // Thread-safe: concurrent calls are serialized internally.
@@ -289,7 +331,7 @@ func (r *Router) warnGRPCRestartIfNeeded(cfg *Config) {
 
 // extractActiveTransportKeys collects all backend transport-pool keys from the
 // config's rules. Keys are formed by transportKey(host, protocol, tls,
-// headerTimeout) so PruneTransports can evict stale entries when any of those
+// origin, headerTimeout) so PruneTransports can evict stale entries when any of those
 // change (e.g. on a Service appProtocol flip, a BackendTLSPolicy swap, or a
 // per-rule timeouts edit). The header timeout is derived from
 // rule.Timeouts the same way getTransport's callers derive it -- see
@@ -305,7 +347,12 @@ func (r *Router) warnGRPCRestartIfNeeded(cfg *Config) {
 func extractActiveTransportKeys(cfg *Config) map[string]bool {
 	keys := make(map[string]bool)
 
-	for _, rule := range cfg.Rules {
+	rules := cfg.Rules
+	if cfg.Fallback != nil {
+		rules = append(slices.Clip(rules), *cfg.Fallback)
+	}
+
+	for _, rule := range rules {
 		headerTimeout := ruleHeaderTimeout(rule.Timeouts)
 
 		for _, backend := range rule.Backends {
@@ -314,7 +361,7 @@ func extractActiveTransportKeys(cfg *Config) map[string]bool {
 				continue
 			}
 
-			keys[transportKey(parsed.Host, backend.Protocol, backend.TLS, headerTimeout)] = true
+			keys[transportKey(parsed.Host, backend.Protocol, backend.TLS, backend.Origin, headerTimeout)] = true
 
 			collectMirrorTransportKeys(keys, backend.Filters)
 		}
@@ -387,7 +434,7 @@ func collectMirrorTransportKeys(keys map[string]bool, filters []RouteFilter) {
 			continue
 		}
 
-		keys[transportKey(parsed.Host, filter.RequestMirror.Protocol, filter.RequestMirror.TLS, 0)] = true
+		keys[transportKey(parsed.Host, filter.RequestMirror.Protocol, filter.RequestMirror.TLS, nil, 0)] = true
 	}
 }
 
@@ -450,6 +497,33 @@ func compileRoutingTable(cfg *Config, factory TransportFactory) (*routingTable,
 
 	sortRulesByPrecedence(table.defaultRules)
 
+	if cfg.Fallback != nil {
+		fallback, err := compileRule(cfg.Fallback, len(cfg.Rules), factory)
+		if err != nil {
+			return nil, errors.Wrap(err, "fallback")
+		}
+
+		// Hostnames and matches are ignored: the fallback takes whatever the
+		// routing table did not.
+		fallback.matches = nil
+		table.fallback = fallback
+	}
+
+	for _, hostname := range cfg.GRPCUnavailableHostnames {
+		normalized := strings.ToLower(hostname)
+		if strings.HasPrefix(normalized, "*.") {
+			table.grpcUnavailableSuffixes = append(table.grpcUnavailableSuffixes, normalized[1:])
+
+			continue
+		}
+
+		if table.grpcUnavailableHosts == nil {
+			table.grpcUnavailableHosts = make(map[string]bool)
+		}
+
+		table.grpcUnavailableHosts[normalized] = true
+	}
+
 	return table, nil
 }
 
@@ -1579,3 +1579,40 @@ func TestRouter_QueryParamCountDominatesRuleIndex(t *testing.T) {
 		})
 	}
 }
+
+// TestRouter_Fallback pins the defaultBackend fallback: Route still misses
+// for an unmatched host, Fallback serves it regardless of the fallback
+// rule's own hostnames, and no fallback means nil (the handler's 404).
+func TestRouter_Fallback(t *testing.T) {
+	t.Parallel()
+
+	router := proxy.NewRouter()
+
+	req := &http.Request{
+		Method: http.MethodGet,
+		Host:   "unknown.example.com",
+		URL:    &url.URL{Path: "/"},
+		Header: http.Header{},
+	}
+
+	require.NoError(t, router.UpdateConfig(&proxy.Config{Version: 1}))
+	assert.Nil(t, router.Fallback(req), "no fallback configured keeps the 404")
+
+	require.NoError(t, router.UpdateConfig(&proxy.Config{
+		Version: 2,
+		Rules: []proxy.RouteRule{{
+			Hostnames: []string{"app.example.com"},
+			Backends:  []proxy.BackendRef{{URL: "http://app:80", Weight: 1}},
+		}},
+		Fallback: &proxy.RouteRule{
+			Hostnames: []string{"ignored.example.com"},
+			Backends:  []proxy.BackendRef{{URL: "http://maintenance.web.svc.cluster.local:8080", Weight: 1}},
+		},
+	}))
+
+	assert.Nil(t, router.Route(req))
+
+	result := router.Fallback(req)
+	require.NotNil(t, result)
+	assert.Equal(t, "http://maintenance.web.svc.cluster.local:8080", result.Rule.Backends[0].URL)
+}
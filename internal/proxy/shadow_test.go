@@ -173,9 +173,9 @@ func TestDetectShadowedRules_FlatIndexTieReportsConfigOrder(t *testing.T) {
 
 // TestDetectShadowedRules_NoFalsePositives pins the deliberately-out-of-scope
 // cases: same-route duplicates (within-route first-wins is spec'd separately),
-// different hostnames, prefix-vs-exact on the same path value, and
-// wildcard-vs-exact hostnames. None of these is the deterministic zero-traffic
-// collision the condition exists for.
+// different hostnames, different paths on a shared hostname, prefix-vs-exact
+// on the same path value, and wildcard-vs-exact hostnames. None of these is the
+// deterministic zero-traffic collision the condition exists for.
 func TestDetectShadowedRules_NoFalsePositives(t *testing.T) {
 	t.Parallel()
 
@@ -209,6 +209,19 @@ func TestDetectShadowedRules_NoFalsePositives(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "same hostname, different paths",
+			cfg: &proxy.Config{
+				Rules: []proxy.RouteRule{
+					{Hostnames: []string{"a.example.com"}, Matches: []proxy.RouteMatch{pathPrefixMatch("/api")}},
+					{Hostnames: []string{"a.example.com"}, Matches: []proxy.RouteMatch{pathPrefixMatch("/web")}},
+				},
+				Provenance: []proxy.RuleProvenance{
+					prov("HTTPRoute", "ns", "api", shadowT0, 0),
+					prov("HTTPRoute", "ns", "web", shadowT1, 0),
+				},
+			},
+		},
 		{
 			name: "prefix versus exact on the same path",
 			cfg: &proxy.Config{
@@ -35,8 +35,8 @@ func Autoscaler(input *Input) *autoscalingv2.HorizontalPodAutoscaler {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        DeploymentName(input.Gateway),
 			Namespace:   input.Gateway.Namespace,
-			Labels:      resourceLabels(input.Gateway),
-			Annotations: resourceAnnotations(input.Gateway),
+			Labels:      resourceLabels(input.Gateway, input.Defaults.CommonMetadata),
+			Annotations: resourceAnnotations(input.Gateway, input.Defaults.CommonMetadata),
 		},
 		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
 			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
@@ -0,0 +1,45 @@
+package render
+
+import (
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// DisruptionBudget renders the per-Gateway PodDisruptionBudget when
+// spec.podDisruptionBudget.enabled is set and the proxy runs more than one
+// replica; nil otherwise. minAvailable: 1 keeps a tunnel connector up
+// through a node drain. On a single replica the same budget would block the
+// drain outright, so it is not rendered there.
+func DisruptionBudget(input *Input) *policyv1.PodDisruptionBudget {
+	budget := input.Config.Spec.PodDisruptionBudget
+	if budget == nil || !budget.Enabled || minimumReplicas(input) <= 1 {
+		return nil
+	}
+
+	minAvailable := intstr.FromInt32(1)
+
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        DeploymentName(input.Gateway),
+			Namespace:   input.Gateway.Namespace,
+			Labels:      resourceLabels(input.Gateway, input.Defaults.CommonMetadata),
+			Annotations: resourceAnnotations(input.Gateway, input.Defaults.CommonMetadata),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector:     &metav1.LabelSelector{MatchLabels: selectorLabels(input.Gateway)},
+		},
+	}
+}
+
+// minimumReplicas is the fewest replicas the proxy Deployment runs: the
+// fixed count, or the autoscaler's floor (clamped to its ceiling, as in
+// Autoscaler).
+func minimumReplicas(input *Input) int32 {
+	if auto := input.Config.Spec.Autoscaling; auto != nil {
+		return min(auto.EffectiveMinReplicas(), auto.MaxReplicas)
+	}
+
+	return *replicaCount(input.Config)
+}
@@ -0,0 +1,79 @@
+package render_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/cloudflare-tunnel-gateway-controller/internal/render"
+)
+
+// TestDisruptionBudget_Shape pins the budget contract: minAvailable 1 over
+// the rendered Deployment's pods, named like the Deployment.
+func TestDisruptionBudget_Shape(t *testing.T) {
+	t.Parallel()
+
+	input := testInput("edge")
+	input.Config.Spec.PodDisruptionBudget = &v1alpha1.ProxyPodDisruptionBudget{Enabled: true}
+
+	pdb := render.DisruptionBudget(input)
+	require.NotNil(t, pdb, "the default two replicas get a budget")
+
+	assert.Equal(t, "cf-proxy-edge", pdb.Name)
+	assert.Equal(t, "tenant-a", pdb.Namespace)
+	require.NotNil(t, pdb.Spec.MinAvailable)
+	assert.Equal(t, 1, pdb.Spec.MinAvailable.IntValue())
+	assert.Nil(t, pdb.Spec.MaxUnavailable)
+
+	deployment := render.ProxyDeployment(input)
+	require.NotNil(t, pdb.Spec.Selector)
+	assert.Equal(t, deployment.Spec.Selector.MatchLabels, pdb.Spec.Selector.MatchLabels)
+}
+
+// TestDisruptionBudget_OnlyWithSeveralReplicas pins the opt-in and the
+// single-replica guard: a budget on one replica would block every drain.
+func TestDisruptionBudget_OnlyWithSeveralReplicas(t *testing.T) {
+	t.Parallel()
+
+	one, three := int32(1), int32(3)
+
+	tests := []struct {
+		name        string
+		budget      *v1alpha1.ProxyPodDisruptionBudget
+		replicas    *int32
+		autoscaling *v1alpha1.ProxyAutoscaling
+		want        bool
+	}{
+		{name: "not configured"},
+		{name: "disabled", budget: &v1alpha1.ProxyPodDisruptionBudget{}},
+		{name: "default replicas", budget: &v1alpha1.ProxyPodDisruptionBudget{Enabled: true}, want: true},
+		{name: "three replicas", budget: &v1alpha1.ProxyPodDisruptionBudget{Enabled: true}, replicas: &three, want: true},
+		{name: "single replica", budget: &v1alpha1.ProxyPodDisruptionBudget{Enabled: true}, replicas: &one},
+		{
+			name:        "autoscaling from one replica",
+			budget:      &v1alpha1.ProxyPodDisruptionBudget{Enabled: true},
+			autoscaling: &v1alpha1.ProxyAutoscaling{MinReplicas: &one, MaxReplicas: 5, TargetInflightPerPod: 10},
+		},
+		{
+			name:        "autoscaling from the default floor",
+			budget:      &v1alpha1.ProxyPodDisruptionBudget{Enabled: true},
+			autoscaling: &v1alpha1.ProxyAutoscaling{MaxReplicas: 5, TargetInflightPerPod: 10},
+			want:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := testInput("edge")
+			input.Config.Spec.PodDisruptionBudget = tt.budget
+			input.Config.Spec.Replicas = tt.replicas
+			input.Config.Spec.Autoscaling = tt.autoscaling
+
+			assert.Equal(t, tt.want, render.DisruptionBudget(input) != nil)
+		})
+	}
+}
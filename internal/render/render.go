@@ -93,6 +93,18 @@ type Defaults struct {
 	// TunnelProtocol is the proxy's edge transport (--tunnel-protocol).
 	// "auto"/"" is the binary default and is not rendered.
 	TunnelProtocol string
+	// CommonMetadata is stamped on every rendered resource
+	// (--common-labels, --common-annotations).
+	CommonMetadata Metadata
+}
+
+// Metadata is a label and annotation set the operator asks the controller to
+// stamp on every object it creates, for GitOps and ownership tooling. It sits
+// underneath everything else: Gateway infrastructure metadata overrides it,
+// and controller-owned keys override both.
+type Metadata struct {
+	Labels      map[string]string
+	Annotations map[string]string
 }
 
 // NetworkPolicyInput carries the controller-level config the per-Gateway
@@ -108,6 +120,8 @@ type NetworkPolicyInput struct {
 	// port (which also serves /metrics) from matching namespaces so Prometheus
 	// can scrape. Nil = controller namespace only.
 	MonitoringNamespaceSelector *metav1.LabelSelector
+	// CommonMetadata is the controller-wide metadata (see Defaults).
+	CommonMetadata Metadata
 }
 
 // Input is everything a render pass needs.
@@ -182,11 +196,15 @@ func selectorLabels(gateway *gatewayv1.Gateway) map[string]string {
 	}
 }
 
-// resourceLabels is the full label set for rendered resource metadata:
-// infrastructure.labels first (Gateway API SHOULD), controller-owned keys on
-// top so a tenant cannot spoof the selector or management markers.
-func resourceLabels(gateway *gatewayv1.Gateway) map[string]string {
-	labels := make(map[string]string)
+// resourceLabels is the full label set for rendered resource metadata: the
+// controller-wide common labels, then infrastructure.labels (Gateway API
+// SHOULD), controller-owned keys on top so neither can spoof the selector or
+// management markers.
+func resourceLabels(gateway *gatewayv1.Gateway, common Metadata) map[string]string {
+	labels := maps.Clone(common.Labels)
+	if labels == nil {
+		labels = make(map[string]string)
+	}
 
 	if gateway.Spec.Infrastructure != nil {
 		for key, value := range gateway.Spec.Infrastructure.Labels {
@@ -211,25 +229,45 @@ func resourceLabels(gateway *gatewayv1.Gateway) map[string]string {
 	return labels
 }
 
-// resourceAnnotations propagates infrastructure.annotations.
-func resourceAnnotations(gateway *gatewayv1.Gateway) map[string]string {
-	if gateway.Spec.Infrastructure == nil || len(gateway.Spec.Infrastructure.Annotations) == 0 {
+// resourceAnnotations propagates the controller-wide common annotations and
+// infrastructure.annotations, the latter winning on a shared key.
+func resourceAnnotations(gateway *gatewayv1.Gateway, common Metadata) map[string]string {
+	var infra map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue
+	if gateway.Spec.Infrastructure != nil {
+		infra = gateway.Spec.Infrastructure.Annotations
+	}
+
+	if len(common.Annotations) == 0 && len(infra) == 0 {
 		return nil
 	}
 
-	annotations := make(map[string]string, len(gateway.Spec.Infrastructure.Annotations))
-	for key, value := range gateway.Spec.Infrastructure.Annotations {
+	annotations := make(map[string]string, len(common.Annotations)+len(infra))
+	maps.Copy(annotations, common.Annotations)
+
+	for key, value := range infra {
 		annotations[string(key)] = string(value)
 	}
 
 	return annotations
 }
 
+// GeneratedSecretMetadata returns the metadata for a controller-created
+// Secret: the common set alone. Clones, so the caller may mutate the result.
+func GeneratedSecretMetadata(common Metadata) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Labels:      maps.Clone(common.Labels),
+		Annotations: maps.Clone(common.Annotations),
+	}
+}
+
 // ProxyDeployment renders the per-Gateway proxy Deployment. Input is taken by
 // pointer: it is at the gocritic hugeParam budget, and both rotation tokens
 // live in it.
 func ProxyDeployment(input *Input) *appsv1.Deployment {
-	podAnnotations := resourceAnnotations(input.Gateway)
+	// The common metadata stays off the pod template: the controller creates
+	// the Deployment, not its pods, and editing the flag must not roll every
+	// data plane in the cluster.
+	podAnnotations := resourceAnnotations(input.Gateway, Metadata{})
 	if podAnnotations == nil {
 		podAnnotations = make(map[string]string, 2)
 	}
@@ -246,12 +284,12 @@ func ProxyDeployment(input *Input) *appsv1.Deployment {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      DeploymentName(input.Gateway),
 			Namespace: input.Gateway.Namespace,
-			Labels:    resourceLabels(input.Gateway),
+			Labels:    resourceLabels(input.Gateway, input.Defaults.CommonMetadata),
 			// A fresh resourceAnnotations call (not podAnnotations): the
 			// Deployment object's OWN annotations must NOT carry the pod
 			// template's rotation-hash entries, and resourceAnnotations returns
 			// an independent map so this cannot alias the mutated podAnnotations.
-			Annotations: resourceAnnotations(input.Gateway),
+			Annotations: resourceAnnotations(input.Gateway, input.Defaults.CommonMetadata),
 		},
 		Spec: appsv1.DeploymentSpec{
 			Replicas: replicaCount(input.Config),
@@ -272,7 +310,7 @@ func ProxyDeployment(input *Input) *appsv1.Deployment {
 			ProgressDeadlineSeconds: new(progressDeadlineSeconds),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      resourceLabels(input.Gateway),
+					Labels:      resourceLabels(input.Gateway, Metadata{}),
 					Annotations: podAnnotations,
 				},
 				Spec: corev1.PodSpec{
@@ -476,8 +514,8 @@ func ConfigService(input *Input) *corev1.Service {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        ConfigServiceName(input.Gateway),
 			Namespace:   input.Gateway.Namespace,
-			Labels:      resourceLabels(input.Gateway),
-			Annotations: resourceAnnotations(input.Gateway),
+			Labels:      resourceLabels(input.Gateway, input.Defaults.CommonMetadata),
+			Annotations: resourceAnnotations(input.Gateway, input.Defaults.CommonMetadata),
 		},
 		Spec: corev1.ServiceSpec{
 			Type:                     corev1.ServiceTypeClusterIP,
@@ -522,8 +560,8 @@ func ProxyNetworkPolicy(input NetworkPolicyInput) *networkingv1.NetworkPolicy {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        NetworkPolicyName(input.Gateway),
 			Namespace:   input.Gateway.Namespace,
-			Labels:      resourceLabels(input.Gateway),
-			Annotations: resourceAnnotations(input.Gateway),
+			Labels:      resourceLabels(input.Gateway, input.CommonMetadata),
+			Annotations: resourceAnnotations(input.Gateway, input.CommonMetadata),
 		},
 		Spec: networkingv1.NetworkPolicySpec{
 			PodSelector: metav1.LabelSelector{MatchLabels: selectorLabels(input.Gateway)},
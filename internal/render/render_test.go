@@ -633,6 +633,64 @@ func TestProxyDeployment_InfrastructureLabelsPropagate(t *testing.T) {
 	assert.Equal(t, "edge", deployment.Spec.Template.Labels["cf.k8s.lex.la/gateway"])
 }
 
+// TestRender_CommonMetadataPropagates pins the controller-wide common
+// metadata: stamped on every rendered object, overridden by infrastructure
+// metadata and controller-owned keys, and kept off the pod template so
+// editing it never rolls the data plane.
+func TestRender_CommonMetadataPropagates(t *testing.T) {
+	t.Parallel()
+
+	common := render.Metadata{
+		Labels: map[string]string{
+			"team":                   "platform",
+			"argocd.argoproj.io/app": "edge",
+			"cf.k8s.lex.la/gateway":  "spoofed",
+		},
+		Annotations: map[string]string{"owner": "sre", "note": "common"},
+	}
+
+	input := testInput("edge")
+	input.Defaults.CommonMetadata = common
+	input.Gateway.Spec.Infrastructure = &gatewayv1.GatewayInfrastructure{
+		Labels:      map[gatewayv1.LabelKey]gatewayv1.LabelValue{"team": "a"},
+		Annotations: map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue{"note": "tenant-supplied"},
+	}
+
+	deployment := render.ProxyDeployment(input)
+	service := render.ConfigService(input)
+	netpol := render.ProxyNetworkPolicy(render.NetworkPolicyInput{
+		Gateway:             input.Gateway,
+		ControllerNamespace: "cf-system",
+		CommonMetadata:      common,
+	})
+
+	for name, meta := range map[string]metav1.ObjectMeta{
+		"deployment":    deployment.ObjectMeta,
+		"service":       service.ObjectMeta,
+		"networkpolicy": netpol.ObjectMeta,
+	} {
+		assert.Equal(t, "edge", meta.Labels["argocd.argoproj.io/app"], "%s carries the common label", name)
+		assert.Equal(t, "sre", meta.Annotations["owner"], "%s carries the common annotation", name)
+		assert.Equal(t, "a", meta.Labels["team"], "%s: infrastructure labels win over common labels", name)
+		assert.Equal(t, "tenant-supplied", meta.Annotations["note"],
+			"%s: infrastructure annotations win over common annotations", name)
+		assert.Equal(t, "edge", meta.Labels[render.GatewayLabel],
+			"%s: controller-owned label keys win over common labels", name)
+	}
+
+	assert.NotContains(t, deployment.Spec.Template.Labels, "argocd.argoproj.io/app",
+		"common labels must not reach the pod template")
+	assert.NotContains(t, deployment.Spec.Template.Annotations, "owner",
+		"common annotations must not reach the pod template")
+
+	secretMeta := render.GeneratedSecretMetadata(common)
+	assert.Equal(t, common.Labels, secretMeta.Labels)
+	assert.Equal(t, common.Annotations, secretMeta.Annotations)
+
+	secretMeta.Labels["mutated"] = "yes"
+	assert.NotContains(t, common.Labels, "mutated", "the Secret metadata must not alias the common set")
+}
+
 // TestProxyDeployment_ExplicitEmptyResourcesDropDefaults pins that a non-nil
 // empty `resources: {}` is a deliberate opt-out of the chart-parity defaults,
 // not a request to re-apply them — the rendered container carries no
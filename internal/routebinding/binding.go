@@ -166,6 +166,12 @@ func (v *Validator) evaluateListenerBinding(
 	parentNamespace string,
 	route *RouteInfo,
 ) (gatewayv1.RouteConditionReason, error) {
+	// A listener this controller cannot serve is not accepted, so nothing
+	// may attach to it, whatever its allowedRoutes admit.
+	if !ServableProtocol(protocol) {
+		return gatewayv1.RouteReasonNotAllowedByListeners, nil
+	}
+
 	if !HostnamesIntersect(listenerHostname, route.Hostnames) {
 		return gatewayv1.RouteReasonNoMatchingListenerHostname, nil
 	}
@@ -59,6 +59,37 @@ func TestValidateBinding(t *testing.T) {
 			expectedReason:   gatewayv1.RouteReasonAccepted,
 			expectedMatched:  []gatewayv1.SectionName{"http"},
 		},
+		{
+			name: "TCPRoute rejected - TCP listener has no data plane",
+			gateway: &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-gateway",
+					Namespace: "default",
+				},
+				Spec: gatewayv1.GatewaySpec{
+					Listeners: []gatewayv1.Listener{
+						{
+							Name:     "tcp",
+							Port:     5432,
+							Protocol: gatewayv1.TCPProtocolType,
+							AllowedRoutes: &gatewayv1.AllowedRoutes{
+								Namespaces: &gatewayv1.RouteNamespaces{
+									From: &fromAll,
+								},
+							},
+						},
+					},
+				},
+			},
+			route: &RouteInfo{
+				Name:      "test-route",
+				Namespace: "default",
+				Kind:      "TCPRoute",
+			},
+			expectedAccepted: false,
+			expectedReason:   gatewayv1.RouteReasonNotAllowedByListeners,
+			expectedMatched:  nil,
+		},
 		{
 			name: "route rejected - hostname mismatch",
 			gateway: &gatewayv1.Gateway{
@@ -646,3 +677,74 @@ func TestValidateBinding_SelectorErrorPropagates(t *testing.T) {
 	require.Error(t, err, "an invalid AllowedRoutes selector must propagate as an error, not a silent rejection")
 	assert.Contains(t, err.Error(), "invalid label selector")
 }
+
+// TestValidateBinding_WildcardHostnames pins listener/route hostname
+// intersection end to end through ValidateBinding for the four
+// exact/wildcard combinations, including multi-label subdomains, the apex
+// exclusion, and label-boundary matching.
+func TestValidateBinding_WildcardHostnames(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		listenerHost  gatewayv1.Hostname
+		routeHosts    []gatewayv1.Hostname
+		expectedBound bool
+	}{
+		{name: "exact vs exact equal", listenerHost: "app.example.com", routeHosts: []gatewayv1.Hostname{"app.example.com"}, expectedBound: true},
+		{name: "exact vs exact different", listenerHost: "app.example.com", routeHosts: []gatewayv1.Hostname{"web.example.com"}},
+		{name: "exact vs exact is case-insensitive", listenerHost: "App.Example.com", routeHosts: []gatewayv1.Hostname{"app.example.COM"}, expectedBound: true},
+
+		{name: "wildcard listener vs single-label subdomain", listenerHost: "*.example.com", routeHosts: []gatewayv1.Hostname{"app.example.com"}, expectedBound: true},
+		{name: "wildcard listener vs multi-label subdomain", listenerHost: "*.example.com", routeHosts: []gatewayv1.Hostname{"a.b.c.example.com"}, expectedBound: true},
+		{name: "wildcard listener vs apex", listenerHost: "*.example.com", routeHosts: []gatewayv1.Hostname{"example.com"}},
+		{name: "wildcard listener vs label-boundary lookalike", listenerHost: "*.example.com", routeHosts: []gatewayv1.Hostname{"badexample.com"}},
+		{name: "wildcard listener vs other domain", listenerHost: "*.example.com", routeHosts: []gatewayv1.Hostname{"app.example.net"}},
+
+		{name: "exact listener vs wildcard route", listenerHost: "app.example.com", routeHosts: []gatewayv1.Hostname{"*.example.com"}, expectedBound: true},
+		{name: "multi-label exact listener vs wildcard route", listenerHost: "a.b.example.com", routeHosts: []gatewayv1.Hostname{"*.example.com"}, expectedBound: true},
+		{name: "apex listener vs wildcard route", listenerHost: "example.com", routeHosts: []gatewayv1.Hostname{"*.example.com"}},
+		{name: "exact listener vs nested wildcard route above it", listenerHost: "app.example.com", routeHosts: []gatewayv1.Hostname{"*.dev.example.com"}},
+
+		{name: "wildcard vs equal wildcard", listenerHost: "*.example.com", routeHosts: []gatewayv1.Hostname{"*.example.com"}, expectedBound: true},
+		{name: "wildcard listener vs nested wildcard route", listenerHost: "*.example.com", routeHosts: []gatewayv1.Hostname{"*.dev.example.com"}, expectedBound: true},
+		{name: "nested wildcard listener vs broader wildcard route", listenerHost: "*.dev.example.com", routeHosts: []gatewayv1.Hostname{"*.example.com"}, expectedBound: true},
+		{name: "sibling wildcards", listenerHost: "*.dev.example.com", routeHosts: []gatewayv1.Hostname{"*.prod.example.com"}},
+		{name: "wildcard vs label-boundary lookalike wildcard", listenerHost: "*.example.com", routeHosts: []gatewayv1.Hostname{"*.badexample.com"}},
+
+		{name: "one of several route hostnames intersects", listenerHost: "*.example.com", routeHosts: []gatewayv1.Hostname{"example.net", "example.com", "api.example.com"}, expectedBound: true},
+	}
+
+	fromAll := gatewayv1.NamespacesFromAll
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gateway := &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-gateway", Namespace: "default"},
+				Spec: gatewayv1.GatewaySpec{
+					Listeners: []gatewayv1.Listener{{
+						Name:          "http",
+						Port:          80,
+						Protocol:      gatewayv1.HTTPProtocolType,
+						Hostname:      ptr(tt.listenerHost),
+						AllowedRoutes: &gatewayv1.AllowedRoutes{Namespaces: &gatewayv1.RouteNamespaces{From: &fromAll}},
+					}},
+				},
+			}
+			route := &RouteInfo{Name: "test-route", Namespace: "default", Hostnames: tt.routeHosts, Kind: KindHTTPRoute}
+
+			result, err := NewValidator(setupFakeClient()).ValidateBinding(context.Background(), gateway, route)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedBound, result.Accepted)
+
+			if tt.expectedBound {
+				assert.Equal(t, gatewayv1.RouteReasonAccepted, result.Reason)
+			} else {
+				assert.Equal(t, gatewayv1.RouteReasonNoMatchingListenerHostname, result.Reason)
+			}
+		})
+	}
+}
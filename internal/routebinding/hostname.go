@@ -10,7 +10,8 @@ import (
 // Per Gateway API spec:
 //   - If listener has no hostname (nil or empty), it accepts all routes.
 //   - If route has no hostnames (nil or empty), it matches any listener.
-//   - Otherwise, at least one hostname must match.
+//   - Otherwise, at least one hostname must match (see hostnameMatches for
+//     the exact/wildcard rules).
 func HostnamesIntersect(listenerHostname *gatewayv1.Hostname, routeHostnames []gatewayv1.Hostname) bool {
 	if listenerHostname == nil || *listenerHostname == "" {
 		return true
@@ -94,6 +95,18 @@ func EffectiveListenerHostnames(listenerHostname *gatewayv1.Hostname, routeHostn
 // hostnameMatches checks if a listener hostname matches a route hostname.
 // Supports wildcard prefixes like *.example.com per Gateway API spec.
 // DNS names are case-insensitive, so comparison is done in lowercase.
+//
+// The four combinations:
+//   - exact vs exact: equal names only.
+//   - wildcard listener vs exact route: the route host is a proper subdomain
+//     of the wildcard suffix, at any depth ("*.example.com" matches
+//     "a.b.example.com" but not "example.com").
+//   - exact listener vs wildcard route: the same rule with the roles swapped.
+//   - wildcard vs wildcard: one suffix is nested under the other (or they are
+//     equal); disjoint suffixes never intersect.
+//
+// Suffixes are compared with their leading dot, so matching is always on a
+// label boundary: "*.example.com" never matches "badexample.com".
 func hostnameMatches(listenerHost, routeHost string) bool {
 	listenerHost = strings.ToLower(listenerHost)
 	routeHost = strings.ToLower(routeHost)
@@ -82,6 +82,31 @@ func getDefaultKindsForProtocol(protocol gatewayv1.ProtocolType) []gatewayv1.Rou
 	}
 }
 
+// isSupportedKind reports whether this controller serves the route kind. Only
+// HTTPRoute and GRPCRoute have a data plane; TCPRoutes are reconciled but
+// never bind (see ServableProtocol).
+func isSupportedKind(kind gatewayv1.Kind) bool {
+	return kind == KindHTTPRoute || kind == KindGRPCRoute
+}
+
+// ServableProtocol reports whether this controller has a data plane for the
+// listener protocol. Only HTTP and HTTPS carry HTTPRoute / GRPCRoute through
+// the in-process proxy; TCP, TLS, UDP, and any unrecognised protocol have
+// none (the embedded connector rejects TCP streams). A listener of any other
+// protocol is not accepted, and no route binds to it.
+func ServableProtocol(protocol gatewayv1.ProtocolType) bool {
+	switch protocol {
+	case gatewayv1.HTTPProtocolType, gatewayv1.HTTPSProtocolType:
+		return true
+	case gatewayv1.TCPProtocolType, gatewayv1.TLSProtocolType, gatewayv1.UDPProtocolType:
+		return false
+	default:
+		// Any unrecognised protocol (e.g. the conformance suite's INVALID) has
+		// no data plane here either.
+		return false
+	}
+}
+
 // kindMatches checks if the allowed kind matches the route kind.
 func kindMatches(allowed gatewayv1.RouteGroupKind, routeKind gatewayv1.Kind) bool {
 	if allowed.Kind != routeKind {
@@ -126,8 +151,7 @@ func FilterSupportedKinds(
 			group = *kind.Group
 		}
 
-		// Only HTTPRoute and GRPCRoute are supported by this controller
-		if group == gatewayGroup && (kind.Kind == KindHTTPRoute || kind.Kind == KindGRPCRoute) {
+		if group == gatewayGroup && isSupportedKind(kind.Kind) {
 			supported = append(supported, kind)
 		} else if explicitlySpecified {
 			// Mark as invalid only if kinds were explicitly specified
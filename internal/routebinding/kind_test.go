@@ -265,6 +265,36 @@ func TestFilterSupportedKinds(t *testing.T) {
 			expectedHasAny:    false,
 			expectedHasInvald: false, // Default kinds don't count as invalid
 		},
+		{
+			name: "explicit TCPRoute on HTTP listener marks invalid",
+			allowedRoutes: &gatewayv1.AllowedRoutes{
+				Kinds: []gatewayv1.RouteGroupKind{
+					{
+						Group: groupPtr(gatewayv1.GroupName),
+						Kind:  "TCPRoute",
+					},
+				},
+			},
+			protocol:          gatewayv1.HTTPProtocolType,
+			expectedKinds:     nil,
+			expectedHasAny:    false,
+			expectedHasInvald: true,
+		},
+		{
+			name: "explicit TCPRoute on TCP listener marks invalid",
+			allowedRoutes: &gatewayv1.AllowedRoutes{
+				Kinds: []gatewayv1.RouteGroupKind{
+					{
+						Group: groupPtr(gatewayv1.GroupName),
+						Kind:  "TCPRoute",
+					},
+				},
+			},
+			protocol:          gatewayv1.TCPProtocolType,
+			expectedKinds:     nil,
+			expectedHasAny:    false,
+			expectedHasInvald: true,
+		},
 		{
 			name:              "nil allowedRoutes UDP returns empty - UDPRoute not supported but not invalid",
 			allowedRoutes:     nil,